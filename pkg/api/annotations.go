@@ -1,8 +1,10 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -31,6 +33,7 @@ const defaultAnnotationsLimit = 100
 //
 // Responses:
 // 200: getAnnotationsResponse
+// 400: badRequestError
 // 401: unauthorisedError
 // 500: internalServerError
 func (hs *HTTPServer) GetAnnotations(c *contextmodel.ReqContext) response.Response {
@@ -48,6 +51,8 @@ func (hs *HTTPServer) GetAnnotations(c *contextmodel.ReqContext) response.Respon
 		Tags:         c.QueryStrings("tags"),
 		Type:         c.Query("type"),
 		MatchAny:     c.QueryBool("matchAny"),
+		TagMatch:     c.Query("tagMatch"),
+		Text:         c.Query("text"),
 		SignedInUser: c.SignedInUser,
 	}
 	if query.Limit == 0 {
@@ -76,7 +81,7 @@ func (hs *HTTPServer) GetAnnotations(c *contextmodel.ReqContext) response.Respon
 
 	items, err := hs.annotationsRepo.Find(c.Req.Context(), query)
 	if err != nil {
-		return response.Error(http.StatusInternalServerError, "Failed to get annotations", err)
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to get annotations", err)
 	}
 
 	for _, item := range items {
@@ -589,6 +594,203 @@ func (hs *HTTPServer) GetAnnotationTags(c *contextmodel.ReqContext) response.Res
 	return response.JSON(http.StatusOK, annotations.GetAnnotationTagsResponse{Result: result})
 }
 
+// swagger:route GET /annotations/regions/stats annotations getAnnotationRegionStats
+//
+// Get statistics of region annotations.
+//
+// Returns the number of region annotations in a time range, the time that they cover, the regions with overlapping
+// regions merged, and the same statistics per day or per week. The regions are clipped to the time range, and all
+// durations are in milliseconds.
+//
+// Responses:
+// 200: getAnnotationRegionStatsResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 500: internalServerError
+func (hs *HTTPServer) GetAnnotationRegionStats(c *contextmodel.ReqContext) response.Response {
+	query := &annotations.RegionStatsQuery{
+		ItemQuery: annotations.ItemQuery{
+			From:         c.QueryInt64("from"),
+			To:           c.QueryInt64("to"),
+			OrgID:        c.GetOrgID(),
+			DashboardUID: c.Query("dashboardUID"),
+			PanelID:      c.QueryInt64("panelId"),
+			Tags:         c.QueryStrings("tags"),
+			Type:         c.Query("type"),
+			MatchAny:     c.QueryBool("matchAny"),
+			TagMatch:     c.Query("tagMatch"),
+			Text:         c.Query("text"),
+			SignedInUser: c.SignedInUser,
+		},
+		Bucket:   c.Query("bucket"),
+		Timezone: c.Query("timezone"),
+	}
+
+	stats, err := annotations.FindRegionStats(c.Req.Context(), hs.annotationsRepo, query)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to get annotation region stats", err)
+	}
+	return response.JSON(http.StatusOK, stats)
+}
+
+// swagger:route POST /annotations/import annotations importAnnotations
+//
+// Import Annotations.
+//
+// Creates annotations from NDJSON or CSV, with the fields `id`, `time`, `timeEnd`, `text`, `tags`, `dashboardUID` and
+// `panelId`. The annotations of a request are created in a single transaction, and annotations with an `id` that was
+// already imported into the organization are skipped. Dashboard UIDs can be mapped to the UIDs of the dashboards in
+// this organization with `mapDashboardUID=from:to`. The body is limited to 32 MiB.
+//
+// Responses:
+// 200: importAnnotationsResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (hs *HTTPServer) ImportAnnotations(c *contextmodel.ReqContext) response.Response {
+	mapping, err := annotations.ParseDashboardUIDMapping(c.QueryStrings("mapDashboardUID"))
+	if err != nil {
+		return response.ErrOrFallback(http.StatusBadRequest, "Failed to import annotations", err)
+	}
+	body := http.MaxBytesReader(c.Resp, c.Req.Body, annotations.MaxBulkSize)
+	bulkItems, err := annotations.DecodeBulkItems(body, bulkAnnotationsFormat(c))
+	if err != nil {
+		return response.ErrOrFallback(http.StatusBadRequest, "Failed to import annotations", err)
+	}
+
+	// The dashboard IDs of the dashboard UIDs that the annotations can be created in.
+	dashboardIDs := make(map[string]int64)
+	userID, _ := identity.UserIdentifier(c.GetID())
+	items := make([]annotations.Item, 0, len(bulkItems))
+	for _, bulkItem := range bulkItems {
+		dashboardUID := bulkItem.DashboardUID
+		if uid, ok := mapping[dashboardUID]; ok {
+			dashboardUID = uid
+		}
+
+		dashboardID, ok := dashboardIDs[dashboardUID]
+		if !ok {
+			if dashboardUID != "" {
+				query := dashboards.GetDashboardQuery{OrgID: c.GetOrgID(), UID: dashboardUID}
+				queryResult, err := hs.DashboardService.GetDashboard(c.Req.Context(), &query)
+				if err != nil {
+					return response.Error(http.StatusBadRequest, "Invalid dashboard UID "+dashboardUID+" in annotation import", err)
+				}
+				dashboardID = queryResult.ID
+			}
+
+			if canSave, err := hs.canCreateAnnotation(c, dashboardUID); err != nil || !canSave {
+				if !hs.Features.IsEnabled(c.Req.Context(), featuremgmt.FlagAnnotationPermissionUpdate) {
+					return dashboardGuardianResponse(err)
+				} else if err != nil {
+					return response.Error(http.StatusInternalServerError, "Error while checking annotation permissions", err)
+				} else {
+					return response.Error(http.StatusForbidden, "Access denied to save the annotations", nil)
+				}
+			}
+			dashboardIDs[dashboardUID] = dashboardID
+		}
+
+		item := annotations.Item{
+			OrgID:        c.GetOrgID(),
+			UserID:       userID,
+			DashboardID:  dashboardID,
+			DashboardUID: dashboardUID,
+			PanelID:      bulkItem.PanelID,
+			Epoch:        bulkItem.Time,
+			EpochEnd:     bulkItem.TimeEnd,
+			Text:         bulkItem.Text,
+			Tags:         bulkItem.Tags,
+		}
+		if bulkItem.ID != "" {
+			item.ExternalID = &bulkItem.ID
+		}
+		items = append(items, item)
+	}
+
+	result, err := hs.annotationsRepo.Import(c.Req.Context(), items)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to import annotations", err)
+	}
+	return response.JSON(http.StatusOK, result)
+}
+
+// swagger:route GET /annotations/export annotations exportAnnotations
+//
+// Export Annotations.
+//
+// Returns the annotations that match the filters as NDJSON or CSV, in the format of the import. The `id` of an
+// annotation is the `id` it was imported with, or its ID. Importing annotations that were not imported back into the
+// same organization creates copies of them.
+//
+// Produces:
+// - application/x-ndjson
+// - text/csv
+//
+// Responses:
+// 200: exportAnnotationsResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 500: internalServerError
+func (hs *HTTPServer) ExportAnnotations(c *contextmodel.ReqContext) response.Response {
+	format := bulkAnnotationsFormat(c)
+	if format != annotations.BulkFormatNDJSON && format != annotations.BulkFormatCSV {
+		return response.Error(http.StatusBadRequest, "Unknown annotation export format "+format, nil)
+	}
+
+	query := &annotations.ItemQuery{
+		From:         c.QueryInt64("from"),
+		To:           c.QueryInt64("to"),
+		OrgID:        c.GetOrgID(),
+		DashboardUID: c.Query("dashboardUID"),
+		PanelID:      c.QueryInt64("panelId"),
+		Limit:        c.QueryInt64("limit"),
+		Tags:         c.QueryStrings("tags"),
+		Type:         c.Query("type"),
+		MatchAny:     c.QueryBool("matchAny"),
+		TagMatch:     c.Query("tagMatch"),
+		Text:         c.Query("text"),
+		SignedInUser: c.SignedInUser,
+	}
+	if query.Limit <= 0 || query.Limit > annotations.MaxBulkItems {
+		query.Limit = annotations.MaxBulkItems
+	}
+
+	items, err := hs.annotationsRepo.Find(c.Req.Context(), query)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to get annotations", err)
+	}
+
+	bulkItems := make([]annotations.BulkItem, 0, len(items))
+	for _, item := range items {
+		bulkItems = append(bulkItems, annotations.BulkItemFromDTO(item))
+	}
+	var buf bytes.Buffer
+	if err := annotations.EncodeBulkItems(&buf, format, bulkItems); err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to export annotations", err)
+	}
+
+	contentType := "application/x-ndjson"
+	if format == annotations.BulkFormatCSV {
+		contentType = "text/csv; charset=utf-8"
+	}
+	return response.Respond(http.StatusOK, buf.Bytes()).
+		SetHeader("Content-Type", contentType).
+		SetHeader("Content-Disposition", fmt.Sprintf(`attachment;filename="annotations.%s"`, format))
+}
+
+// bulkAnnotationsFormat returns the format of the format query parameter, or of the content type of the request.
+func bulkAnnotationsFormat(c *contextmodel.ReqContext) string {
+	if format := c.Query("format"); format != "" {
+		return format
+	}
+	if strings.HasPrefix(c.Req.Header.Get("Content-Type"), "text/csv") {
+		return annotations.BulkFormatCSV
+	}
+	return annotations.BulkFormatNDJSON
+}
+
 // AnnotationTypeScopeResolver provides an ScopeAttributeResolver able to
 // resolve annotation types. Scope "annotations:id:<id>" will be translated to "annotations:type:<type>,
 // where <type> is the type of annotation with id <id>.
@@ -796,6 +998,20 @@ type GetAnnotationsParams struct {
 	// in:query
 	// required:false
 	MatchAny bool `json:"matchAny"`
+	// How tags are matched
+	// in:query
+	// required:false
+	// Description:
+	// * `exact` - tags are equal to the tags of the query
+	// * `prefix` - tags start with the tags of the query
+	// * `regex` - tags fully match the tags of the query as regular expressions
+	// enum: exact,prefix,regex
+	// default: exact
+	TagMatch string `json:"tagMatch"`
+	// Find annotations whose text contains all the words of the search, ignoring case
+	// in:query
+	// required:false
+	Text string `json:"text"`
 }
 
 // swagger:parameters getAnnotationTags
@@ -811,6 +1027,137 @@ type GetAnnotationTagsParams struct {
 	Limit string `json:"limit"`
 }
 
+// swagger:parameters getAnnotationRegionStats
+type GetAnnotationRegionStatsParams struct {
+	// Start of the time range in epoch milliseconds.
+	// in:query
+	// required:true
+	From int64 `json:"from"`
+	// End of the time range in epoch milliseconds.
+	// in:query
+	// required:true
+	To int64 `json:"to"`
+	// Only aggregate the annotations of a dashboard
+	// in:query
+	// required:false
+	DashboardUID string `json:"dashboardUID"`
+	// Only aggregate the annotations of a panel
+	// in:query
+	// required:false
+	PanelID int64 `json:"panelId"`
+	// Only aggregate the annotations with these tags
+	// in:query
+	// required:false
+	// type: array
+	// collectionFormat: multi
+	Tags []string `json:"tags"`
+	// Aggregate alerts or user created annotations
+	// in:query
+	// required:false
+	// enum: alert,annotation
+	Type string `json:"type"`
+	// Match any or all tags
+	// in:query
+	// required:false
+	MatchAny bool `json:"matchAny"`
+	// How tags are matched
+	// in:query
+	// required:false
+	// enum: exact,prefix,regex
+	// default: exact
+	TagMatch string `json:"tagMatch"`
+	// Only aggregate the annotations whose text contains all the words of the search, ignoring case
+	// in:query
+	// required:false
+	Text string `json:"text"`
+	// Size of the buckets
+	// in:query
+	// required:false
+	// enum: day,week
+	// default: day
+	Bucket string `json:"bucket"`
+	// IANA timezone that the buckets start in
+	// in:query
+	// required:false
+	// default: UTC
+	Timezone string `json:"timezone"`
+}
+
+// swagger:parameters importAnnotations
+type ImportAnnotationsParams struct {
+	// Format of the annotations. Defaults to CSV if the content type is text/csv, and NDJSON otherwise.
+	// in:query
+	// required:false
+	// enum: ndjson,csv
+	Format string `json:"format"`
+	// Dashboard UIDs of the annotations that are replaced, in the format `from:to`
+	// in:query
+	// required:false
+	// type: array
+	// collectionFormat: multi
+	MapDashboardUID []string `json:"mapDashboardUID"`
+	// The annotations in NDJSON or CSV
+	// in:body
+	// required:true
+	Body string `json:"body"`
+}
+
+// swagger:parameters exportAnnotations
+type ExportAnnotationsParams struct {
+	// Format of the annotations
+	// in:query
+	// required:false
+	// enum: ndjson,csv
+	// default: ndjson
+	Format string `json:"format"`
+	// Export annotations created after specific epoch datetime in milliseconds.
+	// in:query
+	// required:false
+	From int64 `json:"from"`
+	// Export annotations created before specific epoch datetime in milliseconds.
+	// in:query
+	// required:false
+	To int64 `json:"to"`
+	// Only export the annotations of a dashboard
+	// in:query
+	// required:false
+	DashboardUID string `json:"dashboardUID"`
+	// Only export the annotations of a panel
+	// in:query
+	// required:false
+	PanelID int64 `json:"panelId"`
+	// Max number of exported annotations.
+	// in:query
+	// required:false
+	// default: 10000
+	Limit int64 `json:"limit"`
+	// Only export the annotations with these tags
+	// in:query
+	// required:false
+	// type: array
+	// collectionFormat: multi
+	Tags []string `json:"tags"`
+	// Export alerts or user created annotations
+	// in:query
+	// required:false
+	// enum: alert,annotation
+	Type string `json:"type"`
+	// Match any or all tags
+	// in:query
+	// required:false
+	MatchAny bool `json:"matchAny"`
+	// How tags are matched
+	// in:query
+	// required:false
+	// enum: exact,prefix,regex
+	// default: exact
+	TagMatch string `json:"tagMatch"`
+	// Only export the annotations whose text contains all the words of the search, ignoring case
+	// in:query
+	// required:false
+	Text string `json:"text"`
+}
+
 // swagger:parameters massDeleteAnnotations
 type MassDeleteAnnotationsParams struct {
 	// in:body
@@ -859,6 +1206,27 @@ type GetAnnotationsResponse struct {
 	Body []*annotations.ItemDTO `json:"body"`
 }
 
+// swagger:response getAnnotationRegionStatsResponse
+type GetAnnotationRegionStatsResponse struct {
+	// The response message
+	// in: body
+	Body *annotations.RegionStats `json:"body"`
+}
+
+// swagger:response importAnnotationsResponse
+type ImportAnnotationsResponse struct {
+	// The response message
+	// in: body
+	Body annotations.ImportResult `json:"body"`
+}
+
+// swagger:response exportAnnotationsResponse
+type ExportAnnotationsResponse struct {
+	// The annotations in NDJSON or CSV
+	// in: body
+	Body string `json:"body"`
+}
+
 // swagger:response getAnnotationByIDResponse
 type GetAnnotationByIDResponse struct {
 	// The response message
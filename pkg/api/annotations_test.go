@@ -104,6 +104,98 @@ func TestAPI_Annotations(t *testing.T) {
 			expectedCode: http.StatusForbidden,
 			permissions:  []accesscontrol.Permission{},
 		},
+		{
+			desc:         "should be able to fetch annotation region stats with correct permission",
+			path:         "/api/annotations/regions/stats?from=1709510400000&to=1709596800000",
+			method:       http.MethodGet,
+			expectedCode: http.StatusOK,
+			permissions:  []accesscontrol.Permission{{Action: accesscontrol.ActionAnnotationsRead}},
+		},
+		{
+			desc:         "should not be able to fetch annotation region stats without a time range",
+			path:         "/api/annotations/regions/stats",
+			method:       http.MethodGet,
+			expectedCode: http.StatusBadRequest,
+			permissions:  []accesscontrol.Permission{{Action: accesscontrol.ActionAnnotationsRead}},
+		},
+		{
+			desc:         "should not be able to fetch annotation region stats without correct permission",
+			path:         "/api/annotations/regions/stats?from=1709510400000&to=1709596800000",
+			method:       http.MethodGet,
+			expectedCode: http.StatusForbidden,
+			permissions:  []accesscontrol.Permission{},
+		},
+		{
+			desc:         "should be able to import organization annotations with correct permission",
+			path:         "/api/annotations/import",
+			method:       http.MethodPost,
+			body:         "{\"id\": \"ci-1\", \"time\": 1, \"text\": \"deploy\"}\n{\"time\": 2, \"text\": \"deploy\"}",
+			expectedCode: http.StatusOK,
+			permissions:  []accesscontrol.Permission{{Action: accesscontrol.ActionAnnotationsCreate, Scope: accesscontrol.ScopeAnnotationsTypeOrganization}},
+		},
+		{
+			desc:         "should be able to import annotations as CSV",
+			path:         "/api/annotations/import?format=csv",
+			method:       http.MethodPost,
+			body:         "id,time,text,tags\nci-1,1,deploy,\"deploy,ci\"",
+			expectedCode: http.StatusOK,
+			permissions:  []accesscontrol.Permission{{Action: accesscontrol.ActionAnnotationsCreate, Scope: accesscontrol.ScopeAnnotationsTypeOrganization}},
+		},
+		{
+			desc:         "should not be able to import annotations without text",
+			path:         "/api/annotations/import",
+			method:       http.MethodPost,
+			body:         "{\"time\": 1}",
+			expectedCode: http.StatusBadRequest,
+			permissions:  []accesscontrol.Permission{{Action: accesscontrol.ActionAnnotationsCreate, Scope: accesscontrol.ScopeAnnotationsTypeOrganization}},
+		},
+		{
+			desc:         "should not be able to import organization annotations without correct permission",
+			path:         "/api/annotations/import",
+			method:       http.MethodPost,
+			body:         "{\"time\": 1, \"text\": \"deploy\"}",
+			expectedCode: http.StatusForbidden,
+			permissions:  []accesscontrol.Permission{{Action: accesscontrol.ActionAnnotationsCreate, Scope: accesscontrol.ScopeAnnotationsTypeDashboard}},
+		},
+		{
+			desc:         "should be able to import dashboard annotations into a mapped dashboard with annotationPermissionUpdate enabled",
+			path:         "/api/annotations/import?mapDashboardUID=other-dash:test-dash",
+			method:       http.MethodPost,
+			body:         "{\"time\": 1, \"text\": \"deploy\", \"dashboardUID\": \"other-dash\", \"panelId\": 2}",
+			featureFlags: []any{featuremgmt.FlagAnnotationPermissionUpdate},
+			expectedCode: http.StatusOK,
+			permissions:  []accesscontrol.Permission{{Action: accesscontrol.ActionAnnotationsCreate, Scope: dashboards.ScopeDashboardsProvider.GetResourceScopeUID(dashUID)}},
+		},
+		{
+			desc:         "should not be able to import dashboard annotations of a dashboard that does not exist",
+			path:         "/api/annotations/import",
+			method:       http.MethodPost,
+			body:         "{\"time\": 1, \"text\": \"deploy\", \"dashboardUID\": \"other-dash\", \"panelId\": 2}",
+			featureFlags: []any{featuremgmt.FlagAnnotationPermissionUpdate},
+			expectedCode: http.StatusBadRequest,
+			permissions:  []accesscontrol.Permission{{Action: accesscontrol.ActionAnnotationsCreate, Scope: dashboards.ScopeDashboardsProvider.GetResourceScopeUID(dashUID)}},
+		},
+		{
+			desc:         "should be able to export annotations with correct permission",
+			path:         "/api/annotations/export?format=csv",
+			method:       http.MethodGet,
+			expectedCode: http.StatusOK,
+			permissions:  []accesscontrol.Permission{{Action: accesscontrol.ActionAnnotationsRead, Scope: accesscontrol.ScopeAnnotationsAll}},
+		},
+		{
+			desc:         "should not be able to export annotations in an unknown format",
+			path:         "/api/annotations/export?format=xml",
+			method:       http.MethodGet,
+			expectedCode: http.StatusBadRequest,
+			permissions:  []accesscontrol.Permission{{Action: accesscontrol.ActionAnnotationsRead, Scope: accesscontrol.ScopeAnnotationsAll}},
+		},
+		{
+			desc:         "should not be able to export annotations without correct permission",
+			path:         "/api/annotations/export",
+			method:       http.MethodGet,
+			expectedCode: http.StatusForbidden,
+			permissions:  []accesscontrol.Permission{},
+		},
 		{
 			desc:         "should be able to update dashboard annotation with correct permission",
 			path:         "/api/annotations/2",
@@ -404,6 +496,7 @@ func TestAPI_Annotations(t *testing.T) {
 				hs.annotationsRepo = repo
 				hs.Features = featuremgmt.WithFeatures(tt.featureFlags...)
 				dashService := &dashboards.FakeDashboardService{}
+				dashService.On("GetDashboard", mock.Anything, &dashboards.GetDashboardQuery{UID: "other-dash", OrgID: 1}).Return(nil, dashboards.ErrDashboardNotFound)
 				dashService.On("GetDashboard", mock.Anything, mock.Anything).Return(&dashboards.Dashboard{UID: dashUID, FolderUID: folderUID, FolderID: 1}, nil)
 				folderService := &foldertest.FakeService{}
 				folderService.ExpectedFolder = &folder.Folder{UID: folderUID, ID: 1}
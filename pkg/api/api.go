@@ -534,6 +534,9 @@ func (hs *HTTPServer) registerRoutes() {
 			annotationsRoute.Patch("/:annotationId", authorize(ac.EvalPermission(ac.ActionAnnotationsWrite, ac.ScopeAnnotationsID)), routing.Wrap(hs.PatchAnnotation))
 			annotationsRoute.Post("/graphite", authorize(ac.EvalPermission(ac.ActionAnnotationsCreate, ac.ScopeAnnotationsTypeOrganization)), routing.Wrap(hs.PostGraphiteAnnotation))
 			annotationsRoute.Get("/tags", authorize(ac.EvalPermission(ac.ActionAnnotationsRead)), routing.Wrap(hs.GetAnnotationTags))
+			annotationsRoute.Get("/regions/stats", authorize(ac.EvalPermission(ac.ActionAnnotationsRead)), routing.Wrap(hs.GetAnnotationRegionStats))
+			annotationsRoute.Post("/import", authorize(ac.EvalPermission(ac.ActionAnnotationsCreate)), routing.Wrap(hs.ImportAnnotations))
+			annotationsRoute.Get("/export", authorize(ac.EvalPermission(ac.ActionAnnotationsRead)), routing.Wrap(hs.ExportAnnotations))
 		})
 
 		apiRoute.Post("/frontend-metrics", routing.Wrap(hs.PostFrontendMetrics))
@@ -550,6 +553,9 @@ func (hs *HTTPServer) registerRoutes() {
 
 			// Some channels may have info
 			liveRoute.Get("/info/*", routing.Wrap(hs.Live.HandleInfoHTTP))
+
+			// History of managed stream channels
+			liveRoute.Get("/history/*", routing.Wrap(hs.Live.HandleHistoryHTTP))
 		}, requestmeta.SetSLOGroup(requestmeta.SLOGroupNone))
 	}, reqSignedIn)
 
@@ -0,0 +1,253 @@
+package expr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/expr/metrics"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+)
+
+// The function used to combine the values of a group
+// +enum
+type AggregateFunc string
+
+const (
+	AggregateSum      AggregateFunc = "sum"
+	AggregateAvg      AggregateFunc = "avg"
+	AggregateMin      AggregateFunc = "min"
+	AggregateMax      AggregateFunc = "max"
+	AggregateCount    AggregateFunc = "count"
+	AggregateQuantile AggregateFunc = "quantile"
+)
+
+var supportedAggregateFuncs = []string{
+	string(AggregateSum),
+	string(AggregateAvg),
+	string(AggregateMin),
+	string(AggregateMax),
+	string(AggregateCount),
+	string(AggregateQuantile),
+}
+
+// AggregateCommand is an expression command that groups Series or Numbers by a subset of
+// their labels and combines the values of each group, like "sum by (cluster)" in PromQL.
+type AggregateCommand struct {
+	VarToAggregate string
+	Func           AggregateFunc
+	By             []string
+	Quantile       float64
+	refID          string
+}
+
+// NewAggregateCommand creates a new AggregateCommand.
+func NewAggregateCommand(refID, varToAggregate string, fn AggregateFunc, by []string, quantile float64) (*AggregateCommand, error) {
+	switch fn {
+	case AggregateSum, AggregateAvg, AggregateMin, AggregateMax, AggregateCount:
+	case AggregateQuantile:
+		if quantile < 0 || quantile > 1 {
+			return nil, fmt.Errorf("quantile must be between 0 and 1, got %v", quantile)
+		}
+	default:
+		return nil, fmt.Errorf("expected aggregate function to be one of [%s], got %s", strings.Join(supportedAggregateFuncs, ", "), fn)
+	}
+	return &AggregateCommand{
+		VarToAggregate: varToAggregate,
+		Func:           fn,
+		By:             by,
+		Quantile:       quantile,
+		refID:          refID,
+	}, nil
+}
+
+// UnmarshalAggregateCommand creates an AggregateCommand from Grafana's frontend query.
+func UnmarshalAggregateCommand(rn *rawNode) (*AggregateCommand, error) {
+	q := AggregateQuery{}
+	if err := json.Unmarshal(rn.QueryRaw, &q); err != nil {
+		return nil, fmt.Errorf("failed to parse the aggregate command: %w", err)
+	}
+	varToAggregate := strings.TrimPrefix(q.Expression, "$")
+	if varToAggregate == "" {
+		return nil, fmt.Errorf("no variable specified to aggregate for refId %v", rn.RefID)
+	}
+	fn := AggregateFunc(strings.ToLower(string(q.Aggregator)))
+	var quantile float64
+	if q.Quantile != nil {
+		quantile = *q.Quantile
+	} else if fn == AggregateQuantile {
+		return nil, fmt.Errorf("quantile must be specified when the aggregator is '%s'", AggregateQuantile)
+	}
+	return NewAggregateCommand(rn.RefID, varToAggregate, fn, q.By, quantile)
+}
+
+// NeedsVars returns the variable names (refIds) that are dependencies
+// to execute the command and allows the command to fulfill the Command interface.
+func (ac *AggregateCommand) NeedsVars() []string {
+	return []string{ac.VarToAggregate}
+}
+
+// Execute runs the command and returns the results or an error if the command
+// failed to execute.
+func (ac *AggregateCommand) Execute(ctx context.Context, _ time.Time, vars mathexp.Vars, tracer tracing.Tracer, _ *metrics.ExprMetrics) (mathexp.Results, error) {
+	_, span := tracer.Start(ctx, "SSE.ExecuteAggregate")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("aggregator", string(ac.Func)),
+		attribute.StringSlice("by", ac.By),
+	)
+
+	groups := make(map[string]*aggregateGroup)
+	var order []string
+	var seriesCount, numberCount int
+	for _, val := range vars[ac.VarToAggregate].Values {
+		switch v := val.(type) {
+		case mathexp.Series:
+			seriesCount++
+		case mathexp.Number:
+			numberCount++
+		case mathexp.NoData:
+			return mathexp.Results{Values: mathexp.Values{v.New()}}, nil
+		default:
+			return mathexp.Results{}, fmt.Errorf("can only aggregate type series or number, got type %v", val.Type())
+		}
+		if seriesCount > 0 && numberCount > 0 {
+			return mathexp.Results{}, fmt.Errorf("can not aggregate a mix of series and numbers in %s", ac.VarToAggregate)
+		}
+		labels := ac.groupLabels(val.GetLabels())
+		key := labels.String()
+		g, ok := groups[key]
+		if !ok {
+			g = &aggregateGroup{labels: labels}
+			groups[key] = g
+			order = append(order, key)
+		}
+		g.values = append(g.values, val)
+	}
+
+	newRes := mathexp.Results{}
+	for _, key := range order {
+		g := groups[key]
+		if numberCount > 0 {
+			newRes.Values = append(newRes.Values, ac.aggregateNumbers(g))
+		} else {
+			newRes.Values = append(newRes.Values, ac.aggregateSeries(g))
+		}
+	}
+	return newRes, nil
+}
+
+func (ac *AggregateCommand) Type() string {
+	return TypeAggregate.String()
+}
+
+type aggregateGroup struct {
+	labels data.Labels
+	values []mathexp.Value
+}
+
+// groupLabels returns the subset of labels that are in the command's By list.
+func (ac *AggregateCommand) groupLabels(labels data.Labels) data.Labels {
+	result := data.Labels{}
+	for _, name := range ac.By {
+		if v, ok := labels[name]; ok {
+			result[name] = v
+		}
+	}
+	return result
+}
+
+func (ac *AggregateCommand) aggregateNumbers(g *aggregateGroup) mathexp.Number {
+	vals := make([]float64, 0, len(g.values))
+	for _, v := range g.values {
+		if f := v.(mathexp.Number).GetFloat64Value(); f != nil && !math.IsNaN(*f) {
+			vals = append(vals, *f)
+		}
+	}
+	n := mathexp.NewNumber(ac.refID, g.labels)
+	n.SetValue(ac.aggregate(vals))
+	return n
+}
+
+// aggregateSeries combines the points of the series in the group that share a timestamp.
+// The resulting series has a point for every timestamp seen in any of the series.
+func (ac *AggregateCommand) aggregateSeries(g *aggregateGroup) mathexp.Series {
+	byTime := make(map[time.Time][]float64)
+	var times []time.Time
+	for _, v := range g.values {
+		s := v.(mathexp.Series)
+		for i := 0; i < s.Len(); i++ {
+			t, f := s.GetPoint(i)
+			vals, ok := byTime[t]
+			if !ok {
+				times = append(times, t)
+			}
+			if f != nil && !math.IsNaN(*f) {
+				vals = append(vals, *f)
+			}
+			byTime[t] = vals
+		}
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+
+	s := mathexp.NewSeries(ac.refID, g.labels, len(times))
+	for i, t := range times {
+		s.SetPoint(i, t, ac.aggregate(byTime[t]))
+	}
+	return s
+}
+
+// aggregate applies the command's function to the values, from which nil and NaN values have been left out.
+// Nil is returned when there are no values, except for count which returns 0.
+func (ac *AggregateCommand) aggregate(vals []float64) *float64 {
+	if ac.Func == AggregateCount {
+		c := float64(len(vals))
+		return &c
+	}
+	if len(vals) == 0 {
+		return nil
+	}
+	var result float64
+	switch ac.Func {
+	case AggregateSum, AggregateAvg:
+		for _, v := range vals {
+			result += v
+		}
+		if ac.Func == AggregateAvg {
+			result /= float64(len(vals))
+		}
+	case AggregateMin:
+		result = vals[0]
+		for _, v := range vals[1:] {
+			result = math.Min(result, v)
+		}
+	case AggregateMax:
+		result = vals[0]
+		for _, v := range vals[1:] {
+			result = math.Max(result, v)
+		}
+	case AggregateQuantile:
+		result = quantile(ac.Quantile, vals)
+	}
+	return &result
+}
+
+// quantile calculates the q-quantile of the values using linear interpolation between the closest ranks.
+// The values are sorted in place.
+func quantile(q float64, vals []float64) float64 {
+	sort.Float64s(vals)
+	rank := q * float64(len(vals)-1)
+	lower := math.Floor(rank)
+	upper := math.Ceil(rank)
+	weight := rank - lower
+	return vals[int(lower)]*(1-weight) + vals[int(upper)]*weight
+}
@@ -0,0 +1,185 @@
+package expr
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+func TestUnmarshalAggregateCommand(t *testing.T) {
+	cases := []struct {
+		description   string
+		query         string
+		expectedError string
+		assert        func(*testing.T, *AggregateCommand)
+	}{
+		{
+			description: "unmarshal proper object",
+			query:       `{ "expression": "$A", "type": "aggregate", "aggregator": "sum", "by": ["cluster"] }`,
+			assert: func(t *testing.T, cmd *AggregateCommand) {
+				require.Equal(t, []string{"A"}, cmd.NeedsVars())
+				require.Equal(t, AggregateSum, cmd.Func)
+				require.Equal(t, []string{"cluster"}, cmd.By)
+			},
+		},
+		{
+			description: "unmarshal quantile",
+			query:       `{ "expression": "A", "type": "aggregate", "aggregator": "quantile", "quantile": 0.95 }`,
+			assert: func(t *testing.T, cmd *AggregateCommand) {
+				require.Equal(t, AggregateQuantile, cmd.Func)
+				require.Equal(t, 0.95, cmd.Quantile)
+			},
+		},
+		{
+			description:   "quantile without value should error",
+			query:         `{ "expression": "A", "type": "aggregate", "aggregator": "quantile" }`,
+			expectedError: "quantile must be specified",
+		},
+		{
+			description:   "upper case quantile without value should error",
+			query:         `{ "expression": "A", "type": "aggregate", "aggregator": "Quantile" }`,
+			expectedError: "quantile must be specified",
+		},
+		{
+			description:   "quantile out of range should error",
+			query:         `{ "expression": "A", "type": "aggregate", "aggregator": "quantile", "quantile": 2 }`,
+			expectedError: "quantile must be between 0 and 1",
+		},
+		{
+			description:   "unsupported aggregator should error",
+			query:         `{ "expression": "A", "type": "aggregate", "aggregator": "stddev" }`,
+			expectedError: "expected aggregate function to be one of",
+		},
+		{
+			description:   "missing expression should error",
+			query:         `{ "type": "aggregate", "aggregator": "sum" }`,
+			expectedError: "no variable specified to aggregate",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			cmd, err := UnmarshalAggregateCommand(&rawNode{
+				RefID:     "B",
+				QueryRaw:  []byte(tc.query),
+				TimeRange: RelativeTimeRange{},
+			})
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			tc.assert(t, cmd)
+		})
+	}
+}
+
+func TestAggregateExecute(t *testing.T) {
+	number := func(labels data.Labels, f *float64) mathexp.Number {
+		n := mathexp.NewNumber("B", labels)
+		n.SetValue(f)
+		return n
+	}
+	series := func(labels data.Labels, values ...*float64) mathexp.Series {
+		s := mathexp.NewSeries("B", labels, len(values))
+		for i, v := range values {
+			s.SetPoint(i, time.Unix(int64(i*10), 0), v)
+		}
+		return s
+	}
+	execute := func(t *testing.T, fn AggregateFunc, by []string, q float64, values ...mathexp.Value) mathexp.Results {
+		t.Helper()
+		cmd, err := NewAggregateCommand("B", "A", fn, by, q)
+		require.NoError(t, err)
+		res, err := cmd.Execute(context.Background(), time.Now(), mathexp.Vars{"A": {Values: values}}, tracing.InitializeTracerForTest(), nil)
+		require.NoError(t, err)
+		return res
+	}
+
+	numbers := []mathexp.Value{
+		number(data.Labels{"cluster": "a", "pod": "1"}, util.Pointer(1.0)),
+		number(data.Labels{"cluster": "a", "pod": "2"}, util.Pointer(3.0)),
+		number(data.Labels{"cluster": "b", "pod": "3"}, util.Pointer(10.0)),
+		number(data.Labels{"cluster": "b", "pod": "4"}, nil),
+	}
+
+	t.Run("numbers grouped by label", func(t *testing.T) {
+		cases := []struct {
+			fn       AggregateFunc
+			q        float64
+			expected []*float64
+		}{
+			{fn: AggregateSum, expected: []*float64{util.Pointer(4.0), util.Pointer(10.0)}},
+			{fn: AggregateAvg, expected: []*float64{util.Pointer(2.0), util.Pointer(10.0)}},
+			{fn: AggregateMin, expected: []*float64{util.Pointer(1.0), util.Pointer(10.0)}},
+			{fn: AggregateMax, expected: []*float64{util.Pointer(3.0), util.Pointer(10.0)}},
+			{fn: AggregateCount, expected: []*float64{util.Pointer(2.0), util.Pointer(1.0)}},
+			{fn: AggregateQuantile, q: 0.5, expected: []*float64{util.Pointer(2.0), util.Pointer(10.0)}},
+		}
+		for _, tc := range cases {
+			t.Run(string(tc.fn), func(t *testing.T) {
+				res := execute(t, tc.fn, []string{"cluster"}, tc.q, numbers...)
+				require.Equal(t, mathexp.Values{
+					number(data.Labels{"cluster": "a"}, tc.expected[0]),
+					number(data.Labels{"cluster": "b"}, tc.expected[1]),
+				}, res.Values)
+			})
+		}
+	})
+
+	t.Run("empty by aggregates everything", func(t *testing.T) {
+		res := execute(t, AggregateSum, nil, 0, numbers...)
+		require.Len(t, res.Values, 1)
+		require.Equal(t, data.Labels{}, res.Values[0].GetLabels())
+		require.Equal(t, util.Pointer(14.0), res.Values[0].(mathexp.Number).GetFloat64Value())
+	})
+
+	t.Run("series are aggregated per timestamp", func(t *testing.T) {
+		res := execute(t, AggregateMax, []string{"cluster"}, 0,
+			series(data.Labels{"cluster": "a", "pod": "1"}, util.Pointer(1.0), nil, util.Pointer(5.0)),
+			series(data.Labels{"cluster": "a", "pod": "2"}, util.Pointer(2.0), nil),
+		)
+		require.Equal(t, mathexp.Values{
+			series(data.Labels{"cluster": "a"}, util.Pointer(2.0), nil, util.Pointer(5.0)),
+		}, res.Values)
+	})
+
+	t.Run("NaN values are ignored like nil values", func(t *testing.T) {
+		for _, fn := range []AggregateFunc{AggregateSum, AggregateAvg, AggregateMin, AggregateMax} {
+			t.Run(string(fn), func(t *testing.T) {
+				res := execute(t, fn, nil, 0,
+					number(data.Labels{"pod": "1"}, util.Pointer(2.0)),
+					number(data.Labels{"pod": "2"}, util.Pointer(math.NaN())),
+				)
+				require.Equal(t, mathexp.Values{number(data.Labels{}, util.Pointer(2.0))}, res.Values)
+			})
+		}
+
+		res := execute(t, AggregateCount, nil, 0,
+			series(data.Labels{"pod": "1"}, util.Pointer(1.0), util.Pointer(math.NaN())),
+			series(data.Labels{"pod": "2"}, util.Pointer(math.NaN()), util.Pointer(math.NaN())),
+		)
+		require.Equal(t, mathexp.Values{series(data.Labels{}, util.Pointer(1.0), util.Pointer(0.0))}, res.Values)
+	})
+
+	t.Run("no data is passed through", func(t *testing.T) {
+		res := execute(t, AggregateSum, nil, 0, mathexp.NoData{}.New())
+		require.Equal(t, mathexp.Values{mathexp.NoData{}.New()}, res.Values)
+	})
+
+	t.Run("mix of series and numbers should error", func(t *testing.T) {
+		cmd, err := NewAggregateCommand("B", "A", AggregateSum, nil, 0)
+		require.NoError(t, err)
+		vars := mathexp.Vars{"A": {Values: mathexp.Values{series(nil, util.Pointer(1.0)), number(nil, util.Pointer(1.0))}}}
+		_, err = cmd.Execute(context.Background(), time.Now(), vars, tracing.InitializeTracerForTest(), nil)
+		require.ErrorContains(t, err, "can not aggregate a mix of series and numbers")
+	})
+}
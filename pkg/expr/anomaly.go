@@ -0,0 +1,273 @@
+package expr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/expr/metrics"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+// The method used to calculate the expected band of values
+// +enum
+type AnomalyAlgorithm string
+
+const (
+	// Mean and standard deviation of the preceding window
+	AnomalyZScore AnomalyAlgorithm = "zscore"
+
+	// Median and median absolute deviation of the preceding window
+	AnomalyMAD AnomalyAlgorithm = "mad"
+
+	// Mean and standard deviation of the window one season earlier, e.g. the same hour last week
+	AnomalySeasonal AnomalyAlgorithm = "seasonal"
+)
+
+const (
+	// anomalyBandLabel is the label added to the band series to tell them apart from the anomaly flags.
+	anomalyBandLabel = "anomaly_band"
+
+	// madScale makes the median absolute deviation a consistent estimator of the standard deviation of normally distributed data.
+	madScale = 1.4826
+
+	// anomalyMinPoints is the minimum number of points in a window needed to calculate a band.
+	anomalyMinPoints = 2
+)
+
+var supportedAnomalyAlgorithms = []string{
+	string(AnomalyZScore),
+	string(AnomalyMAD),
+	string(AnomalySeasonal),
+}
+
+// AnomalyCommand is an expression command that detects anomalies in time series in-process.
+// For every point it calculates a band of expected values from a reference window and
+// returns a series with 1 where the point is outside the band, and 0 where it is inside.
+// Points for which there is not enough data to calculate a band are null.
+type AnomalyCommand struct {
+	VarToDetect string
+	Algorithm   AnomalyAlgorithm
+	Window      time.Duration
+	Season      time.Duration
+	Sensitivity float64
+	Bands       bool
+	refID       string
+}
+
+// NewAnomalyCommand creates a new AnomalyCommand.
+func NewAnomalyCommand(refID, varToDetect string, algorithm AnomalyAlgorithm, window, season time.Duration, sensitivity float64, bands bool) (*AnomalyCommand, error) {
+	switch algorithm {
+	case AnomalyZScore, AnomalyMAD:
+	case AnomalySeasonal:
+		if season <= 0 {
+			return nil, fmt.Errorf("season must be greater than zero for the '%s' algorithm", algorithm)
+		}
+	default:
+		return nil, fmt.Errorf("expected anomaly algorithm to be one of [%s], got %s", strings.Join(supportedAnomalyAlgorithms, ", "), algorithm)
+	}
+	if window <= 0 {
+		return nil, fmt.Errorf("window must be greater than zero")
+	}
+	if sensitivity <= 0 {
+		return nil, fmt.Errorf("sensitivity must be greater than zero, got %v", sensitivity)
+	}
+	return &AnomalyCommand{
+		VarToDetect: varToDetect,
+		Algorithm:   algorithm,
+		Window:      window,
+		Season:      season,
+		Sensitivity: sensitivity,
+		Bands:       bands,
+		refID:       refID,
+	}, nil
+}
+
+// UnmarshalAnomalyCommand creates an AnomalyCommand from Grafana's frontend query.
+func UnmarshalAnomalyCommand(rn *rawNode) (*AnomalyCommand, error) {
+	q := AnomalyQuery{}
+	if err := json.Unmarshal(rn.QueryRaw, &q); err != nil {
+		return nil, fmt.Errorf("failed to parse the anomaly command: %w", err)
+	}
+	varToDetect := strings.TrimPrefix(q.Expression, "$")
+	if varToDetect == "" {
+		return nil, fmt.Errorf("no variable specified to detect anomalies for refId %v", rn.RefID)
+	}
+	window, err := gtime.ParseDuration(q.Window)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to parse anomaly "window" duration field %q: %w`, q.Window, err)
+	}
+	var season time.Duration
+	if q.Season != "" {
+		season, err = gtime.ParseDuration(q.Season)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to parse anomaly "season" duration field %q: %w`, q.Season, err)
+		}
+	}
+	sensitivity := float64(3)
+	if q.Sensitivity != nil {
+		sensitivity = *q.Sensitivity
+	}
+	return NewAnomalyCommand(rn.RefID, varToDetect, q.Algorithm, window, season, sensitivity, q.Bands)
+}
+
+// NeedsVars returns the variable names (refIds) that are dependencies
+// to execute the command and allows the command to fulfill the Command interface.
+func (ac *AnomalyCommand) NeedsVars() []string {
+	return []string{ac.VarToDetect}
+}
+
+// Execute runs the command and returns the results or an error if the command
+// failed to execute.
+func (ac *AnomalyCommand) Execute(ctx context.Context, _ time.Time, vars mathexp.Vars, tracer tracing.Tracer, _ *metrics.ExprMetrics) (mathexp.Results, error) {
+	_, span := tracer.Start(ctx, "SSE.ExecuteAnomaly")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("algorithm", string(ac.Algorithm)))
+
+	newRes := mathexp.Results{}
+	for _, val := range vars[ac.VarToDetect].Values {
+		switch v := val.(type) {
+		case mathexp.Series:
+			newRes.Values = append(newRes.Values, ac.detect(v)...)
+		case mathexp.NoData:
+			newRes.Values = append(newRes.Values, v.New())
+		default:
+			return newRes, fmt.Errorf("can only detect anomalies in type series, got type %v", val.Type())
+		}
+	}
+	return newRes, nil
+}
+
+func (ac *AnomalyCommand) Type() string {
+	return TypeAnomaly.String()
+}
+
+// detect returns the anomaly flags for the series and, if bands are enabled, the lower and upper band series.
+func (ac *AnomalyCommand) detect(s mathexp.Series) []mathexp.Value {
+	points := make([]anomalyPoint, 0, s.Len())
+	for i := 0; i < s.Len(); i++ {
+		t, f := s.GetPoint(i)
+		if f == nil || math.IsNaN(*f) || math.IsInf(*f, 0) {
+			continue
+		}
+		points = append(points, anomalyPoint{t: t, v: *f})
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].t.Before(points[j].t) })
+
+	flags := mathexp.NewSeries(ac.refID, s.GetLabels(), s.Len())
+	var lower, upper mathexp.Series
+	if ac.Bands {
+		lower = mathexp.NewSeries(ac.refID, bandLabels(s.GetLabels(), "lower"), s.Len())
+		upper = mathexp.NewSeries(ac.refID, bandLabels(s.GetLabels(), "upper"), s.Len())
+	}
+
+	for i := 0; i < s.Len(); i++ {
+		t, f := s.GetPoint(i)
+		lo, hi, ok := ac.band(points, t)
+		var flag, loPtr, hiPtr *float64
+		if ok {
+			loPtr, hiPtr = &lo, &hi
+			if f != nil && !math.IsNaN(*f) {
+				if *f < lo || *f > hi {
+					flag = util.Pointer(float64(1))
+				} else {
+					flag = util.Pointer(float64(0))
+				}
+			}
+		}
+		flags.SetPoint(i, t, flag)
+		if ac.Bands {
+			lower.SetPoint(i, t, loPtr)
+			upper.SetPoint(i, t, hiPtr)
+		}
+	}
+
+	if !ac.Bands {
+		return []mathexp.Value{flags}
+	}
+	return []mathexp.Value{flags, lower, upper}
+}
+
+type anomalyPoint struct {
+	t time.Time
+	v float64
+}
+
+// band calculates the expected band of values at time t from the points in the reference window.
+// For the rolling algorithms the window is the one that ends right before t, for the seasonal algorithm it is
+// the one that ends at t minus the season. ok is false when the window has too few points.
+func (ac *AnomalyCommand) band(points []anomalyPoint, t time.Time) (lower, upper float64, ok bool) {
+	end := t
+	includeEnd := false
+	if ac.Algorithm == AnomalySeasonal {
+		end = t.Add(-ac.Season)
+		includeEnd = true
+	}
+	start := end.Add(-ac.Window)
+
+	// points in (start, end), or (start, end] for the seasonal window.
+	from := sort.Search(len(points), func(i int) bool { return points[i].t.After(start) })
+	to := sort.Search(len(points), func(i int) bool {
+		if includeEnd {
+			return points[i].t.After(end)
+		}
+		return !points[i].t.Before(end)
+	})
+	if to-from < anomalyMinPoints {
+		return 0, 0, false
+	}
+	vals := make([]float64, 0, to-from)
+	for _, p := range points[from:to] {
+		vals = append(vals, p.v)
+	}
+
+	var center, spread float64
+	if ac.Algorithm == AnomalyMAD {
+		center = median(vals)
+		deviations := make([]float64, len(vals))
+		for i, v := range vals {
+			deviations[i] = math.Abs(v - center)
+		}
+		spread = madScale * median(deviations)
+	} else {
+		for _, v := range vals {
+			center += v
+		}
+		center /= float64(len(vals))
+		for _, v := range vals {
+			spread += (v - center) * (v - center)
+		}
+		spread = math.Sqrt(spread / float64(len(vals)))
+	}
+	return center - ac.Sensitivity*spread, center + ac.Sensitivity*spread, true
+}
+
+// median returns the median of the values. The values are sorted in place.
+func median(vals []float64) float64 {
+	sort.Float64s(vals)
+	mid := len(vals) / 2
+	if len(vals)%2 == 0 {
+		return (vals[mid-1] + vals[mid]) / 2
+	}
+	return vals[mid]
+}
+
+func bandLabels(labels data.Labels, band string) data.Labels {
+	result := data.Labels{}
+	if labels != nil {
+		result = labels.Copy()
+	}
+	result[anomalyBandLabel] = band
+	return result
+}
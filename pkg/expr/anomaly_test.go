@@ -0,0 +1,161 @@
+package expr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+func TestUnmarshalAnomalyCommand(t *testing.T) {
+	cases := []struct {
+		description   string
+		query         string
+		expectedError string
+		assert        func(*testing.T, *AnomalyCommand)
+	}{
+		{
+			description: "unmarshal proper object with default sensitivity",
+			query:       `{ "expression": "$A", "type": "anomaly", "algorithm": "zscore", "window": "1h" }`,
+			assert: func(t *testing.T, cmd *AnomalyCommand) {
+				require.Equal(t, []string{"A"}, cmd.NeedsVars())
+				require.Equal(t, AnomalyZScore, cmd.Algorithm)
+				require.Equal(t, time.Hour, cmd.Window)
+				require.Equal(t, float64(3), cmd.Sensitivity)
+				require.False(t, cmd.Bands)
+			},
+		},
+		{
+			description: "unmarshal seasonal",
+			query:       `{ "expression": "A", "type": "anomaly", "algorithm": "seasonal", "window": "1h", "season": "1w", "sensitivity": 2, "bands": true }`,
+			assert: func(t *testing.T, cmd *AnomalyCommand) {
+				require.Equal(t, AnomalySeasonal, cmd.Algorithm)
+				require.Equal(t, 7*24*time.Hour, cmd.Season)
+				require.Equal(t, float64(2), cmd.Sensitivity)
+				require.True(t, cmd.Bands)
+			},
+		},
+		{
+			description:   "seasonal without season should error",
+			query:         `{ "expression": "A", "type": "anomaly", "algorithm": "seasonal", "window": "1h" }`,
+			expectedError: "season must be greater than zero",
+		},
+		{
+			description:   "unsupported algorithm should error",
+			query:         `{ "expression": "A", "type": "anomaly", "algorithm": "prophet", "window": "1h" }`,
+			expectedError: "expected anomaly algorithm to be one of",
+		},
+		{
+			description:   "invalid window should error",
+			query:         `{ "expression": "A", "type": "anomaly", "algorithm": "mad", "window": "an hour" }`,
+			expectedError: `failed to parse anomaly "window" duration field`,
+		},
+		{
+			description:   "negative sensitivity should error",
+			query:         `{ "expression": "A", "type": "anomaly", "algorithm": "mad", "window": "1h", "sensitivity": -1 }`,
+			expectedError: "sensitivity must be greater than zero",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			cmd, err := UnmarshalAnomalyCommand(&rawNode{
+				RefID:     "B",
+				QueryRaw:  []byte(tc.query),
+				TimeRange: RelativeTimeRange{},
+			})
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			tc.assert(t, cmd)
+		})
+	}
+}
+
+func TestAnomalyExecute(t *testing.T) {
+	series := func(values ...float64) mathexp.Series {
+		s := mathexp.NewSeries("A", data.Labels{"host": "a"}, len(values))
+		for i, v := range values {
+			s.SetPoint(i, time.Unix(int64(i), 0), util.Pointer(v))
+		}
+		return s
+	}
+	execute := func(t *testing.T, cmd *AnomalyCommand, values ...mathexp.Value) mathexp.Values {
+		t.Helper()
+		res, err := cmd.Execute(context.Background(), time.Now(), mathexp.Vars{"A": {Values: values}}, tracing.InitializeTracerForTest(), nil)
+		require.NoError(t, err)
+		return res.Values
+	}
+	flags := func(v mathexp.Value) []*float64 {
+		s := v.(mathexp.Series)
+		result := make([]*float64, 0, s.Len())
+		for i := 0; i < s.Len(); i++ {
+			result = append(result, s.GetValue(i))
+		}
+		return result
+	}
+	zero, one := util.Pointer(float64(0)), util.Pointer(float64(1))
+
+	t.Run("zscore flags points outside the band of the preceding window", func(t *testing.T) {
+		cmd, err := NewAnomalyCommand("B", "A", AnomalyZScore, 5*time.Second, 0, 2, false)
+		require.NoError(t, err)
+		values := execute(t, cmd, series(10, 10, 10, 12, 10, 10, 12, 10, 50, 10))
+		require.Len(t, values, 1)
+		require.Equal(t, data.Labels{"host": "a"}, values[0].GetLabels())
+		require.Equal(t, []*float64{nil, nil, zero, one, zero, zero, zero, zero, one, zero}, flags(values[0]))
+	})
+
+	t.Run("mad flags points outside the band of the preceding window", func(t *testing.T) {
+		cmd, err := NewAnomalyCommand("B", "A", AnomalyMAD, 5*time.Second, 0, 3, false)
+		require.NoError(t, err)
+		values := execute(t, cmd, series(10, 11, 9, 10, 11, 9, 10, 11, 50, 10))
+		require.Equal(t, []*float64{nil, nil, zero, zero, zero, zero, zero, zero, one, zero}, flags(values[0]))
+	})
+
+	t.Run("seasonal compares with the window one season earlier", func(t *testing.T) {
+		cmd, err := NewAnomalyCommand("B", "A", AnomalySeasonal, 3*time.Second, 10*time.Second, 3, false)
+		require.NoError(t, err)
+		values := execute(t, cmd, series(
+			5, 5, 6, 5, 5, 6, 5, 5, 6, 5,
+			5, 5, 6, 5, 5, 30, 5, 5, 6, 5,
+		))
+		f := flags(values[0])
+		for i := 0; i < 11; i++ {
+			require.Nil(t, f[i], "no data one season earlier for point %d", i)
+		}
+		require.Equal(t, one, f[15])
+		require.Equal(t, zero, f[16])
+	})
+
+	t.Run("bands are returned with a band label", func(t *testing.T) {
+		cmd, err := NewAnomalyCommand("B", "A", AnomalyZScore, 5*time.Second, 0, 1, true)
+		require.NoError(t, err)
+		values := execute(t, cmd, series(2, 4, 4))
+		require.Len(t, values, 3)
+		require.Equal(t, data.Labels{"host": "a", "anomaly_band": "lower"}, values[1].GetLabels())
+		require.Equal(t, data.Labels{"host": "a", "anomaly_band": "upper"}, values[2].GetLabels())
+		require.Equal(t, []*float64{nil, nil, util.Pointer(float64(2))}, flags(values[1]))
+		require.Equal(t, []*float64{nil, nil, util.Pointer(float64(4))}, flags(values[2]))
+	})
+
+	t.Run("no data is passed through", func(t *testing.T) {
+		cmd, err := NewAnomalyCommand("B", "A", AnomalyZScore, time.Minute, 0, 3, false)
+		require.NoError(t, err)
+		require.Equal(t, mathexp.Values{mathexp.NoData{}.New()}, execute(t, cmd, mathexp.NoData{}.New()))
+	})
+
+	t.Run("numbers should error", func(t *testing.T) {
+		cmd, err := NewAnomalyCommand("B", "A", AnomalyZScore, time.Minute, 0, 3, false)
+		require.NoError(t, err)
+		_, err = cmd.Execute(context.Background(), time.Now(), mathexp.Vars{"A": {Values: mathexp.Values{mathexp.NewNumber("A", nil)}}}, tracing.InitializeTracerForTest(), nil)
+		require.Error(t, err)
+	})
+}
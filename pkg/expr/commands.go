@@ -218,22 +218,31 @@ type ResampleCommand struct {
 	VarToResample string
 	Downsampler   mathexp.ReducerID
 	Upsampler     mathexp.Upsampler
+	Alignment     ResampleAlignment
 	TimeRange     TimeRange
 	refID         string
 }
 
 // NewResampleCommand creates a new ResampleCMD.
-func NewResampleCommand(refID, rawWindow, varToResample string, downsampler mathexp.ReducerID, upsampler mathexp.Upsampler, tr TimeRange) (*ResampleCommand, error) {
+func NewResampleCommand(refID, rawWindow, varToResample string, downsampler mathexp.ReducerID, upsampler mathexp.Upsampler, alignment ResampleAlignment, tr TimeRange) (*ResampleCommand, error) {
 	// TODO: validate reducer here, before execution
 	window, err := gtime.ParseDuration(rawWindow)
 	if err != nil {
 		return nil, fmt.Errorf(`failed to parse resample "window" duration field %q: %w`, window, err)
 	}
+	switch alignment {
+	case "":
+		alignment = ResampleAlignFrom
+	case ResampleAlignFrom, ResampleAlignInterval:
+	default:
+		return nil, fmt.Errorf("resample alignment '%s' is not supported. Supported only: [%s,%s]", alignment, ResampleAlignFrom, ResampleAlignInterval)
+	}
 	return &ResampleCommand{
 		Window:        window,
 		VarToResample: varToResample,
 		Downsampler:   downsampler,
 		Upsampler:     upsampler,
+		Alignment:     alignment,
 		TimeRange:     tr,
 		refID:         refID,
 	}, nil
@@ -282,10 +291,19 @@ func UnmarshalResampleCommand(rn *rawNode) (*ResampleCommand, error) {
 		return nil, fmt.Errorf("expected resample downsampler to be a string, got type %T", upsampler)
 	}
 
+	var alignment string
+	if rawAlignment, ok := rn.Query["alignment"]; ok {
+		alignment, ok = rawAlignment.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected resample alignment to be a string, got type %T", rawAlignment)
+		}
+	}
+
 	return NewResampleCommand(rn.RefID, window,
 		varToResample,
 		mathexp.ReducerID(downsampler),
 		mathexp.Upsampler(upsampler),
+		ResampleAlignment(alignment),
 		rn.TimeRange)
 }
 
@@ -302,6 +320,9 @@ func (gr *ResampleCommand) Execute(ctx context.Context, now time.Time, vars math
 	defer span.End()
 	newRes := mathexp.Results{}
 	timeRange := gr.TimeRange.AbsoluteTime(now)
+	if gr.Alignment == ResampleAlignInterval {
+		timeRange.From = alignToInterval(timeRange.From, gr.Window)
+	}
 	for _, val := range vars[gr.VarToResample].Values {
 		if val == nil {
 			continue
@@ -327,6 +348,18 @@ func (gr *ResampleCommand) Type() string {
 	return TypeResample.String()
 }
 
+// alignToInterval returns the first time at or after t that is a multiple of the interval since the Unix epoch.
+func alignToInterval(t time.Time, interval time.Duration) time.Time {
+	if interval <= 0 {
+		return t
+	}
+	aligned := time.Unix(0, t.UnixNano()-t.UnixNano()%int64(interval)).In(t.Location())
+	if aligned.Before(t) {
+		aligned = aligned.Add(interval)
+	}
+	return aligned
+}
+
 // CommandType is the type of the expression command.
 type CommandType int
 
@@ -345,6 +378,12 @@ const (
 	TypeThreshold
 	// TypeSQL is the CMDType for running SQL expressions
 	TypeSQL
+	// TypeAggregate is the CMDType for aggregating values across series by labels.
+	TypeAggregate
+	// TypeAnomaly is the CMDType for detecting anomalies in time series.
+	TypeAnomaly
+	// TypeForecast is the CMDType for forecasting time series.
+	TypeForecast
 )
 
 func (gt CommandType) String() string {
@@ -361,6 +400,12 @@ func (gt CommandType) String() string {
 		return "threshold"
 	case TypeSQL:
 		return "sql"
+	case TypeAggregate:
+		return "aggregate"
+	case TypeAnomaly:
+		return "anomaly"
+	case TypeForecast:
+		return "forecast"
 	default:
 		return "unknown"
 	}
@@ -381,6 +426,12 @@ func ParseCommandType(s string) (CommandType, error) {
 		return TypeThreshold, nil
 	case "sql":
 		return TypeSQL, nil
+	case "aggregate":
+		return TypeAggregate, nil
+	case "anomaly":
+		return TypeAnomaly, nil
+	case "forecast":
+		return TypeForecast, nil
 	default:
 		return TypeUnknown, fmt.Errorf("'%v' is not a recognized expression type", s)
 	}
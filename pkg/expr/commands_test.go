@@ -221,7 +221,7 @@ func TestResampleCommand_Execute(t *testing.T) {
 		From: -10 * time.Second,
 		To:   0,
 	}
-	cmd, err := NewResampleCommand(util.GenerateShortUID(), "1s", varToReduce, "sum", "pad", tr)
+	cmd, err := NewResampleCommand(util.GenerateShortUID(), "1s", varToReduce, "sum", "pad", "", tr)
 	require.NoError(t, err)
 
 	var tests = []struct {
@@ -273,3 +273,40 @@ func TestResampleCommand_Execute(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestResampleCommand_Alignment(t *testing.T) {
+	varToResample := util.GenerateShortUID()
+	now := time.Unix(1000, 0) // 16m40s after the epoch
+	tr := RelativeTimeRange{
+		From: -5 * time.Minute,
+		To:   0,
+	}
+	vars := mathexp.Vars{
+		varToResample: mathexp.Results{Values: mathexp.Values{mathexp.NewSeries(varToResample, nil, 0)}},
+	}
+
+	t.Run("should start at the beginning of the time range by default", func(t *testing.T) {
+		cmd, err := NewResampleCommand(util.GenerateShortUID(), "1m", varToResample, "mean", "pad", "", tr)
+		require.NoError(t, err)
+		require.Equal(t, ResampleAlignFrom, cmd.Alignment)
+		result, err := cmd.Execute(context.Background(), now, vars, tracing.InitializeTracerForTest(), nil)
+		require.NoError(t, err)
+		s := result.Values[0].(mathexp.Series)
+		require.Equal(t, time.Unix(700, 0), s.GetTime(0))
+	})
+
+	t.Run("should align to multiples of the window when alignment is interval", func(t *testing.T) {
+		cmd, err := NewResampleCommand(util.GenerateShortUID(), "1m", varToResample, "mean", "pad", ResampleAlignInterval, tr)
+		require.NoError(t, err)
+		result, err := cmd.Execute(context.Background(), now, vars, tracing.InitializeTracerForTest(), nil)
+		require.NoError(t, err)
+		s := result.Values[0].(mathexp.Series)
+		require.Equal(t, time.Unix(720, 0), s.GetTime(0))
+		require.Equal(t, time.Unix(960, 0), s.GetTime(s.Len()-1))
+	})
+
+	t.Run("should error on unknown alignment", func(t *testing.T) {
+		_, err := NewResampleCommand(util.GenerateShortUID(), "1m", varToResample, "mean", "pad", "foo", tr)
+		require.Error(t, err)
+	})
+}
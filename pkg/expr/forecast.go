@@ -0,0 +1,388 @@
+package expr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/expr/metrics"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+)
+
+// The model fitted to the series
+// +enum
+type ForecastModel string
+
+const (
+	// Least squares linear regression, like predict_linear in PromQL
+	ForecastLinear ForecastModel = "linear"
+
+	// Holt-Winters exponential smoothing, with an additive season if a season is set
+	ForecastHoltWinters ForecastModel = "holt_winters"
+)
+
+// What the forecast expression returns
+// +enum
+type ForecastOutput string
+
+const (
+	// The forecasted series over the horizon
+	ForecastOutputSeries ForecastOutput = "forecast"
+
+	// The number of seconds until the forecast crosses the threshold
+	ForecastOutputTimeToThreshold ForecastOutput = "time_to_threshold"
+)
+
+// maxForecastSteps is the maximum number of points that are forecasted for a series.
+const maxForecastSteps = 10000
+
+const (
+	defaultForecastAlpha = 0.5
+	defaultForecastBeta  = 0.1
+	defaultForecastGamma = 0.1
+)
+
+var supportedForecastModels = []string{
+	string(ForecastLinear),
+	string(ForecastHoltWinters),
+}
+
+// ForecastCommand is an expression command that fits a model to each input series and forecasts
+// its values over a horizon. Depending on the output it returns the forecasted series, or a number
+// with the seconds until the forecast crosses a threshold.
+type ForecastCommand struct {
+	VarToForecast string
+	Model         ForecastModel
+	Horizon       time.Duration
+	Season        time.Duration
+	Output        ForecastOutput
+	Threshold     float64
+	Alpha         float64
+	Beta          float64
+	Gamma         float64
+	refID         string
+}
+
+// NewForecastCommand creates a new ForecastCommand.
+func NewForecastCommand(refID, varToForecast string, model ForecastModel, horizon, season time.Duration, output ForecastOutput, threshold float64) (*ForecastCommand, error) {
+	switch model {
+	case ForecastLinear, ForecastHoltWinters:
+	default:
+		return nil, fmt.Errorf("expected forecast model to be one of [%s], got %s", strings.Join(supportedForecastModels, ", "), model)
+	}
+	switch output {
+	case "":
+		output = ForecastOutputSeries
+	case ForecastOutputSeries, ForecastOutputTimeToThreshold:
+	default:
+		return nil, fmt.Errorf("forecast output '%s' is not supported. Supported only: [%s,%s]", output, ForecastOutputSeries, ForecastOutputTimeToThreshold)
+	}
+	if horizon <= 0 {
+		return nil, fmt.Errorf("horizon must be greater than zero")
+	}
+	if season < 0 {
+		return nil, fmt.Errorf("season must not be negative")
+	}
+	return &ForecastCommand{
+		VarToForecast: varToForecast,
+		Model:         model,
+		Horizon:       horizon,
+		Season:        season,
+		Output:        output,
+		Threshold:     threshold,
+		Alpha:         defaultForecastAlpha,
+		Beta:          defaultForecastBeta,
+		Gamma:         defaultForecastGamma,
+		refID:         refID,
+	}, nil
+}
+
+// UnmarshalForecastCommand creates a ForecastCommand from Grafana's frontend query.
+func UnmarshalForecastCommand(rn *rawNode) (*ForecastCommand, error) {
+	q := ForecastQuery{}
+	if err := json.Unmarshal(rn.QueryRaw, &q); err != nil {
+		return nil, fmt.Errorf("failed to parse the forecast command: %w", err)
+	}
+	varToForecast := strings.TrimPrefix(q.Expression, "$")
+	if varToForecast == "" {
+		return nil, fmt.Errorf("no variable specified to forecast for refId %v", rn.RefID)
+	}
+	horizon, err := gtime.ParseDuration(q.Horizon)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to parse forecast "horizon" duration field %q: %w`, q.Horizon, err)
+	}
+	var season time.Duration
+	if q.Season != "" {
+		season, err = gtime.ParseDuration(q.Season)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to parse forecast "season" duration field %q: %w`, q.Season, err)
+		}
+	}
+	var threshold float64
+	if q.Threshold != nil {
+		threshold = *q.Threshold
+	} else if q.Output == ForecastOutputTimeToThreshold {
+		return nil, fmt.Errorf("threshold must be specified when the output is '%s'", ForecastOutputTimeToThreshold)
+	}
+
+	cmd, err := NewForecastCommand(rn.RefID, varToForecast, q.Model, horizon, season, q.Output, threshold)
+	if err != nil {
+		return nil, err
+	}
+	for name, v := range map[string]*float64{"alpha": q.Alpha, "beta": q.Beta, "gamma": q.Gamma} {
+		if v != nil && (*v < 0 || *v > 1) {
+			return nil, fmt.Errorf("%s must be between 0 and 1, got %v", name, *v)
+		}
+	}
+	if q.Alpha != nil {
+		cmd.Alpha = *q.Alpha
+	}
+	if q.Beta != nil {
+		cmd.Beta = *q.Beta
+	}
+	if q.Gamma != nil {
+		cmd.Gamma = *q.Gamma
+	}
+	return cmd, nil
+}
+
+// NeedsVars returns the variable names (refIds) that are dependencies
+// to execute the command and allows the command to fulfill the Command interface.
+func (fc *ForecastCommand) NeedsVars() []string {
+	return []string{fc.VarToForecast}
+}
+
+// Execute runs the command and returns the results or an error if the command
+// failed to execute.
+func (fc *ForecastCommand) Execute(ctx context.Context, _ time.Time, vars mathexp.Vars, tracer tracing.Tracer, _ *metrics.ExprMetrics) (mathexp.Results, error) {
+	_, span := tracer.Start(ctx, "SSE.ExecuteForecast")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("model", string(fc.Model)),
+		attribute.String("output", string(fc.Output)),
+	)
+
+	newRes := mathexp.Results{}
+	for _, val := range vars[fc.VarToForecast].Values {
+		switch v := val.(type) {
+		case mathexp.Series:
+			forecast, err := fc.forecast(v)
+			if err != nil {
+				return newRes, err
+			}
+			if fc.Output == ForecastOutputTimeToThreshold {
+				n := mathexp.NewNumber(fc.refID, v.GetLabels())
+				n.SetValue(fc.timeToThreshold(v, forecast))
+				newRes.Values = append(newRes.Values, n)
+				continue
+			}
+			newRes.Values = append(newRes.Values, forecast)
+		case mathexp.NoData:
+			newRes.Values = append(newRes.Values, v.New())
+		default:
+			return newRes, fmt.Errorf("can only forecast type series, got type %v", val.Type())
+		}
+	}
+	return newRes, nil
+}
+
+func (fc *ForecastCommand) Type() string {
+	return TypeForecast.String()
+}
+
+// forecast fits the model to the non-null points of the series and returns the forecasted points over the horizon.
+// The forecast has one point per step, which is the median interval between the points of the series.
+// If there are not enough points to fit the model the forecast is empty.
+func (fc *ForecastCommand) forecast(s mathexp.Series) (mathexp.Series, error) {
+	times := make([]time.Time, 0, s.Len())
+	values := make([]float64, 0, s.Len())
+	for _, i := range sortedSeriesIndexes(s) {
+		t, f := s.GetPoint(i)
+		if f == nil || math.IsNaN(*f) || math.IsInf(*f, 0) {
+			continue
+		}
+		times = append(times, t)
+		values = append(values, *f)
+	}
+
+	result := mathexp.NewSeries(fc.refID, s.GetLabels(), 0)
+	if len(values) < 2 {
+		return result, nil
+	}
+	step := medianStep(times)
+	if step <= 0 {
+		return result, nil
+	}
+	last := times[len(times)-1]
+	steps := int(fc.Horizon / step)
+	if steps > maxForecastSteps {
+		return result, fmt.Errorf("forecast horizon of %s with a step of %s needs %d points, more than the maximum of %d: use a shorter horizon or a longer interval", fc.Horizon, step, steps, maxForecastSteps)
+	}
+
+	var predict func(t time.Time, h int) float64
+	switch fc.Model {
+	case ForecastLinear:
+		slope, intercept := linearRegression(times, values)
+		predict = func(t time.Time, _ int) float64 {
+			return intercept + slope*t.Sub(times[0]).Seconds()
+		}
+	case ForecastHoltWinters:
+		m := int(fc.Season / step)
+		if m >= 2 && len(values) < 2*m {
+			return result, fmt.Errorf("holt_winters with a season of %s needs at least two seasons of data, got %d points with a step of %s", fc.Season, len(values), step)
+		}
+		if m < 2 {
+			m = 0
+		}
+		hw := fitHoltWinters(values, m, fc.Alpha, fc.Beta, fc.Gamma)
+		predict = func(_ time.Time, h int) float64 {
+			return hw.forecast(h)
+		}
+	}
+
+	for h := 1; h <= steps; h++ {
+		t := last.Add(time.Duration(h) * step)
+		v := predict(t, h)
+		result.AppendPoint(t, &v)
+	}
+	return result, nil
+}
+
+// timeToThreshold returns the seconds from the last point of the series until the forecast crosses the threshold.
+// The crossing time is interpolated linearly between forecast points.
+// It returns 0 if the last value is already at the threshold, and nil if the forecast does not cross it within the horizon.
+func (fc *ForecastCommand) timeToThreshold(s mathexp.Series, forecast mathexp.Series) *float64 {
+	var lastTime time.Time
+	var lastValue *float64
+	for _, i := range sortedSeriesIndexes(s) {
+		t, f := s.GetPoint(i)
+		if f == nil || math.IsNaN(*f) || math.IsInf(*f, 0) {
+			continue
+		}
+		lastTime, lastValue = t, f
+	}
+	if lastValue == nil {
+		return nil
+	}
+	if *lastValue == fc.Threshold {
+		zero := float64(0)
+		return &zero
+	}
+	rising := *lastValue < fc.Threshold
+
+	prevTime, prev := lastTime, *lastValue
+	for i := 0; i < forecast.Len(); i++ {
+		t, f := forecast.GetPoint(i)
+		if (rising && *f >= fc.Threshold) || (!rising && *f <= fc.Threshold) {
+			ratio := (fc.Threshold - prev) / (*f - prev)
+			crossing := prevTime.Add(time.Duration(ratio * float64(t.Sub(prevTime))))
+			seconds := crossing.Sub(lastTime).Seconds()
+			return &seconds
+		}
+		prevTime, prev = t, *f
+	}
+	return nil
+}
+
+// sortedSeriesIndexes returns the indexes of the series points ordered by time.
+func sortedSeriesIndexes(s mathexp.Series) []int {
+	idx := make([]int, s.Len())
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool { return s.GetTime(idx[i]).Before(s.GetTime(idx[j])) })
+	return idx
+}
+
+// medianStep returns the median interval between consecutive times.
+func medianStep(times []time.Time) time.Duration {
+	steps := make([]time.Duration, 0, len(times)-1)
+	for i := 1; i < len(times); i++ {
+		steps = append(steps, times[i].Sub(times[i-1]))
+	}
+	sort.Slice(steps, func(i, j int) bool { return steps[i] < steps[j] })
+	return steps[len(steps)/2]
+}
+
+// linearRegression returns the least squares fit of the values against the seconds since the first time.
+func linearRegression(times []time.Time, values []float64) (slope, intercept float64) {
+	var sumX, sumY, sumXY, sumX2 float64
+	n := float64(len(values))
+	for i, v := range values {
+		x := times[i].Sub(times[0]).Seconds()
+		sumX += x
+		sumY += v
+		sumXY += x * v
+		sumX2 += x * x
+	}
+	denominator := n*sumX2 - sumX*sumX
+	if denominator == 0 {
+		return 0, sumY / n
+	}
+	slope = (n*sumXY - sumX*sumY) / denominator
+	intercept = (sumY - slope*sumX) / n
+	return slope, intercept
+}
+
+type holtWinters struct {
+	level    float64
+	trend    float64
+	seasonal []float64 // one value per point of the input, empty without a season
+	season   int
+}
+
+// fitHoltWinters fits an additive Holt-Winters model with a season of m points to the values.
+// If m is 0 it fits Holt's linear trend model, without a seasonal component.
+func fitHoltWinters(values []float64, m int, alpha, beta, gamma float64) holtWinters {
+	hw := holtWinters{season: m}
+	if m == 0 {
+		hw.level = values[0]
+		hw.trend = values[1] - values[0]
+		for _, v := range values[1:] {
+			prevLevel := hw.level
+			hw.level = alpha*v + (1-alpha)*(hw.level+hw.trend)
+			hw.trend = beta*(hw.level-prevLevel) + (1-beta)*hw.trend
+		}
+		return hw
+	}
+
+	var firstMean, secondMean float64
+	for i := 0; i < m; i++ {
+		firstMean += values[i]
+		secondMean += values[m+i]
+	}
+	firstMean /= float64(m)
+	secondMean /= float64(m)
+	hw.level = firstMean
+	hw.trend = (secondMean - firstMean) / float64(m)
+	hw.seasonal = make([]float64, len(values))
+	for i := 0; i < m; i++ {
+		hw.seasonal[i] = values[i] - firstMean
+	}
+	for i := m; i < len(values); i++ {
+		prevLevel := hw.level
+		hw.level = alpha*(values[i]-hw.seasonal[i-m]) + (1-alpha)*(hw.level+hw.trend)
+		hw.trend = beta*(hw.level-prevLevel) + (1-beta)*hw.trend
+		hw.seasonal[i] = gamma*(values[i]-hw.level) + (1-gamma)*hw.seasonal[i-m]
+	}
+	return hw
+}
+
+// forecast returns the value h steps after the last fitted point.
+func (hw holtWinters) forecast(h int) float64 {
+	v := hw.level + float64(h)*hw.trend
+	if hw.season > 0 {
+		n := len(hw.seasonal)
+		v += hw.seasonal[n-hw.season+(h-1)%hw.season]
+	}
+	return v
+}
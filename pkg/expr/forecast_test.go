@@ -0,0 +1,186 @@
+package expr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+func TestUnmarshalForecastCommand(t *testing.T) {
+	cases := []struct {
+		description   string
+		query         string
+		expectedError string
+		assert        func(*testing.T, *ForecastCommand)
+	}{
+		{
+			description: "unmarshal proper object with defaults",
+			query:       `{ "expression": "$A", "type": "forecast", "model": "linear", "horizon": "4h" }`,
+			assert: func(t *testing.T, cmd *ForecastCommand) {
+				require.Equal(t, []string{"A"}, cmd.NeedsVars())
+				require.Equal(t, ForecastLinear, cmd.Model)
+				require.Equal(t, 4*time.Hour, cmd.Horizon)
+				require.Equal(t, ForecastOutputSeries, cmd.Output)
+				require.Equal(t, defaultForecastAlpha, cmd.Alpha)
+			},
+		},
+		{
+			description: "unmarshal holt winters with time to threshold",
+			query: `{ "expression": "A", "type": "forecast", "model": "holt_winters", "horizon": "4h", "season": "1d",
+				"output": "time_to_threshold", "threshold": 95, "alpha": 0.3, "beta": 0.2, "gamma": 0.4 }`,
+			assert: func(t *testing.T, cmd *ForecastCommand) {
+				require.Equal(t, ForecastHoltWinters, cmd.Model)
+				require.Equal(t, 24*time.Hour, cmd.Season)
+				require.Equal(t, ForecastOutputTimeToThreshold, cmd.Output)
+				require.Equal(t, float64(95), cmd.Threshold)
+				require.Equal(t, 0.3, cmd.Alpha)
+				require.Equal(t, 0.2, cmd.Beta)
+				require.Equal(t, 0.4, cmd.Gamma)
+			},
+		},
+		{
+			description:   "time to threshold without threshold should error",
+			query:         `{ "expression": "A", "type": "forecast", "model": "linear", "horizon": "4h", "output": "time_to_threshold" }`,
+			expectedError: "threshold must be specified",
+		},
+		{
+			description:   "unsupported model should error",
+			query:         `{ "expression": "A", "type": "forecast", "model": "arima", "horizon": "4h" }`,
+			expectedError: "expected forecast model to be one of",
+		},
+		{
+			description:   "missing horizon should error",
+			query:         `{ "expression": "A", "type": "forecast", "model": "linear" }`,
+			expectedError: `failed to parse forecast "horizon" duration field`,
+		},
+		{
+			description:   "smoothing factor out of range should error",
+			query:         `{ "expression": "A", "type": "forecast", "model": "holt_winters", "horizon": "4h", "alpha": 2 }`,
+			expectedError: "alpha must be between 0 and 1",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			cmd, err := UnmarshalForecastCommand(&rawNode{
+				RefID:     "B",
+				QueryRaw:  []byte(tc.query),
+				TimeRange: RelativeTimeRange{},
+			})
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			tc.assert(t, cmd)
+		})
+	}
+}
+
+func TestForecastExecute(t *testing.T) {
+	labels := data.Labels{"mountpoint": "/"}
+	series := func(values ...float64) mathexp.Series {
+		s := mathexp.NewSeries("A", labels, len(values))
+		for i, v := range values {
+			s.SetPoint(i, time.Unix(int64(i), 0), util.Pointer(v))
+		}
+		return s
+	}
+	execute := func(t *testing.T, cmd *ForecastCommand, values ...mathexp.Value) mathexp.Values {
+		t.Helper()
+		res, err := cmd.Execute(context.Background(), time.Now(), mathexp.Vars{"A": {Values: values}}, tracing.InitializeTracerForTest(), nil)
+		require.NoError(t, err)
+		return res.Values
+	}
+	points := func(v mathexp.Value) map[int64]float64 {
+		s := v.(mathexp.Series)
+		result := make(map[int64]float64, s.Len())
+		for i := 0; i < s.Len(); i++ {
+			t, f := s.GetPoint(i)
+			result[t.Unix()] = *f
+		}
+		return result
+	}
+	linear := series(0, 2, 4, 6, 8, 10, 12, 14, 16, 18)
+
+	t.Run("linear forecasts the trend over the horizon", func(t *testing.T) {
+		cmd, err := NewForecastCommand("B", "A", ForecastLinear, 5*time.Second, 0, "", 0)
+		require.NoError(t, err)
+		values := execute(t, cmd, linear)
+		require.Len(t, values, 1)
+		require.Equal(t, labels, values[0].GetLabels())
+		require.Equal(t, map[int64]float64{10: 20, 11: 22, 12: 24, 13: 26, 14: 28}, points(values[0]))
+	})
+
+	t.Run("holt winters without season follows the trend", func(t *testing.T) {
+		cmd, err := NewForecastCommand("B", "A", ForecastHoltWinters, 3*time.Second, 0, "", 0)
+		require.NoError(t, err)
+		values := execute(t, cmd, linear)
+		require.Equal(t, map[int64]float64{10: 20, 11: 22, 12: 24}, points(values[0]))
+	})
+
+	t.Run("holt winters with season repeats the seasonal pattern", func(t *testing.T) {
+		cmd, err := NewForecastCommand("B", "A", ForecastHoltWinters, 4*time.Second, 4*time.Second, "", 0)
+		require.NoError(t, err)
+		values := execute(t, cmd, series(1, 2, 3, 2, 1, 2, 3, 2, 1, 2, 3, 2))
+		require.Equal(t, map[int64]float64{12: 1, 13: 2, 14: 3, 15: 2}, points(values[0]))
+	})
+
+	t.Run("horizon is limited to a maximum number of points", func(t *testing.T) {
+		cmd, err := NewForecastCommand("B", "A", ForecastLinear, 365*24*time.Hour, 0, "", 0)
+		require.NoError(t, err)
+		_, err = cmd.Execute(context.Background(), time.Now(), mathexp.Vars{"A": {Values: mathexp.Values{linear}}}, tracing.InitializeTracerForTest(), nil)
+		require.ErrorContains(t, err, "more than the maximum")
+	})
+
+	t.Run("holt winters with season needs two seasons of data", func(t *testing.T) {
+		cmd, err := NewForecastCommand("B", "A", ForecastHoltWinters, 4*time.Second, 8*time.Second, "", 0)
+		require.NoError(t, err)
+		_, err = cmd.Execute(context.Background(), time.Now(), mathexp.Vars{"A": {Values: mathexp.Values{linear}}}, tracing.InitializeTracerForTest(), nil)
+		require.ErrorContains(t, err, "needs at least two seasons of data")
+	})
+
+	t.Run("time to threshold", func(t *testing.T) {
+		cases := []struct {
+			name      string
+			threshold float64
+			expected  *float64
+		}{
+			{name: "crossed within the horizon", threshold: 25, expected: util.Pointer(3.5)},
+			{name: "already at the threshold", threshold: 18, expected: util.Pointer(float64(0))},
+			{name: "not crossed within the horizon", threshold: 100, expected: nil},
+			{name: "moving away from the threshold", threshold: 10, expected: nil},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				cmd, err := NewForecastCommand("B", "A", ForecastLinear, 5*time.Second, 0, ForecastOutputTimeToThreshold, tc.threshold)
+				require.NoError(t, err)
+				values := execute(t, cmd, linear)
+				require.Len(t, values, 1)
+				n := values[0].(mathexp.Number)
+				require.Equal(t, labels, n.GetLabels())
+				require.Equal(t, tc.expected, n.GetFloat64Value())
+			})
+		}
+	})
+
+	t.Run("series with less than two points has an empty forecast", func(t *testing.T) {
+		cmd, err := NewForecastCommand("B", "A", ForecastLinear, 5*time.Second, 0, "", 0)
+		require.NoError(t, err)
+		values := execute(t, cmd, series(1))
+		require.Equal(t, 0, values[0].(mathexp.Series).Len())
+	})
+
+	t.Run("no data is passed through", func(t *testing.T) {
+		cmd, err := NewForecastCommand("B", "A", ForecastLinear, time.Minute, 0, "", 0)
+		require.NoError(t, err)
+		require.Equal(t, mathexp.Values{mathexp.NoData{}.New()}, execute(t, cmd, mathexp.NoData{}.New()))
+	})
+}
@@ -137,10 +137,14 @@ func (dp *DataPipeline) execute(c context.Context, now time.Time, s *Service) (m
 			return vars, makeUnexpectedNodeTypeError(node.RefID(), node.NodeType().String())
 		}
 
+		start := time.Now()
 		res, err := execNode.Execute(c, now, vars, s)
 		if err != nil {
 			res.Error = err
 		}
+		if recorder := executionRecorderFromContext(c); recorder != nil {
+			recorder.RecordNodeDuration(node.RefID(), time.Since(start))
+		}
 
 		vars[node.RefID()] = res
 	}
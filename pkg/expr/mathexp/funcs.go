@@ -1,7 +1,11 @@
 package mathexp
 
 import (
+	"fmt"
 	"math"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
 
 	"github.com/grafana/grafana/pkg/expr/mathexp/parse"
 )
@@ -68,6 +72,33 @@ var builtins = map[string]parse.Func{
 		VariantReturn: true,
 		F:             floor,
 	},
+	"rate": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet},
+		Return: parse.TypeSeriesSet,
+		F:      rate,
+	},
+	"delta": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet},
+		Return: parse.TypeSeriesSet,
+		F:      delta,
+	},
+	"cumsum": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet},
+		Return: parse.TypeSeriesSet,
+		F:      cumsum,
+	},
+	"timeshift": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet, parse.TypeString},
+		Return: parse.TypeSeriesSet,
+		F:      timeshift,
+		Check:  checkDurationArg,
+	},
+	"moving_avg": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet, parse.TypeString},
+		Return: parse.TypeSeriesSet,
+		F:      movingAvg,
+		Check:  checkDurationArg,
+	},
 }
 
 // abs returns the absolute value for each result in NumberSet, SeriesSet, or Scalar
@@ -307,3 +338,176 @@ func floor(e *State, varSet Results) (Results, error) {
 	}
 	return newRes, nil
 }
+
+// rate returns the per-second rate of increase between consecutive points of each series.
+// The series is treated as a monotonic counter: a decrease in value is considered a counter reset,
+// in which case the new value is the increase since the reset.
+// Null and NaN values produce a null point and are skipped when finding the previous point.
+func rate(e *State, varSet Results) (Results, error) {
+	return perSeries(e, varSet, "rate", func(s Series) (Series, error) {
+		return seriesDiff(e.RefID, s, func(prevT, t time.Time, prev, cur float64) *float64 {
+			elapsed := t.Sub(prevT).Seconds()
+			if elapsed <= 0 {
+				return nil
+			}
+			increase := cur - prev
+			if cur < prev { // counter reset
+				increase = cur
+			}
+			r := increase / elapsed
+			return &r
+		}), nil
+	})
+}
+
+// delta returns the difference between each point of each series and the previous point.
+// Null and NaN values produce a null point and are skipped when finding the previous point.
+func delta(e *State, varSet Results) (Results, error) {
+	return perSeries(e, varSet, "delta", func(s Series) (Series, error) {
+		return seriesDiff(e.RefID, s, func(_, _ time.Time, prev, cur float64) *float64 {
+			d := cur - prev
+			return &d
+		}), nil
+	})
+}
+
+// cumsum returns the running total of each series. Null and NaN values produce a null point
+// and do not contribute to the total.
+func cumsum(e *State, varSet Results) (Results, error) {
+	return perSeries(e, varSet, "cumsum", func(s Series) (Series, error) {
+		newSeries := NewSeries(e.RefID, s.GetLabels(), s.Len())
+		total := float64(0)
+		for i := 0; i < s.Len(); i++ {
+			t, f := s.GetPoint(i)
+			if f == nil || math.IsNaN(*f) {
+				newSeries.SetPoint(i, t, nil)
+				continue
+			}
+			total += *f
+			nF := total
+			newSeries.SetPoint(i, t, &nF)
+		}
+		return newSeries, nil
+	})
+}
+
+// timeshift moves the timestamps of each series forward by the given duration (e.g. "1h"),
+// so that the values from an hour ago line up with the current time. A negative duration moves them backwards.
+func timeshift(e *State, varSet Results, rawDuration string) (Results, error) {
+	d, err := gtime.ParseDuration(rawDuration)
+	if err != nil {
+		return Results{}, fmt.Errorf("timeshift: invalid duration %q: %w", rawDuration, err)
+	}
+	return perSeries(e, varSet, "timeshift", func(s Series) (Series, error) {
+		newSeries := NewSeries(e.RefID, s.GetLabels(), s.Len())
+		for i := 0; i < s.Len(); i++ {
+			t, f := s.GetPoint(i)
+			newSeries.SetPoint(i, t.Add(d), f)
+		}
+		return newSeries, nil
+	})
+}
+
+// movingAvg returns, for each point of each series, the average of the values within the
+// window (e.g. "5m") that ends at that point, inclusive. Null and NaN values are excluded from the average.
+// If there are no values in the window the point is null.
+func movingAvg(e *State, varSet Results, rawWindow string) (Results, error) {
+	window, err := gtime.ParseDuration(rawWindow)
+	if err != nil {
+		return Results{}, fmt.Errorf("moving_avg: invalid window %q: %w", rawWindow, err)
+	}
+	if window <= 0 {
+		return Results{}, fmt.Errorf("moving_avg: window must be greater than zero, got %q", rawWindow)
+	}
+	return perSeries(e, varSet, "moving_avg", func(s Series) (Series, error) {
+		newSeries := NewSeries(e.RefID, s.GetLabels(), s.Len())
+		start := 0
+		sum := float64(0)
+		count := 0
+		for i := 0; i < s.Len(); i++ {
+			t, f := s.GetPoint(i)
+			if f != nil && !math.IsNaN(*f) {
+				sum += *f
+				count++
+			}
+			for ; start < i; start++ {
+				st, sf := s.GetPoint(start)
+				if st.After(t.Add(-window)) {
+					break
+				}
+				if sf != nil && !math.IsNaN(*sf) {
+					sum -= *sf
+					count--
+				}
+			}
+			if count == 0 {
+				newSeries.SetPoint(i, t, nil)
+				continue
+			}
+			avg := sum / float64(count)
+			newSeries.SetPoint(i, t, &avg)
+		}
+		return newSeries, nil
+	})
+}
+
+// checkDurationArg validates at parse time that the second argument of a function is a valid duration.
+func checkDurationArg(_ *parse.Tree, f *parse.FuncNode) error {
+	arg, ok := f.Args[1].(*parse.StringNode)
+	if !ok {
+		return fmt.Errorf("parse: expected a duration string as the second argument of %s", f.Name)
+	}
+	if _, err := gtime.ParseDuration(arg.Text); err != nil {
+		return fmt.Errorf("parse: invalid duration %q for %s: %w", arg.Text, f.Name, err)
+	}
+	return nil
+}
+
+// perSeries sorts a copy of each Series in varSet by time and passes it to seriesF.
+// NoData values are passed through, any other value type results in an error.
+func perSeries(e *State, varSet Results, name string, seriesF func(s Series) (Series, error)) (Results, error) {
+	newRes := Results{}
+	for _, res := range varSet.Values {
+		switch v := res.(type) {
+		case Series:
+			sorted := NewSeries(v.GetName(), v.GetLabels(), v.Len())
+			for i := 0; i < v.Len(); i++ {
+				t, f := v.GetPoint(i)
+				sorted.SetPoint(i, t, f)
+			}
+			sorted.SortByTime(false)
+			newSeries, err := seriesF(sorted)
+			if err != nil {
+				return newRes, err
+			}
+			newRes.Values = append(newRes.Values, newSeries)
+		case NoData:
+			newRes.Values = append(newRes.Values, v.New())
+		default:
+			return newRes, fmt.Errorf("%s expects a series, got %v", name, res.Type())
+		}
+	}
+	return newRes, nil
+}
+
+// seriesDiff calls diffF with each point of the series and the closest previous point that has a value.
+// The first point with a value, and points that are null or NaN, are null in the returned series.
+func seriesDiff(refID string, s Series, diffF func(prevT, t time.Time, prev, cur float64) *float64) Series {
+	newSeries := NewSeries(refID, s.GetLabels(), s.Len())
+	var prevT time.Time
+	var prev *float64
+	for i := 0; i < s.Len(); i++ {
+		t, f := s.GetPoint(i)
+		if f == nil || math.IsNaN(*f) {
+			newSeries.SetPoint(i, t, nil)
+			continue
+		}
+		if prev == nil {
+			newSeries.SetPoint(i, t, nil)
+		} else {
+			newSeries.SetPoint(i, t, diffF(prevT, t, *prev, *f))
+		}
+		prevT, prev = t, f
+	}
+	return newSeries
+}
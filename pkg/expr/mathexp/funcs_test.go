@@ -140,3 +140,155 @@ func TestIsNumberFunc(t *testing.T) {
 		})
 	}
 }
+
+func TestTimeFuncs(t *testing.T) {
+	var tests = []struct {
+		name      string
+		expr      string
+		vars      Vars
+		newErrIs  require.ErrorAssertionFunc
+		execErrIs require.ErrorAssertionFunc
+		results   Results
+	}{
+		{
+			name: "rate on series handles nulls and counter resets",
+			expr: "rate($A)",
+			vars: Vars{
+				"A": resultValuesNoErr(
+					makeSeries("", nil,
+						tp{time.Unix(10, 0), float64Pointer(10)},
+						tp{time.Unix(20, 0), float64Pointer(30)},
+						tp{time.Unix(30, 0), nil},
+						tp{time.Unix(40, 0), float64Pointer(70)},
+						tp{time.Unix(50, 0), float64Pointer(5)}),
+				),
+			},
+			newErrIs:  require.NoError,
+			execErrIs: require.NoError,
+			results: resultValuesNoErr(
+				makeSeries("", nil,
+					tp{time.Unix(10, 0), nil},
+					tp{time.Unix(20, 0), float64Pointer(2)},
+					tp{time.Unix(30, 0), nil},
+					tp{time.Unix(40, 0), float64Pointer(2)},
+					tp{time.Unix(50, 0), float64Pointer(0.5)}),
+			),
+		},
+		{
+			name: "delta on unsorted series",
+			expr: "delta($A)",
+			vars: Vars{
+				"A": resultValuesNoErr(
+					makeSeries("", nil,
+						tp{time.Unix(20, 0), float64Pointer(3)},
+						tp{time.Unix(10, 0), float64Pointer(5)},
+						tp{time.Unix(30, 0), float64Pointer(math.NaN())},
+						tp{time.Unix(40, 0), float64Pointer(4)}),
+				),
+			},
+			newErrIs:  require.NoError,
+			execErrIs: require.NoError,
+			results: resultValuesNoErr(
+				makeSeries("", nil,
+					tp{time.Unix(10, 0), nil},
+					tp{time.Unix(20, 0), float64Pointer(-2)},
+					tp{time.Unix(30, 0), nil},
+					tp{time.Unix(40, 0), float64Pointer(1)}),
+			),
+		},
+		{
+			name: "cumsum skips nulls",
+			expr: "cumsum($A)",
+			vars: Vars{
+				"A": resultValuesNoErr(
+					makeSeries("", nil,
+						tp{time.Unix(10, 0), float64Pointer(1)},
+						tp{time.Unix(20, 0), nil},
+						tp{time.Unix(30, 0), float64Pointer(2)}),
+				),
+			},
+			newErrIs:  require.NoError,
+			execErrIs: require.NoError,
+			results: resultValuesNoErr(
+				makeSeries("", nil,
+					tp{time.Unix(10, 0), float64Pointer(1)},
+					tp{time.Unix(20, 0), nil},
+					tp{time.Unix(30, 0), float64Pointer(3)}),
+			),
+		},
+		{
+			name: "timeshift moves timestamps forward",
+			expr: `timeshift($A, "1m")`,
+			vars: Vars{
+				"A": resultValuesNoErr(
+					makeSeries("", nil,
+						tp{time.Unix(10, 0), float64Pointer(1)},
+						tp{time.Unix(20, 0), float64Pointer(2)}),
+				),
+			},
+			newErrIs:  require.NoError,
+			execErrIs: require.NoError,
+			results: resultValuesNoErr(
+				makeSeries("", nil,
+					tp{time.Unix(70, 0), float64Pointer(1)},
+					tp{time.Unix(80, 0), float64Pointer(2)}),
+			),
+		},
+		{
+			name: "moving_avg over time window",
+			expr: `moving_avg($A, "20s")`,
+			vars: Vars{
+				"A": resultValuesNoErr(
+					makeSeries("", nil,
+						tp{time.Unix(10, 0), float64Pointer(2)},
+						tp{time.Unix(20, 0), float64Pointer(4)},
+						tp{time.Unix(30, 0), nil},
+						tp{time.Unix(40, 0), float64Pointer(9)},
+						tp{time.Unix(70, 0), nil}),
+				),
+			},
+			newErrIs:  require.NoError,
+			execErrIs: require.NoError,
+			results: resultValuesNoErr(
+				makeSeries("", nil,
+					tp{time.Unix(10, 0), float64Pointer(2)},
+					tp{time.Unix(20, 0), float64Pointer(3)},
+					tp{time.Unix(30, 0), float64Pointer(4)},
+					tp{time.Unix(40, 0), float64Pointer(9)},
+					tp{time.Unix(70, 0), nil}),
+			),
+		},
+		{
+			name: "rate on number - should error",
+			expr: "rate($A)",
+			vars: Vars{
+				"A": resultValuesNoErr(makeNumber("", nil, float64Pointer(7))),
+			},
+			newErrIs:  require.NoError,
+			execErrIs: require.Error,
+		},
+		{
+			name:     "moving_avg with invalid window - should error",
+			expr:     `moving_avg($A, "five minutes")`,
+			newErrIs: require.Error,
+		},
+		{
+			name:     "timeshift without duration - should error",
+			expr:     `timeshift($A)`,
+			newErrIs: require.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := New(tt.expr)
+			tt.newErrIs(t, err)
+			if e != nil {
+				res, err := e.Execute("", tt.vars, tracing.InitializeTracerForTest())
+				tt.execErrIs(t, err)
+				if tt.results.Values != nil {
+					require.Equal(t, tt.results, res)
+				}
+			}
+		})
+	}
+}
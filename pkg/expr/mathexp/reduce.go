@@ -22,11 +22,22 @@ const (
 	ReducerCount  ReducerID = "count"
 	ReducerLast   ReducerID = "last"
 	ReducerMedian ReducerID = "median"
+	ReducerFirst  ReducerID = "first"
+	ReducerStdDev ReducerID = "stddev"
+	ReducerRange  ReducerID = "range"
+	ReducerDiff   ReducerID = "diff"
+	// Percentage change from the first to the last value
+	ReducerDiffPercent ReducerID = "diffperc"
+	ReducerP95         ReducerID = "p95"
+	ReducerP99         ReducerID = "p99"
 )
 
 // GetSupportedReduceFuncs returns collection of supported function names
 func GetSupportedReduceFuncs() []ReducerID {
-	return []ReducerID{ReducerSum, ReducerMean, ReducerMin, ReducerMax, ReducerCount, ReducerLast, ReducerMedian}
+	return []ReducerID{
+		ReducerSum, ReducerMean, ReducerMin, ReducerMax, ReducerCount, ReducerLast, ReducerMedian,
+		ReducerFirst, ReducerStdDev, ReducerRange, ReducerDiff, ReducerDiffPercent, ReducerP95, ReducerP99,
+	}
 }
 
 func Sum(fv *Float64Field) *float64 {
@@ -126,6 +137,92 @@ func Median(fv *Float64Field) *float64 {
 	}
 }
 
+func First(fv *Float64Field) *float64 {
+	var f float64
+	if fv.Len() == 0 {
+		f = math.NaN()
+		return &f
+	}
+	return fv.GetValue(0)
+}
+
+// StdDev returns the population standard deviation of the values.
+func StdDev(fv *Float64Field) *float64 {
+	mean := Avg(fv)
+	if math.IsNaN(*mean) {
+		return mean
+	}
+	var sumSquares float64
+	for i := 0; i < fv.Len(); i++ {
+		d := *fv.GetValue(i) - *mean
+		sumSquares += d * d
+	}
+	f := math.Sqrt(sumSquares / float64(fv.Len()))
+	return &f
+}
+
+// Range returns the difference between the largest and the smallest value.
+func Range(fv *Float64Field) *float64 {
+	f := *Max(fv) - *Min(fv)
+	return &f
+}
+
+// Diff returns the difference between the last and the first value.
+func Diff(fv *Float64Field) *float64 {
+	first, last := First(fv), Last(fv)
+	if first == nil || last == nil {
+		nan := math.NaN()
+		return &nan
+	}
+	f := *last - *first
+	return &f
+}
+
+// DiffPercent returns the change from the first to the last value as a percentage of the first value.
+func DiffPercent(fv *Float64Field) *float64 {
+	first, diff := First(fv), Diff(fv)
+	if first == nil {
+		return diff
+	}
+	f := *diff / *first * 100
+	return &f
+}
+
+func P95(fv *Float64Field) *float64 {
+	return percentile(fv, 0.95)
+}
+
+func P99(fv *Float64Field) *float64 {
+	return percentile(fv, 0.99)
+}
+
+// percentile returns the p-th percentile (0 < p < 1) of the values,
+// linearly interpolated between the closest ranks in the same way as Median.
+func percentile(fv *Float64Field, p float64) *float64 {
+	values := make([]float64, 0, fv.Len())
+	for i := 0; i < fv.Len(); i++ {
+		v := fv.GetValue(i)
+		if v == nil || math.IsNaN(*v) {
+			nan := math.NaN()
+			return &nan
+		}
+		values = append(values, *v)
+	}
+
+	if len(values) == 0 {
+		nan := math.NaN()
+		return &nan
+	}
+
+	sort.Float64s(values)
+	rank := p * float64(len(values)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	weight := rank - float64(lower)
+	f := values[lower]*(1-weight) + values[upper]*weight
+	return &f
+}
+
 func GetReduceFunc(rFunc ReducerID) (ReducerFunc, error) {
 	switch rFunc {
 	case ReducerSum:
@@ -142,6 +239,20 @@ func GetReduceFunc(rFunc ReducerID) (ReducerFunc, error) {
 		return Last, nil
 	case ReducerMedian:
 		return Median, nil
+	case ReducerFirst:
+		return First, nil
+	case ReducerStdDev:
+		return StdDev, nil
+	case ReducerRange:
+		return Range, nil
+	case ReducerDiff:
+		return Diff, nil
+	case ReducerDiffPercent:
+		return DiffPercent, nil
+	case ReducerP95:
+		return P95, nil
+	case ReducerP99:
+		return P99, nil
 	default:
 		return nil, fmt.Errorf("reduction %v not implemented", rFunc)
 	}
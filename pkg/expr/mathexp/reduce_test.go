@@ -28,6 +28,25 @@ var seriesEmpty = Vars{
 	),
 }
 
+var seriesFiveValues = Vars{
+	"A": resultValuesNoErr(
+		makeSeries("temp", nil,
+			tp{time.Unix(5, 0), float64Pointer(4)},
+			tp{time.Unix(10, 0), float64Pointer(2)},
+			tp{time.Unix(15, 0), float64Pointer(8)},
+			tp{time.Unix(20, 0), float64Pointer(6)},
+			tp{time.Unix(25, 0), float64Pointer(10)}),
+	),
+}
+
+var seriesHundredValues = func() Vars {
+	points := make([]tp, 0, 101)
+	for i := 100; i >= 0; i-- {
+		points = append(points, tp{time.Unix(int64(i), 0), float64Pointer(float64(i))})
+	}
+	return Vars{"A": resultValuesNoErr(makeSeries("temp", nil, points...))}
+}()
+
 func TestSeriesReduce(t *testing.T) {
 	var tests = []struct {
 		name        string
@@ -274,6 +293,132 @@ func TestSeriesReduce(t *testing.T) {
 			resultsIs:   require.Equal,
 			results:     resultValuesNoErr(makeNumber("", nil, nil)),
 		},
+		{
+			name:        "first series",
+			red:         "first",
+			varToReduce: "A",
+			vars:        seriesFiveValues,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, float64Pointer(4))),
+		},
+		{
+			name:        "first empty series",
+			red:         "first",
+			varToReduce: "A",
+			vars:        seriesEmpty,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, NaN)),
+		},
+		{
+			name:        "stddev series",
+			red:         "stddev",
+			varToReduce: "A",
+			vars:        seriesFiveValues,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, float64Pointer(math.Sqrt(8)))),
+		},
+		{
+			name:        "stddev series with a nil value",
+			red:         "stddev",
+			varToReduce: "A",
+			vars:        seriesWithNil,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, NaN)),
+		},
+		{
+			name:        "range series",
+			red:         "range",
+			varToReduce: "A",
+			vars:        seriesFiveValues,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, float64Pointer(8))),
+		},
+		{
+			name:        "range series with a nil value",
+			red:         "range",
+			varToReduce: "A",
+			vars:        seriesWithNil,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, NaN)),
+		},
+		{
+			name:        "diff series",
+			red:         "diff",
+			varToReduce: "A",
+			vars:        seriesFiveValues,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, float64Pointer(6))),
+		},
+		{
+			name:        "diff series with a nil value",
+			red:         "diff",
+			varToReduce: "A",
+			vars:        seriesWithNil,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, NaN)),
+		},
+		{
+			name:        "diffperc series",
+			red:         "diffperc",
+			varToReduce: "A",
+			vars:        seriesFiveValues,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, float64Pointer(150))),
+		},
+		{
+			name:        "diffperc empty series",
+			red:         "diffperc",
+			varToReduce: "A",
+			vars:        seriesEmpty,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, NaN)),
+		},
+		{
+			name:        "p95 series",
+			red:         "p95",
+			varToReduce: "A",
+			vars:        seriesHundredValues,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, float64Pointer(95))),
+		},
+		{
+			name:        "p99 series",
+			red:         "p99",
+			varToReduce: "A",
+			vars:        seriesHundredValues,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, float64Pointer(99))),
+		},
+		{
+			name:        "p95 series with a nil value",
+			red:         "p95",
+			varToReduce: "A",
+			vars:        seriesWithNil,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, NaN)),
+		},
+		{
+			name:        "p99 empty series",
+			red:         "p99",
+			varToReduce: "A",
+			vars:        seriesEmpty,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, NaN)),
+		},
 	}
 
 	for _, tt := range tests {
@@ -394,6 +539,34 @@ func TestSeriesReduceDropNN(t *testing.T) {
 			vars:        seriesWithNil,
 			results:     resultValuesNoErr(makeNumber("", nil, float64Pointer(1))),
 		},
+		{
+			name:        "DropNN: stddev series with a nil value and real value",
+			red:         "stddev",
+			varToReduce: "A",
+			vars:        seriesWithNil,
+			results:     resultValuesNoErr(makeNumber("", nil, float64Pointer(0))),
+		},
+		{
+			name:        "DropNN: range series that becomes empty after filtering non-number",
+			red:         "range",
+			varToReduce: "A",
+			vars:        seriesNonNumbers,
+			results:     resultValuesNoErr(makeNumber("", nil, nil)),
+		},
+		{
+			name:        "DropNN: diff series with a nil value and real value",
+			red:         "diff",
+			varToReduce: "A",
+			vars:        seriesWithNil,
+			results:     resultValuesNoErr(makeNumber("", nil, float64Pointer(0))),
+		},
+		{
+			name:        "DropNN: p95 series that becomes empty after filtering non-number",
+			red:         "p95",
+			varToReduce: "A",
+			vars:        seriesNonNumbers,
+			results:     resultValuesNoErr(makeNumber("", nil, nil)),
+		},
 	}
 
 	for _, tt := range tests {
@@ -516,6 +689,34 @@ func TestSeriesReduceReplaceNN(t *testing.T) {
 			vars:        seriesWithNil,
 			results:     resultValuesNoErr(makeNumber("", nil, float64Pointer(2))),
 		},
+		{
+			name:        "replaceNN: first series that becomes empty after filtering non-number",
+			red:         "first",
+			varToReduce: "A",
+			vars:        seriesNonNumbers,
+			results:     resultValuesNoErr(makeNumber("", nil, float64Pointer(replaceWith))),
+		},
+		{
+			name:        "replaceNN: range series with a nil value and real value",
+			red:         "range",
+			varToReduce: "A",
+			vars:        seriesWithNil,
+			results:     resultValuesNoErr(makeNumber("", nil, float64Pointer(math.Abs(2-replaceWith)))),
+		},
+		{
+			name:        "replaceNN: diff series with a nil value and real value",
+			red:         "diff",
+			varToReduce: "A",
+			vars:        seriesWithNil,
+			results:     resultValuesNoErr(makeNumber("", nil, float64Pointer(replaceWith-2))),
+		},
+		{
+			name:        "replaceNN: p99 empty series",
+			red:         "p99",
+			varToReduce: "A",
+			vars:        seriesEmpty,
+			results:     resultValuesNoErr(makeNumber("", nil, float64Pointer(replaceWith))),
+		},
 	}
 
 	for _, tt := range tests {
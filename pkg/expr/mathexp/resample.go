@@ -20,6 +20,12 @@ const (
 
 	// Do not fill values (nill)
 	UpsamplerFillNA Upsampler = "fillna"
+
+	// Interpolate linearly between the last seen and the next value
+	UpsamplerLinear Upsampler = "linear"
+
+	// Use the value closest in time, either the last seen or the next value
+	UpsamplerNearest Upsampler = "nearest"
 )
 
 // Resample turns the Series into a Number based on the given reduction function
@@ -28,9 +34,14 @@ func (s Series) Resample(refID string, interval time.Duration, downsampler Reduc
 	if newSeriesLength <= 0 {
 		return s, fmt.Errorf("the series cannot be sampled further; the time range is shorter than the interval")
 	}
+	reduce, err := GetReduceFunc(downsampler)
+	if err != nil {
+		return s, fmt.Errorf("downsampling %v not implemented", downsampler)
+	}
 	resampled := NewSeries(refID, s.GetLabels(), newSeriesLength+1)
 	bookmark := 0
 	var lastSeen *float64
+	var lastSeenTime time.Time
 	idx := 0
 	t := from
 	for !t.After(to) && idx <= newSeriesLength {
@@ -44,6 +55,7 @@ func (s Series) Resample(refID string, interval time.Duration, downsampler Reduc
 			bookmark++
 			sIdx++
 			lastSeen = v
+			lastSeenTime = st
 			vals = append(vals, v)
 		}
 		var value *float64
@@ -63,6 +75,28 @@ func (s Series) Resample(refID string, interval time.Duration, downsampler Reduc
 				}
 			case UpsamplerFillNA:
 				value = nil
+			case UpsamplerLinear:
+				if lastSeen == nil || sIdx == s.Len() { // nothing to interpolate between
+					value = nil
+					break
+				}
+				nextTime, next := s.GetPoint(sIdx)
+				if next == nil {
+					value = nil
+					break
+				}
+				ratio := float64(t.Sub(lastSeenTime)) / float64(nextTime.Sub(lastSeenTime))
+				interpolated := *lastSeen + (*next-*lastSeen)*ratio
+				value = &interpolated
+			case UpsamplerNearest:
+				value = lastSeen
+				if sIdx == s.Len() { // no vals left
+					break
+				}
+				nextTime, next := s.GetPoint(sIdx)
+				if lastSeen == nil || (next != nil && nextTime.Sub(t) < t.Sub(lastSeenTime)) {
+					value = next
+				}
 			default:
 				return s, fmt.Errorf("upsampling %v not implemented", upsampler)
 			}
@@ -71,22 +105,7 @@ func (s Series) Resample(refID string, interval time.Duration, downsampler Reduc
 		} else { // downsampling
 			fVec := data.NewField("", s.GetLabels(), vals)
 			ff := Float64Field(*fVec)
-			var tmp *float64
-			switch downsampler {
-			case ReducerSum:
-				tmp = Sum(&ff)
-			case ReducerMean:
-				tmp = Avg(&ff)
-			case ReducerMin:
-				tmp = Min(&ff)
-			case ReducerMax:
-				tmp = Max(&ff)
-			case ReducerLast:
-				tmp = Last(&ff)
-			default:
-				return s, fmt.Errorf("downsampling %v not implemented", downsampler)
-			}
-			value = tmp
+			value = reduce(&ff)
 		}
 		resampled.SetPoint(idx, t, value)
 		t = t.Add(interval)
@@ -277,6 +277,62 @@ func TestResampleSeries(t *testing.T) {
 				time.Unix(9, 0), float64Pointer(0),
 			}),
 		},
+		{
+			name:        "resample series: upsampling (mean / linear )",
+			interval:    time.Second * 2,
+			downsampler: "mean",
+			upsampler:   "linear",
+			timeRange: backend.TimeRange{
+				From: time.Unix(0, 0),
+				To:   time.Unix(11, 0),
+			},
+			seriesToResample: makeSeries("", nil, tp{
+				time.Unix(2, 0), float64Pointer(0),
+			}, tp{
+				time.Unix(7, 0), float64Pointer(10),
+			}),
+			series: makeSeries("", nil, tp{
+				time.Unix(0, 0), nil,
+			}, tp{
+				time.Unix(2, 0), float64Pointer(0),
+			}, tp{
+				time.Unix(4, 0), float64Pointer(4),
+			}, tp{
+				time.Unix(6, 0), float64Pointer(8),
+			}, tp{
+				time.Unix(8, 0), float64Pointer(10),
+			}, tp{
+				time.Unix(10, 0), nil,
+			}),
+		},
+		{
+			name:        "resample series: upsampling (mean / nearest )",
+			interval:    time.Second * 2,
+			downsampler: "mean",
+			upsampler:   "nearest",
+			timeRange: backend.TimeRange{
+				From: time.Unix(0, 0),
+				To:   time.Unix(11, 0),
+			},
+			seriesToResample: makeSeries("", nil, tp{
+				time.Unix(2, 0), float64Pointer(0),
+			}, tp{
+				time.Unix(7, 0), float64Pointer(10),
+			}),
+			series: makeSeries("", nil, tp{
+				time.Unix(0, 0), float64Pointer(0),
+			}, tp{
+				time.Unix(2, 0), float64Pointer(0),
+			}, tp{
+				time.Unix(4, 0), float64Pointer(0),
+			}, tp{
+				time.Unix(6, 0), float64Pointer(10),
+			}, tp{
+				time.Unix(8, 0), float64Pointer(10),
+			}, tp{
+				time.Unix(10, 0), float64Pointer(10),
+			}),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -290,3 +346,42 @@ func TestResampleSeries(t *testing.T) {
 		})
 	}
 }
+
+func TestResampleSeriesDownsamplers(t *testing.T) {
+	seriesToResample := makeSeries("", nil, tp{
+		time.Unix(1, 0), float64Pointer(1),
+	}, tp{
+		time.Unix(2, 0), float64Pointer(5),
+	}, tp{
+		time.Unix(3, 0), float64Pointer(3),
+	})
+	var tests = []struct {
+		downsampler ReducerID
+		expected    float64
+	}{
+		{downsampler: ReducerCount, expected: 3},
+		{downsampler: ReducerMedian, expected: 3},
+		{downsampler: ReducerFirst, expected: 1},
+		{downsampler: ReducerStdDev, expected: 1.632993161855452},
+		{downsampler: ReducerRange, expected: 4},
+		{downsampler: ReducerDiff, expected: 2},
+		{downsampler: ReducerDiffPercent, expected: 200},
+		{downsampler: ReducerP95, expected: 4.8},
+		{downsampler: ReducerP99, expected: 4.96},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.downsampler), func(t *testing.T) {
+			series, err := seriesToResample.Resample("", time.Second*5, tt.downsampler, UpsamplerFillNA, time.Unix(0, 0), time.Unix(5, 0))
+			require.NoError(t, err)
+			require.Equal(t, 2, series.Len())
+			_, v := series.GetPoint(1)
+			require.NotNil(t, v)
+			assert.InDelta(t, tt.expected, *v, 1e-9)
+		})
+	}
+
+	t.Run("unknown downsampler should error", func(t *testing.T) {
+		_, err := seriesToResample.Resample("", time.Second*5, "unknown", UpsamplerFillNA, time.Unix(0, 0), time.Unix(5, 0))
+		require.ErrorContains(t, err, "downsampling unknown not implemented")
+	})
+}
@@ -150,6 +150,12 @@ func buildCMDNode(ctx context.Context, rn *rawNode, toggles featuremgmt.FeatureT
 		node.Command, err = UnmarshalThresholdCommand(rn)
 	case TypeSQL:
 		node.Command, err = UnmarshalSQLCommand(ctx, rn, cfg)
+	case TypeAggregate:
+		node.Command, err = UnmarshalAggregateCommand(rn)
+	case TypeAnomaly:
+		node.Command, err = UnmarshalAnomalyCommand(rn)
+	case TypeForecast:
+		node.Command, err = UnmarshalForecastCommand(rn)
 	default:
 		return nil, fmt.Errorf("expression command type '%v' in expression '%v' not implemented", commandType, rn.RefID)
 	}
@@ -260,6 +266,17 @@ func executeDSNodesGrouped(ctx context.Context, now time.Time, vars mathexp.Vars
 			ctx, span := s.tracer.Start(ctx, "SSE.ExecuteDatasourceQuery")
 			defer span.End()
 
+			recorder := executionRecorderFromContext(ctx)
+			if recorder != nil {
+				start := time.Now()
+				defer func() {
+					duration := time.Since(start)
+					for _, dn := range nodeGroup {
+						recorder.RecordNodeDuration(dn.refID, duration)
+					}
+				}()
+			}
+
 			firstNode := nodeGroup[0]
 			logger := logger.FromContext(ctx).New("datasourceType", firstNode.datasource.Type,
 				"queryRefId", firstNode.refID,
@@ -350,6 +367,9 @@ func executeDSNodesGrouped(ctx context.Context, now time.Time, vars mathexp.Vars
 					instrument(err, "")
 					return
 				}
+				if recorder != nil {
+					recorder.RecordDatasourceResponse(dn.refID, dataFrames)
+				}
 
 				var result mathexp.Results
 				responseType, result, err := s.converter.Convert(ctx, dn.datasource.Type, dataFrames)
@@ -439,6 +459,9 @@ func (dn *DSNode) Execute(ctx context.Context, now time.Time, _ mathexp.Vars, s
 	if err != nil {
 		return mathexp.Results{}, MakeQueryError(dn.refID, dn.datasource.UID, err)
 	}
+	if recorder := executionRecorderFromContext(ctx); recorder != nil {
+		recorder.RecordDatasourceResponse(dn.refID, dataFrames)
+	}
 
 	var result mathexp.Results
 
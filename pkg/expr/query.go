@@ -29,6 +29,15 @@ const (
 
 	// SQL query
 	QueryTypeSQL QueryType = "sql"
+
+	// Aggregate query results by labels
+	QueryTypeAggregate QueryType = "aggregate"
+
+	// Detect anomalies in query results
+	QueryTypeAnomaly QueryType = "anomaly"
+
+	// Forecast query results
+	QueryTypeForecast QueryType = "forecast"
 )
 
 type MathQuery struct {
@@ -60,6 +69,75 @@ type ResampleQuery struct {
 
 	// The upsample function
 	Upsampler mathexp.Upsampler `json:"upsampler"`
+
+	// How the resampled points are aligned
+	Alignment ResampleAlignment `json:"alignment,omitempty"`
+}
+
+// QueryType = aggregate
+type AggregateQuery struct {
+	// Reference to single query result
+	Expression string `json:"expression" jsonschema:"minLength=1,example=$A"`
+
+	// The function used to combine the values of each group
+	Aggregator AggregateFunc `json:"aggregator"`
+
+	// The labels to group by. When empty, all values are combined into one
+	By []string `json:"by,omitempty"`
+
+	// The quantile (between 0 and 1), only valid when the aggregator is quantile
+	Quantile *float64 `json:"quantile,omitempty"`
+}
+
+// QueryType = anomaly
+type AnomalyQuery struct {
+	// Reference to single query result
+	Expression string `json:"expression" jsonschema:"minLength=1,example=$A"`
+
+	// The method used to calculate the expected band of values
+	Algorithm AnomalyAlgorithm `json:"algorithm"`
+
+	// The duration of the reference window used to calculate the band
+	Window string `json:"window" jsonschema:"minLength=1,example=1h,example=30m"`
+
+	// The length of a season, only used by the seasonal algorithm
+	Season string `json:"season,omitempty" jsonschema:"example=1w,example=1d"`
+
+	// The width of the band, in standard deviations. Defaults to 3
+	Sensitivity *float64 `json:"sensitivity,omitempty"`
+
+	// Also return the lower and upper band series
+	Bands bool `json:"bands,omitempty"`
+}
+
+// QueryType = forecast
+type ForecastQuery struct {
+	// Reference to single query result
+	Expression string `json:"expression" jsonschema:"minLength=1,example=$A"`
+
+	// The model fitted to each series
+	Model ForecastModel `json:"model"`
+
+	// How far past the last point to forecast
+	Horizon string `json:"horizon" jsonschema:"minLength=1,example=4h,example=1d"`
+
+	// The length of a season, only used by the holt_winters model
+	Season string `json:"season,omitempty" jsonschema:"example=1d,example=1w"`
+
+	// What to return, the forecasted series (default) or the time until the threshold is crossed
+	Output ForecastOutput `json:"output,omitempty"`
+
+	// The value to compute the time to, required when output is time_to_threshold
+	Threshold *float64 `json:"threshold,omitempty"`
+
+	// Holt-Winters level smoothing factor between 0 and 1. Defaults to 0.5
+	Alpha *float64 `json:"alpha,omitempty"`
+
+	// Holt-Winters trend smoothing factor between 0 and 1. Defaults to 0.1
+	Beta *float64 `json:"beta,omitempty"`
+
+	// Holt-Winters seasonal smoothing factor between 0 and 1. Defaults to 0.1
+	Gamma *float64 `json:"gamma,omitempty"`
 }
 
 type ThresholdQuery struct {
@@ -107,6 +185,18 @@ const (
 	ReduceModeReplace ReduceMode = "replaceNN"
 )
 
+// Resample alignment
+// +enum
+type ResampleAlignment string
+
+const (
+	// Default alignment, points start at the beginning of the query time range
+	ResampleAlignFrom ResampleAlignment = "from"
+
+	// Align points to multiples of the window since the Unix epoch, e.g. on the minute for a 1m window
+	ResampleAlignInterval ResampleAlignment = "interval"
+)
+
 //go:embed query.types.json
 var f embed.FS
 
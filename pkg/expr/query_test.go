@@ -11,6 +11,7 @@ import (
 
 	"github.com/grafana/grafana/pkg/expr/classic"
 	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/util"
 )
 
 func TestQueryTypeDefinitions(t *testing.T) {
@@ -26,6 +27,11 @@ func TestQueryTypeDefinitions(t *testing.T) {
 				reflect.TypeOf(mathexp.UpsamplerPad), // pick an example value (not the root)
 				reflect.TypeOf(ReduceModeDrop),       // pick an example value (not the root)
 				reflect.TypeOf(ThresholdIsAbove),
+				reflect.TypeOf(AggregateSum),
+				reflect.TypeOf(ResampleAlignInterval),
+				reflect.TypeOf(AnomalyZScore),
+				reflect.TypeOf(ForecastLinear),
+				reflect.TypeOf(ForecastOutputSeries),
 				reflect.TypeOf(classic.ConditionOperatorAnd),
 			},
 		})
@@ -78,6 +84,79 @@ func TestQueryTypeDefinitions(t *testing.T) {
 						Upsampler:   mathexp.UpsamplerPad,
 					}),
 				},
+				{
+					Name: "resample on the minute with linear interpolation",
+					SaveModel: data.AsUnstructured(ResampleQuery{
+						Expression:  "$A",
+						Window:      "1m",
+						Downsampler: mathexp.ReducerMean,
+						Upsampler:   mathexp.UpsamplerLinear,
+						Alignment:   ResampleAlignInterval,
+					}),
+				},
+			},
+		},
+		schemabuilder.QueryTypeInfo{
+			Discriminators: data.NewDiscriminators("type", QueryTypeAggregate),
+			GoType:         reflect.TypeOf(&AggregateQuery{}),
+			Examples: []data.QueryExample{
+				{
+					Name: "sum by cluster",
+					SaveModel: data.AsUnstructured(AggregateQuery{
+						Expression: "$A",
+						Aggregator: AggregateSum,
+						By:         []string{"cluster"},
+					}),
+				},
+			},
+		},
+		schemabuilder.QueryTypeInfo{
+			Discriminators: data.NewDiscriminators("type", QueryTypeAnomaly),
+			GoType:         reflect.TypeOf(&AnomalyQuery{}),
+			Examples: []data.QueryExample{
+				{
+					Name: "rolling z-score over the last hour",
+					SaveModel: data.AsUnstructured(AnomalyQuery{
+						Expression: "$A",
+						Algorithm:  AnomalyZScore,
+						Window:     "1h",
+					}),
+				},
+				{
+					Name: "same hour last week with bands",
+					SaveModel: data.AsUnstructured(AnomalyQuery{
+						Expression: "$A",
+						Algorithm:  AnomalySeasonal,
+						Window:     "1h",
+						Season:     "1w",
+						Bands:      true,
+					}),
+				},
+			},
+		},
+		schemabuilder.QueryTypeInfo{
+			Discriminators: data.NewDiscriminators("type", QueryTypeForecast),
+			GoType:         reflect.TypeOf(&ForecastQuery{}),
+			Examples: []data.QueryExample{
+				{
+					Name: "linear forecast of the next 4 hours",
+					SaveModel: data.AsUnstructured(ForecastQuery{
+						Expression: "$A",
+						Model:      ForecastLinear,
+						Horizon:    "4h",
+					}),
+				},
+				{
+					Name: "seconds until 95 within the next 4 hours",
+					SaveModel: data.AsUnstructured(ForecastQuery{
+						Expression: "$A",
+						Model:      ForecastHoltWinters,
+						Horizon:    "4h",
+						Season:     "1d",
+						Output:     ForecastOutputTimeToThreshold,
+						Threshold:  util.Pointer(95.0),
+					}),
+				},
 			},
 		},
 		schemabuilder.QueryTypeInfo{
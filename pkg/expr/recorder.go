@@ -0,0 +1,31 @@
+package expr
+
+import (
+	"context"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// ExecutionRecorder is notified while the nodes of a pipeline are executed. It makes it possible to inspect
+// what a pipeline did beyond the results of its nodes.
+type ExecutionRecorder interface {
+	// RecordDatasourceResponse is called with the frames that a data source returned for a query before they
+	// are converted to the results of the node.
+	RecordDatasourceResponse(refID string, frames data.Frames)
+	// RecordNodeDuration is called with how long it took to execute a node. Queries that are sent to a data
+	// source in the same request share the duration of the request.
+	RecordNodeDuration(refID string, duration time.Duration)
+}
+
+type executionRecorderKey struct{}
+
+// WithExecutionRecorder returns a context that makes pipelines executed with it report to the recorder.
+func WithExecutionRecorder(ctx context.Context, r ExecutionRecorder) context.Context {
+	return context.WithValue(ctx, executionRecorderKey{}, r)
+}
+
+func executionRecorderFromContext(ctx context.Context) ExecutionRecorder {
+	r, _ := ctx.Value(executionRecorderKey{}).(ExecutionRecorder)
+	return r
+}
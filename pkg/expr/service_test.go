@@ -101,6 +101,64 @@ func TestService(t *testing.T) {
 	}
 }
 
+func TestServiceExecutionRecorder(t *testing.T) {
+	dsDF := data.NewFrame("test",
+		data.NewField("time", nil, []time.Time{time.Unix(1, 0)}),
+		data.NewField("value", data.Labels{"test": "label"}, []*float64{fp(2)}),
+	)
+
+	resp := map[string]backend.DataResponse{
+		"A": {Frames: data.Frames{dsDF}},
+	}
+
+	queries := []Query{
+		{
+			RefID: "A",
+			DataSource: &datasources.DataSource{
+				OrgID: 1,
+				UID:   "test",
+				Type:  "test",
+			},
+			JSON: json.RawMessage(`{ "datasource": { "uid": "1" }, "intervalMs": 1000, "maxDataPoints": 1000 }`),
+			TimeRange: AbsoluteTimeRange{
+				From: time.Time{},
+				To:   time.Time{},
+			},
+		},
+		{
+			RefID:      "B",
+			DataSource: dataSourceModel(),
+			JSON:       json.RawMessage(`{ "datasource": { "uid": "__expr__", "type": "__expr__"}, "type": "math", "expression": "$A * 2" }`),
+		},
+	}
+
+	s, req := newMockQueryService(resp, queries)
+
+	pl, err := s.BuildPipeline(t.Context(), req)
+	require.NoError(t, err)
+
+	recorder := &fakeExecutionRecorder{datasourceFrames: map[string]data.Frames{}}
+	_, err = s.ExecutePipeline(WithExecutionRecorder(context.Background(), recorder), time.Now(), pl)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"A", "B"}, recorder.nodes)
+	require.Len(t, recorder.datasourceFrames, 1)
+	require.Equal(t, data.Frames{dsDF}, recorder.datasourceFrames["A"])
+}
+
+type fakeExecutionRecorder struct {
+	nodes            []string
+	datasourceFrames map[string]data.Frames
+}
+
+func (r *fakeExecutionRecorder) RecordDatasourceResponse(refID string, frames data.Frames) {
+	r.datasourceFrames[refID] = frames
+}
+
+func (r *fakeExecutionRecorder) RecordNodeDuration(refID string, _ time.Duration) {
+	r.nodes = append(r.nodes, refID)
+}
+
 func TestDSQueryError(t *testing.T) {
 	resp := map[string]backend.DataResponse{
 		"A": {Error: fmt.Errorf("womp womp")},
@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 
 	"github.com/grafana/grafana/pkg/expr/mathexp"
@@ -25,7 +27,38 @@ type ThresholdCommand struct {
 	RefID         string
 	ThresholdFunc ThresholdType
 	Invert        bool
-	predicate     predicate
+	// Window, if set, reduces each series to a single number that tells whether the condition
+	// held for enough of the most recent points instead of evaluating every point.
+	Window    *ThresholdWindow
+	predicate predicate
+}
+
+// ThresholdWindow describes how many of the most recent points of a series must match the condition.
+// Either Count of the last Of points must match, or all points must have matched continuously for the duration For.
+type ThresholdWindow struct {
+	Count int
+	Of    int
+	For   time.Duration
+}
+
+// NewThresholdWindow creates a ThresholdWindow. If of is zero it defaults to count.
+func NewThresholdWindow(count, of int, forDuration time.Duration) (*ThresholdWindow, error) {
+	if count < 0 || of < 0 || forDuration < 0 {
+		return nil, errors.New("window count, of and for must not be negative")
+	}
+	if count > 0 && forDuration > 0 {
+		return nil, errors.New("window can either specify count or for, but not both")
+	}
+	if count == 0 && forDuration == 0 {
+		return nil, errors.New("window requires either count or for")
+	}
+	if of == 0 {
+		of = count
+	}
+	if of < count {
+		return nil, fmt.Errorf("window count %d must not be greater than the number of points %d", count, of)
+	}
+	return &ThresholdWindow{Count: count, Of: of, For: forDuration}, nil
 }
 
 // +enum
@@ -150,12 +183,19 @@ func UnmarshalThresholdCommand(rn *rawNode) (Command, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid condition: %w", err)
 	}
+	if firstCondition.Window != nil {
+		threshold.Window, err = firstCondition.Window.toWindow()
+		if err != nil {
+			return nil, fmt.Errorf("invalid window: %w", err)
+		}
+	}
 	if firstCondition.UnloadEvaluator != nil {
 		unloading, err := NewThresholdCommand(rn.RefID, referenceVar, firstCondition.UnloadEvaluator.Type, firstCondition.UnloadEvaluator.Params)
 		if err != nil {
 			return nil, fmt.Errorf("invalid unloadCondition: %w", err)
 		}
 		unloading.Invert = true
+		unloading.Window = threshold.Window
 		var d Fingerprints
 		if firstCondition.LoadedDimensions != nil {
 			d, err = FingerprintsFromFrame(firstCondition.LoadedDimensions)
@@ -194,6 +234,12 @@ func (tc *ThresholdCommand) Execute(_ context.Context, _ time.Time, vars mathexp
 	for _, val := range refVarResult.Values {
 		switch v := val.(type) {
 		case mathexp.Series:
+			if tc.Window != nil {
+				n := mathexp.NewNumber(tc.RefID, v.GetLabels())
+				n.SetValue(tc.evalWindow(v))
+				newRes.Values = append(newRes.Values, n)
+				continue
+			}
 			s := mathexp.NewSeries(tc.RefID, v.GetLabels(), v.Len())
 			for i := 0; i < v.Len(); i++ {
 				t, value := v.GetPoint(i)
@@ -219,6 +265,52 @@ func (tc *ThresholdCommand) Execute(_ context.Context, _ time.Time, vars mathexp
 func (tc *ThresholdCommand) Type() string {
 	return TypeThreshold.String()
 }
+
+// evalWindow returns 1 if the condition held for the window of the most recent points of the series, and 0 if it did not.
+// Null values never match the condition. It returns nil if the series has no points.
+// If the command is inverted, the window is evaluated with the original condition and the result is inverted,
+// so an unloading threshold releases a dimension only after the unload condition held for the whole window.
+func (tc *ThresholdCommand) evalWindow(s mathexp.Series) *float64 {
+	if s.Len() == 0 {
+		return nil
+	}
+	type point struct {
+		t     time.Time
+		match bool
+	}
+	points := make([]point, 0, s.Len())
+	for i := 0; i < s.Len(); i++ {
+		t, f := s.GetPoint(i)
+		points = append(points, point{t: t, match: f != nil && tc.predicate.Eval(*f)})
+	}
+	sort.SliceStable(points, func(i, j int) bool { return points[i].t.Before(points[j].t) })
+
+	var result bool
+	if tc.Window.For > 0 {
+		last := points[len(points)-1]
+		start := len(points)
+		for start > 0 && points[start-1].match {
+			start--
+		}
+		result = start < len(points) && last.t.Sub(points[start].t) >= tc.Window.For
+	} else {
+		matched := 0
+		for _, p := range points[max(0, len(points)-tc.Window.Of):] {
+			if p.match {
+				matched++
+			}
+		}
+		result = matched >= tc.Window.Count
+	}
+	if tc.Invert {
+		result = !result
+	}
+	if result {
+		return util.Pointer(float64(1))
+	}
+	return util.Pointer(float64(0))
+}
+
 func IsSupportedThresholdFunc(name string) bool {
 	isSupported := false
 
@@ -240,6 +332,31 @@ type ThresholdConditionJSON struct {
 	Evaluator        ConditionEvalJSON  `json:"evaluator"`
 	UnloadEvaluator  *ConditionEvalJSON `json:"unloadEvaluator,omitempty"`
 	LoadedDimensions *data.Frame        `json:"loadedDimensions,omitempty"`
+	// Reduces each time series to 1 or 0 depending on whether the condition held for the most recent points
+	Window *ThresholdWindowJSON `json:"window,omitempty"`
+}
+
+// ThresholdWindowJSON makes a threshold fire only if the condition held for "count" of the last "of" points
+// of a time series, or continuously for the duration "for" up to the last point.
+type ThresholdWindowJSON struct {
+	// The number of points that must match the condition
+	Count int `json:"count,omitempty"`
+	// The number of most recent points to consider. Defaults to count
+	Of int `json:"of,omitempty"`
+	// The duration for which the condition must have held continuously, e.g. 5m
+	For string `json:"for,omitempty"`
+}
+
+func (w ThresholdWindowJSON) toWindow() (*ThresholdWindow, error) {
+	var forDuration time.Duration
+	if w.For != "" {
+		var err error
+		forDuration, err = gtime.ParseDuration(w.For)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to parse "for" duration field %q: %w`, w.For, err)
+		}
+	}
+	return NewThresholdWindow(w.Count, w.Of, forDuration)
 }
 
 // IsHysteresisExpression returns true if the raw model describes a hysteresis command:
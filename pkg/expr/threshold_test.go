@@ -183,6 +183,92 @@ func TestUnmarshalThresholdCommand(t *testing.T) {
 				require.Equal(t, greaterThanPredicate{20.0}, cmd.predicate)
 			},
 		},
+		{
+			description: "unmarshal with N of M samples window",
+			query: `{
+				"expression" : "A",
+				"type": "threshold",
+				"conditions": [{
+					"evaluator": {
+						"type": "gt",
+						"params": [20]
+					},
+					"window": {
+						"count": 3,
+						"of": 5
+					}
+				}]
+			}`,
+			assert: func(t *testing.T, command Command) {
+				require.IsType(t, &ThresholdCommand{}, command)
+				cmd := command.(*ThresholdCommand)
+				require.Equal(t, &ThresholdWindow{Count: 3, Of: 5}, cmd.Window)
+			},
+		},
+		{
+			description: "unmarshal hysteresis with duration window applies it to both thresholds",
+			query: `{
+				"expression" : "A",
+				"type": "threshold",
+				"conditions": [{
+					"evaluator": {
+						"type": "gt",
+						"params": [100]
+					},
+					"unloadEvaluator": {
+						"type": "lt",
+						"params": [31]
+					},
+					"window": {
+						"for": "5m"
+					}
+				}]
+			}`,
+			assert: func(t *testing.T, command Command) {
+				require.IsType(t, &HysteresisCommand{}, command)
+				cmd := command.(*HysteresisCommand)
+				require.Equal(t, &ThresholdWindow{For: 5 * time.Minute}, cmd.LoadingThresholdFunc.Window)
+				require.Equal(t, &ThresholdWindow{For: 5 * time.Minute}, cmd.UnloadingThresholdFunc.Window)
+			},
+		},
+		{
+			description: "unmarshal with window with both count and for should error",
+			query: `{
+				"expression" : "A",
+				"type": "threshold",
+				"conditions": [{
+					"evaluator": {
+						"type": "gt",
+						"params": [20]
+					},
+					"window": {
+						"count": 3,
+						"for": "5m"
+					}
+				}]
+			}`,
+			shouldError:   true,
+			expectedError: "window can either specify count or for, but not both",
+		},
+		{
+			description: "unmarshal with window count greater than of should error",
+			query: `{
+				"expression" : "A",
+				"type": "threshold",
+				"conditions": [{
+					"evaluator": {
+						"type": "gt",
+						"params": [20]
+					},
+					"window": {
+						"count": 3,
+						"of": 2
+					}
+				}]
+			}`,
+			shouldError:   true,
+			expectedError: "must not be greater than the number of points",
+		},
 		{
 			description: "unmarshal with missing conditions should error",
 			query: `{
@@ -646,3 +732,105 @@ func TestThresholdExecute(t *testing.T) {
 		})
 	}
 }
+
+func TestThresholdExecuteWithWindow(t *testing.T) {
+	labels := data.Labels{"host": "a"}
+	series := func(values ...*float64) mathexp.Series {
+		s := mathexp.NewSeries("A", labels, len(values))
+		for i, v := range values {
+			s.SetPoint(i, time.Unix(int64(i*60), 0), v)
+		}
+		return s
+	}
+	f := func(v float64) *float64 { return util.Pointer(v) }
+
+	testCases := []struct {
+		name     string
+		window   ThresholdWindow
+		invert   bool
+		input    mathexp.Value
+		expected *float64
+	}{
+		{
+			name:     "N of M matches",
+			window:   ThresholdWindow{Count: 2, Of: 3},
+			input:    series(f(20), f(20), f(5), f(20), f(5), f(20)),
+			expected: f(1),
+		},
+		{
+			name:     "N of M does not match",
+			window:   ThresholdWindow{Count: 3, Of: 3},
+			input:    series(f(20), f(20), f(20), f(5), f(20), f(20)),
+			expected: f(0),
+		},
+		{
+			name:     "N of M with fewer points than M",
+			window:   ThresholdWindow{Count: 2, Of: 5},
+			input:    series(f(20), f(20)),
+			expected: f(1),
+		},
+		{
+			name:     "nulls do not match",
+			window:   ThresholdWindow{Count: 2, Of: 2},
+			input:    series(f(20), nil),
+			expected: f(0),
+		},
+		{
+			name:     "held for the duration",
+			window:   ThresholdWindow{For: 2 * time.Minute},
+			input:    series(f(5), f(20), f(20), f(20)),
+			expected: f(1),
+		},
+		{
+			name:     "not held for the duration",
+			window:   ThresholdWindow{For: 3 * time.Minute},
+			input:    series(f(5), f(20), f(20), f(20)),
+			expected: f(0),
+		},
+		{
+			name:     "last point does not match",
+			window:   ThresholdWindow{For: time.Minute},
+			input:    series(f(20), f(20), f(20), f(5)),
+			expected: f(0),
+		},
+		{
+			name:     "inverted result of the window",
+			window:   ThresholdWindow{Count: 2, Of: 2},
+			invert:   true,
+			input:    series(f(5), f(20)),
+			expected: f(1),
+		},
+		{
+			name:     "empty series",
+			window:   ThresholdWindow{Count: 1, Of: 1},
+			input:    series(),
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd, err := NewThresholdCommand("B", "A", ThresholdIsAbove, []float64{10})
+			require.NoError(t, err)
+			cmd.Window = &tc.window
+			cmd.Invert = tc.invert
+
+			res, err := cmd.Execute(context.Background(), time.Now(), mathexp.Vars{"A": {Values: mathexp.Values{tc.input}}}, tracing.InitializeTracerForTest(), nil)
+			require.NoError(t, err)
+			require.Len(t, res.Values, 1)
+			n, ok := res.Values[0].(mathexp.Number)
+			require.True(t, ok)
+			require.Equal(t, labels, n.GetLabels())
+			require.Equal(t, tc.expected, n.GetFloat64Value())
+		})
+	}
+
+	t.Run("numbers are evaluated as without window", func(t *testing.T) {
+		cmd, err := NewThresholdCommand("B", "A", ThresholdIsAbove, []float64{10})
+		require.NoError(t, err)
+		cmd.Window = &ThresholdWindow{Count: 2, Of: 2}
+		res, err := cmd.Execute(context.Background(), time.Now(), mathexp.Vars{"A": {Values: mathexp.Values{newNumber(labels, f(20))}}}, tracing.InitializeTracerForTest(), nil)
+		require.NoError(t, err)
+		require.Equal(t, f(1), res.Values[0].(mathexp.Number).GetFloat64Value())
+	})
+}
@@ -17,6 +17,9 @@ var (
 type Repository interface {
 	Save(ctx context.Context, item *Item) error
 	SaveMany(ctx context.Context, items []Item) error
+	// Import inserts the items in a single transaction. Items with an ExternalID that already exists in their
+	// organization, or that appears earlier in items, are skipped.
+	Import(ctx context.Context, items []Item) (ImportResult, error)
 	Update(ctx context.Context, item *Item) error
 	Find(ctx context.Context, query *ItemQuery) ([]*ItemDTO, error)
 	Delete(ctx context.Context, params *DeleteParams) error
@@ -89,6 +89,34 @@ func (_m *FakeAnnotationsRepo) FindTags(ctx context.Context, query *TagsQuery) (
 	return r0, r1
 }
 
+// Import provides a mock function with given fields: ctx, items
+func (_m *FakeAnnotationsRepo) Import(ctx context.Context, items []Item) (ImportResult, error) {
+	ret := _m.Called(ctx, items)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Import")
+	}
+
+	var r0 ImportResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []Item) (ImportResult, error)); ok {
+		return rf(ctx, items)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []Item) ImportResult); ok {
+		r0 = rf(ctx, items)
+	} else {
+		r0 = ret.Get(0).(ImportResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []Item) error); ok {
+		r1 = rf(ctx, items)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: ctx, item
 func (_m *FakeAnnotationsRepo) Save(ctx context.Context, item *Item) error {
 	ret := _m.Called(ctx, item)
@@ -72,11 +72,18 @@ func (r *RepositoryImpl) SaveMany(ctx context.Context, items []annotations.Item)
 	return r.writer.AddMany(ctx, items)
 }
 
+func (r *RepositoryImpl) Import(ctx context.Context, items []annotations.Item) (annotations.ImportResult, error) {
+	return r.writer.Import(ctx, items)
+}
+
 func (r *RepositoryImpl) Update(ctx context.Context, item *annotations.Item) error {
 	return r.writer.Update(ctx, item)
 }
 
 func (r *RepositoryImpl) Find(ctx context.Context, query *annotations.ItemQuery) ([]*annotations.ItemDTO, error) {
+	if err := query.ValidateSearch(); err != nil {
+		return nil, err
+	}
 	if query.Limit == 0 {
 		query.Limit = 100
 	}
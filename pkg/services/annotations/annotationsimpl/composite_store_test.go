@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -127,6 +128,27 @@ func TestCompositeStore(t *testing.T) {
 		require.Equal(t, expected, res.Tags)
 	})
 
+	t.Run("should pass search to all readers", func(t *testing.T) {
+		var queries []annotations.ItemQuery
+		var mu sync.Mutex
+		getFn := func(ctx context.Context, query annotations.ItemQuery, resources *accesscontrol.AccessResources) ([]*annotations.ItemDTO, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			queries = append(queries, query)
+			return []*annotations.ItemDTO{}, nil
+		}
+
+		store := &CompositeStore{
+			log.NewNopLogger(),
+			[]readStore{newFakeReader(withGetFn(getFn)), newFakeReader(withGetFn(getFn))},
+		}
+
+		query := annotations.ItemQuery{Text: "rollback", Tags: []string{"env:prod"}, TagMatch: annotations.TagMatchPrefix}
+		_, err := store.Get(context.Background(), query, nil)
+		require.NoError(t, err)
+		require.Equal(t, []annotations.ItemQuery{query, query}, queries)
+	})
+
 	// Check if reader is not modifying query since it might cause a race condition in case of composite store
 	t.Run("should not modify query", func(t *testing.T) {
 		getFn1 := func(ctx context.Context, query annotations.ItemQuery, resources *accesscontrol.AccessResources) ([]*annotations.ItemDTO, error) {
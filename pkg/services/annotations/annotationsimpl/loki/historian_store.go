@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"sort"
 	"time"
+	"unicode"
 
 	"github.com/grafana/grafana/pkg/services/ngalert/lokiclient"
 	"golang.org/x/exp/constraints"
@@ -140,6 +141,17 @@ func (r *LokiHistorianStore) Get(ctx context.Context, query annotations.ItemQuer
 			items = append(items, r.annotationsFromStream(stream, *accessResources)...)
 		}
 	}
+	// The text of state history annotations is built from the entries, so Loki only narrows down the entries with
+	// the words that are also in their log lines, and the text is searched here.
+	if words := annotations.TextSearchWords(query.Text); len(words) > 0 {
+		matching := make([]*annotations.ItemDTO, 0, len(items))
+		for _, item := range items {
+			if annotations.MatchesText(item.Text, words) {
+				matching = append(matching, item)
+			}
+		}
+		items = matching
+	}
 	sort.Sort(annotations.SortedItems(items))
 	return items, err
 }
@@ -281,6 +293,7 @@ func buildHistoryQuery(query *annotations.ItemQuery, dashboards map[string]int64
 		DashboardUID: query.DashboardUID,
 		PanelID:      query.PanelID,
 		RuleUID:      ruleUID,
+		Contains:     lineFilterWords(annotations.TextSearchWords(query.Text)),
 	}
 
 	// nolint: staticcheck
@@ -297,6 +310,31 @@ func buildHistoryQuery(query *annotations.ItemQuery, dashboards map[string]int64
 	return historyQuery
 }
 
+// lineFilterWords returns the search words that are matched on the log lines in Loki. The text of an annotation
+// formats the values and labels of the entry differently than its JSON log line, so only words of letters, digits,
+// dashes and underscores, with at least one letter, are matched there. The limit of the query then applies to the
+// entries that contain the words.
+func lineFilterWords(words []string) []string {
+	var result []string
+	for _, word := range words {
+		hasLetter := false
+		valid := true
+		for _, r := range word {
+			switch {
+			case unicode.IsLetter(r):
+				hasLetter = true
+			case unicode.IsDigit(r) || r == '-' || r == '_':
+			default:
+				valid = false
+			}
+		}
+		if valid && hasLetter {
+			result = append(result, word)
+		}
+	}
+	return result
+}
+
 func useStore(cfg setting.UnifiedAlertingStateHistorySettings) bool {
 	if !cfg.Enabled {
 		return false
@@ -329,6 +329,36 @@ func TestIntegrationAlertStateHistoryStore(t *testing.T) {
 			}
 		})
 
+		t.Run("should only return history whose text matches the search", func(t *testing.T) {
+			fakeLokiClient.rangeQueryRes = []lokiclient.Stream{
+				historian.StatesToStream(ruleMetaFromRule(t, dashboardRules[dashboard1.UID][0]), transitions, map[string]string{}, log.NewNopLogger()),
+				historian.StatesToStream(ruleMetaFromRule(t, dashboardRules[dashboard1.UID][1]), transitions, map[string]string{}, log.NewNopLogger()),
+			}
+
+			query := annotations.ItemQuery{
+				OrgID:        1,
+				DashboardUID: dashboard1.UID,
+				From:         start.UnixMilli(),
+				To:           start.Add(time.Second * time.Duration(numTransitions+1)).UnixMilli(),
+				Text:         "TEST rule",
+			}
+			resources := &annotation_ac.AccessResources{
+				Dashboards: map[string]int64{
+					dashboard1.UID: dashboard1.ID,
+				},
+				CanAccessDashAnnotations: true,
+			}
+			res, err := store.Get(context.Background(), query, resources)
+			require.NoError(t, err)
+			require.Len(t, res, 2*numTransitions)
+			require.Contains(t, fakeLokiClient.lastQuery, `|~ "(?i)test" |~ "(?i)rule" | json`)
+
+			query.Text = "test rollback"
+			res, err = store.Get(context.Background(), query, resources)
+			require.NoError(t, err)
+			require.Empty(t, res)
+		})
+
 		t.Run("should return nothing if query is for tags only", func(t *testing.T) {
 			fakeLokiClient.rangeQueryRes = []lokiclient.Stream{
 				historian.StatesToStream(ruleMetaFromRule(t, dashboardRules[dashboard1.UID][0]), transitions, map[string]string{}, log.NewNopLogger()),
@@ -578,6 +608,17 @@ func TestBuildHistoryQuery(t *testing.T) {
 		require.Zero(t, query.DashboardUID)
 	})
 
+	t.Run("should match the search words on the log lines", func(t *testing.T) {
+		query := buildHistoryQuery(
+			&annotations.ItemQuery{
+				Text: "CPU {team=ops} high-load",
+			},
+			map[string]int64{},
+			"rule-uid",
+		)
+		require.Equal(t, []string{"cpu", "high-load"}, query.Contains)
+	})
+
 	t.Run("should skip dashboard UID when not in query", func(t *testing.T) {
 		query := buildHistoryQuery(
 			&annotations.ItemQuery{},
@@ -590,6 +631,11 @@ func TestBuildHistoryQuery(t *testing.T) {
 	})
 }
 
+func TestLineFilterWords(t *testing.T) {
+	words := lineFilterWords([]string{"cpu", "high-load", "db_1", "1.000000", "{team=ops}", "42", "müll"})
+	require.Equal(t, []string{"cpu", "high-load", "db_1", "müll"}, words)
+}
+
 func TestBuildTransition(t *testing.T) {
 	t.Run("should return error when entry contains invalid state strings", func(t *testing.T) {
 		_, err := buildTransition(historian.LokiEntry{
@@ -732,6 +778,7 @@ func ruleMetaFromRule(t *testing.T, rule *ngmodels.AlertRule) historymodel.RuleM
 		OrgID: rule.OrgID,
 		UID:   rule.UID,
 		ID:    rule.ID,
+		Title: rule.Title,
 	}
 
 	if rule.DashboardUID != nil {
@@ -816,6 +863,7 @@ type FakeLokiClient struct {
 	metrics       *metrics.Historian
 	log           log.Logger
 	rangeQueryRes []lokiclient.Stream
+	lastQuery     string
 }
 
 func NewFakeLokiClient() *FakeLokiClient {
@@ -838,6 +886,7 @@ func NewFakeLokiClient() *FakeLokiClient {
 }
 
 func (c *FakeLokiClient) RangeQuery(ctx context.Context, query string, from, to, limit int64) (lokiclient.QueryRes, error) {
+	c.lastQuery = query
 	streams := make([]lokiclient.Stream, len(c.rangeQueryRes))
 
 	// clamp time range using logic from historian
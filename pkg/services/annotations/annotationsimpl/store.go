@@ -28,6 +28,7 @@ type writeStore interface {
 	commonStore
 	Add(ctx context.Context, items *annotations.Item) error
 	AddMany(ctx context.Context, items []annotations.Item) error
+	Import(ctx context.Context, items []annotations.Item) (annotations.ImportResult, error)
 	Update(ctx context.Context, item *annotations.Item) error
 	Delete(ctx context.Context, params *annotations.DeleteParams) error
 	CleanAnnotations(ctx context.Context, cfg setting.AnnotationCleanupSettings, annotationType string) (int64, error)
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -140,6 +141,106 @@ func (r *xormRepositoryImpl) AddMany(ctx context.Context, items []annotations.It
 	})
 }
 
+// importLookupBatchSize is the number of external IDs that are looked up at once during an import.
+const importLookupBatchSize = 500
+
+func (r *xormRepositoryImpl) Import(ctx context.Context, items []annotations.Item) (annotations.ImportResult, error) {
+	result := annotations.ImportResult{}
+	err := r.db.InTransaction(ctx, func(ctx context.Context) error {
+		// Collect the external IDs that already exist, per organization.
+		existing := make(map[int64]map[string]bool)
+		for _, item := range items {
+			if item.ExternalID == nil {
+				continue
+			}
+			if existing[item.OrgID] == nil {
+				existing[item.OrgID] = make(map[string]bool)
+			}
+			existing[item.OrgID][*item.ExternalID] = false
+		}
+		for orgID, ids := range existing {
+			if err := r.findExternalIDs(ctx, orgID, ids); err != nil {
+				return err
+			}
+		}
+
+		for i := range items {
+			item := &items[i]
+			if item.ExternalID == nil {
+				if err := r.Add(ctx, item); err != nil {
+					return err
+				}
+				result.Imported++
+				continue
+			}
+
+			if existing[item.OrgID][*item.ExternalID] {
+				result.Skipped++
+				continue
+			}
+			existing[item.OrgID][*item.ExternalID] = true
+			added, err := r.addImported(ctx, item)
+			if err != nil {
+				return err
+			}
+			if added {
+				result.Imported++
+			} else {
+				result.Skipped++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return annotations.ImportResult{}, err
+	}
+	return result, nil
+}
+
+// addImported adds an annotation with an external ID, and returns false if the external ID was imported by a
+// concurrent import in the meantime. The insert is done in a savepoint, because a constraint violation aborts the
+// whole transaction in PostgreSQL.
+func (r *xormRepositoryImpl) addImported(ctx context.Context, item *annotations.Item) (bool, error) {
+	added := false
+	err := r.db.WithDbSession(ctx, func(sess *db.Session) error {
+		if _, err := sess.Exec("SAVEPOINT annotation_import"); err != nil {
+			return err
+		}
+		if err := r.Add(ctx, item); err != nil {
+			if !r.db.GetDialect().IsUniqueConstraintViolation(err) {
+				return err
+			}
+			_, err := sess.Exec("ROLLBACK TO SAVEPOINT annotation_import")
+			return err
+		}
+		added = true
+		_, err := sess.Exec("RELEASE SAVEPOINT annotation_import")
+		return err
+	})
+	return added, err
+}
+
+// findExternalIDs sets the external IDs of ids that exist in the organization to true.
+func (r *xormRepositoryImpl) findExternalIDs(ctx context.Context, orgID int64, ids map[string]bool) error {
+	lookup := make([]string, 0, len(ids))
+	for id := range ids {
+		lookup = append(lookup, id)
+	}
+	return r.db.WithDbSession(ctx, func(sess *db.Session) error {
+		for start := 0; start < len(lookup); start += importLookupBatchSize {
+			end := min(start+importLookupBatchSize, len(lookup))
+			found := make([]string, 0)
+			if err := sess.Table("annotation").Cols("external_id").Where("org_id = ?", orgID).In("external_id", lookup[start:end]).Find(&found); err != nil {
+				return err
+			}
+			for _, id := range found {
+				ids[id] = true
+			}
+		}
+		return nil
+	})
+}
+
 func (r *xormRepositoryImpl) Update(ctx context.Context, item *annotations.Item) error {
 	return r.db.InTransaction(ctx, func(ctx context.Context) error {
 		return r.update(ctx, item)
@@ -273,6 +374,7 @@ func (r *xormRepositoryImpl) Get(ctx context.Context, query annotations.ItemQuer
 				annotation.data,
 				annotation.created,
 				annotation.updated,
+				annotation.external_id,
 				usr.email,
 				usr.login,
 				r.title as alert_name
@@ -334,7 +436,13 @@ func (r *xormRepositoryImpl) Get(ctx context.Context, query annotations.ItemQuer
 			sql.WriteString(` AND a.alert_id = 0`)
 		}
 
-		if len(query.Tags) > 0 {
+		for _, word := range annotations.TextSearchWords(query.Text) {
+			textFilter, textParam := likeOperator(r.db.GetDialect(), "a.text", true, word, true)
+			sql.WriteString(" AND " + textFilter)
+			params = append(params, textParam)
+		}
+
+		if len(query.Tags) > 0 && (query.TagMatch == "" || query.TagMatch == annotations.TagMatchExact) {
 			keyValueFilters := []string{}
 
 			tags := tag.ParseTagPairs(query.Tags)
@@ -364,6 +472,19 @@ func (r *xormRepositoryImpl) Get(ctx context.Context, query annotations.ItemQuer
 					sql.WriteString(fmt.Sprintf(" AND (%s) = %d ", tagsSubQuery, len(tags)))
 				}
 			}
+		} else if len(query.Tags) > 0 {
+			tagFilters, tagParams, err := r.tagMatchFilters(sess, query)
+			if err != nil {
+				return err
+			}
+			if len(tagFilters) > 0 {
+				operator := " AND "
+				if query.MatchAny {
+					operator = " OR "
+				}
+				sql.WriteString(" AND (" + strings.Join(tagFilters, operator) + ")")
+				params = append(params, tagParams...)
+			}
 		}
 
 		acFilter, acParams := r.getAccessControlFilter(query.SignedInUser, accessResources)
@@ -390,6 +511,80 @@ func (r *xormRepositoryImpl) Get(ctx context.Context, query annotations.ItemQuer
 	return items, err
 }
 
+// likeEscaper escapes the wildcards of LIKE patterns with '!', which, unlike a backslash, needs no escaping in the string
+// literals of any of the supported databases.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// likeOperator returns a LIKE filter that matches the pattern literally.
+func likeOperator(dialect migrator.Dialect, column string, wildcardBefore bool, pattern string, wildcardAfter bool) (string, string) {
+	filter, param := dialect.LikeOperator(column, wildcardBefore, likeEscaper.Replace(pattern), wildcardAfter)
+	return filter + " ESCAPE '!'", param
+}
+
+// tagMatchFilters returns a filter for each tag of a query that is matched by prefix or as a regular expression. A
+// filter checks that the annotation has at least one tag that matches.
+func (r *xormRepositoryImpl) tagMatchFilters(sess *db.Session, query annotations.ItemQuery) ([]string, []any, error) {
+	dialect := r.db.GetDialect()
+	keyColumn := "tag." + dialect.Quote("key")
+	valueColumn := "tag." + dialect.Quote("value")
+
+	// Not all databases support regular expressions, so the tags of the organization are matched here instead.
+	var orgTags []tag.Tag
+	if query.TagMatch == annotations.TagMatchRegex {
+		err := sess.SQL(`
+			SELECT DISTINCT tag.id, `+keyColumn+`, `+valueColumn+` FROM tag
+			INNER JOIN annotation_tag `+r.db.Quote("at")+` ON `+r.db.Quote("at")+`.tag_id = tag.id
+			INNER JOIN annotation ON annotation.id = `+r.db.Quote("at")+`.annotation_id
+			WHERE annotation.org_id = ?`, query.OrgID).Find(&orgTags)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	filters := make([]string, 0, len(query.Tags))
+	params := make([]any, 0, len(query.Tags))
+	for _, pattern := range query.Tags {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+
+		var filter string
+		if query.TagMatch == annotations.TagMatchRegex {
+			re, err := annotations.TagRegexp(pattern)
+			if err != nil {
+				return nil, nil, err
+			}
+			ids := make([]string, 0)
+			for i := range orgTags {
+				if re.MatchString(tag.JoinTagPairs([]*tag.Tag{&orgTags[i]})[0]) {
+					ids = append(ids, strconv.FormatInt(orgTags[i].Id, 10))
+				}
+			}
+			if len(ids) == 0 {
+				filters = append(filters, "1 = 0")
+				continue
+			}
+			filter = "tag.id IN (" + strings.Join(ids, ", ") + ")"
+		} else if key, value, hasValue := strings.Cut(pattern, ":"); hasValue {
+			valueFilter, valueParam := likeOperator(dialect, valueColumn, false, strings.TrimSpace(value), true)
+			filter = keyColumn + " = ? AND " + valueFilter
+			params = append(params, strings.TrimSpace(key), valueParam)
+		} else {
+			keyFilter, keyParam := likeOperator(dialect, keyColumn, false, key, true)
+			filter = keyFilter
+			params = append(params, keyParam)
+		}
+
+		filters = append(filters, `EXISTS (
+			SELECT 1 FROM annotation_tag `+r.db.Quote("at")+`
+			INNER JOIN tag ON tag.id = `+r.db.Quote("at")+`.tag_id
+			WHERE `+r.db.Quote("at")+`.annotation_id = a.id AND `+filter+`
+		)`)
+	}
+	return filters, params, nil
+}
+
 func (r *xormRepositoryImpl) getAccessControlFilter(user identity.Requester, accessResources *accesscontrol.AccessResources) (string, []any) {
 	if accessResources.SkipAccessControlFilter {
 		return "", nil
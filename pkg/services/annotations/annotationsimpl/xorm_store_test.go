@@ -24,6 +24,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/user"
 	"github.com/grafana/grafana/pkg/setting"
 	"github.com/grafana/grafana/pkg/tests/testsuite"
+	"github.com/grafana/grafana/pkg/util"
 )
 
 func TestMain(m *testing.M) {
@@ -247,6 +248,75 @@ func TestIntegrationAnnotations(t *testing.T) {
 			assert.Len(t, inserted, count)
 		})
 
+		t.Run("Can import annotations and skip known external ids", func(t *testing.T) {
+			ctx := context.Background()
+			query := annotations.ItemQuery{OrgID: 102, SignedInUser: testUser}
+			accRes := &annotation_ac.AccessResources{CanAccessOrgAnnotations: true}
+
+			result, err := store.Import(ctx, []annotations.Item{
+				{OrgID: 102, Epoch: 10, Text: "deploy 1", ExternalID: util.Pointer("ci-1"), Tags: []string{"deploy"}},
+				{OrgID: 102, Epoch: 20, Text: "deploy 2", ExternalID: util.Pointer("ci-2")},
+				{OrgID: 102, Epoch: 20, Text: "deploy 2 again", ExternalID: util.Pointer("ci-2")},
+				{OrgID: 102, Epoch: 30, Text: "without id"},
+				// The same external id in another organization.
+				{OrgID: 103, Epoch: 10, Text: "deploy 1", ExternalID: util.Pointer("ci-1")},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, annotations.ImportResult{Imported: 4, Skipped: 1}, result)
+
+			result, err = store.Import(ctx, []annotations.Item{
+				{OrgID: 102, Epoch: 10, Text: "deploy 1", ExternalID: util.Pointer("ci-1")},
+				{OrgID: 102, Epoch: 40, Text: "deploy 3", ExternalID: util.Pointer("ci-3")},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, annotations.ImportResult{Imported: 1, Skipped: 1}, result)
+
+			items, err := store.Get(ctx, query, accRes)
+			require.NoError(t, err)
+			require.Len(t, items, 4)
+			byText := make(map[string]*annotations.ItemDTO)
+			for _, item := range items {
+				byText[item.Text] = item
+			}
+			assert.Equal(t, "ci-1", byText["deploy 1"].ExternalID)
+			assert.Equal(t, []string{"deploy"}, byText["deploy 1"].Tags)
+			assert.Equal(t, "ci-3", byText["deploy 3"].ExternalID)
+			assert.Empty(t, byText["without id"].ExternalID)
+
+			t.Run("skips external ids that are imported concurrently", func(t *testing.T) {
+				require.NoError(t, store.Add(ctx, &annotations.Item{OrgID: 104, Epoch: 10, Text: "deploy 1", ExternalID: util.Pointer("ci-1")}))
+
+				err := store.db.InTransaction(ctx, func(ctx context.Context) error {
+					added, err := store.addImported(ctx, &annotations.Item{OrgID: 104, Epoch: 10, Text: "deploy 1", ExternalID: util.Pointer("ci-1")})
+					require.NoError(t, err)
+					assert.False(t, added)
+
+					// The transaction can still be used after the constraint violation.
+					added, err = store.addImported(ctx, &annotations.Item{OrgID: 104, Epoch: 20, Text: "deploy 2", ExternalID: util.Pointer("ci-2")})
+					require.NoError(t, err)
+					assert.True(t, added)
+					return nil
+				})
+				require.NoError(t, err)
+
+				items, err := store.Get(ctx, annotations.ItemQuery{OrgID: 104, SignedInUser: testUser}, accRes)
+				require.NoError(t, err)
+				assert.Len(t, items, 2)
+			})
+
+			t.Run("rolls back the batch on error", func(t *testing.T) {
+				_, err := store.Import(ctx, []annotations.Item{
+					{OrgID: 102, Epoch: 50, Text: "deploy 4", ExternalID: util.Pointer("ci-4")},
+					{OrgID: 102, Epoch: 60, Text: "too many tags", ExternalID: util.Pointer("ci-5"), Tags: []string{strings.Repeat("a", int(cfg.AnnotationMaximumTagsLength+1))}},
+				})
+				require.ErrorIs(t, err, annotations.ErrBaseTagLimitExceeded)
+
+				items, err := store.Get(ctx, query, accRes)
+				require.NoError(t, err)
+				assert.Len(t, items, 4)
+			})
+		})
+
 		t.Run("Can query for annotation by id", func(t *testing.T) {
 			items, err := store.Get(context.Background(), annotations.ItemQuery{
 				OrgID:        1,
@@ -366,6 +436,108 @@ func TestIntegrationAnnotations(t *testing.T) {
 			assert.Len(t, items, 1)
 		})
 
+		t.Run("Should find annotations by text", func(t *testing.T) {
+			accRes := &annotation_ac.AccessResources{CanAccessOrgAnnotations: true}
+			items, err := store.Get(context.Background(), annotations.ItemQuery{
+				OrgID:        1,
+				From:         1,
+				To:           25,
+				Text:         "ROLL forward",
+				SignedInUser: testUser,
+			}, accRes)
+			require.NoError(t, err)
+			assert.Empty(t, items)
+
+			items, err = store.Get(context.Background(), annotations.ItemQuery{
+				OrgID:        1,
+				From:         1,
+				To:           25,
+				Text:         "ROLL",
+				SignedInUser: testUser,
+			}, accRes)
+			require.NoError(t, err)
+			require.Len(t, items, 1)
+			assert.Equal(t, organizationAnnotation2.ID, items[0].ID)
+
+			// Wildcards of LIKE are matched literally.
+			for _, text := range []string{"%", "r_llback", "!"} {
+				items, err = store.Get(context.Background(), annotations.ItemQuery{
+					OrgID:        1,
+					From:         1,
+					To:           25,
+					Text:         text,
+					SignedInUser: testUser,
+				}, accRes)
+				require.NoError(t, err)
+				assert.Empty(t, items, text)
+			}
+		})
+
+		t.Run("Should find annotations by tag prefix", func(t *testing.T) {
+			accRes := &annotation_ac.AccessResources{
+				Dashboards:               map[string]int64{dashboard.UID: 1},
+				CanAccessDashAnnotations: true,
+			}
+			query := annotations.ItemQuery{
+				OrgID:        1,
+				DashboardUID: dashboard.UID,
+				From:         1,
+				To:           15,
+				TagMatch:     annotations.TagMatchPrefix,
+				Tags:         []string{"type:out", "serv"},
+				SignedInUser: testUser,
+			}
+			items, err := store.Get(context.Background(), query, accRes)
+			require.NoError(t, err)
+			assert.Len(t, items, 1)
+
+			query.Tags = []string{"type:out", "server:server-2"}
+			items, err = store.Get(context.Background(), query, accRes)
+			require.NoError(t, err)
+			assert.Empty(t, items)
+
+			query.MatchAny = true
+			items, err = store.Get(context.Background(), query, accRes)
+			require.NoError(t, err)
+			assert.Len(t, items, 1)
+
+			// Wildcards of LIKE are matched literally.
+			query.Tags = []string{"type:o_t", "%"}
+			items, err = store.Get(context.Background(), query, accRes)
+			require.NoError(t, err)
+			assert.Empty(t, items)
+		})
+
+		t.Run("Should find annotations by tag regex", func(t *testing.T) {
+			accRes := &annotation_ac.AccessResources{CanAccessOrgAnnotations: true}
+			query := annotations.ItemQuery{
+				OrgID:        1,
+				From:         1,
+				To:           25,
+				TagMatch:     annotations.TagMatchRegex,
+				Tags:         []string{"deploy|rollback"},
+				SignedInUser: testUser,
+			}
+			items, err := store.Get(context.Background(), query, accRes)
+			require.NoError(t, err)
+			assert.Len(t, items, 2)
+
+			// The expression must match the whole tag.
+			query.Tags = []string{"roll"}
+			items, err = store.Get(context.Background(), query, accRes)
+			require.NoError(t, err)
+			assert.Empty(t, items)
+
+			dashAccRes := &annotation_ac.AccessResources{
+				Dashboards:               map[string]int64{dashboard.UID: 1, dashboard2.UID: 2},
+				CanAccessDashAnnotations: true,
+			}
+			query.Tags = []string{`server:server-\d+`, "type:.*"}
+			items, err = store.Get(context.Background(), query, dashAccRes)
+			require.NoError(t, err)
+			assert.Len(t, items, 2)
+		})
+
 		t.Run("Can update annotation and remove all tags", func(t *testing.T) {
 			query := annotations.ItemQuery{
 				OrgID:        1,
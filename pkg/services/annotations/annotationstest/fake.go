@@ -61,6 +61,33 @@ func (repo *fakeAnnotationsRepo) SaveMany(ctx context.Context, items []annotatio
 	return nil
 }
 
+func (repo *fakeAnnotationsRepo) Import(_ context.Context, items []annotations.Item) (annotations.ImportResult, error) {
+	repo.mtx.Lock()
+	defer repo.mtx.Unlock()
+
+	result := annotations.ImportResult{}
+	existing := map[string]bool{}
+	for _, v := range repo.annotations {
+		if v.ExternalID != nil {
+			existing[*v.ExternalID] = true
+		}
+	}
+	for _, i := range items {
+		if i.ExternalID != nil {
+			if existing[*i.ExternalID] {
+				result.Skipped++
+				continue
+			}
+			existing[*i.ExternalID] = true
+		}
+		i.ID = int64(len(repo.annotations) + 1)
+		repo.annotations[i.ID] = i
+		result.Imported++
+	}
+
+	return result, nil
+}
+
 func (repo *fakeAnnotationsRepo) Update(_ context.Context, item *annotations.Item) error {
 	return nil
 }
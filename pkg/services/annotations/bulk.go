@@ -0,0 +1,316 @@
+package annotations
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+)
+
+const (
+	// BulkFormatNDJSON is newline delimited JSON, with one annotation per line. It is the default.
+	BulkFormatNDJSON = "ndjson"
+	// BulkFormatCSV is CSV with a header row. Tags are a JSON array of strings in a single column.
+	BulkFormatCSV = "csv"
+)
+
+// MaxBulkItems is the maximum number of annotations that are imported or exported at once.
+const MaxBulkItems = 10000
+
+// MaxBulkSize is the maximum size in bytes of the annotations that are imported at once.
+const MaxBulkSize = 32 << 20
+
+// maxBulkLineSize is the maximum size of a line of NDJSON.
+const maxBulkLineSize = 1 << 20
+
+// maxExternalIDLength is the length of the external_id column.
+const maxExternalIDLength = 255
+
+// bulkColumns are the columns of the CSV format, in the order they are exported.
+var bulkColumns = []string{"id", "time", "timeEnd", "text", "tags", "dashboardUID", "panelId"}
+
+const errInvalidBulkTmpl = "Invalid annotations: {{ .Public.Reason }}"
+
+var ErrBaseInvalidBulk = errutil.BadRequest("annotations.invalid-bulk").MustTemplate(errInvalidBulkTmpl, errutil.WithPublic(errInvalidBulkTmpl))
+
+func errInvalidBulk(reason string) error {
+	return ErrBaseInvalidBulk.Build(errutil.TemplateData{Public: map[string]any{"Reason": reason}})
+}
+
+// BulkItem is an annotation that is imported or exported in bulk.
+type BulkItem struct {
+	// ID is the client-supplied ID of the annotation. Annotations with an ID that was already imported are skipped.
+	ID           string   `json:"id,omitempty"`
+	Time         int64    `json:"time"`
+	TimeEnd      int64    `json:"timeEnd,omitempty"`
+	Text         string   `json:"text"`
+	Tags         []string `json:"tags,omitempty"`
+	DashboardUID string   `json:"dashboardUID,omitempty"`
+	PanelID      int64    `json:"panelId,omitempty"`
+}
+
+// ImportResult is the result of an import of annotations.
+type ImportResult struct {
+	// Imported is the number of annotations that were created.
+	Imported int64 `json:"imported"`
+	// Skipped is the number of annotations whose ID was already imported.
+	Skipped int64 `json:"skipped"`
+}
+
+func (i *BulkItem) validate() error {
+	switch {
+	case i.Time <= 0:
+		return errors.New("time is required")
+	case i.TimeEnd != 0 && i.TimeEnd < i.Time:
+		return errors.New("timeEnd is before time")
+	case i.Text == "":
+		return errors.New("text is required")
+	case len(i.ID) > maxExternalIDLength:
+		return fmt.Errorf("id is longer than %d characters", maxExternalIDLength)
+	}
+	return nil
+}
+
+// BulkItemFromDTO returns the annotation as a bulk item. The ID is the client-supplied ID if the annotation was
+// imported, and the ID of the annotation otherwise, so that exported annotations are only imported once into another
+// organization. Importing annotations that were not imported back into their own organization creates copies of
+// them, because their IDs only match annotations that were imported with the same ID.
+func BulkItemFromDTO(dto *ItemDTO) BulkItem {
+	item := BulkItem{
+		ID:      dto.ExternalID,
+		Time:    dto.Time,
+		TimeEnd: dto.TimeEnd,
+		Text:    dto.Text,
+		Tags:    dto.Tags,
+		PanelID: dto.PanelID,
+	}
+	if item.ID == "" && dto.ID != 0 {
+		item.ID = strconv.FormatInt(dto.ID, 10)
+	}
+	if dto.DashboardUID != nil {
+		item.DashboardUID = *dto.DashboardUID
+	}
+	return item
+}
+
+// ParseDashboardUIDMapping parses pairs of dashboard UIDs in the format `from:to`.
+func ParseDashboardUIDMapping(pairs []string) (map[string]string, error) {
+	mapping := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		from, to, ok := strings.Cut(pair, ":")
+		if !ok || from == "" || to == "" {
+			return nil, errInvalidBulk("invalid dashboard UID mapping " + pair)
+		}
+		mapping[from] = to
+	}
+	return mapping, nil
+}
+
+// DecodeBulkItems reads and validates the annotations of r in the format. It returns an error if there are more than
+// MaxBulkItems annotations.
+func DecodeBulkItems(r io.Reader, format string) ([]BulkItem, error) {
+	switch format {
+	case "", BulkFormatNDJSON:
+		return decodeNDJSON(r)
+	case BulkFormatCSV:
+		return decodeCSV(r)
+	default:
+		return nil, errInvalidBulk("unknown format " + format)
+	}
+}
+
+func decodeNDJSON(r io.Reader) ([]BulkItem, error) {
+	items := make([]BulkItem, 0)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxBulkLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		b := bytes.TrimSpace(scanner.Bytes())
+		if len(b) == 0 {
+			continue
+		}
+		if len(items) == MaxBulkItems {
+			return nil, errInvalidBulk(fmt.Sprintf("more than %d annotations", MaxBulkItems))
+		}
+		var item BulkItem
+		if err := json.Unmarshal(b, &item); err != nil {
+			return nil, errInvalidBulk(fmt.Sprintf("line %d: %s", line, err))
+		}
+		if err := item.validate(); err != nil {
+			return nil, errInvalidBulk(fmt.Sprintf("line %d: %s", line, err))
+		}
+		items = append(items, item)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errInvalidBulk(err.Error())
+	}
+	return items, nil
+}
+
+func decodeCSV(r io.Reader) ([]BulkItem, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errInvalidBulk("missing header")
+		}
+		return nil, errInvalidBulk(err.Error())
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if !slices.Contains(bulkColumns, name) {
+			return nil, errInvalidBulk("unknown column " + name)
+		}
+		columns[name] = i
+	}
+	for _, name := range []string{"time", "text"} {
+		if _, ok := columns[name]; !ok {
+			return nil, errInvalidBulk("missing column " + name)
+		}
+	}
+
+	items := make([]BulkItem, 0)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errInvalidBulk(err.Error())
+		}
+		line, _ := reader.FieldPos(0)
+		if len(items) == MaxBulkItems {
+			return nil, errInvalidBulk(fmt.Sprintf("more than %d annotations", MaxBulkItems))
+		}
+		item, err := bulkItemFromRecord(record, columns)
+		if err == nil {
+			err = item.validate()
+		}
+		if err != nil {
+			return nil, errInvalidBulk(fmt.Sprintf("line %d: %s", line, err))
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func bulkItemFromRecord(record []string, columns map[string]int) (BulkItem, error) {
+	var item BulkItem
+	value := func(name string) string {
+		if i, ok := columns[name]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	number := func(name string) (int64, error) {
+		v := value(name)
+		if v == "" {
+			return 0, nil
+		}
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s %s", name, v)
+		}
+		return n, nil
+	}
+
+	var err error
+	if item.Time, err = number("time"); err != nil {
+		return item, err
+	}
+	if item.TimeEnd, err = number("timeEnd"); err != nil {
+		return item, err
+	}
+	if item.PanelID, err = number("panelId"); err != nil {
+		return item, err
+	}
+	item.ID = value("id")
+	item.Text = record[columns["text"]]
+	item.DashboardUID = value("dashboardUID")
+	if item.Tags, err = parseCSVTags(value("tags")); err != nil {
+		return item, err
+	}
+	return item, nil
+}
+
+// parseCSVTags parses the tags column of CSV. It is a JSON array of strings, which is how the tags are exported, or,
+// to make CSV easier to write by hand, a list of tags separated by commas.
+func parseCSVTags(value string) ([]string, error) {
+	if strings.HasPrefix(value, "[") {
+		var tags []string
+		if err := json.Unmarshal([]byte(value), &tags); err != nil {
+			return nil, fmt.Errorf("invalid tags %s", value)
+		}
+		if len(tags) == 0 {
+			return nil, nil
+		}
+		return tags, nil
+	}
+	var tags []string
+	for _, t := range strings.Split(value, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	return tags, nil
+}
+
+// formatCSVTags formats the tags for the tags column of CSV, as a JSON array so that tags with commas are kept whole.
+func formatCSVTags(tags []string) (string, error) {
+	if len(tags) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(tags)
+	return string(b), err
+}
+
+// EncodeBulkItems writes the annotations to w in the format.
+func EncodeBulkItems(w io.Writer, format string, items []BulkItem) error {
+	switch format {
+	case "", BulkFormatNDJSON:
+		enc := json.NewEncoder(w)
+		for i := range items {
+			if err := enc.Encode(&items[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	case BulkFormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(bulkColumns); err != nil {
+			return err
+		}
+		for _, item := range items {
+			tags, err := formatCSVTags(item.Tags)
+			if err != nil {
+				return err
+			}
+			record := []string{
+				item.ID,
+				strconv.FormatInt(item.Time, 10),
+				strconv.FormatInt(item.TimeEnd, 10),
+				item.Text,
+				tags,
+				item.DashboardUID,
+				strconv.FormatInt(item.PanelID, 10),
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	default:
+		return errInvalidBulk("unknown format " + format)
+	}
+}
@@ -0,0 +1,107 @@
+package annotations
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkItems(t *testing.T) {
+	items := []BulkItem{
+		{ID: "ci-1", Time: 10, TimeEnd: 20, Text: "deploy, \"v2\"", Tags: []string{"deploy", "env:prod"}, DashboardUID: "dash", PanelID: 2},
+		{Time: 30, Text: "organization annotation"},
+		{Time: 40, Text: "tags with separators", Tags: []string{"team:a,b", " padded ", "[bracket]", "quote\""}},
+	}
+
+	for _, format := range []string{BulkFormatNDJSON, BulkFormatCSV} {
+		t.Run(format+" round trip", func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, EncodeBulkItems(&buf, format, items))
+			decoded, err := DecodeBulkItems(&buf, format)
+			require.NoError(t, err)
+			assert.Equal(t, items, decoded)
+		})
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, EncodeBulkItems(&buf, BulkFormatCSV, items[:1]))
+		assert.Equal(t, "id,time,timeEnd,text,tags,dashboardUID,panelId\nci-1,10,20,\"deploy, \"\"v2\"\"\",\"[\"\"deploy\"\",\"\"env:prod\"\"]\",dash,2\n", buf.String())
+
+		// Columns can be left out and in any order.
+		decoded, err := DecodeBulkItems(strings.NewReader("text,time\ndeploy,10\n"), BulkFormatCSV)
+		require.NoError(t, err)
+		assert.Equal(t, []BulkItem{{Time: 10, Text: "deploy"}}, decoded)
+
+		// Tags can also be separated by commas instead of being a JSON array.
+		decoded, err = DecodeBulkItems(strings.NewReader("text,time,tags\ndeploy,10,\"deploy, env:prod,\"\n"), BulkFormatCSV)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"deploy", "env:prod"}, decoded[0].Tags)
+	})
+
+	t.Run("ndjson skips blank lines", func(t *testing.T) {
+		decoded, err := DecodeBulkItems(strings.NewReader("{\"time\": 10, \"text\": \"deploy\"}\n\n{\"time\": 20, \"text\": \"deploy\"}\n"), BulkFormatNDJSON)
+		require.NoError(t, err)
+		assert.Len(t, decoded, 2)
+	})
+
+	t.Run("invalid annotations", func(t *testing.T) {
+		testCases := []struct {
+			desc   string
+			format string
+			input  string
+			reason string
+		}{
+			{desc: "unknown format", format: "xml", reason: "unknown format xml"},
+			{desc: "invalid json", format: BulkFormatNDJSON, input: "{\"time\": 10, \"text\": \"a\"}\n{", reason: "line 2:"},
+			{desc: "missing time", format: BulkFormatNDJSON, input: "{\"text\": \"a\"}", reason: "line 1: time is required"},
+			{desc: "missing text", format: BulkFormatNDJSON, input: "{\"time\": 10}", reason: "line 1: text is required"},
+			{desc: "end before start", format: BulkFormatNDJSON, input: "{\"time\": 10, \"timeEnd\": 5, \"text\": \"a\"}", reason: "line 1: timeEnd is before time"},
+			{desc: "id too long", format: BulkFormatNDJSON, input: fmt.Sprintf("{\"id\": %q, \"time\": 10, \"text\": \"a\"}", strings.Repeat("a", 256)), reason: "line 1: id is longer"},
+			{desc: "missing header", format: BulkFormatCSV, reason: "missing header"},
+			{desc: "unknown column", format: BulkFormatCSV, input: "time,text,user\n", reason: "unknown column user"},
+			{desc: "missing column", format: BulkFormatCSV, input: "time\n10\n", reason: "missing column text"},
+			{desc: "invalid number", format: BulkFormatCSV, input: "time,text\n10,a\nnow,b\n", reason: "line 3: invalid time now"},
+			{desc: "invalid tags", format: BulkFormatCSV, input: "time,text,tags\n10,a,\"[\"\"deploy\"\"\"\n", reason: "line 2: invalid tags [\"deploy\""},
+			{desc: "wrong number of fields", format: BulkFormatCSV, input: "time,text\n10\n", reason: "wrong number of fields"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.desc, func(t *testing.T) {
+				_, err := DecodeBulkItems(strings.NewReader(tc.input), tc.format)
+				require.ErrorIs(t, err, ErrBaseInvalidBulk)
+				assert.Contains(t, err.Error(), tc.reason)
+			})
+		}
+	})
+
+	t.Run("too many annotations", func(t *testing.T) {
+		input := strings.Repeat("{\"time\": 10, \"text\": \"a\"}\n", MaxBulkItems+1)
+		_, err := DecodeBulkItems(strings.NewReader(input), BulkFormatNDJSON)
+		require.ErrorIs(t, err, ErrBaseInvalidBulk)
+	})
+}
+
+func TestBulkItemFromDTO(t *testing.T) {
+	dashboardUID := "dash"
+	item := BulkItemFromDTO(&ItemDTO{ID: 12, Time: 10, TimeEnd: 20, Text: "deploy", Tags: []string{"deploy"}, DashboardUID: &dashboardUID, PanelID: 2})
+	assert.Equal(t, BulkItem{ID: "12", Time: 10, TimeEnd: 20, Text: "deploy", Tags: []string{"deploy"}, DashboardUID: "dash", PanelID: 2}, item)
+
+	// Imported annotations keep their ID.
+	item = BulkItemFromDTO(&ItemDTO{ID: 12, Time: 10, Text: "deploy", ExternalID: "ci-1"})
+	assert.Equal(t, "ci-1", item.ID)
+}
+
+func TestParseDashboardUIDMapping(t *testing.T) {
+	mapping, err := ParseDashboardUIDMapping([]string{"a:b", "c:d"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "b", "c": "d"}, mapping)
+
+	for _, pair := range []string{"a", "a:", ":b"} {
+		_, err := ParseDashboardUIDMapping([]string{pair})
+		require.ErrorIs(t, err, ErrBaseInvalidBulk)
+	}
+}
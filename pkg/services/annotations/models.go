@@ -20,6 +20,10 @@ type ItemQuery struct {
 	Tags         []string `json:"tags"`
 	Type         string   `json:"type"`
 	MatchAny     bool     `json:"matchAny"`
+	// TagMatch is how Tags are matched, see TagMatchExact, TagMatchPrefix and TagMatchRegex.
+	TagMatch string `json:"tagMatch"`
+	// Text only returns annotations whose text contains all the words of Text, ignoring case.
+	Text         string `json:"text"`
 	SignedInUser identity.Requester
 
 	Limit int64 `json:"limit"`
@@ -99,6 +103,9 @@ type Item struct {
 	Updated      int64            `json:"updated"`
 	Tags         []string         `json:"tags"`
 	Data         *simplejson.Json `json:"data"`
+	// ExternalID is the client-supplied ID of an imported annotation. It is unique in the organization, and nil for
+	// annotations without one.
+	ExternalID *string `json:"externalId" xorm:"external_id"`
 
 	// needed until we remove it from db
 	Type  string
@@ -131,6 +138,7 @@ type ItemDTO struct {
 	Email        string           `json:"email"`
 	AvatarURL    string           `json:"avatarUrl" xorm:"avatar_url"`
 	Data         *simplejson.Json `json:"data"`
+	ExternalID   string           `json:"externalId,omitempty" xorm:"external_id"`
 }
 
 type SortedItems []*ItemDTO
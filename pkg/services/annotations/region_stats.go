@@ -0,0 +1,156 @@
+package annotations
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+)
+
+const (
+	// RegionBucketDay aggregates regions per day. It is the default.
+	RegionBucketDay = "day"
+	// RegionBucketWeek aggregates regions per week, starting on Monday.
+	RegionBucketWeek = "week"
+)
+
+// MaxRegionStatsAnnotations is the maximum number of annotations that are aggregated by a region stats query.
+const MaxRegionStatsAnnotations = 10000
+
+var ErrRegionStatsTooManyAnnotations = errutil.BadRequest("annotations.region-stats-too-many", errutil.WithPublicMessage("Too many annotations match the query, use a shorter time range or more filters."))
+
+// RegionStatsQuery is the query for the statistics of region annotations. The annotations are filtered with the
+// ItemQuery, whose time range is required.
+type RegionStatsQuery struct {
+	ItemQuery
+	// Bucket is the size of the buckets, see RegionBucketDay and RegionBucketWeek.
+	Bucket string `json:"bucket"`
+	// Timezone is the IANA timezone the buckets start in. Defaults to UTC.
+	Timezone string `json:"timezone"`
+}
+
+// RegionStats are the statistics of the region annotations in a time range. Regions are clipped to the time range,
+// and durations are in milliseconds.
+type RegionStats struct {
+	// Count is the number of regions.
+	Count int64 `json:"count"`
+	// Duration is the time covered by the regions. Overlapping regions are only counted once.
+	Duration int64 `json:"duration"`
+	// Intervals are the regions, with overlapping regions merged.
+	Intervals []RegionInterval `json:"intervals"`
+	Buckets   []RegionBucket   `json:"buckets"`
+}
+
+type RegionInterval struct {
+	Time    int64 `json:"time"`
+	TimeEnd int64 `json:"timeEnd"`
+}
+
+type RegionBucket struct {
+	// Time is the start of the bucket.
+	Time int64 `json:"time"`
+	// Count is the number of regions that overlap the bucket.
+	Count int64 `json:"count"`
+	// Duration is the time of the bucket that is covered by regions.
+	Duration int64 `json:"duration"`
+}
+
+// FindRegionStats finds the annotations of the query in the repository and returns the statistics of the regions.
+func FindRegionStats(ctx context.Context, repo Repository, query *RegionStatsQuery) (*RegionStats, error) {
+	if query.From <= 0 || query.To <= query.From {
+		return nil, errInvalidSearch("a time range is required")
+	}
+	switch query.Bucket {
+	case "":
+		query.Bucket = RegionBucketDay
+	case RegionBucketDay, RegionBucketWeek:
+	default:
+		return nil, errInvalidSearch("unknown bucket " + query.Bucket)
+	}
+	loc, err := time.LoadLocation(query.Timezone)
+	if err != nil {
+		return nil, errInvalidSearch("unknown timezone " + query.Timezone)
+	}
+
+	itemQuery := query.ItemQuery
+	itemQuery.Limit = MaxRegionStatsAnnotations + 1
+	items, err := repo.Find(ctx, &itemQuery)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) > MaxRegionStatsAnnotations {
+		return nil, ErrRegionStatsTooManyAnnotations.Errorf("more than %d annotations", MaxRegionStatsAnnotations)
+	}
+	return ComputeRegionStats(items, time.UnixMilli(query.From).In(loc), time.UnixMilli(query.To).In(loc), query.Bucket), nil
+}
+
+// ComputeRegionStats returns the statistics of the region annotations of items between from and to. Annotations
+// that are not regions are ignored. The buckets start in the location of from.
+func ComputeRegionStats(items []*ItemDTO, from, to time.Time, bucket string) *RegionStats {
+	fromMs, toMs := from.UnixMilli(), to.UnixMilli()
+	regions := make([]RegionInterval, 0, len(items))
+	for _, item := range items {
+		if item.TimeEnd <= item.Time || item.TimeEnd <= fromMs || item.Time >= toMs {
+			continue
+		}
+		regions = append(regions, RegionInterval{Time: max(item.Time, fromMs), TimeEnd: min(item.TimeEnd, toMs)})
+	}
+
+	stats := &RegionStats{
+		Count:     int64(len(regions)),
+		Intervals: mergeRegions(regions),
+		Buckets:   make([]RegionBucket, 0),
+	}
+	for _, interval := range stats.Intervals {
+		stats.Duration += interval.TimeEnd - interval.Time
+	}
+
+	for start := bucketStart(from, bucket); start.Before(to); {
+		end := start.AddDate(0, 0, 1)
+		if bucket == RegionBucketWeek {
+			end = start.AddDate(0, 0, 7)
+		}
+		b := RegionBucket{Time: start.UnixMilli()}
+		startMs, endMs := start.UnixMilli(), end.UnixMilli()
+		for _, r := range regions {
+			if r.Time < endMs && r.TimeEnd > startMs {
+				b.Count++
+			}
+		}
+		for _, interval := range stats.Intervals {
+			if overlap := min(interval.TimeEnd, endMs) - max(interval.Time, startMs); overlap > 0 {
+				b.Duration += overlap
+			}
+		}
+		stats.Buckets = append(stats.Buckets, b)
+		start = end
+	}
+	return stats
+}
+
+// mergeRegions returns the regions sorted by time, with overlapping and adjacent regions merged.
+func mergeRegions(regions []RegionInterval) []RegionInterval {
+	sorted := make([]RegionInterval, len(regions))
+	copy(sorted, regions)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Time < sorted[j].Time })
+
+	merged := make([]RegionInterval, 0, len(sorted))
+	for _, r := range sorted {
+		if last := len(merged) - 1; last >= 0 && r.Time <= merged[last].TimeEnd {
+			merged[last].TimeEnd = max(merged[last].TimeEnd, r.TimeEnd)
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// bucketStart returns the start of the day or the week, on Monday, of t.
+func bucketStart(t time.Time, bucket string) time.Time {
+	start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	if bucket == RegionBucketWeek {
+		start = start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+	}
+	return start
+}
@@ -0,0 +1,111 @@
+package annotations
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeRegionStats(t *testing.T) {
+	hour := time.Hour.Milliseconds()
+	from := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC) // Monday
+	to := from.AddDate(0, 0, 3)
+	at := func(h int64) int64 { return from.UnixMilli() + h*hour }
+
+	items := []*ItemDTO{
+		{Time: at(1), TimeEnd: at(3)},
+		{Time: at(2), TimeEnd: at(5)},
+		// Adjacent to the previous region.
+		{Time: at(5), TimeEnd: at(6)},
+		// Spans two days.
+		{Time: at(22), TimeEnd: at(26)},
+		// Not a region.
+		{Time: at(30), TimeEnd: at(30)},
+		// Clipped to the time range.
+		{Time: at(-5), TimeEnd: at(1)},
+		{Time: at(70), TimeEnd: at(80)},
+		// Outside of the time range.
+		{Time: at(-5), TimeEnd: at(-1)},
+	}
+
+	t.Run("per day", func(t *testing.T) {
+		stats := ComputeRegionStats(items, from, to, RegionBucketDay)
+		assert.Equal(t, int64(6), stats.Count)
+		assert.Equal(t, 6*hour+4*hour+2*hour, stats.Duration)
+		assert.Equal(t, []RegionInterval{
+			{Time: at(0), TimeEnd: at(6)},
+			{Time: at(22), TimeEnd: at(26)},
+			{Time: at(70), TimeEnd: at(72)},
+		}, stats.Intervals)
+		assert.Equal(t, []RegionBucket{
+			{Time: at(0), Count: 5, Duration: 8 * hour},
+			{Time: at(24), Count: 1, Duration: 2 * hour},
+			{Time: at(48), Count: 1, Duration: 2 * hour},
+		}, stats.Buckets)
+	})
+
+	t.Run("per week in a timezone", func(t *testing.T) {
+		loc, err := time.LoadLocation("Europe/Berlin")
+		require.NoError(t, err)
+
+		stats := ComputeRegionStats(items, from.In(loc), to.In(loc), RegionBucketWeek)
+		require.Len(t, stats.Buckets, 1)
+		// The week starts on Monday at midnight in Berlin, one hour before the time range.
+		assert.Equal(t, at(-1), stats.Buckets[0].Time)
+		assert.Equal(t, int64(6), stats.Buckets[0].Count)
+		assert.Equal(t, stats.Duration, stats.Buckets[0].Duration)
+	})
+
+	t.Run("without regions", func(t *testing.T) {
+		stats := ComputeRegionStats(nil, from, to, RegionBucketDay)
+		assert.Zero(t, stats.Count)
+		assert.Empty(t, stats.Intervals)
+		assert.Len(t, stats.Buckets, 3)
+	})
+}
+
+func TestFindRegionStats(t *testing.T) {
+	from := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+
+	t.Run("invalid queries", func(t *testing.T) {
+		queries := []RegionStatsQuery{
+			{},
+			{ItemQuery: ItemQuery{From: from.UnixMilli(), To: from.UnixMilli()}},
+			{ItemQuery: ItemQuery{From: from.UnixMilli(), To: from.AddDate(0, 0, 1).UnixMilli()}, Bucket: "month"},
+			{ItemQuery: ItemQuery{From: from.UnixMilli(), To: from.AddDate(0, 0, 1).UnixMilli()}, Timezone: "Mars/Olympus"},
+		}
+		for _, q := range queries {
+			_, err := FindRegionStats(context.Background(), NewFakeAnnotationsRepo(t), &q)
+			require.ErrorIs(t, err, ErrBaseInvalidSearch)
+		}
+	})
+
+	t.Run("finds the annotations of the query", func(t *testing.T) {
+		repo := NewFakeAnnotationsRepo(t)
+		repo.On("Find", mock.Anything, mock.MatchedBy(func(q *ItemQuery) bool {
+			return q.Limit == MaxRegionStatsAnnotations+1 && q.Tags[0] == "incident"
+		})).Return([]*ItemDTO{{Time: from.Add(time.Hour).UnixMilli(), TimeEnd: from.Add(2 * time.Hour).UnixMilli()}}, nil)
+
+		stats, err := FindRegionStats(context.Background(), repo, &RegionStatsQuery{
+			ItemQuery: ItemQuery{OrgID: 1, From: from.UnixMilli(), To: from.AddDate(0, 0, 1).UnixMilli(), Tags: []string{"incident"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), stats.Count)
+		assert.Equal(t, time.Hour.Milliseconds(), stats.Duration)
+		assert.Len(t, stats.Buckets, 1)
+	})
+
+	t.Run("too many annotations", func(t *testing.T) {
+		repo := NewFakeAnnotationsRepo(t)
+		repo.On("Find", mock.Anything, mock.Anything).Return(make([]*ItemDTO, MaxRegionStatsAnnotations+1), nil)
+
+		_, err := FindRegionStats(context.Background(), repo, &RegionStatsQuery{
+			ItemQuery: ItemQuery{OrgID: 1, From: from.UnixMilli(), To: from.AddDate(0, 0, 1).UnixMilli()},
+		})
+		require.ErrorIs(t, err, ErrRegionStatsTooManyAnnotations)
+	})
+}
@@ -0,0 +1,65 @@
+package annotations
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+)
+
+const (
+	// TagMatchExact matches tags that are equal to the tags of the query. It is the default.
+	TagMatchExact = "exact"
+	// TagMatchPrefix matches tags that start with the tags of the query, for example `env:prod` matches `env:production`.
+	TagMatchPrefix = "prefix"
+	// TagMatchRegex matches tags that fully match the tags of the query as regular expressions, for example
+	// `env:(prod|staging)`. Tags are matched as `key` or `key:value`.
+	TagMatchRegex = "regex"
+)
+
+const errInvalidSearchTmpl = "Invalid annotation search: {{ .Public.Reason }}"
+
+var ErrBaseInvalidSearch = errutil.BadRequest("annotations.invalid-search").MustTemplate(errInvalidSearchTmpl, errutil.WithPublic(errInvalidSearchTmpl))
+
+func errInvalidSearch(reason string) error {
+	return ErrBaseInvalidSearch.Build(errutil.TemplateData{Public: map[string]any{"Reason": reason}})
+}
+
+// ValidateSearch checks the tag match mode of the query and that its tags are valid regular expressions if they are
+// matched as regular expressions.
+func (q *ItemQuery) ValidateSearch() error {
+	switch q.TagMatch {
+	case "", TagMatchExact, TagMatchPrefix:
+	case TagMatchRegex:
+		for _, t := range q.Tags {
+			if _, err := TagRegexp(t); err != nil {
+				return errInvalidSearch("invalid tag regex " + t)
+			}
+		}
+	default:
+		return errInvalidSearch("unknown tag match " + q.TagMatch)
+	}
+	return nil
+}
+
+// TextSearchWords splits the text of a search into lower case words.
+func TextSearchWords(text string) []string {
+	return strings.Fields(strings.ToLower(text))
+}
+
+// MatchesText returns true if the text contains all the words, ignoring case.
+func MatchesText(text string, words []string) bool {
+	text = strings.ToLower(text)
+	for _, w := range words {
+		if !strings.Contains(text, w) {
+			return false
+		}
+	}
+	return true
+}
+
+// TagRegexp compiles a tag of a query that is matched as a regular expression. The expression must match the whole
+// tag.
+func TagRegexp(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + strings.TrimSpace(pattern) + ")$")
+}
@@ -0,0 +1,46 @@
+package annotations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSearch(t *testing.T) {
+	valid := []ItemQuery{
+		{},
+		{TagMatch: TagMatchExact, Tags: []string{"env:("}},
+		{TagMatch: TagMatchPrefix, Tags: []string{"env:("}},
+		{TagMatch: TagMatchRegex, Tags: []string{"env:(prod|staging)"}},
+	}
+	for _, q := range valid {
+		require.NoError(t, q.ValidateSearch())
+	}
+
+	invalid := []ItemQuery{
+		{TagMatch: "glob"},
+		{TagMatch: TagMatchRegex, Tags: []string{"env:("}},
+	}
+	for _, q := range invalid {
+		require.ErrorIs(t, q.ValidateSearch(), ErrBaseInvalidSearch)
+	}
+}
+
+func TestMatchesText(t *testing.T) {
+	words := TextSearchWords("  Rollback of PAYMENTS-api ")
+	assert.Equal(t, []string{"rollback", "of", "payments-api"}, words)
+
+	assert.True(t, MatchesText("Rollback of payments-api to v1.2", words))
+	assert.True(t, MatchesText("payments-api: start of rollback", words))
+	assert.False(t, MatchesText("Rollback of orders-api", words))
+	assert.True(t, MatchesText("anything", nil))
+}
+
+func TestTagRegexp(t *testing.T) {
+	re, err := TagRegexp("env:(prod|staging)")
+	require.NoError(t, err)
+	assert.True(t, re.MatchString("env:prod"))
+	assert.False(t, re.MatchString("env:production"))
+	assert.False(t, re.MatchString("old-env:prod"))
+}
@@ -0,0 +1,136 @@
+package caching
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+)
+
+const (
+	queryKeyPrefix    = "query-cache:query:"
+	resourceKeyPrefix = "query-cache:resource:"
+)
+
+// dataSourceConfig is the part of the JSON data of a data source that affects caching.
+type dataSourceConfig struct {
+	// TTL of the cached results of the data source in milliseconds. A negative value disables caching.
+	TTL             *int64          `json:"queryCachingTTL,omitempty"`
+	OAuthPassThru   bool            `json:"oauthPassThru,omitempty"`
+	TeamHTTPHeaders json.RawMessage `json:"teamHttpHeaders,omitempty"`
+}
+
+func parseDataSourceConfig(ds *backend.DataSourceInstanceSettings) dataSourceConfig {
+	config := dataSourceConfig{}
+	if len(ds.JSONData) > 0 {
+		// Invalid JSON data is handled as a data source without caching settings.
+		_ = json.Unmarshal(ds.JSONData, &config)
+	}
+	return config
+}
+
+// forwardsIdentity returns true if the results of the data source depend on the user who sends the request.
+func (c dataSourceConfig) forwardsIdentity() bool {
+	return c.OAuthPassThru || (len(c.TeamHTTPHeaders) > 0 && string(c.TeamHTTPHeaders) != "null")
+}
+
+// hasIdentityHeaders returns true if credentials of the user are forwarded to the data source.
+func hasIdentityHeaders(headers http.Header) bool {
+	return headers.Get(backend.OAuthIdentityTokenHeaderName) != "" ||
+		headers.Get(backend.OAuthIdentityIDTokenHeaderName) != "" ||
+		headers.Get(backend.CookiesHeaderName) != ""
+}
+
+// cacheKey contains everything that the result of a request depends on. Results are shared between the users of an
+// organization unless the data source uses the identity of the user, in which case the key contains the user.
+type cacheKey struct {
+	OrgID             int64           `json:"orgId"`
+	DatasourceUID     string          `json:"datasourceUid"`
+	DatasourceUpdated int64           `json:"datasourceUpdated"`
+	User              string          `json:"user,omitempty"`
+	Queries           []queryCacheKey `json:"queries,omitempty"`
+	Path              string          `json:"path,omitempty"`
+	URL               string          `json:"url,omitempty"`
+	Body              []byte          `json:"body,omitempty"`
+}
+
+type queryCacheKey struct {
+	RefID         string          `json:"refId"`
+	QueryType     string          `json:"queryType,omitempty"`
+	MaxDataPoints int64           `json:"maxDataPoints,omitempty"`
+	Interval      time.Duration   `json:"interval,omitempty"`
+	From          int64           `json:"from"`
+	To            int64           `json:"to"`
+	JSON          json.RawMessage `json:"json,omitempty"`
+}
+
+func newCacheKey(pCtx backend.PluginContext, perUser bool) cacheKey {
+	key := cacheKey{
+		OrgID:             pCtx.OrgID,
+		DatasourceUID:     pCtx.DataSourceInstanceSettings.UID,
+		DatasourceUpdated: pCtx.DataSourceInstanceSettings.Updated.UnixMilli(),
+	}
+	if perUser {
+		// An anonymous user is still a distinct user from the users who are signed in.
+		key.User = "anonymous"
+		if pCtx.User != nil {
+			key.User = "user:" + pCtx.User.Login
+		}
+	}
+	return key
+}
+
+func (k cacheKey) hash(prefix string) (string, error) {
+	data, err := json.Marshal(k)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return prefix + strconv.FormatInt(k.OrgID, 10) + ":" + hex.EncodeToString(sum[:]), nil
+}
+
+func (s *OSSCachingService) queryKey(req *backend.QueryDataRequest, perUser bool) (string, error) {
+	key := newCacheKey(req.PluginContext, perUser)
+	key.Queries = make([]queryCacheKey, 0, len(req.Queries))
+	for _, q := range req.Queries {
+		from, to := s.timeRangeKey(q.TimeRange)
+		key.Queries = append(key.Queries, queryCacheKey{
+			RefID:         q.RefID,
+			QueryType:     q.QueryType,
+			MaxDataPoints: q.MaxDataPoints,
+			Interval:      q.Interval,
+			From:          from,
+			To:            to,
+			JSON:          q.JSON,
+		})
+	}
+	return key.hash(queryKeyPrefix)
+}
+
+func (s *OSSCachingService) resourceKey(req *backend.CallResourceRequest, perUser bool) string {
+	key := newCacheKey(req.PluginContext, perUser)
+	key.Path = req.Path
+	key.URL = req.URL
+	key.Body = req.Body
+	// The key contains only strings and bytes that can always be marshalled.
+	result, _ := key.hash(resourceKeyPrefix)
+	return result
+}
+
+// timeRangeKey returns the time range of a query in milliseconds for the cache key. Time ranges that end within
+// the bucket interval of the current time are considered relative to now, for example "last 6 hours", and their
+// end and duration are rounded to the interval. This way, refreshes of the same query within the interval use
+// the same key.
+func (s *OSSCachingService) timeRangeKey(tr backend.TimeRange) (int64, int64) {
+	bucket := s.cfg.TimeRangeBucket
+	if bucket <= 0 || s.now().Sub(tr.To).Abs() > bucket {
+		return tr.From.UnixMilli(), tr.To.UnixMilli()
+	}
+	to := tr.To.Truncate(bucket)
+	from := to.Add(-tr.To.Sub(tr.From).Round(bucket))
+	return from.UnixMilli(), to.UnixMilli()
+}
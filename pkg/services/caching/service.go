@@ -2,8 +2,19 @@ package caching
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/remotecache"
+	"github.com/grafana/grafana/pkg/services/contexthandler"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/setting"
 )
 
 const (
@@ -35,8 +46,13 @@ type CachedResourceDataResponse struct {
 	UpdateCacheFn CacheResourceResponseFn
 }
 
-func ProvideCachingService() *OSSCachingService {
-	return &OSSCachingService{}
+func ProvideCachingService(cfg *setting.Cfg, cache remotecache.CacheStorage) *OSSCachingService {
+	return &OSSCachingService{
+		cfg:   cfg.QueryCaching,
+		cache: cache,
+		log:   log.New("query-caching"),
+		now:   time.Now,
+	}
 }
 
 type CachingService interface {
@@ -49,16 +65,160 @@ type CachingService interface {
 	HandleResourceRequest(context.Context, *backend.CallResourceRequest) (bool, CachedResourceDataResponse)
 }
 
-// Implementation of interface - does nothing
+// OSSCachingService caches the results of data source queries and GET resource requests in the remote cache.
+// A zero OSSCachingService does nothing.
 type OSSCachingService struct {
+	cfg   setting.QueryCachingSettings
+	cache remotecache.CacheStorage
+	log   log.Logger
+	now   func() time.Time
 }
 
 func (s *OSSCachingService) HandleQueryRequest(ctx context.Context, req *backend.QueryDataRequest) (bool, CachedQueryDataResponse) {
-	return false, CachedQueryDataResponse{}
+	ds := req.PluginContext.DataSourceInstanceSettings
+	if !s.enabled() || ds == nil {
+		return false, CachedQueryDataResponse{}
+	}
+	reqCtx := contexthandler.FromContext(ctx)
+	config := parseDataSourceConfig(ds)
+	ttl := s.cfg.TTL
+	if config.TTL != nil && *config.TTL != 0 {
+		ttl = time.Duration(*config.TTL) * time.Millisecond
+	}
+	if ttl <= 0 {
+		setCacheStatus(reqCtx, StatusDisabled)
+		return false, CachedQueryDataResponse{}
+	}
+	if reqCtx != nil && reqCtx.SkipQueryCache {
+		setCacheStatus(reqCtx, StatusBypass)
+		return false, CachedQueryDataResponse{}
+	}
+
+	key, err := s.queryKey(req, config.forwardsIdentity() || hasIdentityHeaders(req.GetHTTPHeaders()))
+	if err != nil {
+		s.log.FromContext(ctx).Warn("Failed to create query cache key", "datasource", ds.UID, "error", err)
+		setCacheStatus(reqCtx, StatusError)
+		return false, CachedQueryDataResponse{}
+	}
+
+	data, err := s.cache.Get(ctx, key)
+	switch {
+	case err == nil:
+		resp := &backend.QueryDataResponse{}
+		if err := json.Unmarshal(data, resp); err == nil {
+			setCacheStatus(reqCtx, StatusHit)
+			return true, CachedQueryDataResponse{Response: resp}
+		}
+		s.log.FromContext(ctx).Warn("Failed to decode cached query response", "datasource", ds.UID, "error", err)
+	case !errors.Is(err, remotecache.ErrCacheItemNotFound):
+		s.log.FromContext(ctx).Warn("Failed to read from the query cache", "datasource", ds.UID, "error", err)
+		setCacheStatus(reqCtx, StatusError)
+		return false, CachedQueryDataResponse{}
+	}
+
+	setCacheStatus(reqCtx, StatusMiss)
+	return false, CachedQueryDataResponse{
+		UpdateCacheFn: func(ctx context.Context, resp *backend.QueryDataResponse) {
+			if resp == nil || !isSuccessfulQueryResponse(resp) {
+				return
+			}
+			data, err := json.Marshal(resp)
+			if err != nil {
+				s.log.FromContext(ctx).Warn("Failed to encode query response for the cache", "datasource", ds.UID, "error", err)
+				return
+			}
+			s.set(ctx, key, data, ttl)
+		},
+	}
 }
 
 func (s *OSSCachingService) HandleResourceRequest(ctx context.Context, req *backend.CallResourceRequest) (bool, CachedResourceDataResponse) {
-	return false, CachedResourceDataResponse{}
+	ds := req.PluginContext.DataSourceInstanceSettings
+	if !s.enabled() || s.cfg.ResourcesTTL <= 0 || ds == nil || req.Method != http.MethodGet {
+		return false, CachedResourceDataResponse{}
+	}
+	reqCtx := contexthandler.FromContext(ctx)
+	config := parseDataSourceConfig(ds)
+	if config.TTL != nil && *config.TTL < 0 {
+		setCacheStatus(reqCtx, StatusDisabled)
+		return false, CachedResourceDataResponse{}
+	}
+	if reqCtx != nil && reqCtx.SkipQueryCache {
+		setCacheStatus(reqCtx, StatusBypass)
+		return false, CachedResourceDataResponse{}
+	}
+
+	key := s.resourceKey(req, config.forwardsIdentity() || hasIdentityHeaders(req.GetHTTPHeaders()))
+	data, err := s.cache.Get(ctx, key)
+	switch {
+	case err == nil:
+		resp := &backend.CallResourceResponse{}
+		if err := json.Unmarshal(data, resp); err == nil {
+			setCacheStatus(reqCtx, StatusHit)
+			return true, CachedResourceDataResponse{Response: resp}
+		}
+		s.log.FromContext(ctx).Warn("Failed to decode cached resource response", "datasource", ds.UID, "error", err)
+	case !errors.Is(err, remotecache.ErrCacheItemNotFound):
+		s.log.FromContext(ctx).Warn("Failed to read from the query cache", "datasource", ds.UID, "error", err)
+		setCacheStatus(reqCtx, StatusError)
+		return false, CachedResourceDataResponse{}
+	}
+
+	setCacheStatus(reqCtx, StatusMiss)
+	var calls atomic.Int32
+	return false, CachedResourceDataResponse{
+		UpdateCacheFn: func(ctx context.Context, resp *backend.CallResourceResponse) {
+			// Only responses that are sent at once are cached. If the plugin streams the response, the first part
+			// that was cached is removed.
+			if calls.Add(1) > 1 {
+				if err := s.cache.Delete(ctx, key); err != nil && !errors.Is(err, remotecache.ErrCacheItemNotFound) {
+					s.log.FromContext(ctx).Warn("Failed to delete streamed resource response from the cache", "datasource", ds.UID, "error", err)
+				}
+				return
+			}
+			if resp == nil || resp.Status < http.StatusOK || resp.Status >= http.StatusMultipleChoices {
+				return
+			}
+			data, err := json.Marshal(resp)
+			if err != nil {
+				s.log.FromContext(ctx).Warn("Failed to encode resource response for the cache", "datasource", ds.UID, "error", err)
+				return
+			}
+			s.set(ctx, key, data, s.cfg.ResourcesTTL)
+		},
+	}
+}
+
+func (s *OSSCachingService) enabled() bool {
+	return s.cache != nil && s.cfg.Enabled
+}
+
+func (s *OSSCachingService) set(ctx context.Context, key string, data []byte, ttl time.Duration) {
+	if s.cfg.MaxValueSize > 0 && len(data) > s.cfg.MaxValueSize {
+		s.log.FromContext(ctx).Debug("Response is too large to be cached", "size", len(data), "limit", s.cfg.MaxValueSize)
+		return
+	}
+	if err := s.cache.Set(ctx, key, data, ttl); err != nil {
+		s.log.FromContext(ctx).Warn("Failed to write to the query cache", "error", err)
+	}
+}
+
+func setCacheStatus(reqCtx *contextmodel.ReqContext, status string) {
+	if reqCtx == nil || reqCtx.Resp == nil {
+		return
+	}
+	reqCtx.Resp.Header().Set(XCacheHeader, status)
+}
+
+// isSuccessfulQueryResponse returns false if any of the queries failed. Failed queries are not cached so that they
+// are retried by the next request.
+func isSuccessfulQueryResponse(resp *backend.QueryDataResponse) bool {
+	for _, r := range resp.Responses {
+		if r.Error != nil || r.Status >= backend.StatusBadRequest {
+			return false
+		}
+	}
+	return true
 }
 
 var _ CachingService = &OSSCachingService{}
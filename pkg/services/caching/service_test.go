@@ -0,0 +1,310 @@
+package caching
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/remotecache"
+	"github.com/grafana/grafana/pkg/services/contexthandler/ctxkey"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+func TestOSSCachingService_HandleQueryRequest(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 3, 0, time.UTC)
+
+	t.Run("zero value does nothing", func(t *testing.T) {
+		s := &OSSCachingService{}
+		hit, resp := s.HandleQueryRequest(context.Background(), queryRequest(now, nil))
+		assert.False(t, hit)
+		assert.Nil(t, resp.UpdateCacheFn)
+	})
+
+	t.Run("caches successful responses", func(t *testing.T) {
+		s, cache := newTestCachingService(now)
+
+		ctx, reqCtx := newRequestContext()
+		hit, resp := s.HandleQueryRequest(ctx, queryRequest(now, nil))
+		assert.False(t, hit)
+		assert.Equal(t, StatusMiss, reqCtx.Resp.Header().Get(XCacheHeader))
+		require.NotNil(t, resp.UpdateCacheFn)
+
+		result := &backend.QueryDataResponse{Responses: backend.Responses{
+			"A": {Frames: data.Frames{data.NewFrame("A", data.NewField("value", nil, []float64{1, 2}))}},
+		}}
+		resp.UpdateCacheFn(ctx, result)
+		require.Len(t, cache.Storage, 1)
+
+		ctx, reqCtx = newRequestContext()
+		hit, resp = s.HandleQueryRequest(ctx, queryRequest(now, nil))
+		require.True(t, hit)
+		assert.Equal(t, StatusHit, reqCtx.Resp.Header().Get(XCacheHeader))
+		require.Contains(t, resp.Response.Responses, "A")
+		assert.Equal(t, []float64{1, 2}, valuesOf(t, resp.Response.Responses["A"].Frames[0]))
+	})
+
+	t.Run("does not cache failed responses", func(t *testing.T) {
+		s, cache := newTestCachingService(now)
+		ctx, _ := newRequestContext()
+		_, resp := s.HandleQueryRequest(ctx, queryRequest(now, nil))
+		resp.UpdateCacheFn(ctx, &backend.QueryDataResponse{Responses: backend.Responses{"A": {Error: errors.New("failed")}}})
+		resp.UpdateCacheFn(ctx, &backend.QueryDataResponse{Responses: backend.Responses{"A": {Status: backend.StatusBadGateway}}})
+		assert.Empty(t, cache.Storage)
+	})
+
+	t.Run("does not cache responses larger than the limit", func(t *testing.T) {
+		s, cache := newTestCachingService(now)
+		s.cfg.MaxValueSize = 10
+		ctx, _ := newRequestContext()
+		_, resp := s.HandleQueryRequest(ctx, queryRequest(now, nil))
+		resp.UpdateCacheFn(ctx, &backend.QueryDataResponse{Responses: backend.Responses{"A": {Frames: data.Frames{data.NewFrame("A")}}}})
+		assert.Empty(t, cache.Storage)
+	})
+
+	t.Run("uses the TTL of the data source", func(t *testing.T) {
+		s, _ := newTestCachingService(now)
+
+		ctx, reqCtx := newRequestContext()
+		hit, resp := s.HandleQueryRequest(ctx, queryRequest(now, map[string]any{"queryCachingTTL": -1}))
+		assert.False(t, hit)
+		assert.Nil(t, resp.UpdateCacheFn)
+		assert.Equal(t, StatusDisabled, reqCtx.Resp.Header().Get(XCacheHeader))
+
+		ctx, reqCtx = newRequestContext()
+		_, resp = s.HandleQueryRequest(ctx, queryRequest(now, map[string]any{"queryCachingTTL": 60000}))
+		assert.NotNil(t, resp.UpdateCacheFn)
+		assert.Equal(t, StatusMiss, reqCtx.Resp.Header().Get(XCacheHeader))
+	})
+
+	t.Run("is bypassed if the request skips the cache", func(t *testing.T) {
+		s, _ := newTestCachingService(now)
+		ctx, reqCtx := newRequestContext()
+		reqCtx.SkipQueryCache = true
+		hit, resp := s.HandleQueryRequest(ctx, queryRequest(now, nil))
+		assert.False(t, hit)
+		assert.Nil(t, resp.UpdateCacheFn)
+		assert.Equal(t, StatusBypass, reqCtx.Resp.Header().Get(XCacheHeader))
+	})
+
+	t.Run("reports cache errors", func(t *testing.T) {
+		s, _ := newTestCachingService(now)
+		s.cache = failingCacheStorage{}
+		ctx, reqCtx := newRequestContext()
+		hit, resp := s.HandleQueryRequest(ctx, queryRequest(now, nil))
+		assert.False(t, hit)
+		assert.Nil(t, resp.UpdateCacheFn)
+		assert.Equal(t, StatusError, reqCtx.Resp.Header().Get(XCacheHeader))
+	})
+}
+
+func TestOSSCachingService_queryKey(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 3, 0, time.UTC)
+	s, _ := newTestCachingService(now)
+
+	key := func(t *testing.T, req *backend.QueryDataRequest, perUser bool) string {
+		t.Helper()
+		k, err := s.queryKey(req, perUser)
+		require.NoError(t, err)
+		return k
+	}
+	base := key(t, queryRequest(now, nil), false)
+
+	t.Run("relative time ranges within the bucket share the key", func(t *testing.T) {
+		later := queryRequest(now.Add(5*time.Second), nil)
+		s.now = func() time.Time { return now.Add(5 * time.Second) }
+		t.Cleanup(func() { s.now = func() time.Time { return now } })
+		assert.Equal(t, base, key(t, later, false))
+
+		s.now = func() time.Time { return now.Add(10 * time.Second) }
+		assert.NotEqual(t, base, key(t, queryRequest(now.Add(10*time.Second), nil), false))
+	})
+
+	t.Run("absolute time ranges are not rounded", func(t *testing.T) {
+		req := queryRequest(now.Add(-time.Hour), nil)
+		other := queryRequest(now.Add(-time.Hour+time.Second), nil)
+		assert.NotEqual(t, key(t, req, false), key(t, other, false))
+	})
+
+	t.Run("depends on the query, data source and user", func(t *testing.T) {
+		req := queryRequest(now, nil)
+		req.Queries[0].JSON = json.RawMessage(`{"expr":"down"}`)
+		assert.NotEqual(t, base, key(t, req, false))
+
+		req = queryRequest(now, nil)
+		req.PluginContext.DataSourceInstanceSettings.Updated = now
+		assert.NotEqual(t, base, key(t, req, false))
+
+		req = queryRequest(now, nil)
+		req.PluginContext.OrgID = 2
+		assert.NotEqual(t, base, key(t, req, false))
+
+		userKey := key(t, queryRequest(now, nil), true)
+		assert.NotEqual(t, base, userKey)
+		req = queryRequest(now, nil)
+		req.PluginContext.User = &backend.User{Login: "other"}
+		assert.NotEqual(t, userKey, key(t, req, true))
+	})
+
+	t.Run("is per user if the data source uses the identity of the user", func(t *testing.T) {
+		assert.True(t, parseDataSourceConfig(&backend.DataSourceInstanceSettings{JSONData: json.RawMessage(`{"oauthPassThru":true}`)}).forwardsIdentity())
+		assert.True(t, parseDataSourceConfig(&backend.DataSourceInstanceSettings{JSONData: json.RawMessage(`{"teamHttpHeaders":{"headers":{}}}`)}).forwardsIdentity())
+		assert.False(t, parseDataSourceConfig(&backend.DataSourceInstanceSettings{JSONData: json.RawMessage(`{"httpMethod":"POST"}`)}).forwardsIdentity())
+
+		req := queryRequest(now, nil)
+		assert.False(t, hasIdentityHeaders(req.GetHTTPHeaders()))
+		req.SetHTTPHeader(backend.OAuthIdentityTokenHeaderName, "Bearer token")
+		assert.True(t, hasIdentityHeaders(req.GetHTTPHeaders()))
+	})
+}
+
+func TestOSSCachingService_HandleResourceRequest(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 3, 0, time.UTC)
+
+	t.Run("caches GET requests", func(t *testing.T) {
+		s, cache := newTestCachingService(now)
+		ctx, _ := newRequestContext()
+		hit, resp := s.HandleResourceRequest(ctx, resourceRequest(http.MethodGet))
+		assert.False(t, hit)
+		require.NotNil(t, resp.UpdateCacheFn)
+		resp.UpdateCacheFn(ctx, &backend.CallResourceResponse{Status: http.StatusOK, Body: []byte(`["job"]`)})
+		require.Len(t, cache.Storage, 1)
+
+		ctx, reqCtx := newRequestContext()
+		hit, resp = s.HandleResourceRequest(ctx, resourceRequest(http.MethodGet))
+		require.True(t, hit)
+		assert.Equal(t, StatusHit, reqCtx.Resp.Header().Get(XCacheHeader))
+		assert.Equal(t, []byte(`["job"]`), resp.Response.Body)
+	})
+
+	t.Run("does not cache streamed responses", func(t *testing.T) {
+		s, cache := newTestCachingService(now)
+		ctx, _ := newRequestContext()
+		_, resp := s.HandleResourceRequest(ctx, resourceRequest(http.MethodGet))
+		resp.UpdateCacheFn(ctx, &backend.CallResourceResponse{Status: http.StatusOK, Body: []byte(`part 1`)})
+		resp.UpdateCacheFn(ctx, &backend.CallResourceResponse{Body: []byte(`part 2`)})
+		assert.Empty(t, cache.Storage)
+	})
+
+	t.Run("does not cache failed responses", func(t *testing.T) {
+		s, cache := newTestCachingService(now)
+		ctx, _ := newRequestContext()
+		_, resp := s.HandleResourceRequest(ctx, resourceRequest(http.MethodGet))
+		resp.UpdateCacheFn(ctx, &backend.CallResourceResponse{Status: http.StatusInternalServerError})
+		assert.Empty(t, cache.Storage)
+	})
+
+	t.Run("ignores other methods", func(t *testing.T) {
+		s, _ := newTestCachingService(now)
+		ctx, _ := newRequestContext()
+		hit, resp := s.HandleResourceRequest(ctx, resourceRequest(http.MethodPost))
+		assert.False(t, hit)
+		assert.Nil(t, resp.UpdateCacheFn)
+	})
+
+	t.Run("is disabled if the resources TTL is zero", func(t *testing.T) {
+		s, _ := newTestCachingService(now)
+		s.cfg.ResourcesTTL = 0
+		ctx, _ := newRequestContext()
+		_, resp := s.HandleResourceRequest(ctx, resourceRequest(http.MethodGet))
+		assert.Nil(t, resp.UpdateCacheFn)
+	})
+}
+
+func newTestCachingService(now time.Time) (*OSSCachingService, remotecache.FakeCacheStorage) {
+	cache := remotecache.NewFakeCacheStorage()
+	return &OSSCachingService{
+		cfg: setting.QueryCachingSettings{
+			Enabled:         true,
+			TTL:             time.Minute,
+			ResourcesTTL:    time.Minute,
+			TimeRangeBucket: 10 * time.Second,
+			MaxValueSize:    1024 * 1024,
+		},
+		cache: cache,
+		log:   log.NewNopLogger(),
+		now:   func() time.Time { return now },
+	}, cache
+}
+
+func newRequestContext() (context.Context, *contextmodel.ReqContext) {
+	req := httptest.NewRequest(http.MethodPost, "/api/ds/query", nil)
+	reqCtx := &contextmodel.ReqContext{
+		Context: &web.Context{
+			Req:  req,
+			Resp: web.NewResponseWriter(req.Method, httptest.NewRecorder()),
+		},
+		SignedInUser: &user.SignedInUser{OrgID: 1, Login: "viewer"},
+	}
+	return ctxkey.Set(context.Background(), reqCtx), reqCtx
+}
+
+func dataSourceSettings(jsonData map[string]any) *backend.DataSourceInstanceSettings {
+	raw, _ := json.Marshal(jsonData)
+	return &backend.DataSourceInstanceSettings{UID: "prometheus", Type: "prometheus", JSONData: raw}
+}
+
+// queryRequest returns a request for the last hour before to.
+func queryRequest(to time.Time, jsonData map[string]any) *backend.QueryDataRequest {
+	return &backend.QueryDataRequest{
+		PluginContext: backend.PluginContext{
+			OrgID:                      1,
+			User:                       &backend.User{Login: "viewer"},
+			DataSourceInstanceSettings: dataSourceSettings(jsonData),
+		},
+		Queries: []backend.DataQuery{{
+			RefID:     "A",
+			TimeRange: backend.TimeRange{From: to.Add(-time.Hour), To: to},
+			JSON:      json.RawMessage(`{"expr":"up"}`),
+		}},
+	}
+}
+
+func resourceRequest(method string) *backend.CallResourceRequest {
+	return &backend.CallResourceRequest{
+		PluginContext: backend.PluginContext{
+			OrgID:                      1,
+			User:                       &backend.User{Login: "viewer"},
+			DataSourceInstanceSettings: dataSourceSettings(nil),
+		},
+		Path:   "api/v1/labels",
+		Method: method,
+		URL:    "api/v1/labels?match[]=up",
+	}
+}
+
+func valuesOf(t *testing.T, frame *data.Frame) []float64 {
+	t.Helper()
+	require.Len(t, frame.Fields, 1)
+	values := make([]float64, 0, frame.Fields[0].Len())
+	for i := 0; i < frame.Fields[0].Len(); i++ {
+		values = append(values, frame.Fields[0].At(i).(float64))
+	}
+	return values
+}
+
+type failingCacheStorage struct{}
+
+func (failingCacheStorage) Get(context.Context, string) ([]byte, error) {
+	return nil, errors.New("connection refused")
+}
+
+func (failingCacheStorage) Set(context.Context, string, []byte, time.Duration) error {
+	return errors.New("connection refused")
+}
+
+func (failingCacheStorage) Delete(context.Context, string) error {
+	return errors.New("connection refused")
+}
@@ -0,0 +1,83 @@
+package dashboardreports
+
+import (
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/org"
+)
+
+const (
+	ScopeReportsRoot = "dashboard.reports"
+
+	ActionReportsRead   = "dashboard.reports:read"
+	ActionReportsCreate = "dashboard.reports:create"
+	ActionReportsWrite  = "dashboard.reports:write"
+	ActionReportsDelete = "dashboard.reports:delete"
+	ActionReportsSend   = "dashboard.reports:send"
+	// ActionReportsAdmin allows to update, delete and send the reports created by other users. Without it, users can
+	// only change and send their own reports, because a report renders its dashboard as the user who created it.
+	ActionReportsAdmin = "dashboard.reports:admin"
+)
+
+var (
+	ScopeReportsProvider = accesscontrol.NewScopeProvider(ScopeReportsRoot)
+
+	ScopeReportsAll = ScopeReportsProvider.GetResourceAllScope()
+)
+
+var (
+	reportsReaderRole = accesscontrol.RoleDTO{
+		Name:        accesscontrol.FixedRolePrefix + "dashboard.reports:reader",
+		DisplayName: "Reader",
+		Description: "List dashboard reports and their run history",
+		Group:       "Dashboard reports",
+		Permissions: []accesscontrol.Permission{
+			{Action: ActionReportsRead, Scope: ScopeReportsAll},
+		},
+	}
+
+	reportsWriterRole = accesscontrol.RoleDTO{
+		Name:        accesscontrol.FixedRolePrefix + "dashboard.reports:writer",
+		DisplayName: "Writer",
+		Description: "Create dashboard reports, and update, delete and send the reports created by the user",
+		Group:       "Dashboard reports",
+		Permissions: []accesscontrol.Permission{
+			{Action: ActionReportsRead, Scope: ScopeReportsAll},
+			{Action: ActionReportsCreate},
+			{Action: ActionReportsWrite, Scope: ScopeReportsAll},
+			{Action: ActionReportsDelete, Scope: ScopeReportsAll},
+			{Action: ActionReportsSend, Scope: ScopeReportsAll},
+		},
+	}
+
+	reportsAdminRole = accesscontrol.RoleDTO{
+		Name:        accesscontrol.FixedRolePrefix + "dashboard.reports:admin",
+		DisplayName: "Admin",
+		Description: "Create, update, delete and send all dashboard reports, including the reports created by other users",
+		Group:       "Dashboard reports",
+		Permissions: []accesscontrol.Permission{
+			{Action: ActionReportsRead, Scope: ScopeReportsAll},
+			{Action: ActionReportsCreate},
+			{Action: ActionReportsWrite, Scope: ScopeReportsAll},
+			{Action: ActionReportsDelete, Scope: ScopeReportsAll},
+			{Action: ActionReportsSend, Scope: ScopeReportsAll},
+			{Action: ActionReportsAdmin, Scope: ScopeReportsAll},
+		},
+	}
+)
+
+func declareFixedRoles(ac accesscontrol.Service) error {
+	reader := accesscontrol.RoleRegistration{
+		Role:   reportsReaderRole,
+		Grants: []string{string(org.RoleEditor)},
+	}
+	writer := accesscontrol.RoleRegistration{
+		Role:   reportsWriterRole,
+		Grants: []string{string(org.RoleEditor)},
+	}
+	admin := accesscontrol.RoleRegistration{
+		Role:   reportsAdminRole,
+		Grants: []string{string(org.RoleAdmin)},
+	}
+
+	return ac.DeclareFixedRoles(reader, writer, admin)
+}
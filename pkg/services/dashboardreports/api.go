@@ -0,0 +1,301 @@
+package dashboardreports
+
+import (
+	"net/http"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/api/routing"
+	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+func (s *DashboardReportsService) registerAPIEndpoints() {
+	authorize := ac.Middleware(s.accessControl)
+	uidScope := ScopeReportsProvider.GetResourceScopeUID(ac.Parameter(":uid"))
+
+	s.RouteRegister.Group("/api/dashboard-reports", func(entities routing.RouteRegister) {
+		entities.Get("/", authorize(ac.EvalPermission(ActionReportsRead)), routing.Wrap(s.listHandler))
+		entities.Post("/", authorize(ac.EvalPermission(ActionReportsCreate)), routing.Wrap(s.createHandler))
+		entities.Get("/:uid", authorize(ac.EvalPermission(ActionReportsRead, uidScope)), routing.Wrap(s.getHandler))
+		entities.Put("/:uid", authorize(ac.EvalPermission(ActionReportsWrite, uidScope)), routing.Wrap(s.updateHandler))
+		entities.Delete("/:uid", authorize(ac.EvalPermission(ActionReportsDelete, uidScope)), routing.Wrap(s.deleteHandler))
+		entities.Get("/:uid/runs", authorize(ac.EvalPermission(ActionReportsRead, uidScope)), routing.Wrap(s.listRunsHandler))
+		entities.Post("/:uid/send", authorize(ac.EvalPermission(ActionReportsSend, uidScope)), routing.Wrap(s.sendHandler))
+	})
+}
+
+// canReadDashboard checks that the user may read the dashboard of a report, because the report sends the dashboard
+// to its recipients.
+func (s *DashboardReportsService) canReadDashboard(c *contextmodel.ReqContext, dashboardUID string) (bool, error) {
+	evaluator := ac.EvalPermission(dashboards.ActionDashboardsRead, dashboards.ScopeDashboardsProvider.GetResourceScopeUID(dashboardUID))
+	return s.accessControl.Evaluate(c.Req.Context(), c.SignedInUser, evaluator)
+}
+
+// canManageReport checks that the user may change or send a report. The dashboard of a report is rendered as the
+// user who created it, so only this user or users with the admin action may do so.
+func (s *DashboardReportsService) canManageReport(c *contextmodel.ReqContext, report *Report) (bool, error) {
+	if report.CreatedBy == c.SignedInUser.UserID {
+		return true, nil
+	}
+	evaluator := ac.EvalPermission(ActionReportsAdmin, ScopeReportsProvider.GetResourceScopeUID(report.UID))
+	return s.accessControl.Evaluate(c.Req.Context(), c.SignedInUser, evaluator)
+}
+
+// getReadableReport returns the report of the request if the user may read its dashboard.
+func (s *DashboardReportsService) getReadableReport(c *contextmodel.ReqContext) (*Report, response.Response) {
+	report, err := s.GetReport(c.Req.Context(), c.GetOrgID(), web.Params(c.Req)[":uid"])
+	if err != nil {
+		return nil, response.ErrOrFallback(http.StatusInternalServerError, "Failed to get report", err)
+	}
+	if ok, err := s.canReadDashboard(c, report.DashboardUID); err != nil || !ok {
+		return nil, response.Error(http.StatusForbidden, "Access denied to the dashboard of the report", err)
+	}
+	return report, nil
+}
+
+// getManageableReport returns the report of the request if the user may change or send it.
+func (s *DashboardReportsService) getManageableReport(c *contextmodel.ReqContext) (*Report, response.Response) {
+	report, resp := s.getReadableReport(c)
+	if resp != nil {
+		return nil, resp
+	}
+	if ok, err := s.canManageReport(c, report); err != nil || !ok {
+		return nil, response.Error(http.StatusForbidden, "Only the creator of the report or an admin can change or send it", err)
+	}
+	return report, nil
+}
+
+// swagger:route GET /dashboard-reports dashboard_reports listDashboardReports
+//
+// Get all dashboard reports of the organization.
+//
+// Only the reports of dashboards that the user can read are returned.
+//
+// Responses:
+// 200: listDashboardReportsResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (s *DashboardReportsService) listHandler(c *contextmodel.ReqContext) response.Response {
+	reports, err := s.ListReports(c.Req.Context(), c.GetOrgID())
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to get reports", err)
+	}
+
+	readable := make([]*Report, 0, len(reports))
+	for _, report := range reports {
+		ok, err := s.canReadDashboard(c, report.DashboardUID)
+		if err != nil {
+			return response.Error(http.StatusInternalServerError, "Failed to check access to the dashboards of the reports", err)
+		}
+		if ok {
+			readable = append(readable, report)
+		}
+	}
+	return response.JSON(http.StatusOK, readable)
+}
+
+// swagger:route POST /dashboard-reports dashboard_reports createDashboardReport
+//
+// Create a dashboard report.
+//
+// The dashboard of the report is rendered as the user who creates the report.
+//
+// Responses:
+// 200: dashboardReportResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 409: conflictError
+// 500: internalServerError
+func (s *DashboardReportsService) createHandler(c *contextmodel.ReqContext) response.Response {
+	cmd := CreateReportCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	if ok, err := s.canReadDashboard(c, cmd.DashboardUID); err != nil || !ok {
+		return response.Error(http.StatusForbidden, "Access denied to the dashboard of the report", err)
+	}
+
+	report, err := s.CreateReport(c.Req.Context(), c.SignedInUser, cmd)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to create report", err)
+	}
+	return response.JSON(http.StatusOK, report)
+}
+
+// swagger:route GET /dashboard-reports/{uid} dashboard_reports getDashboardReport
+//
+// Get a dashboard report.
+//
+// Responses:
+// 200: dashboardReportResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (s *DashboardReportsService) getHandler(c *contextmodel.ReqContext) response.Response {
+	report, resp := s.getReadableReport(c)
+	if resp != nil {
+		return resp
+	}
+	return response.JSON(http.StatusOK, report)
+}
+
+// swagger:route PUT /dashboard-reports/{uid} dashboard_reports updateDashboardReport
+//
+// Update a dashboard report.
+//
+// Only the creator of the report, or a user with the `dashboard.reports:admin` action, can update it.
+//
+// Responses:
+// 200: dashboardReportResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (s *DashboardReportsService) updateHandler(c *contextmodel.ReqContext) response.Response {
+	cmd := UpdateReportCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	if _, resp := s.getManageableReport(c); resp != nil {
+		return resp
+	}
+	if ok, err := s.canReadDashboard(c, cmd.DashboardUID); err != nil || !ok {
+		return response.Error(http.StatusForbidden, "Access denied to the dashboard of the report", err)
+	}
+
+	report, err := s.UpdateReport(c.Req.Context(), c.GetOrgID(), web.Params(c.Req)[":uid"], cmd)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to update report", err)
+	}
+	return response.JSON(http.StatusOK, report)
+}
+
+// swagger:route DELETE /dashboard-reports/{uid} dashboard_reports deleteDashboardReport
+//
+// Delete a dashboard report and its run history.
+//
+// Only the creator of the report, or a user with the `dashboard.reports:admin` action, can delete it.
+//
+// Responses:
+// 200: okResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (s *DashboardReportsService) deleteHandler(c *contextmodel.ReqContext) response.Response {
+	if _, resp := s.getManageableReport(c); resp != nil {
+		return resp
+	}
+	if err := s.DeleteReport(c.Req.Context(), c.GetOrgID(), web.Params(c.Req)[":uid"]); err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to delete report", err)
+	}
+	return response.Success("Report deleted")
+}
+
+// swagger:route GET /dashboard-reports/{uid}/runs dashboard_reports listDashboardReportRuns
+//
+// Get the run history of a dashboard report.
+//
+// Returns the latest runs of the report, starting with the most recent one. Use the `limit` parameter to control
+// the maximum number of runs returned.
+//
+// Responses:
+// 200: listDashboardReportRunsResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (s *DashboardReportsService) listRunsHandler(c *contextmodel.ReqContext) response.Response {
+	if _, resp := s.getReadableReport(c); resp != nil {
+		return resp
+	}
+	runs, err := s.ListRuns(c.Req.Context(), c.GetOrgID(), web.Params(c.Req)[":uid"], c.QueryInt("limit"))
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to get report runs", err)
+	}
+	return response.JSON(http.StatusOK, runs)
+}
+
+// swagger:route POST /dashboard-reports/{uid}/send dashboard_reports sendDashboardReport
+//
+// Send a dashboard report now.
+//
+// The report is sent regardless of its schedule and whether it is enabled. The run is added to the run history of
+// the report and returned, including the error if the report could not be sent. Only the creator of the report, or
+// a user with the `dashboard.reports:admin` action, can send it.
+//
+// Responses:
+// 200: dashboardReportRunResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (s *DashboardReportsService) sendHandler(c *contextmodel.ReqContext) response.Response {
+	if _, resp := s.getManageableReport(c); resp != nil {
+		return resp
+	}
+	run, err := s.SendReport(c.Req.Context(), c.GetOrgID(), web.Params(c.Req)[":uid"])
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to send report", err)
+	}
+	return response.JSON(http.StatusOK, run)
+}
+
+// swagger:parameters getDashboardReport updateDashboardReport deleteDashboardReport listDashboardReportRuns sendDashboardReport
+type DashboardReportUIDParam struct {
+	// in:path
+	// required:true
+	UID string `json:"uid"`
+}
+
+// swagger:parameters createDashboardReport
+type CreateDashboardReportParams struct {
+	// in:body
+	// required:true
+	Body CreateReportCommand `json:"body"`
+}
+
+// swagger:parameters updateDashboardReport
+type UpdateDashboardReportParams struct {
+	// in:body
+	// required:true
+	Body UpdateReportCommand `json:"body"`
+}
+
+// swagger:parameters listDashboardReportRuns
+type ListDashboardReportRunsParams struct {
+	// Maximum number of runs to return.
+	// in:query
+	// required:false
+	Limit int `json:"limit"`
+}
+
+// swagger:response dashboardReportResponse
+type DashboardReportResponse struct {
+	// in: body
+	Body Report `json:"body"`
+}
+
+// swagger:response listDashboardReportsResponse
+type ListDashboardReportsResponse struct {
+	// in: body
+	Body []Report `json:"body"`
+}
+
+// swagger:response listDashboardReportRunsResponse
+type ListDashboardReportRunsResponse struct {
+	// in: body
+	Body []Run `json:"body"`
+}
+
+// swagger:response dashboardReportRunResponse
+type DashboardReportRunResponse struct {
+	// in: body
+	Body Run `json:"body"`
+}
@@ -0,0 +1,99 @@
+package dashboardreports
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestIntegrationDashboardReportsAPI(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	sc := setupTestScenario(t)
+	sc.service.accessControl = acimpl.ProvideAccessControl(featuremgmt.WithFeatures())
+	sc.service.RouteRegister = routing.NewRouteRegister()
+	sc.service.registerAPIEndpoints()
+	server := webtest.NewServer(t, sc.service.RouteRegister)
+
+	own := sc.createReport(t, validSpec())
+	otherSpec := validSpec()
+	otherSpec.DashboardUID = "other"
+	other := sc.createReport(t, otherSpec)
+
+	readSLO := accesscontrol.Permission{Action: dashboards.ActionDashboardsRead, Scope: dashboards.ScopeDashboardsProvider.GetResourceScopeUID("slo")}
+	writer := append([]accesscontrol.Permission{readSLO}, reportsWriterRole.Permissions...)
+	admin := append([]accesscontrol.Permission{readSLO}, reportsAdminRole.Permissions...)
+
+	send := func(t *testing.T, method, path string, body any, userID int64, permissions []accesscontrol.Permission) *http.Response {
+		t.Helper()
+		var buf bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&buf).Encode(body))
+		}
+		req := server.NewRequest(method, path, &buf)
+		webtest.RequestWithSignedInUser(req, &user.SignedInUser{
+			UserID: userID, OrgID: 1, OrgRole: org.RoleEditor,
+			Permissions: map[int64]map[string][]string{1: accesscontrol.GroupScopesByActionContext(context.Background(), permissions)},
+		})
+		res, err := server.SendJSON(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, res.Body.Close()) })
+		return res
+	}
+
+	t.Run("requires the read action", func(t *testing.T) {
+		res := send(t, http.MethodGet, "/api/dashboard-reports/", nil, sc.user.UserID, []accesscontrol.Permission{readSLO})
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	})
+
+	t.Run("only returns the reports of readable dashboards", func(t *testing.T) {
+		res := send(t, http.MethodGet, "/api/dashboard-reports/", nil, sc.user.UserID, writer)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		var reports []Report
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&reports))
+		require.Len(t, reports, 1)
+		assert.Equal(t, own.UID, reports[0].UID)
+
+		res = send(t, http.MethodGet, "/api/dashboard-reports/"+own.UID, nil, sc.user.UserID, writer)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		res = send(t, http.MethodGet, "/api/dashboard-reports/"+other.UID, nil, sc.user.UserID, writer)
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+		res = send(t, http.MethodGet, "/api/dashboard-reports/"+other.UID+"/runs", nil, sc.user.UserID, writer)
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	})
+
+	t.Run("only the creator or an admin can change or send a report", func(t *testing.T) {
+		update := UpdateReportCommand{ReportSpec: validSpec()}
+		anotherUser := sc.user.UserID + 1
+
+		res := send(t, http.MethodPut, "/api/dashboard-reports/"+own.UID, update, anotherUser, writer)
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+		res = send(t, http.MethodPost, "/api/dashboard-reports/"+own.UID+"/send", nil, anotherUser, writer)
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+		res = send(t, http.MethodDelete, "/api/dashboard-reports/"+own.UID, nil, anotherUser, writer)
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+		res = send(t, http.MethodPut, "/api/dashboard-reports/"+own.UID, update, sc.user.UserID, writer)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		res = send(t, http.MethodPut, "/api/dashboard-reports/"+own.UID, update, anotherUser, admin)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		res = send(t, http.MethodDelete, "/api/dashboard-reports/"+own.UID, nil, anotherUser, admin)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	})
+}
@@ -0,0 +1,235 @@
+package dashboardreports
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+const (
+	invalidReportTmpl = "Invalid report: {{ .Public.Reason }}"
+	maxNameLength     = 190
+)
+
+var (
+	ErrReportNotFound = errutil.NotFound("dashboardreports.notFound", errutil.WithPublicMessage("Report not found")).Errorf("report not found")
+	ErrReportExists   = errutil.Conflict("dashboardreports.exists", errutil.WithPublicMessage("A report with the same UID already exists")).Errorf("report already exists")
+	ErrReportInvalid  = errutil.ValidationFailed("dashboardreports.invalid").MustTemplate(invalidReportTmpl, errutil.WithPublic(invalidReportTmpl))
+)
+
+func invalidReport(format string, args ...any) error {
+	return ErrReportInvalid.Build(errutil.TemplateData{
+		Public: map[string]any{
+			"Reason": fmt.Sprintf(format, args...),
+		},
+	})
+}
+
+// Format is the format of a file that is attached to a report.
+type Format string
+
+const (
+	// FormatPDF attaches the dashboard as a PDF document.
+	FormatPDF Format = "pdf"
+	// FormatPNG attaches an image of the dashboard, or an image of each of the panels of the report.
+	FormatPNG Format = "png"
+	// FormatCSV attaches the data of each of the panels of the report as a CSV file.
+	FormatCSV Format = "csv"
+)
+
+type RunStatus string
+
+const (
+	RunStatusSuccess RunStatus = "success"
+	RunStatusFailed  RunStatus = "failed"
+)
+
+type RunTrigger string
+
+const (
+	// RunTriggerSchedule is a run that was started by the schedule of the report.
+	RunTriggerSchedule RunTrigger = "schedule"
+	// RunTriggerManual is a run that was started by a user through the API.
+	RunTriggerManual RunTrigger = "manual"
+)
+
+// Schedule is when a report is sent.
+type Schedule struct {
+	// Cron expression with five fields, for example "0 9 * * 1" for every Monday at 9:00.
+	Cron string `json:"cron"`
+	// Timezone of the cron expression and of the rendered dashboard. Defaults to UTC.
+	Timezone string `json:"timezone,omitempty"`
+}
+
+func (s Schedule) location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(s.Timezone)
+}
+
+// Next returns the first time after the given time at which the report is due.
+func (s Schedule) Next(after time.Time) (time.Time, error) {
+	loc, err := s.location()
+	if err != nil {
+		return time.Time{}, err
+	}
+	schedule, err := cron.ParseStandard(s.Cron)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return schedule.Next(after.In(loc)), nil
+}
+
+// TimeRange is the time range of the dashboard in the report, for example from "now-7d" to "now". If it is empty,
+// the time range that is saved in the dashboard is used.
+type TimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+func (tr TimeRange) IsZero() bool {
+	return tr.From == "" && tr.To == ""
+}
+
+// RenderOptions configure how the dashboard is rendered.
+type RenderOptions struct {
+	// Width of the rendered images in pixels. Defaults to the default image width of the renderer.
+	Width int `json:"width,omitempty"`
+	// Height of the rendered images in pixels. Defaults to the default image height of the renderer.
+	Height int `json:"height,omitempty"`
+	// Theme is either "light" or "dark". Defaults to "light".
+	Theme models.Theme `json:"theme,omitempty"`
+}
+
+// ReportSpec is the part of a report that is set by users.
+type ReportSpec struct {
+	Name         string    `json:"name"`
+	DashboardUID string    `json:"dashboardUid"`
+	Enabled      bool      `json:"enabled"`
+	Schedule     Schedule  `json:"schedule"`
+	TimeRange    TimeRange `json:"timeRange"`
+	// Variables are the values of the template variables of the dashboard, by variable name.
+	Variables map[string][]string `json:"variables,omitempty"`
+	Formats   []Format            `json:"formats"`
+	// PanelIDs are the panels that are attached as images or CSV files. If it is empty, PNG attachments contain
+	// the whole dashboard. CSV attachments require at least one panel.
+	PanelIDs   []int64  `json:"panelIds,omitempty"`
+	Recipients []string `json:"recipients"`
+	ReplyTo    string   `json:"replyTo,omitempty"`
+	// Message is added to the body of the email.
+	Message string `json:"message,omitempty"`
+	// FailureRecipients are notified when a report cannot be sent. Defaults to the user who created the report.
+	FailureRecipients []string      `json:"failureRecipients,omitempty"`
+	Options           RenderOptions `json:"options"`
+}
+
+// Validate checks the spec and sets the defaults of optional fields.
+func (s *ReportSpec) Validate() error {
+	if s.Name == "" {
+		return invalidReport("name is required")
+	}
+	if len(s.Name) > maxNameLength {
+		return invalidReport("name is longer than %d characters", maxNameLength)
+	}
+	if s.DashboardUID == "" {
+		return invalidReport("dashboardUid is required")
+	}
+	if _, err := cron.ParseStandard(s.Schedule.Cron); err != nil {
+		return invalidReport("invalid cron expression %q: %s", s.Schedule.Cron, err)
+	}
+	if _, err := s.Schedule.location(); err != nil {
+		return invalidReport("invalid timezone %q", s.Schedule.Timezone)
+	}
+	if (s.TimeRange.From == "") != (s.TimeRange.To == "") {
+		return invalidReport("timeRange requires both from and to")
+	}
+	if len(s.Formats) == 0 {
+		return invalidReport("at least one format is required")
+	}
+	for i, f := range s.Formats {
+		switch f {
+		case FormatPDF, FormatPNG:
+		case FormatCSV:
+			if len(s.PanelIDs) == 0 {
+				return invalidReport("format csv requires at least one panel")
+			}
+		default:
+			return invalidReport("unknown format %q", f)
+		}
+		if slices.Contains(s.Formats[:i], f) {
+			return invalidReport("duplicate format %q", f)
+		}
+	}
+	if len(s.Recipients) == 0 {
+		return invalidReport("at least one recipient is required")
+	}
+	for _, address := range append(slices.Clone(s.Recipients), s.FailureRecipients...) {
+		if !util.IsEmail(address) {
+			return invalidReport("invalid email address %q", address)
+		}
+	}
+	if s.ReplyTo != "" && !util.IsEmail(s.ReplyTo) {
+		return invalidReport("invalid email address %q", s.ReplyTo)
+	}
+	if s.Options.Width < 0 || s.Options.Height < 0 {
+		return invalidReport("width and height must not be negative")
+	}
+	if s.Options.Theme == "" {
+		s.Options.Theme = models.ThemeLight
+	}
+	if _, err := models.ParseTheme(string(s.Options.Theme)); err != nil {
+		return invalidReport("theme can only be light or dark")
+	}
+	return nil
+}
+
+// Report sends a dashboard by email on a schedule.
+type Report struct {
+	ID    int64  `json:"-"`
+	UID   string `json:"uid"`
+	OrgID int64  `json:"orgId"`
+	ReportSpec
+	// CreatedBy is the user as who the dashboard is rendered.
+	CreatedBy int64     `json:"createdBy"`
+	Created   time.Time `json:"created"`
+	Updated   time.Time `json:"updated"`
+	// NextRunAt is the next time the report is sent. It is nil if the report is disabled.
+	NextRunAt *time.Time `json:"nextRunAt,omitempty"`
+}
+
+// Run is one attempt to send a report.
+type Run struct {
+	ID         int64      `json:"id" xorm:"pk autoincr 'id'"`
+	OrgID      int64      `json:"orgId" xorm:"org_id"`
+	ReportUID  string     `json:"reportUid" xorm:"report_uid"`
+	Trigger    RunTrigger `json:"trigger" xorm:"trigger_type"`
+	Status     RunStatus  `json:"status"`
+	Error      string     `json:"error,omitempty"`
+	StartedAt  time.Time  `json:"startedAt"`
+	FinishedAt time.Time  `json:"finishedAt"`
+}
+
+func (Run) TableName() string {
+	return "dashboard_report_run"
+}
+
+// CreateReportCommand creates a report.
+// swagger:model
+type CreateReportCommand struct {
+	// UID of the report. It is generated if it is empty.
+	UID string `json:"uid"`
+	ReportSpec
+}
+
+// UpdateReportCommand replaces the spec of a report.
+// swagger:model
+type UpdateReportCommand struct {
+	ReportSpec
+}
@@ -0,0 +1,78 @@
+package dashboardreports
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/models"
+)
+
+func validSpec() ReportSpec {
+	return ReportSpec{
+		Name:         "Weekly SLOs",
+		DashboardUID: "slo",
+		Enabled:      true,
+		Schedule:     Schedule{Cron: "0 9 * * 1", Timezone: "Europe/Berlin"},
+		TimeRange:    TimeRange{From: "now-7d", To: "now"},
+		Variables:    map[string][]string{"cluster": {"eu", "us"}},
+		Formats:      []Format{FormatPDF, FormatCSV},
+		PanelIDs:     []int64{2},
+		Recipients:   []string{"leadership@example.com"},
+	}
+}
+
+func TestReportSpecValidate(t *testing.T) {
+	t.Run("valid spec gets defaults", func(t *testing.T) {
+		spec := validSpec()
+		require.NoError(t, spec.Validate())
+		assert.Equal(t, models.ThemeLight, spec.Options.Theme)
+	})
+
+	testCases := []struct {
+		desc   string
+		modify func(*ReportSpec)
+	}{
+		{desc: "missing name", modify: func(s *ReportSpec) { s.Name = "" }},
+		{desc: "missing dashboard", modify: func(s *ReportSpec) { s.DashboardUID = "" }},
+		{desc: "invalid cron", modify: func(s *ReportSpec) { s.Schedule.Cron = "every monday" }},
+		{desc: "invalid timezone", modify: func(s *ReportSpec) { s.Schedule.Timezone = "Mars/Olympus" }},
+		{desc: "incomplete time range", modify: func(s *ReportSpec) { s.TimeRange.To = "" }},
+		{desc: "no formats", modify: func(s *ReportSpec) { s.Formats = nil }},
+		{desc: "unknown format", modify: func(s *ReportSpec) { s.Formats = []Format{"docx"} }},
+		{desc: "duplicate format", modify: func(s *ReportSpec) { s.Formats = []Format{FormatPNG, FormatPNG} }},
+		{desc: "csv without panels", modify: func(s *ReportSpec) { s.PanelIDs = nil }},
+		{desc: "no recipients", modify: func(s *ReportSpec) { s.Recipients = nil }},
+		{desc: "invalid recipient", modify: func(s *ReportSpec) { s.Recipients = []string{"leadership"} }},
+		{desc: "invalid failure recipient", modify: func(s *ReportSpec) { s.FailureRecipients = []string{"ops"} }},
+		{desc: "invalid theme", modify: func(s *ReportSpec) { s.Options.Theme = "blue" }},
+		{desc: "negative width", modify: func(s *ReportSpec) { s.Options.Width = -1 }},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			spec := validSpec()
+			tc.modify(&spec)
+			require.ErrorIs(t, spec.Validate(), ErrReportInvalid)
+		})
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	after := time.Date(2024, 3, 4, 9, 30, 0, 0, time.UTC) // Monday
+
+	next, err := Schedule{Cron: "0 9 * * 1"}.Next(after)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 11, 9, 0, 0, 0, time.UTC), next.UTC())
+
+	// 9:00 in Berlin is 8:00 UTC in winter.
+	next, err = Schedule{Cron: "0 9 * * 1", Timezone: "Europe/Berlin"}.Next(after)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 11, 8, 0, 0, 0, time.UTC), next.UTC())
+
+	// 11:00 in Berlin is still due on the same day.
+	next, err = Schedule{Cron: "0 11 * * 1", Timezone: "Europe/Berlin"}.Next(after)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC), next.UTC())
+}
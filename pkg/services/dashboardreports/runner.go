@@ -0,0 +1,294 @@
+package dashboardreports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/notifications"
+	"github.com/grafana/grafana/pkg/services/rendering"
+	"github.com/grafana/grafana/pkg/services/user"
+)
+
+const (
+	tmplDashboardReport       = "dashboard_report"
+	tmplDashboardReportFailed = "dashboard_report_failed"
+)
+
+// runDueReports sends the reports that are due now. Each due run is claimed in the database first, so that a
+// report is sent once when several instances of Grafana share the database.
+func (s *DashboardReportsService) runDueReports(ctx context.Context) {
+	now := s.now()
+	reports, err := s.store.getDueReports(ctx, now)
+	if err != nil {
+		s.log.Error("Failed to get the reports that are due", "error", err)
+		return
+	}
+
+	for _, report := range reports {
+		if ctx.Err() != nil {
+			return
+		}
+		logger := s.log.New("org", report.OrgID, "report", report.UID)
+		next, err := report.Schedule.Next(now)
+		if err != nil {
+			logger.Error("Failed to compute the next run of the report", "error", err)
+			continue
+		}
+		claimed, err := s.store.claimRun(ctx, report.ID, *report.NextRunAt, next)
+		if err != nil {
+			logger.Error("Failed to claim the run of the report", "error", err)
+			continue
+		}
+		if !claimed {
+			logger.Debug("Run of the report was claimed by another instance")
+			continue
+		}
+		s.execute(ctx, report, RunTriggerSchedule)
+	}
+}
+
+// execute sends a report, records the run in its history and notifies the failure recipients if it fails.
+func (s *DashboardReportsService) execute(ctx context.Context, report *Report, trigger RunTrigger) *Run {
+	logger := s.log.FromContext(ctx).New("org", report.OrgID, "report", report.UID, "trigger", trigger)
+	run := &Run{
+		OrgID:     report.OrgID,
+		ReportUID: report.UID,
+		Trigger:   trigger,
+		Status:    RunStatusSuccess,
+		StartedAt: s.now(),
+	}
+
+	creator, err := s.users.GetSignedInUser(ctx, &user.GetSignedInUserQuery{UserID: report.CreatedBy, OrgID: report.OrgID})
+	if err == nil {
+		err = s.send(identity.WithRequester(ctx, creator), report, creator)
+	} else {
+		err = fmt.Errorf("failed to get the user who created the report: %w", err)
+	}
+	run.FinishedAt = s.now()
+	if err != nil {
+		logger.Warn("Failed to send the report", "error", err)
+		run.Status = RunStatusFailed
+		run.Error = err.Error()
+		s.notifyFailure(ctx, report, creator, err)
+	} else {
+		logger.Info("Sent the report", "recipients", len(report.Recipients))
+	}
+
+	if err := s.store.insertRun(ctx, run, s.reportsCfg.MaxRunHistory); err != nil {
+		logger.Error("Failed to save the run of the report", "error", err)
+	}
+	return run
+}
+
+func (s *DashboardReportsService) send(ctx context.Context, report *Report, creator *user.SignedInUser) error {
+	if creator.OrgRole == "" {
+		return errors.New("the user who created the report is no longer a member of the organization")
+	}
+	dash, err := s.dashboards.GetDashboard(ctx, &dashboards.GetDashboardQuery{UID: report.DashboardUID, OrgID: report.OrgID})
+	if err != nil {
+		return fmt.Errorf("failed to get the dashboard: %w", err)
+	}
+
+	attachments := make([]*notifications.SendEmailAttachFile, 0, len(report.Formats))
+	for _, format := range report.Formats {
+		files, err := s.render(ctx, report, dash, creator, format)
+		if err != nil {
+			return fmt.Errorf("failed to render %s: %w", format, err)
+		}
+		attachments = append(attachments, files...)
+	}
+
+	cmd := &notifications.SendEmailCommandSync{
+		SendEmailCommand: notifications.SendEmailCommand{
+			To:            report.Recipients,
+			Template:      tmplDashboardReport,
+			Data:          s.templateData(report, dash),
+			AttachedFiles: attachments,
+		},
+	}
+	if report.ReplyTo != "" {
+		cmd.ReplyTo = []string{report.ReplyTo}
+	}
+	if err := s.emails.SendEmailCommandHandlerSync(ctx, cmd); err != nil {
+		return fmt.Errorf("failed to send the email: %w", err)
+	}
+	return nil
+}
+
+// render renders the dashboard of a report in one format. PDF attachments contain the whole dashboard. PNG
+// attachments contain the whole dashboard or one image per panel of the report, and CSV attachments contain one
+// file per panel.
+func (s *DashboardReportsService) render(ctx context.Context, report *Report, dash *dashboards.Dashboard, creator *user.SignedInUser, format Format) ([]*notifications.SendEmailAttachFile, error) {
+	common := rendering.CommonOpts{
+		TimeoutOpts: rendering.TimeoutOpts{
+			Timeout: s.reportsCfg.RenderingTimeout,
+		},
+		AuthOpts: rendering.AuthOpts{
+			OrgID:   report.OrgID,
+			UserID:  creator.UserID,
+			OrgRole: creator.OrgRole,
+		},
+		Timezone:        report.Schedule.Timezone,
+		ConcurrentLimit: s.cfg.RendererConcurrentRequestLimit,
+	}
+
+	if format == FormatCSV {
+		files := make([]*notifications.SendEmailAttachFile, 0, len(report.PanelIDs))
+		for _, panelID := range report.PanelIDs {
+			opts := rendering.CSVOpts{CommonOpts: common}
+			opts.Path = dashboardPath(report, dash, panelID)
+			result, err := s.renderer.RenderCSV(ctx, opts, nil)
+			if err != nil {
+				return nil, err
+			}
+			name := result.FileName
+			if name == "" {
+				name = fmt.Sprintf("%s-panel-%d.csv", dash.Slug, panelID)
+			}
+			file, err := readAttachment(result.FilePath, name)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, file)
+		}
+		return files, nil
+	}
+
+	opts := rendering.Opts{
+		CommonOpts: common,
+		ErrorOpts: rendering.ErrorOpts{
+			ErrorConcurrentLimitReached: true,
+			ErrorRenderUnavailable:      true,
+		},
+		Width:             report.Options.Width,
+		Height:            report.Options.Height,
+		DeviceScaleFactor: s.cfg.RendererDefaultImageScale,
+		Theme:             report.Options.Theme,
+	}
+	if opts.Width == 0 {
+		opts.Width = s.cfg.RendererDefaultImageWidth
+	}
+	if opts.Height == 0 {
+		opts.Height = s.cfg.RendererDefaultImageHeight
+	}
+
+	panelIDs := []int64{0}
+	if format == FormatPNG && len(report.PanelIDs) > 0 {
+		panelIDs = report.PanelIDs
+	}
+	renderType := rendering.RenderPNG
+	if format == FormatPDF {
+		renderType = rendering.RenderPDF
+	}
+	files := make([]*notifications.SendEmailAttachFile, 0, len(panelIDs))
+	for _, panelID := range panelIDs {
+		opts.Path = dashboardPath(report, dash, panelID)
+		result, err := s.renderer.Render(ctx, renderType, opts, nil)
+		if err != nil {
+			return nil, err
+		}
+		name := fmt.Sprintf("%s.%s", dash.Slug, format)
+		if panelID > 0 {
+			name = fmt.Sprintf("%s-panel-%d.%s", dash.Slug, panelID, format)
+		}
+		file, err := readAttachment(result.FilePath, name)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// notifyFailure sends an email about a failed run to the failure recipients of the report, or to the user who
+// created it.
+func (s *DashboardReportsService) notifyFailure(ctx context.Context, report *Report, creator *user.SignedInUser, runErr error) {
+	recipients := report.FailureRecipients
+	if len(recipients) == 0 && creator != nil && creator.Email != "" {
+		recipients = []string{creator.Email}
+	}
+	if len(recipients) == 0 {
+		s.log.Warn("Report has no recipients for failure notifications", "org", report.OrgID, "report", report.UID)
+		return
+	}
+
+	data := s.templateData(report, nil)
+	data["Error"] = runErr.Error()
+	err := s.emails.SendEmailCommandHandlerSync(ctx, &notifications.SendEmailCommandSync{
+		SendEmailCommand: notifications.SendEmailCommand{
+			To:       recipients,
+			Template: tmplDashboardReportFailed,
+			Data:     data,
+		},
+	})
+	if err != nil {
+		s.log.Error("Failed to send the failure notification of the report", "org", report.OrgID, "report", report.UID, "error", err)
+	}
+}
+
+func (s *DashboardReportsService) templateData(report *Report, dash *dashboards.Dashboard) map[string]any {
+	data := map[string]any{
+		"ReportName":   report.Name,
+		"Message":      report.Message,
+		"TimeFrom":     report.TimeRange.From,
+		"TimeTo":       report.TimeRange.To,
+		"DashboardURL": s.cfg.AppURL + "d/" + report.DashboardUID,
+	}
+	if dash != nil {
+		data["DashboardTitle"] = dash.Title
+		data["DashboardURL"] = s.cfg.AppURL + dashboardURL(report, dash)
+	}
+	return data
+}
+
+// dashboardURL returns the path of the dashboard of a report relative to the app URL, with the time range and the
+// variables of the report.
+func dashboardURL(report *Report, dash *dashboards.Dashboard) string {
+	return fmt.Sprintf("d/%s/%s?%s", dash.UID, dash.Slug, dashboardQuery(report).Encode())
+}
+
+// dashboardPath returns the path that is rendered for a report. If panelID is not zero, only the panel is rendered.
+func dashboardPath(report *Report, dash *dashboards.Dashboard, panelID int64) string {
+	query := dashboardQuery(report)
+	if panelID > 0 {
+		query.Set("panelId", strconv.FormatInt(panelID, 10))
+		return fmt.Sprintf("d-solo/%s/%s?%s", dash.UID, dash.Slug, query.Encode())
+	}
+	query.Set("kiosk", "true")
+	return fmt.Sprintf("d/%s/%s?%s", dash.UID, dash.Slug, query.Encode())
+}
+
+func dashboardQuery(report *Report) url.Values {
+	query := url.Values{}
+	query.Set("orgId", strconv.FormatInt(report.OrgID, 10))
+	if !report.TimeRange.IsZero() {
+		query.Set("from", report.TimeRange.From)
+		query.Set("to", report.TimeRange.To)
+	}
+	for name, values := range report.Variables {
+		for _, value := range values {
+			query.Add("var-"+name, value)
+		}
+	}
+	return query
+}
+
+func readAttachment(path string, name string) (*notifications.SendEmailAttachFile, error) {
+	// nolint:gosec
+	// The path is a file that was created by the rendering service.
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rendered file: %w", err)
+	}
+	return &notifications.SendEmailAttachFile{
+		Name:    strings.ReplaceAll(name, "/", "-"),
+		Content: content,
+	}, nil
+}
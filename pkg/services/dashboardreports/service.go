@@ -0,0 +1,185 @@
+package dashboardreports
+
+import (
+	"context"
+	"time"
+
+	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/notifications"
+	"github.com/grafana/grafana/pkg/services/rendering"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+func ProvideService(
+	cfg *setting.Cfg,
+	sqlStore db.DB,
+	routeRegister routing.RouteRegister,
+	accessControl ac.AccessControl,
+	accesscontrolService ac.Service,
+	renderService rendering.Service,
+	emailSender notifications.EmailSender,
+	dashboardService dashboards.DashboardService,
+	userService user.Service,
+) (*DashboardReportsService, error) {
+	s := &DashboardReportsService{
+		store:         &store{db: sqlStore},
+		cfg:           cfg,
+		reportsCfg:    cfg.DashboardReports,
+		RouteRegister: routeRegister,
+		accessControl: accessControl,
+		renderer:      renderService,
+		emails:        emailSender,
+		dashboards:    dashboardService,
+		users:         userService,
+		log:           log.New("dashboard-reports"),
+		now:           time.Now,
+	}
+
+	if err := declareFixedRoles(accesscontrolService); err != nil {
+		return nil, err
+	}
+
+	// Register routes only when dashboard reports are enabled
+	if s.reportsCfg.Enabled {
+		s.registerAPIEndpoints()
+	}
+
+	return s, nil
+}
+
+// DashboardReportsService manages reports that send dashboards by email on a schedule, and sends the reports
+// that are due.
+type DashboardReportsService struct {
+	store         *store
+	cfg           *setting.Cfg
+	reportsCfg    setting.DashboardReportsSettings
+	RouteRegister routing.RouteRegister
+	accessControl ac.AccessControl
+	renderer      rendering.Service
+	emails        notifications.EmailSender
+	dashboards    dashboards.DashboardService
+	users         user.Service
+	log           log.Logger
+	now           func() time.Time
+}
+
+func (s *DashboardReportsService) IsDisabled() bool {
+	return !s.reportsCfg.Enabled
+}
+
+// Run sends the reports that are due until the context is cancelled.
+func (s *DashboardReportsService) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.reportsCfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			s.runDueReports(ctx)
+		}
+	}
+}
+
+func (s *DashboardReportsService) ListReports(ctx context.Context, orgID int64) ([]*Report, error) {
+	return s.store.listReports(ctx, orgID)
+}
+
+func (s *DashboardReportsService) GetReport(ctx context.Context, orgID int64, uid string) (*Report, error) {
+	return s.store.getReport(ctx, orgID, uid)
+}
+
+// CreateReport creates a report of the organization of the user. The dashboard of the report is rendered as this user.
+func (s *DashboardReportsService) CreateReport(ctx context.Context, usr *user.SignedInUser, cmd CreateReportCommand) (*Report, error) {
+	if cmd.UID == "" {
+		cmd.UID = util.GenerateShortUID()
+	} else if err := util.ValidateUID(cmd.UID); err != nil {
+		return nil, invalidReport("%s", err)
+	}
+	if usr.UserID == 0 {
+		return nil, invalidReport("reports can only be created by users and service accounts")
+	}
+	if err := cmd.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	report := &Report{
+		UID:        cmd.UID,
+		OrgID:      usr.OrgID,
+		ReportSpec: cmd.ReportSpec,
+		CreatedBy:  usr.UserID,
+		Created:    now,
+		Updated:    now,
+	}
+	if err := s.setNextRun(report, now); err != nil {
+		return nil, err
+	}
+	if err := s.store.insertReport(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// UpdateReport replaces the spec of a report and reschedules it.
+func (s *DashboardReportsService) UpdateReport(ctx context.Context, orgID int64, uid string, cmd UpdateReportCommand) (*Report, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, err
+	}
+	report, err := s.store.getReport(ctx, orgID, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	report.ReportSpec = cmd.ReportSpec
+	report.Updated = now
+	if err := s.setNextRun(report, now); err != nil {
+		return nil, err
+	}
+	if err := s.store.updateReport(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (s *DashboardReportsService) DeleteReport(ctx context.Context, orgID int64, uid string) error {
+	return s.store.deleteReport(ctx, orgID, uid)
+}
+
+// ListRuns returns the latest runs of a report, starting with the most recent one.
+func (s *DashboardReportsService) ListRuns(ctx context.Context, orgID int64, uid string, limit int) ([]*Run, error) {
+	if _, err := s.store.getReport(ctx, orgID, uid); err != nil {
+		return nil, err
+	}
+	return s.store.listRuns(ctx, orgID, uid, limit)
+}
+
+// SendReport sends a report now, regardless of its schedule and whether it is enabled.
+func (s *DashboardReportsService) SendReport(ctx context.Context, orgID int64, uid string) (*Run, error) {
+	report, err := s.store.getReport(ctx, orgID, uid)
+	if err != nil {
+		return nil, err
+	}
+	return s.execute(ctx, report, RunTriggerManual), nil
+}
+
+func (s *DashboardReportsService) setNextRun(report *Report, now time.Time) error {
+	report.NextRunAt = nil
+	if !report.Enabled {
+		return nil
+	}
+	next, err := report.Schedule.Next(now)
+	if err != nil {
+		return invalidReport("%s", err)
+	}
+	report.NextRunAt = &next
+	return nil
+}
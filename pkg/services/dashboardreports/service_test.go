@@ -0,0 +1,252 @@
+package dashboardreports
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/actest"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/notifications"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/rendering"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/services/user/usertest"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/tests/testsuite"
+)
+
+func TestMain(m *testing.M) {
+	testsuite.Run(m)
+}
+
+type testScenario struct {
+	service  *DashboardReportsService
+	renderer *rendering.MockService
+	emails   *notifications.NotificationServiceMock
+	users    *usertest.FakeUserService
+	user     *user.SignedInUser
+	now      time.Time
+}
+
+func setupTestScenario(t *testing.T) *testScenario {
+	t.Helper()
+
+	cfg := setting.NewCfg()
+	cfg.AppURL = "http://localhost:3000/"
+	cfg.RendererDefaultImageWidth = 1000
+	cfg.RendererDefaultImageHeight = 500
+	cfg.DashboardReports = setting.DashboardReportsSettings{
+		Enabled:          true,
+		CheckInterval:    time.Minute,
+		RenderingTimeout: time.Minute,
+		MaxRunHistory:    2,
+	}
+
+	sc := &testScenario{
+		renderer: rendering.NewMockService(gomock.NewController(t)),
+		emails:   notifications.MockNotificationService(),
+		users:    usertest.NewUserServiceFake(),
+		user:     &user.SignedInUser{UserID: 10, OrgID: 1, OrgRole: org.RoleEditor, Email: "editor@example.com"},
+		now:      time.Date(2024, 3, 4, 8, 0, 0, 0, time.UTC),
+	}
+	sc.users.ExpectedSignedInUser = sc.user
+
+	dashboardService := dashboards.NewFakeDashboardService(t)
+	dashboardService.On("GetDashboard", mock.Anything, mock.Anything).Return(&dashboards.Dashboard{UID: "slo", Slug: "slos", Title: "SLOs"}, nil).Maybe()
+
+	service, err := ProvideService(cfg, db.InitTestDB(t), routing.NewRouteRegister(), actest.FakeAccessControl{ExpectedEvaluate: true}, actest.FakeService{}, sc.renderer, sc.emails, dashboardService, sc.users)
+	require.NoError(t, err)
+	sc.service = service
+	sc.service.now = func() time.Time { return sc.now }
+	return sc
+}
+
+func (sc *testScenario) createReport(t *testing.T, spec ReportSpec) *Report {
+	t.Helper()
+	report, err := sc.service.CreateReport(context.Background(), sc.user, CreateReportCommand{ReportSpec: spec})
+	require.NoError(t, err)
+	return report
+}
+
+// expectRender makes the renderer write a file for each render of the given paths.
+func (sc *testScenario) expectRender(t *testing.T, renderType rendering.RenderType, paths ...string) {
+	t.Helper()
+	for _, path := range paths {
+		sc.renderer.EXPECT().Render(gomock.Any(), renderType, gomock.Any(), nil).DoAndReturn(
+			func(_ context.Context, _ rendering.RenderType, opts rendering.Opts, _ rendering.Session) (*rendering.RenderResult, error) {
+				assert.Equal(t, path, opts.Path)
+				assert.Equal(t, sc.user.UserID, opts.UserID)
+				assert.Equal(t, sc.user.OrgRole, opts.OrgRole)
+				file := filepath.Join(t.TempDir(), "rendered")
+				require.NoError(t, os.WriteFile(file, []byte(opts.Path), 0600))
+				return &rendering.RenderResult{FilePath: file}, nil
+			})
+	}
+}
+
+func TestIntegrationDashboardReports(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+
+	t.Run("create, update and delete reports", func(t *testing.T) {
+		sc := setupTestScenario(t)
+
+		report := sc.createReport(t, validSpec())
+		assert.NotEmpty(t, report.UID)
+		assert.Equal(t, sc.user.UserID, report.CreatedBy)
+		require.NotNil(t, report.NextRunAt)
+		assert.Equal(t, time.Date(2024, 3, 4, 8, 0, 0, 0, time.UTC).Add(7*24*time.Hour), report.NextRunAt.UTC())
+
+		_, err := sc.service.CreateReport(ctx, sc.user, CreateReportCommand{UID: report.UID, ReportSpec: validSpec()})
+		require.ErrorIs(t, err, ErrReportExists)
+
+		stored, err := sc.service.GetReport(ctx, 1, report.UID)
+		require.NoError(t, err)
+		assert.Equal(t, report.ReportSpec, stored.ReportSpec)
+
+		spec := validSpec()
+		spec.Name = "Disabled"
+		spec.Enabled = false
+		updated, err := sc.service.UpdateReport(ctx, 1, report.UID, UpdateReportCommand{ReportSpec: spec})
+		require.NoError(t, err)
+		assert.Nil(t, updated.NextRunAt)
+
+		reports, err := sc.service.ListReports(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		assert.Equal(t, "Disabled", reports[0].Name)
+		assert.False(t, reports[0].Enabled)
+
+		_, err = sc.service.UpdateReport(ctx, 1, "missing", UpdateReportCommand{ReportSpec: spec})
+		require.ErrorIs(t, err, ErrReportNotFound)
+
+		require.NoError(t, sc.service.DeleteReport(ctx, 1, report.UID))
+		_, err = sc.service.GetReport(ctx, 1, report.UID)
+		require.ErrorIs(t, err, ErrReportNotFound)
+	})
+
+	t.Run("due reports are sent once", func(t *testing.T) {
+		sc := setupTestScenario(t)
+		spec := validSpec()
+		spec.Formats = []Format{FormatPNG}
+		spec.PanelIDs = []int64{2, 3}
+		spec.ReplyTo = "ops@example.com"
+		report := sc.createReport(t, spec)
+
+		sc.now = report.NextRunAt.Add(time.Minute)
+		sc.expectRender(t, rendering.RenderPNG,
+			"d-solo/slo/slos?from=now-7d&orgId=1&panelId=2&to=now&var-cluster=eu&var-cluster=us",
+			"d-solo/slo/slos?from=now-7d&orgId=1&panelId=3&to=now&var-cluster=eu&var-cluster=us",
+		)
+		sc.service.runDueReports(ctx)
+		// The report is not due anymore.
+		sc.service.runDueReports(ctx)
+
+		email := sc.emails.EmailSync
+		assert.Equal(t, tmplDashboardReport, email.Template)
+		assert.Equal(t, spec.Recipients, email.To)
+		assert.Equal(t, []string{"ops@example.com"}, email.ReplyTo)
+		assert.Equal(t, "SLOs", email.Data["DashboardTitle"])
+		assert.Equal(t, "http://localhost:3000/d/slo/slos?from=now-7d&orgId=1&to=now&var-cluster=eu&var-cluster=us", email.Data["DashboardURL"])
+		require.Len(t, email.AttachedFiles, 2)
+		assert.Equal(t, "slos-panel-2.png", email.AttachedFiles[0].Name)
+		assert.Equal(t, "slos-panel-3.png", email.AttachedFiles[1].Name)
+
+		stored, err := sc.service.GetReport(ctx, 1, report.UID)
+		require.NoError(t, err)
+		assert.True(t, stored.NextRunAt.After(sc.now))
+
+		runs, err := sc.service.ListRuns(ctx, 1, report.UID, 0)
+		require.NoError(t, err)
+		require.Len(t, runs, 1)
+		assert.Equal(t, RunStatusSuccess, runs[0].Status)
+		assert.Equal(t, RunTriggerSchedule, runs[0].Trigger)
+	})
+
+	t.Run("a claimed run is not sent again", func(t *testing.T) {
+		sc := setupTestScenario(t)
+		report := sc.createReport(t, validSpec())
+
+		claimed, err := sc.service.store.claimRun(ctx, report.ID, *report.NextRunAt, report.NextRunAt.Add(time.Hour))
+		require.NoError(t, err)
+		require.True(t, claimed)
+		claimed, err = sc.service.store.claimRun(ctx, report.ID, *report.NextRunAt, report.NextRunAt.Add(time.Hour))
+		require.NoError(t, err)
+		require.False(t, claimed)
+	})
+
+	t.Run("failed runs notify the failure recipients", func(t *testing.T) {
+		sc := setupTestScenario(t)
+		spec := validSpec()
+		spec.Formats = []Format{FormatPDF}
+		spec.TimeRange = TimeRange{}
+		spec.Variables = nil
+		report := sc.createReport(t, spec)
+
+		sc.renderer.EXPECT().Render(gomock.Any(), rendering.RenderPDF, gomock.Any(), nil).Return(nil, rendering.ErrRenderUnavailable).Times(3)
+
+		run, err := sc.service.SendReport(ctx, 1, report.UID)
+		require.NoError(t, err)
+		assert.Equal(t, RunStatusFailed, run.Status)
+		assert.Equal(t, RunTriggerManual, run.Trigger)
+		assert.Contains(t, run.Error, rendering.ErrRenderUnavailable.Error())
+
+		email := sc.emails.EmailSync
+		assert.Equal(t, tmplDashboardReportFailed, email.Template)
+		assert.Equal(t, []string{"editor@example.com"}, email.To)
+		assert.Equal(t, run.Error, email.Data["Error"])
+
+		// Only the latest runs are kept.
+		for i := 0; i < 2; i++ {
+			_, err := sc.service.SendReport(ctx, 1, report.UID)
+			require.NoError(t, err)
+		}
+		runs, err := sc.service.ListRuns(ctx, 1, report.UID, 0)
+		require.NoError(t, err)
+		require.Len(t, runs, 2)
+		assert.Greater(t, runs[0].ID, runs[1].ID)
+	})
+
+	t.Run("failed email is recorded", func(t *testing.T) {
+		sc := setupTestScenario(t)
+		spec := validSpec()
+		spec.Formats = []Format{FormatPDF}
+		spec.TimeRange = TimeRange{}
+		spec.Variables = nil
+		spec.FailureRecipients = []string{"ops@example.com"}
+		report := sc.createReport(t, spec)
+
+		sc.expectRender(t, rendering.RenderPDF, "d/slo/slos?kiosk=true&orgId=1")
+		var sent []*notifications.SendEmailCommandSync
+		sc.emails.EmailHandlerSync = func(_ context.Context, cmd *notifications.SendEmailCommandSync) error {
+			sent = append(sent, cmd)
+			if cmd.Template == tmplDashboardReport {
+				return errors.New("smtp error")
+			}
+			return nil
+		}
+
+		run, err := sc.service.SendReport(ctx, 1, report.UID)
+		require.NoError(t, err)
+		assert.Equal(t, RunStatusFailed, run.Status)
+		assert.Contains(t, run.Error, "smtp error")
+		require.Len(t, sent, 2)
+		require.Len(t, sent[0].AttachedFiles, 1)
+		assert.Equal(t, "slos.pdf", sent[0].AttachedFiles[0].Name)
+		assert.Equal(t, []string{"ops@example.com"}, sent[1].To)
+	})
+}
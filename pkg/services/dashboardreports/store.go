@@ -0,0 +1,238 @@
+package dashboardreports
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+)
+
+// reportRow is a report in the dashboard_report table. The spec is stored in the definition column, apart from
+// the fields that are used in queries.
+type reportRow struct {
+	ID           int64  `xorm:"pk autoincr 'id'"`
+	OrgID        int64  `xorm:"org_id"`
+	UID          string `xorm:"uid"`
+	Name         string
+	DashboardUID string `xorm:"dashboard_uid"`
+	Enabled      bool
+	Definition   string
+	CreatedBy    int64
+	Created      time.Time
+	Updated      time.Time
+	// NextRunAt is the Unix time in seconds at which the report is due, or 0 if the report is disabled.
+	NextRunAt int64
+}
+
+func (reportRow) TableName() string {
+	return "dashboard_report"
+}
+
+func newReportRow(r *Report) (*reportRow, error) {
+	definition, err := json.Marshal(r.ReportSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal report definition: %w", err)
+	}
+	row := &reportRow{
+		ID:           r.ID,
+		OrgID:        r.OrgID,
+		UID:          r.UID,
+		Name:         r.Name,
+		DashboardUID: r.DashboardUID,
+		Enabled:      r.Enabled,
+		Definition:   string(definition),
+		CreatedBy:    r.CreatedBy,
+		Created:      r.Created,
+		Updated:      r.Updated,
+	}
+	if r.NextRunAt != nil {
+		row.NextRunAt = r.NextRunAt.Unix()
+	}
+	return row, nil
+}
+
+func (row *reportRow) toReport() (*Report, error) {
+	r := &Report{
+		ID:        row.ID,
+		UID:       row.UID,
+		OrgID:     row.OrgID,
+		CreatedBy: row.CreatedBy,
+		Created:   row.Created,
+		Updated:   row.Updated,
+	}
+	if err := json.Unmarshal([]byte(row.Definition), &r.ReportSpec); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal definition of report %s: %w", row.UID, err)
+	}
+	r.Name = row.Name
+	r.DashboardUID = row.DashboardUID
+	r.Enabled = row.Enabled
+	if row.NextRunAt > 0 {
+		next := time.Unix(row.NextRunAt, 0)
+		r.NextRunAt = &next
+	}
+	return r, nil
+}
+
+func toReports(rows []reportRow) ([]*Report, error) {
+	reports := make([]*Report, 0, len(rows))
+	for i := range rows {
+		r, err := rows[i].toReport()
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, r)
+	}
+	return reports, nil
+}
+
+type store struct {
+	db db.DB
+}
+
+func (s *store) listReports(ctx context.Context, orgID int64) ([]*Report, error) {
+	var rows []reportRow
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Where("org_id = ?", orgID).Asc("name", "id").Find(&rows)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toReports(rows)
+}
+
+func (s *store) getReport(ctx context.Context, orgID int64, uid string) (*Report, error) {
+	var row reportRow
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		exists, err := sess.Where("org_id = ? AND uid = ?", orgID, uid).Get(&row)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrReportNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return row.toReport()
+}
+
+// getDueReports returns the enabled reports of all organizations that are due at the given time.
+func (s *store) getDueReports(ctx context.Context, now time.Time) ([]*Report, error) {
+	var rows []reportRow
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Where("enabled = ? AND next_run_at > 0 AND next_run_at <= ?", true, now.Unix()).Asc("next_run_at", "id").Find(&rows)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toReports(rows)
+}
+
+func (s *store) insertReport(ctx context.Context, r *Report) error {
+	row, err := newReportRow(r)
+	if err != nil {
+		return err
+	}
+	err = s.db.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		exists, err := sess.Where("org_id = ? AND uid = ?", r.OrgID, r.UID).Exist(&reportRow{})
+		if err != nil {
+			return err
+		}
+		if exists {
+			return ErrReportExists
+		}
+		_, err = sess.Insert(row)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	r.ID = row.ID
+	return nil
+}
+
+func (s *store) updateReport(ctx context.Context, r *Report) error {
+	row, err := newReportRow(r)
+	if err != nil {
+		return err
+	}
+	return s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		affected, err := sess.Where("org_id = ? AND uid = ?", r.OrgID, r.UID).
+			Cols("name", "dashboard_uid", "enabled", "definition", "updated", "next_run_at").
+			Update(row)
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrReportNotFound
+		}
+		return nil
+	})
+}
+
+// claimRun moves the next run of a report from the given time to the next one. It returns false if another
+// instance of Grafana has claimed the run first.
+func (s *store) claimRun(ctx context.Context, id int64, due time.Time, next time.Time) (bool, error) {
+	var claimed bool
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		res, err := sess.Exec("UPDATE dashboard_report SET next_run_at = ? WHERE id = ? AND next_run_at = ?", next.Unix(), id, due.Unix())
+		if err != nil {
+			return err
+		}
+		affected, err := res.RowsAffected()
+		claimed = affected == 1
+		return err
+	})
+	return claimed, err
+}
+
+func (s *store) deleteReport(ctx context.Context, orgID int64, uid string) error {
+	return s.db.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		affected, err := sess.Where("org_id = ? AND uid = ?", orgID, uid).Delete(&reportRow{})
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrReportNotFound
+		}
+		_, err = sess.Where("org_id = ? AND report_uid = ?", orgID, uid).Delete(&Run{})
+		return err
+	})
+}
+
+func (s *store) listRuns(ctx context.Context, orgID int64, reportUID string, limit int) ([]*Run, error) {
+	runs := make([]*Run, 0)
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		q := sess.Where("org_id = ? AND report_uid = ?", orgID, reportUID).Desc("id")
+		if limit > 0 {
+			q = q.Limit(limit)
+		}
+		return q.Find(&runs)
+	})
+	return runs, err
+}
+
+// insertRun adds a run to the history of a report and removes the oldest runs that exceed the limit.
+func (s *store) insertRun(ctx context.Context, run *Run, limit int) error {
+	return s.db.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		if _, err := sess.Insert(run); err != nil {
+			return err
+		}
+		if limit <= 0 {
+			return nil
+		}
+		var ids []int64
+		err := sess.Table(&Run{}).Cols("id").
+			Where("org_id = ? AND report_uid = ?", run.OrgID, run.ReportUID).
+			Desc("id").Limit(1, limit-1).Find(&ids)
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		_, err = sess.Where("org_id = ? AND report_uid = ? AND id < ?", run.OrgID, run.ReportUID, ids[0]).Delete(&Run{})
+		return err
+	})
+}
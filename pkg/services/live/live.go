@@ -163,17 +163,32 @@ func ProvideService(plugCtxProvider *plugincontext.Provider, cfg *setting.Cfg, r
 		}
 	}
 
+	historyOptions := managedstream.HistoryOptions{
+		MaxFrames: g.Cfg.LiveHistoryMaxFrames,
+		MaxAge:    g.Cfg.LiveHistoryMaxAge,
+	}
+
 	if redisClient != nil {
+		var frameHistory managedstream.FrameHistory
+		if historyOptions.Enabled() {
+			frameHistory = managedstream.NewRedisFrameHistory(redisClient, g.keyPrefix, historyOptions)
+		}
 		managedStreamRunner = managedstream.NewRunner(
 			g.Publish,
 			channelLocalPublisher,
 			managedstream.NewRedisFrameCache(redisClient, g.keyPrefix),
+			frameHistory,
 		)
 	} else {
+		var frameHistory managedstream.FrameHistory
+		if historyOptions.Enabled() {
+			frameHistory = managedstream.NewMemoryFrameHistory(historyOptions)
+		}
 		managedStreamRunner = managedstream.NewRunner(
 			g.Publish,
 			channelLocalPublisher,
 			managedstream.NewMemoryFrameCache(),
+			frameHistory,
 		)
 	}
 
@@ -1190,6 +1205,72 @@ func (g *GrafanaLive) HandleInfoHTTP(ctx *contextmodel.ReqContext) response.Resp
 	})
 }
 
+type streamHistoryResponse struct {
+	Frames []managedstream.HistoryFrame `json:"frames"`
+}
+
+// HandleHistoryHTTP returns the history of a managed stream channel. Time range
+// is set with from and to query parameters in Unix epoch milliseconds.
+func (g *GrafanaLive) HandleHistoryHTTP(ctx *contextmodel.ReqContext) response.Response {
+	channel := web.Params(ctx.Req)["*"]
+	addr, err := live.ParseChannel(channel)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "invalid channel ID", nil)
+	}
+	if addr.Scope != live.ScopeStream {
+		return response.Error(http.StatusBadRequest, "history is only supported for stream channels", nil)
+	}
+
+	from := time.Time{}
+	to := time.Now()
+	if v := ctx.Query("from"); v != "" {
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return response.Error(http.StatusBadRequest, "invalid from parameter", err)
+		}
+		from = time.UnixMilli(ms)
+	}
+	if v := ctx.Query("to"); v != "" {
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return response.Error(http.StatusBadRequest, "invalid to parameter", err)
+		}
+		to = time.UnixMilli(ms)
+	}
+
+	user := ctx.SignedInUser
+	if g.Pipeline != nil {
+		rule, ok, err := g.Pipeline.Get(user.GetOrgID(), channel)
+		if err != nil {
+			logger.Error("Error getting channel rule", "user", user, "channel", channel, "error", err)
+			return response.Error(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil)
+		}
+		if ok && rule.SubscribeAuth != nil {
+			ok, err := rule.SubscribeAuth.CanSubscribe(ctx.Req.Context(), user)
+			if err != nil {
+				logger.Error("Error checking subscribe permissions", "user", user, "channel", channel, "error", err)
+				return response.Error(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil)
+			}
+			if !ok {
+				return response.Error(http.StatusForbidden, http.StatusText(http.StatusForbidden), nil)
+			}
+		}
+	}
+
+	frames, ok, err := g.ManagedStreamRunner.GetHistory(ctx.Req.Context(), user.GetOrgID(), channel, from, to)
+	if err != nil {
+		logger.Error("Error getting stream history", "user", user, "channel", channel, "error", err)
+		return response.Error(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil)
+	}
+	if !ok {
+		return response.Error(http.StatusNotFound, "stream history is not enabled", nil)
+	}
+	if frames == nil {
+		frames = []managedstream.HistoryFrame{}
+	}
+	return response.JSONStreaming(http.StatusOK, streamHistoryResponse{Frames: frames})
+}
+
 // HandleChannelRulesListHTTP ...
 func (g *GrafanaLive) HandleChannelRulesListHTTP(c *contextmodel.ReqContext) response.Response {
 	result, err := g.pipelineStorage.ListChannelRules(c.Req.Context(), c.GetOrgID())
@@ -0,0 +1,105 @@
+package managedstream
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// FrameHistory keeps a bounded history of frames pushed to managed stream channels.
+type FrameHistory interface {
+	// Append adds a frame pushed at time t to the channel history.
+	Append(ctx context.Context, orgID int64, channel string, t time.Time, frameJson data.FrameJSONCache) error
+	// Get returns frames pushed to the channel between from and to inclusive, oldest first.
+	Get(ctx context.Context, orgID int64, channel string, from, to time.Time) ([]HistoryFrame, error)
+}
+
+// HistoryFrame is a frame from the channel history.
+type HistoryFrame struct {
+	Time  time.Time       `json:"time"`
+	Frame json.RawMessage `json:"frame"`
+}
+
+// defaultHistoryMaxFrames bounds the number of frames in the history of every
+// channel when only a maximum age is set.
+const defaultHistoryMaxFrames = 1000
+
+// HistoryOptions bound the history of every channel. Zero value means no limit,
+// at least one of the options must be set.
+type HistoryOptions struct {
+	MaxFrames int
+	MaxAge    time.Duration
+}
+
+// Enabled returns true if the history is bounded by any of the options.
+func (o HistoryOptions) Enabled() bool {
+	return o.MaxFrames > 0 || o.MaxAge > 0
+}
+
+// maxFrames returns the maximum number of frames in the history of a channel,
+// which is bounded even if only a maximum age is set.
+func (o HistoryOptions) maxFrames() int {
+	if o.MaxFrames > 0 {
+		return o.MaxFrames
+	}
+	return defaultHistoryMaxFrames
+}
+
+// idleTimeout returns the time after the last frame after which the history of
+// a channel is removed.
+func (o HistoryOptions) idleTimeout() time.Duration {
+	if o.MaxAge > 0 {
+		return o.MaxAge
+	}
+	return frameCacheTTL
+}
+
+// mergeHistoryFrames merges frames into a single frame with rows of all frames.
+// Frames can be merged only if they have the same schema, so only the newest
+// frames with the schema of the last frame are merged.
+func mergeHistoryFrames(history []HistoryFrame) (*data.Frame, error) {
+	// Newest first.
+	var frames []*data.Frame
+	for i := len(history) - 1; i >= 0; i-- {
+		var frame data.Frame
+		if err := json.Unmarshal(history[i].Frame, &frame); err != nil {
+			return nil, err
+		}
+		if len(frames) > 0 && !sameFrameSchema(frames[0], &frame) {
+			break
+		}
+		frames = append(frames, &frame)
+	}
+	if len(frames) == 0 {
+		return nil, nil
+	}
+	merged := frames[len(frames)-1]
+	for i := len(frames) - 2; i >= 0; i-- {
+		rowLen, err := frames[i].RowLen()
+		if err != nil {
+			return nil, err
+		}
+		for j, field := range merged.Fields {
+			for row := 0; row < rowLen; row++ {
+				field.Append(frames[i].Fields[j].At(row))
+			}
+		}
+	}
+	return merged, nil
+}
+
+func sameFrameSchema(a, b *data.Frame) bool {
+	if len(a.Fields) != len(b.Fields) {
+		return false
+	}
+	for i := range a.Fields {
+		if a.Fields[i].Name != b.Fields[i].Name ||
+			a.Fields[i].Type() != b.Fields[i].Type() ||
+			!a.Fields[i].Labels.Equals(b.Fields[i].Labels) {
+			return false
+		}
+	}
+	return true
+}
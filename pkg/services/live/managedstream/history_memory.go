@@ -0,0 +1,131 @@
+package managedstream
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// historySweepInterval is the minimum time between two removals of idle
+// channels from the memory history.
+const historySweepInterval = time.Minute
+
+// MemoryFrameHistory keeps channel history in a ring buffer per channel. The
+// history of channels without new frames is removed after the idle timeout of
+// the options.
+type MemoryFrameHistory struct {
+	mu        sync.RWMutex
+	options   HistoryOptions
+	channels  map[int64]map[string]*historyRing
+	lastSweep time.Time
+}
+
+// NewMemoryFrameHistory creates new MemoryFrameHistory.
+func NewMemoryFrameHistory(options HistoryOptions) *MemoryFrameHistory {
+	return &MemoryFrameHistory{
+		options:  options,
+		channels: map[int64]map[string]*historyRing{},
+	}
+}
+
+func (h *MemoryFrameHistory) Append(_ context.Context, orgID int64, channel string, t time.Time, frameJson data.FrameJSONCache) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.channels[orgID]; !ok {
+		h.channels[orgID] = map[string]*historyRing{}
+	}
+	ring, ok := h.channels[orgID][channel]
+	if !ok {
+		ring = &historyRing{}
+		h.channels[orgID][channel] = ring
+	}
+	ring.push(HistoryFrame{Time: t, Frame: frameJson.Bytes(data.IncludeAll)}, h.options.maxFrames())
+	ring.lastPush = t
+	if h.options.MaxAge > 0 {
+		ring.dropBefore(t.Add(-h.options.MaxAge))
+	}
+	if t.Sub(h.lastSweep) >= historySweepInterval {
+		h.removeIdleChannels(t)
+		h.lastSweep = t
+	}
+	return nil
+}
+
+// removeIdleChannels removes the history of channels without frames since the
+// idle timeout.
+func (h *MemoryFrameHistory) removeIdleChannels(now time.Time) {
+	minPush := now.Add(-h.options.idleTimeout())
+	for orgID, channels := range h.channels {
+		for channel, ring := range channels {
+			if ring.lastPush.Before(minPush) {
+				delete(channels, channel)
+			}
+		}
+		if len(channels) == 0 {
+			delete(h.channels, orgID)
+		}
+	}
+}
+
+func (h *MemoryFrameHistory) Get(_ context.Context, orgID int64, channel string, from, to time.Time) ([]HistoryFrame, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	ring, ok := h.channels[orgID][channel]
+	if !ok {
+		return nil, nil
+	}
+	var minTime time.Time
+	if h.options.MaxAge > 0 {
+		minTime = time.Now().Add(-h.options.MaxAge)
+	}
+	var result []HistoryFrame
+	ring.each(func(f HistoryFrame) {
+		if f.Time.Before(from) || f.Time.After(to) || f.Time.Before(minTime) {
+			return
+		}
+		result = append(result, f)
+	})
+	return result, nil
+}
+
+// historyRing is a buffer of frames ordered by time. The buffer grows up to
+// the maximum number of frames, then the oldest frame is overwritten.
+type historyRing struct {
+	frames   []HistoryFrame
+	start    int
+	size     int
+	lastPush time.Time
+}
+
+func (r *historyRing) push(f HistoryFrame, maxFrames int) {
+	if r.size == len(r.frames) && len(r.frames) < maxFrames {
+		frames := make([]HistoryFrame, min(max(2*len(r.frames), 8), maxFrames))
+		for i := 0; i < r.size; i++ {
+			frames[i] = r.frames[(r.start+i)%len(r.frames)]
+		}
+		r.frames, r.start = frames, 0
+	}
+	end := (r.start + r.size) % len(r.frames)
+	r.frames[end] = f
+	if r.size < len(r.frames) {
+		r.size++
+	} else {
+		r.start = (r.start + 1) % len(r.frames)
+	}
+}
+
+func (r *historyRing) dropBefore(t time.Time) {
+	for r.size > 0 && r.frames[r.start].Time.Before(t) {
+		r.frames[r.start] = HistoryFrame{}
+		r.start = (r.start + 1) % len(r.frames)
+		r.size--
+	}
+}
+
+func (r *historyRing) each(fn func(f HistoryFrame)) {
+	for i := 0; i < r.size; i++ {
+		fn(r.frames[(r.start+i)%len(r.frames)])
+	}
+}
@@ -0,0 +1,111 @@
+package managedstream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func appendTestFrame(t *testing.T, h FrameHistory, ts time.Time, value float64) {
+	t.Helper()
+	frame := data.NewFrame("test",
+		data.NewField("time", nil, []time.Time{ts}),
+		data.NewField("value", nil, []float64{value}),
+	)
+	frameJsonCache, err := data.FrameToJSONCache(frame)
+	require.NoError(t, err)
+	require.NoError(t, h.Append(context.Background(), 1, "stream/test/cpu", ts, frameJsonCache))
+}
+
+func TestMemoryFrameHistoryMaxFrames(t *testing.T) {
+	h := NewMemoryFrameHistory(HistoryOptions{MaxFrames: 3})
+	now := time.Now()
+	for i := 0; i < 5; i++ {
+		appendTestFrame(t, h, now.Add(time.Duration(i-5)*time.Second), float64(i))
+	}
+
+	history, err := h.Get(context.Background(), 1, "stream/test/cpu", time.Time{}, now)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	require.Equal(t, now.Add(-3*time.Second), history[0].Time)
+	require.Equal(t, now.Add(-time.Second), history[2].Time)
+
+	// Time range is inclusive.
+	history, err = h.Get(context.Background(), 1, "stream/test/cpu", now.Add(-2*time.Second), now.Add(-time.Second))
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+
+	// Other orgs and channels have their own history.
+	history, err = h.Get(context.Background(), 2, "stream/test/cpu", time.Time{}, now)
+	require.NoError(t, err)
+	require.Empty(t, history)
+}
+
+func TestMemoryFrameHistoryMaxAge(t *testing.T) {
+	h := NewMemoryFrameHistory(HistoryOptions{MaxAge: time.Minute})
+	now := time.Now()
+	appendTestFrame(t, h, now.Add(-2*time.Minute), 1)
+	appendTestFrame(t, h, now.Add(-30*time.Second), 2)
+	appendTestFrame(t, h, now.Add(-10*time.Second), 3)
+
+	history, err := h.Get(context.Background(), 1, "stream/test/cpu", time.Time{}, now)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, now.Add(-30*time.Second), history[0].Time)
+}
+
+func TestMemoryFrameHistoryMaxAgeIsBoundedByCount(t *testing.T) {
+	h := NewMemoryFrameHistory(HistoryOptions{MaxAge: time.Hour})
+	now := time.Now()
+	for i := 0; i < defaultHistoryMaxFrames+10; i++ {
+		appendTestFrame(t, h, now.Add(time.Duration(i-defaultHistoryMaxFrames-10)*time.Millisecond), float64(i))
+	}
+
+	history, err := h.Get(context.Background(), 1, "stream/test/cpu", time.Time{}, now)
+	require.NoError(t, err)
+	require.Len(t, history, defaultHistoryMaxFrames)
+	require.Equal(t, now.Add(-time.Duration(defaultHistoryMaxFrames)*time.Millisecond), history[0].Time)
+}
+
+func TestMemoryFrameHistoryRemovesIdleChannels(t *testing.T) {
+	h := NewMemoryFrameHistory(HistoryOptions{MaxAge: time.Minute})
+	now := time.Now()
+	appendTestFrame(t, h, now.Add(-10*time.Minute), 1)
+	require.Len(t, h.channels[1], 1)
+
+	frame := data.NewFrame("test", data.NewField("value", nil, []float64{1}))
+	frameJsonCache, err := data.FrameToJSONCache(frame)
+	require.NoError(t, err)
+	require.NoError(t, h.Append(context.Background(), 2, "stream/test/other", now, frameJsonCache))
+
+	require.NotContains(t, h.channels, int64(1))
+	require.Len(t, h.channels[2], 1)
+}
+
+func TestMergeHistoryFrames(t *testing.T) {
+	h := NewMemoryFrameHistory(HistoryOptions{MaxFrames: 10})
+	now := time.Now()
+
+	// Frame with a different schema is not merged.
+	frame := data.NewFrame("test", data.NewField("other", nil, []string{"a"}))
+	frameJsonCache, err := data.FrameToJSONCache(frame)
+	require.NoError(t, err)
+	require.NoError(t, h.Append(context.Background(), 1, "stream/test/cpu", now.Add(-3*time.Second), frameJsonCache))
+
+	appendTestFrame(t, h, now.Add(-2*time.Second), 1)
+	appendTestFrame(t, h, now.Add(-time.Second), 2)
+
+	history, err := h.Get(context.Background(), 1, "stream/test/cpu", time.Time{}, now)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+
+	merged, err := mergeHistoryFrames(history)
+	require.NoError(t, err)
+	require.Len(t, merged.Fields, 2)
+	require.Equal(t, 2, merged.Fields[1].Len())
+	require.Equal(t, 1.0, merged.Fields[1].At(0))
+	require.Equal(t, 2.0, merged.Fields[1].At(1))
+}
@@ -0,0 +1,81 @@
+package managedstream
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/services/live/orgchannel"
+)
+
+// RedisFrameHistory keeps channel history in a Redis sorted set per channel
+// scored by the push time in milliseconds.
+type RedisFrameHistory struct {
+	redisClient *redis.Client
+	keyPrefix   string
+	options     HistoryOptions
+}
+
+// NewRedisFrameHistory creates new RedisFrameHistory.
+func NewRedisFrameHistory(redisClient *redis.Client, keyPrefix string, options HistoryOptions) *RedisFrameHistory {
+	return &RedisFrameHistory{
+		redisClient: redisClient,
+		keyPrefix:   keyPrefix,
+		options:     options,
+	}
+}
+
+func (h *RedisFrameHistory) Append(ctx context.Context, orgID int64, channel string, t time.Time, frameJson data.FrameJSONCache) error {
+	// Members of a sorted set are unique, so the member also contains the push time.
+	member, err := json.Marshal(HistoryFrame{Time: t, Frame: frameJson.Bytes(data.IncludeAll)})
+	if err != nil {
+		return err
+	}
+	key := h.getHistoryKey(orgchannel.PrependOrgID(orgID, channel))
+
+	pipe := h.redisClient.TxPipeline()
+	defer func() { _ = pipe.Close() }()
+
+	pipe.ZAdd(ctx, key, &redis.Z{Score: float64(t.UnixMilli()), Member: member})
+	if h.options.MaxAge > 0 {
+		pipe.ZRemRangeByScore(ctx, key, "-inf", "("+strconv.FormatInt(t.Add(-h.options.MaxAge).UnixMilli(), 10))
+	}
+	pipe.ZRemRangeByRank(ctx, key, 0, int64(-h.options.maxFrames()-1))
+	pipe.Expire(ctx, key, h.options.idleTimeout())
+
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func (h *RedisFrameHistory) Get(ctx context.Context, orgID int64, channel string, from, to time.Time) ([]HistoryFrame, error) {
+	if h.options.MaxAge > 0 {
+		if minTime := time.Now().Add(-h.options.MaxAge); from.Before(minTime) {
+			from = minTime
+		}
+	}
+	key := h.getHistoryKey(orgchannel.PrependOrgID(orgID, channel))
+	members, err := h.redisClient.ZRangeByScore(ctx, key, &redis.ZRangeBy{
+		Min: strconv.FormatInt(from.UnixMilli(), 10),
+		Max: strconv.FormatInt(to.UnixMilli(), 10),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]HistoryFrame, 0, len(members))
+	for _, member := range members {
+		var f HistoryFrame
+		if err := json.Unmarshal([]byte(member), &f); err != nil {
+			return nil, err
+		}
+		result = append(result, f)
+	}
+	return result, nil
+}
+
+func (h *RedisFrameHistory) getHistoryKey(channelID string) string {
+	return h.keyPrefix + ".managed_stream_history." + channelID
+}
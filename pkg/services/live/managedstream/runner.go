@@ -42,22 +42,35 @@ type Runner struct {
 	publisher      model.ChannelPublisher
 	localPublisher LocalPublisher
 	frameCache     FrameCache
+	frameHistory   FrameHistory
 }
 
 type LocalPublisher interface {
 	PublishLocal(channel string, data []byte) error
 }
 
-// NewRunner creates new Runner.
-func NewRunner(publisher model.ChannelPublisher, localPublisher LocalPublisher, frameCache FrameCache) *Runner {
+// NewRunner creates new Runner. frameHistory is optional, without it
+// subscribers only get the last frame of a channel.
+func NewRunner(publisher model.ChannelPublisher, localPublisher LocalPublisher, frameCache FrameCache, frameHistory FrameHistory) *Runner {
 	return &Runner{
 		publisher:      publisher,
 		localPublisher: localPublisher,
 		streams:        map[int64]map[string]*NamespaceStream{},
 		frameCache:     frameCache,
+		frameHistory:   frameHistory,
 	}
 }
 
+// GetHistory returns frames pushed to the channel between from and to, oldest first.
+// It returns false if history is not enabled.
+func (r *Runner) GetHistory(ctx context.Context, orgID int64, channel string, from, to time.Time) ([]HistoryFrame, bool, error) {
+	if r.frameHistory == nil {
+		return nil, false, nil
+	}
+	frames, err := r.frameHistory.Get(ctx, orgID, channel, from, to)
+	return frames, true, err
+}
+
 func (r *Runner) GetManagedChannels(orgID int64) ([]*ManagedChannel, error) {
 	activeChannels, err := r.frameCache.GetActiveChannels(orgID)
 	if err != nil {
@@ -125,7 +138,7 @@ func (r *Runner) GetOrCreateStream(orgID int64, scope string, namespace string)
 	prefix := scope + "/" + namespace
 	s, ok := r.streams[orgID][prefix]
 	if !ok {
-		s = NewNamespaceStream(orgID, scope, namespace, r.publisher, r.localPublisher, r.frameCache)
+		s = NewNamespaceStream(orgID, scope, namespace, r.publisher, r.localPublisher, r.frameCache, r.frameHistory)
 		r.streams[orgID][prefix] = s
 	}
 	return s, nil
@@ -139,6 +152,7 @@ type NamespaceStream struct {
 	publisher      model.ChannelPublisher
 	localPublisher LocalPublisher
 	frameCache     FrameCache
+	frameHistory   FrameHistory
 	rateMu         sync.RWMutex
 	rates          map[string][60]rateEntry
 }
@@ -156,7 +170,7 @@ type ManagedChannel struct {
 }
 
 // NewNamespaceStream creates new NamespaceStream.
-func NewNamespaceStream(orgID int64, scope string, namespace string, publisher model.ChannelPublisher, localPublisher LocalPublisher, schemaUpdater FrameCache) *NamespaceStream {
+func NewNamespaceStream(orgID int64, scope string, namespace string, publisher model.ChannelPublisher, localPublisher LocalPublisher, schemaUpdater FrameCache, frameHistory FrameHistory) *NamespaceStream {
 	return &NamespaceStream{
 		orgID:          orgID,
 		scope:          scope,
@@ -164,12 +178,14 @@ func NewNamespaceStream(orgID int64, scope string, namespace string, publisher m
 		publisher:      publisher,
 		localPublisher: localPublisher,
 		frameCache:     schemaUpdater,
+		frameHistory:   frameHistory,
 		rates:          map[string][60]rateEntry{},
 	}
 }
 
 // Push sends frame to the stream and saves it for later retrieval by subscribers.
 // * Saves the entire frame to cache.
+// * Appends the entire frame to history if enabled.
 // * If schema has been changed sends entire frame to channel, otherwise only data.
 func (s *NamespaceStream) Push(ctx context.Context, path string, frame *data.Frame) error {
 	jsonFrameCache, err := data.FrameToJSONCache(frame)
@@ -186,6 +202,13 @@ func (s *NamespaceStream) Push(ctx context.Context, path string, frame *data.Fra
 		return err
 	}
 
+	if s.frameHistory != nil {
+		if err := s.frameHistory.Append(ctx, s.orgID, channel, time.Now(), jsonFrameCache); err != nil {
+			// History is not essential for the stream, subscribers still get the last frame.
+			logger.Error("Error appending frame to managed stream history", "error", err, "channel", channel)
+		}
+	}
+
 	// When the schema has not changed, just send the data.
 	include := data.IncludeDataOnly
 	if isUpdated {
@@ -241,6 +264,13 @@ func (s *NamespaceStream) GetHandlerForPath(_ string) (model.ChannelHandler, err
 
 func (s *NamespaceStream) OnSubscribe(ctx context.Context, u identity.Requester, e model.SubscribeEvent) (model.SubscribeReply, backend.SubscribeStreamStatus, error) {
 	reply := model.SubscribeReply{}
+	if s.frameHistory != nil {
+		historyJSON, ok := s.getHistoryFrame(ctx, u.GetOrgID(), e.Channel)
+		if ok {
+			reply.Data = historyJSON
+			return reply, backend.SubscribeStreamStatusOK, nil
+		}
+	}
 	frameJSON, ok, err := s.frameCache.GetFrame(ctx, u.GetOrgID(), e.Channel)
 	if err != nil {
 		return reply, 0, err
@@ -251,6 +281,30 @@ func (s *NamespaceStream) OnSubscribe(ctx context.Context, u identity.Requester,
 	return reply, backend.SubscribeStreamStatusOK, nil
 }
 
+// getHistoryFrame returns the channel history merged into a single frame to
+// replay it to a new subscriber.
+func (s *NamespaceStream) getHistoryFrame(ctx context.Context, orgID int64, channel string) (json.RawMessage, bool) {
+	history, err := s.frameHistory.Get(ctx, orgID, channel, time.Time{}, time.Now())
+	if err != nil {
+		logger.Error("Error getting managed stream history", "error", err, "channel", channel)
+		return nil, false
+	}
+	if len(history) == 0 {
+		return nil, false
+	}
+	frame, err := mergeHistoryFrames(history)
+	if err != nil {
+		logger.Error("Error merging managed stream history", "error", err, "channel", channel)
+		return nil, false
+	}
+	frameJSON, err := data.FrameToJSON(frame, data.IncludeAll)
+	if err != nil {
+		logger.Error("Error encoding managed stream history", "error", err, "channel", channel)
+		return nil, false
+	}
+	return frameJSON, true
+}
+
 func (s *NamespaceStream) OnPublish(_ context.Context, _ identity.Requester, _ model.PublishEvent) (model.PublishReply, backend.PublishStreamStatus, error) {
 	return model.PublishReply{}, backend.PublishStreamStatusPermissionDenied, nil
 }
@@ -2,11 +2,15 @@ package managedstream
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/live/model"
+	"github.com/grafana/grafana/pkg/services/user"
 )
 
 type testPublisher struct {
@@ -19,13 +23,13 @@ func (p *testPublisher) publish(_ int64, _ string, _ []byte) error {
 
 func TestNewManagedStream(t *testing.T) {
 	publisher := &testPublisher{t: t}
-	c := NewNamespaceStream(1, "stream", "a", publisher.publish, nil, NewMemoryFrameCache())
+	c := NewNamespaceStream(1, "stream", "a", publisher.publish, nil, NewMemoryFrameCache(), nil)
 	require.NotNil(t, c)
 }
 
 func TestManagedStreamMinuteRate(t *testing.T) {
 	publisher := &testPublisher{t: t}
-	c := NewNamespaceStream(1, "stream", "a", publisher.publish, nil, NewMemoryFrameCache())
+	c := NewNamespaceStream(1, "stream", "a", publisher.publish, nil, NewMemoryFrameCache(), nil)
 	require.NotNil(t, c)
 
 	c.incRate("test1", time.Now().Unix())
@@ -48,7 +52,7 @@ func TestManagedStreamMinuteRate(t *testing.T) {
 func TestGetManagedStreams(t *testing.T) {
 	publisher := &testPublisher{t: t}
 	frameCache := NewMemoryFrameCache()
-	runner := NewRunner(publisher.publish, nil, frameCache)
+	runner := NewRunner(publisher.publish, nil, frameCache, nil)
 	s1, err := runner.GetOrCreateStream(1, "stream", "test1")
 	require.NoError(t, err)
 	s2, err := runner.GetOrCreateStream(1, "stream", "test2")
@@ -83,3 +87,29 @@ func TestGetManagedStreams(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, managedChannels, 7) // Not affected by other org.
 }
+
+func TestManagedStreamReplayHistory(t *testing.T) {
+	publisher := &testPublisher{t: t}
+	runner := NewRunner(publisher.publish, nil, NewMemoryFrameCache(), NewMemoryFrameHistory(HistoryOptions{MaxFrames: 2}))
+	s, err := runner.GetOrCreateStream(1, "stream", "test")
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		err = s.Push(context.Background(), "cpu", data.NewFrame("cpu", data.NewField("value", nil, []float64{float64(i)})))
+		require.NoError(t, err)
+	}
+
+	reply, _, err := s.OnSubscribe(context.Background(), &user.SignedInUser{OrgID: 1}, model.SubscribeEvent{Channel: "stream/test/cpu", Path: "cpu"})
+	require.NoError(t, err)
+
+	var frame data.Frame
+	require.NoError(t, json.Unmarshal(reply.Data, &frame))
+	require.Equal(t, 2, frame.Fields[0].Len())
+	require.Equal(t, 1.0, frame.Fields[0].At(0))
+	require.Equal(t, 2.0, frame.Fields[0].At(1))
+
+	history, ok, err := runner.GetHistory(context.Background(), 1, "stream/test/cpu", time.Time{}, time.Now())
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Len(t, history, 2)
+}
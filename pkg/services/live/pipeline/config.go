@@ -40,6 +40,9 @@ type ConverterConfig struct {
 	ExactJsonConverterConfig  *ExactJsonConverterConfig  `json:"jsonExact,omitempty"`
 	AutoInfluxConverterConfig *AutoInfluxConverterConfig `json:"influxAuto,omitempty"`
 	JsonFrameConverterConfig  *JsonFrameConverterConfig  `json:"jsonFrame,omitempty"`
+	PrometheusConverterConfig *PrometheusConverterConfig `json:"prometheus,omitempty"`
+	OtlpJsonConverterConfig   *OtlpJsonConverterConfig   `json:"otlpJson,omitempty"`
+	CsvConverterConfig        *CsvConverterConfig        `json:"csv,omitempty"`
 }
 
 type DropFieldsFrameProcessorConfig struct {
@@ -50,11 +53,26 @@ type KeepFieldsFrameProcessorConfig struct {
 	FieldNames []string `json:"fieldNames"`
 }
 
+// AggregateFrameProcessorConfig configures a tumbling window when the slide
+// is not set or equals to the window, and a sliding window otherwise.
+type AggregateFrameProcessorConfig struct {
+	WindowMilliseconds int64                  `json:"windowMilliseconds"`
+	SlideMilliseconds  int64                  `json:"slideMilliseconds,omitempty"`
+	DefaultReducer     AggregateReducer       `json:"defaultReducer,omitempty"` // "last" if not set.
+	Fields             []AggregateFieldConfig `json:"fields,omitempty"`
+}
+
+type AggregateFieldConfig struct {
+	FieldName string           `json:"fieldName"`
+	Reducer   AggregateReducer `json:"reducer"`
+}
+
 type FrameProcessorConfig struct {
 	Type                      string                          `json:"type" ts_type:"Omit<keyof FrameProcessorConfig, 'type'>"`
 	DropFieldsProcessorConfig *DropFieldsFrameProcessorConfig `json:"dropFields,omitempty"`
 	KeepFieldsProcessorConfig *KeepFieldsFrameProcessorConfig `json:"keepFields,omitempty"`
 	MultipleProcessorConfig   *MultipleFrameProcessorConfig   `json:"multiple,omitempty"`
+	AggregateProcessorConfig  *AggregateFrameProcessorConfig  `json:"aggregate,omitempty"`
 }
 
 type MultipleFrameProcessorConfig struct {
@@ -152,4 +170,17 @@ type AutoInfluxConverterConfig struct {
 
 type JsonFrameConverterConfig struct{}
 
+type PrometheusConverterConfig struct{}
+
+type OtlpJsonConverterConfig struct{}
+
+type CsvConverterConfig struct {
+	// Delimiter separates values in a row, defaults to a comma.
+	Delimiter string `json:"delimiter,omitempty"`
+	// TimeField is the name of the column with the row time, defaults to "time".
+	// Values can be RFC3339 timestamps or Unix epoch milliseconds. Without such
+	// column all rows get the time of conversion.
+	TimeField string `json:"timeField,omitempty"`
+}
+
 type ManagedStreamOutputConfig struct{}
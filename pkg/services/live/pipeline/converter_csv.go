@@ -0,0 +1,191 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// CsvConverter decodes CSV with a header row to a single frame.
+//
+// Column types are detected automatically:
+// * Time is taken from the time column, or added automatically
+// * Columns where all values are numbers become float64 fields
+// * Columns where all values are booleans become bool fields
+// * Other columns become string fields
+// Empty values are nulls. If there are both string or bool columns and number
+// columns, the frame is converted to a wide frame where the string and bool
+// columns become labels of the number fields.
+type CsvConverter struct {
+	config      CsvConverterConfig
+	nowTimeFunc func() time.Time
+}
+
+func NewCsvConverter(c CsvConverterConfig) *CsvConverter {
+	return &CsvConverter{config: c}
+}
+
+const ConverterTypeCsv = "csv"
+
+const defaultCsvTimeField = "time"
+
+func (c *CsvConverter) Type() string {
+	return ConverterTypeCsv
+}
+
+func (c *CsvConverter) Convert(_ context.Context, vars Vars, body []byte) ([]*ChannelFrame, error) {
+	nowTimeFunc := c.nowTimeFunc
+	if nowTimeFunc == nil {
+		nowTimeFunc = time.Now
+	}
+
+	reader := csv.NewReader(bytes.NewReader(body))
+	reader.TrimLeadingSpace = true
+	if c.config.Delimiter != "" {
+		delimiter, size := utf8.DecodeRuneInString(c.config.Delimiter)
+		if size != len(c.config.Delimiter) {
+			return nil, fmt.Errorf("csv delimiter must be a single character, got %q", c.config.Delimiter)
+		}
+		reader.Comma = delimiter
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing csv: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("csv without header row")
+	}
+	header, rows := records[0], records[1:]
+
+	timeField := c.config.TimeField
+	if timeField == "" {
+		timeField = defaultCsvTimeField
+	}
+	timeIndex := -1
+	for i, name := range header {
+		if name == "" {
+			return nil, fmt.Errorf("csv column %d without name", i+1)
+		}
+		if name == timeField {
+			timeIndex = i
+		}
+	}
+
+	times := make([]time.Time, len(rows))
+	now := nowTimeFunc()
+	for i, row := range rows {
+		if timeIndex < 0 {
+			times[i] = now
+			continue
+		}
+		times[i], err = parseCsvTime(row[timeIndex])
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+1, err)
+		}
+	}
+
+	// Long frames must be sorted by time for the conversion to wide.
+	order := make([]int, len(rows))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return times[order[i]].Before(times[order[j]]) })
+
+	sortedTimes := make([]time.Time, len(rows))
+	for i, rowIdx := range order {
+		sortedTimes[i] = times[rowIdx]
+	}
+	frame := data.NewFrame(vars.Path, data.NewField(timeField, nil, sortedTimes))
+
+	hasFactors, hasNumbers := false, false
+	for col, name := range header {
+		if col == timeIndex {
+			continue
+		}
+		values := make([]string, len(rows))
+		for i, rowIdx := range order {
+			values[i] = rows[rowIdx][col]
+		}
+		field := csvColumnToField(name, values)
+		if field.Type() == data.FieldTypeNullableFloat64 {
+			hasNumbers = true
+		} else {
+			hasFactors = true
+		}
+		frame.Fields = append(frame.Fields, field)
+	}
+
+	if hasFactors && hasNumbers && len(rows) > 0 {
+		frame, err = data.LongToWide(frame, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error converting csv to wide frame: %w", err)
+		}
+	}
+	return []*ChannelFrame{
+		{Channel: "", Frame: frame},
+	}, nil
+}
+
+// parseCsvTime parses RFC3339 timestamps and Unix epoch milliseconds.
+func parseCsvTime(value string) (time.Time, error) {
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.UnixMilli(ms), nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q, expected RFC3339 or Unix epoch milliseconds", value)
+	}
+	return t, nil
+}
+
+func csvColumnToField(name string, values []string) *data.Field {
+	numbers := make([]*float64, len(values))
+	isNumber := true
+	for i, v := range values {
+		if v == "" {
+			continue
+		}
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			isNumber = false
+			break
+		}
+		numbers[i] = &f
+	}
+	if isNumber {
+		return data.NewField(name, nil, numbers)
+	}
+
+	bools := make([]*bool, len(values))
+	isBool := true
+	for i, v := range values {
+		if v == "" {
+			continue
+		}
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			isBool = false
+			break
+		}
+		bools[i] = &b
+	}
+	if isBool {
+		return data.NewField(name, nil, bools)
+	}
+
+	strs := make([]*string, len(values))
+	for i := range values {
+		if values[i] != "" {
+			strs[i] = &values[i]
+		}
+	}
+	return data.NewField(name, nil, strs)
+}
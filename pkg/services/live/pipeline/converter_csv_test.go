@@ -0,0 +1,60 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/experimental"
+	"github.com/stretchr/testify/require"
+)
+
+func checkCsvConversion(t *testing.T, file string, config CsvConverterConfig) {
+	t.Helper()
+	converter := NewCsvConverter(config)
+	converter.nowTimeFunc = func() time.Time {
+		return time.Date(2021, 01, 01, 12, 12, 12, 0, time.UTC)
+	}
+	channelFrames, err := converter.Convert(context.Background(), Vars{Path: file}, loadTestData(t, file+".csv"))
+	require.NoError(t, err)
+
+	dr := &backend.DataResponse{}
+	for _, cf := range channelFrames {
+		require.Empty(t, cf.Channel)
+		dr.Frames = append(dr.Frames, cf.Frame)
+	}
+	experimental.CheckGoldenJSONResponse(t, "testdata", file+".golden", dr, *update)
+}
+
+func TestCsvConverter_Convert(t *testing.T) {
+	t.Run("labels from string and bool columns", func(t *testing.T) {
+		checkCsvConversion(t, "csv_wide", CsvConverterConfig{})
+	})
+	t.Run("custom delimiter", func(t *testing.T) {
+		checkCsvConversion(t, "csv_values", CsvConverterConfig{Delimiter: ";"})
+	})
+	t.Run("time added automatically", func(t *testing.T) {
+		checkCsvConversion(t, "csv_no_time", CsvConverterConfig{})
+	})
+}
+
+func TestCsvConverter_Convert_InvalidInput(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   CsvConverterConfig
+		body     string
+		expected string
+	}{
+		{name: "empty body", body: "", expected: "csv without header row"},
+		{name: "invalid time", body: "time,value\nyesterday,1\n", expected: `invalid time "yesterday"`},
+		{name: "inconsistent rows", body: "time,value\n1,2,3\n", expected: "error parsing csv"},
+		{name: "invalid delimiter", config: CsvConverterConfig{Delimiter: ";;"}, body: "a;;b\n", expected: "csv delimiter must be a single character"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewCsvConverter(tc.config).Convert(context.Background(), Vars{}, []byte(tc.body))
+			require.ErrorContains(t, err, tc.expected)
+		})
+	}
+}
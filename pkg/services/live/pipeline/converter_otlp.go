@@ -0,0 +1,274 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// OtlpJsonConverter decodes OTLP metrics encoded as JSON (the payload of
+// an OTLP/HTTP ExportMetricsServiceRequest) and transforms it to several
+// ChannelFrame objects where Channel is constructed from original
+// channel + / + <metric_name>. Resource and data point attributes become
+// field labels, the metric unit becomes the field unit.
+type OtlpJsonConverter struct {
+	config      OtlpJsonConverterConfig
+	nowTimeFunc func() time.Time
+}
+
+// NewOtlpJsonConverter creates new OtlpJsonConverter.
+func NewOtlpJsonConverter(c OtlpJsonConverterConfig) *OtlpJsonConverter {
+	return &OtlpJsonConverter{config: c}
+}
+
+const ConverterTypeOtlpJson = "otlpJson"
+
+func (c *OtlpJsonConverter) Type() string {
+	return ConverterTypeOtlpJson
+}
+
+func (c *OtlpJsonConverter) Convert(_ context.Context, vars Vars, body []byte) ([]*ChannelFrame, error) {
+	nowTimeFunc := c.nowTimeFunc
+	if nowTimeFunc == nil {
+		nowTimeFunc = time.Now
+	}
+	now := nowTimeFunc()
+
+	var req otlpMetricsRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, fmt.Errorf("error parsing otlp metrics: %w", err)
+	}
+
+	builder := newMetricFrameBuilder()
+	for _, rm := range req.ResourceMetrics {
+		resourceLabels := otlpLabels(data.Labels{}, rm.Resource.Attributes)
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				if m.Name == "" {
+					return nil, fmt.Errorf("otlp metric without name")
+				}
+				m.addTo(builder, resourceLabels, now)
+			}
+		}
+	}
+	return builder.channelFrames(vars.Channel), nil
+}
+
+// Only the parts of the OTLP metrics data model which can be represented
+// in frames are decoded. Exponential histograms are reduced to their sum
+// and count.
+
+type otlpMetricsRequest struct {
+	ResourceMetrics []otlpResourceMetrics `json:"resourceMetrics"`
+}
+
+type otlpResourceMetrics struct {
+	Resource struct {
+		Attributes []otlpKeyValue `json:"attributes"`
+	} `json:"resource"`
+	ScopeMetrics []otlpScopeMetrics `json:"scopeMetrics"`
+}
+
+type otlpScopeMetrics struct {
+	Metrics []otlpMetric `json:"metrics"`
+}
+
+type otlpMetric struct {
+	Name                 string             `json:"name"`
+	Unit                 string             `json:"unit"`
+	Gauge                *otlpNumberPoints  `json:"gauge"`
+	Sum                  *otlpNumberPoints  `json:"sum"`
+	Histogram            *otlpHistogram     `json:"histogram"`
+	ExponentialHistogram *otlpHistogram     `json:"exponentialHistogram"`
+	Summary              *otlpSummaryPoints `json:"summary"`
+}
+
+type otlpNumberPoints struct {
+	DataPoints []otlpNumberDataPoint `json:"dataPoints"`
+}
+
+type otlpNumberDataPoint struct {
+	Attributes   []otlpKeyValue `json:"attributes"`
+	TimeUnixNano otlpInt        `json:"timeUnixNano"`
+	AsDouble     *otlpNumber    `json:"asDouble"`
+	AsInt        *otlpInt       `json:"asInt"`
+}
+
+type otlpHistogram struct {
+	DataPoints []otlpHistogramDataPoint `json:"dataPoints"`
+}
+
+type otlpHistogramDataPoint struct {
+	Attributes     []otlpKeyValue `json:"attributes"`
+	TimeUnixNano   otlpInt        `json:"timeUnixNano"`
+	Count          otlpInt        `json:"count"`
+	Sum            *otlpNumber    `json:"sum"`
+	BucketCounts   []otlpInt      `json:"bucketCounts"`
+	ExplicitBounds []otlpNumber   `json:"explicitBounds"`
+}
+
+type otlpSummaryPoints struct {
+	DataPoints []otlpSummaryDataPoint `json:"dataPoints"`
+}
+
+type otlpSummaryDataPoint struct {
+	Attributes     []otlpKeyValue `json:"attributes"`
+	TimeUnixNano   otlpInt        `json:"timeUnixNano"`
+	Count          otlpInt        `json:"count"`
+	Sum            otlpNumber     `json:"sum"`
+	QuantileValues []struct {
+		Quantile otlpNumber `json:"quantile"`
+		Value    otlpNumber `json:"value"`
+	} `json:"quantileValues"`
+}
+
+type otlpKeyValue struct {
+	Key   string `json:"key"`
+	Value struct {
+		StringValue *string     `json:"stringValue"`
+		BoolValue   *bool       `json:"boolValue"`
+		IntValue    *otlpInt    `json:"intValue"`
+		DoubleValue *otlpNumber `json:"doubleValue"`
+	} `json:"value"`
+}
+
+// otlpInt is a 64 bit integer which OTLP JSON encodes as a string, or as a JSON number.
+type otlpInt int64
+
+func (n *otlpInt) UnmarshalJSON(b []byte) error {
+	v, err := strconv.ParseInt(string(bytes.Trim(b, `"`)), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid integer %s: %w", b, err)
+	}
+	*n = otlpInt(v)
+	return nil
+}
+
+func (n otlpInt) value() *float64 {
+	v := float64(n)
+	return &v
+}
+
+func (n otlpInt) time(now time.Time) time.Time {
+	if n == 0 {
+		return now
+	}
+	return time.Unix(0, int64(n))
+}
+
+// otlpNumber is a float which OTLP JSON encodes as a JSON number, or as a
+// string for special values.
+type otlpNumber float64
+
+func (n *otlpNumber) UnmarshalJSON(b []byte) error {
+	s := string(bytes.Trim(b, `"`))
+	switch s {
+	case "NaN":
+		*n = otlpNumber(math.NaN())
+		return nil
+	case "Infinity":
+		*n = otlpNumber(math.Inf(1))
+		return nil
+	case "-Infinity":
+		*n = otlpNumber(math.Inf(-1))
+		return nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("invalid number %s: %w", b, err)
+	}
+	*n = otlpNumber(v)
+	return nil
+}
+
+func (n otlpNumber) value() *float64 {
+	v := float64(n)
+	if math.IsNaN(v) {
+		return nil
+	}
+	return &v
+}
+
+func otlpLabels(base data.Labels, attributes []otlpKeyValue) data.Labels {
+	labels := base.Copy()
+	for _, kv := range attributes {
+		switch {
+		case kv.Value.StringValue != nil:
+			labels[kv.Key] = *kv.Value.StringValue
+		case kv.Value.BoolValue != nil:
+			labels[kv.Key] = strconv.FormatBool(*kv.Value.BoolValue)
+		case kv.Value.IntValue != nil:
+			labels[kv.Key] = strconv.FormatInt(int64(*kv.Value.IntValue), 10)
+		case kv.Value.DoubleValue != nil:
+			labels[kv.Key] = formatFloat(float64(*kv.Value.DoubleValue))
+		}
+	}
+	return labels
+}
+
+func (m otlpMetric) addTo(builder *metricFrameBuilder, resourceLabels data.Labels, now time.Time) {
+	var numberPoints []otlpNumberDataPoint
+	if m.Gauge != nil {
+		numberPoints = append(numberPoints, m.Gauge.DataPoints...)
+	}
+	if m.Sum != nil {
+		numberPoints = append(numberPoints, m.Sum.DataPoints...)
+	}
+	for _, dp := range numberPoints {
+		var value *float64
+		if dp.AsDouble != nil {
+			value = dp.AsDouble.value()
+		} else if dp.AsInt != nil {
+			value = dp.AsInt.value()
+		}
+		builder.add(m.Name, dp.TimeUnixNano.time(now), m.Name, otlpLabels(resourceLabels, dp.Attributes), value, m.Unit)
+	}
+
+	if m.Histogram != nil {
+		for _, dp := range m.Histogram.DataPoints {
+			t := dp.TimeUnixNano.time(now)
+			labels := otlpLabels(resourceLabels, dp.Attributes)
+			// OTLP bucket counts are not cumulative, the last bucket has no upper bound.
+			var cumulative otlpInt
+			for i, count := range dp.BucketCounts {
+				cumulative += count
+				le := math.Inf(1)
+				if i < len(dp.ExplicitBounds) {
+					le = float64(dp.ExplicitBounds[i])
+				}
+				builder.add(m.Name, t, m.Name+"_bucket", withLabel(labels, "le", formatFloat(le)), cumulative.value(), "")
+			}
+			m.addSumAndCount(builder, t, labels, dp.Sum, dp.Count)
+		}
+	}
+
+	if m.ExponentialHistogram != nil {
+		for _, dp := range m.ExponentialHistogram.DataPoints {
+			m.addSumAndCount(builder, dp.TimeUnixNano.time(now), otlpLabels(resourceLabels, dp.Attributes), dp.Sum, dp.Count)
+		}
+	}
+
+	if m.Summary != nil {
+		for _, dp := range m.Summary.DataPoints {
+			t := dp.TimeUnixNano.time(now)
+			labels := otlpLabels(resourceLabels, dp.Attributes)
+			for _, q := range dp.QuantileValues {
+				builder.add(m.Name, t, m.Name, withLabel(labels, "quantile", formatFloat(float64(q.Quantile))), q.Value.value(), m.Unit)
+			}
+			m.addSumAndCount(builder, t, labels, &dp.Sum, dp.Count)
+		}
+	}
+}
+
+func (m otlpMetric) addSumAndCount(builder *metricFrameBuilder, t time.Time, labels data.Labels, sum *otlpNumber, count otlpInt) {
+	if sum != nil {
+		builder.add(m.Name, t, m.Name+"_sum", labels, sum.value(), m.Unit)
+	}
+	builder.add(m.Name, t, m.Name+"_count", labels, count.value(), "")
+}
@@ -0,0 +1,37 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/experimental"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOtlpJsonConverter_Convert(t *testing.T) {
+	converter := NewOtlpJsonConverter(OtlpJsonConverterConfig{})
+	channelFrames, err := converter.Convert(context.Background(), Vars{Channel: "stream/test/otlp"}, loadTestJson(t, "otlp"))
+	require.NoError(t, err)
+
+	dr := &backend.DataResponse{}
+	channels := make([]string, 0, len(channelFrames))
+	for _, cf := range channelFrames {
+		channels = append(channels, cf.Channel)
+		dr.Frames = append(dr.Frames, cf.Frame)
+	}
+	require.Equal(t, []string{
+		"stream/test/otlp/queue.size",
+		"stream/test/otlp/requests",
+		"stream/test/otlp/request.duration",
+		"stream/test/otlp/rpc.duration",
+	}, channels)
+
+	experimental.CheckGoldenJSONResponse(t, "testdata", "otlp.golden", dr, *update)
+}
+
+func TestOtlpJsonConverter_Convert_InvalidInput(t *testing.T) {
+	converter := NewOtlpJsonConverter(OtlpJsonConverterConfig{})
+	_, err := converter.Convert(context.Background(), Vars{}, []byte(`{"resourceMetrics": [{"scopeMetrics": [{"metrics": [{"name": "m", "gauge": {"dataPoints": [{"asInt": "one"}]}}]}]}]}`))
+	require.ErrorContains(t, err, "invalid integer")
+}
@@ -0,0 +1,120 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// PrometheusConverter decodes Prometheus text exposition format and transforms
+// it to several ChannelFrame objects where Channel is constructed from original
+// channel + / + <metric_name>. Samples without a timestamp get the time of
+// conversion.
+type PrometheusConverter struct {
+	config      PrometheusConverterConfig
+	nowTimeFunc func() time.Time
+}
+
+// NewPrometheusConverter creates new PrometheusConverter.
+func NewPrometheusConverter(c PrometheusConverterConfig) *PrometheusConverter {
+	return &PrometheusConverter{config: c}
+}
+
+const ConverterTypePrometheus = "prometheus"
+
+func (c *PrometheusConverter) Type() string {
+	return ConverterTypePrometheus
+}
+
+func (c *PrometheusConverter) Convert(_ context.Context, vars Vars, body []byte) ([]*ChannelFrame, error) {
+	nowTimeFunc := c.nowTimeFunc
+	if nowTimeFunc == nil {
+		nowTimeFunc = time.Now
+	}
+	now := nowTimeFunc()
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing prometheus metrics: %w", err)
+	}
+
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	builder := newMetricFrameBuilder()
+	for _, name := range names {
+		for _, m := range families[name].GetMetric() {
+			addPrometheusMetric(builder, name, families[name].GetType(), m, now)
+		}
+	}
+	return builder.channelFrames(vars.Channel), nil
+}
+
+// addPrometheusMetric adds all samples of the metric. Histograms and summaries
+// are split into fields named after the series Prometheus exposes for them.
+func addPrometheusMetric(builder *metricFrameBuilder, name string, metricType dto.MetricType, m *dto.Metric, now time.Time) {
+	t := now
+	if m.TimestampMs != nil {
+		t = time.UnixMilli(m.GetTimestampMs())
+	}
+	labels := data.Labels{}
+	for _, lp := range m.GetLabel() {
+		labels[lp.GetName()] = lp.GetValue()
+	}
+
+	switch metricType {
+	case dto.MetricType_COUNTER:
+		builder.add(name, t, name, labels, prometheusValue(m.GetCounter().GetValue()), "")
+	case dto.MetricType_GAUGE:
+		builder.add(name, t, name, labels, prometheusValue(m.GetGauge().GetValue()), "")
+	case dto.MetricType_SUMMARY:
+		s := m.GetSummary()
+		for _, q := range s.GetQuantile() {
+			builder.add(name, t, name, withLabel(labels, "quantile", formatFloat(q.GetQuantile())), prometheusValue(q.GetValue()), "")
+		}
+		builder.add(name, t, name+"_sum", labels, prometheusValue(s.GetSampleSum()), "")
+		builder.add(name, t, name+"_count", labels, prometheusValue(float64(s.GetSampleCount())), "")
+	case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+		h := m.GetHistogram()
+		for _, b := range h.GetBucket() {
+			builder.add(name, t, name+"_bucket", withLabel(labels, "le", formatFloat(b.GetUpperBound())), prometheusValue(float64(b.GetCumulativeCount())), "")
+		}
+		builder.add(name, t, name+"_sum", labels, prometheusValue(h.GetSampleSum()), "")
+		builder.add(name, t, name+"_count", labels, prometheusValue(float64(h.GetSampleCount())), "")
+	default:
+		builder.add(name, t, name, labels, prometheusValue(m.GetUntyped().GetValue()), "")
+	}
+}
+
+// prometheusValue returns a pointer to the value, NaN values are converted to null.
+func prometheusValue(v float64) *float64 {
+	if math.IsNaN(v) {
+		return nil
+	}
+	return &v
+}
+
+func withLabel(labels data.Labels, name, value string) data.Labels {
+	result := labels.Copy()
+	result[name] = value
+	return result
+}
+
+func formatFloat(v float64) string {
+	if math.IsInf(v, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,53 @@
+package pipeline
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/experimental"
+	"github.com/stretchr/testify/require"
+)
+
+func loadTestData(t testing.TB, file string) []byte {
+	t.Helper()
+	// Safe to disable, this is a test.
+	// nolint:gosec
+	content, err := os.ReadFile(filepath.Join("testdata", file))
+	require.NoError(t, err, "expected to be able to read file")
+	require.True(t, len(content) > 0)
+	return content
+}
+
+func TestPrometheusConverter_Convert(t *testing.T) {
+	converter := NewPrometheusConverter(PrometheusConverterConfig{})
+	converter.nowTimeFunc = func() time.Time {
+		return time.Date(2021, 01, 01, 12, 12, 12, 0, time.UTC)
+	}
+	channelFrames, err := converter.Convert(context.Background(), Vars{Channel: "stream/test/prometheus"}, loadTestData(t, "prometheus.txt"))
+	require.NoError(t, err)
+
+	dr := &backend.DataResponse{}
+	channels := make([]string, 0, len(channelFrames))
+	for _, cf := range channelFrames {
+		channels = append(channels, cf.Channel)
+		dr.Frames = append(dr.Frames, cf.Frame)
+	}
+	require.Equal(t, []string{
+		"stream/test/prometheus/http_request_duration_seconds",
+		"stream/test/prometheus/http_requests_total",
+		"stream/test/prometheus/node_load1",
+		"stream/test/prometheus/rpc_duration_seconds",
+	}, channels)
+
+	experimental.CheckGoldenJSONResponse(t, "testdata", "prometheus.golden", dr, *update)
+}
+
+func TestPrometheusConverter_Convert_InvalidInput(t *testing.T) {
+	converter := NewPrometheusConverter(PrometheusConverterConfig{})
+	_, err := converter.Convert(context.Background(), Vars{}, []byte("metric{label=\"value} 1\n"))
+	require.ErrorContains(t, err, "error parsing prometheus metrics")
+}
@@ -0,0 +1,457 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// AggregateReducer reduces field values in a window to a single value.
+type AggregateReducer string
+
+// Known AggregateReducer types.
+const (
+	AggregateReducerAvg   AggregateReducer = "avg"
+	AggregateReducerMin   AggregateReducer = "min"
+	AggregateReducerMax   AggregateReducer = "max"
+	AggregateReducerLast  AggregateReducer = "last"
+	AggregateReducerCount AggregateReducer = "count"
+)
+
+// AggregateFrameProcessor aggregates frames of a channel over a tumbling or a
+// sliding window. Incoming frames are buffered and the processor returns no
+// frame until a frame with time after the end of a window arrives. Then it
+// returns a frame with one row for each completed window, with the window end
+// as time and every field reduced with its configured reducer. Windows without
+// any values are skipped. When a channel stops receiving frames, a timer flushes
+// its windows as they close, assuming that the time of frames advances like the
+// wall clock, and the channel state is removed once nothing is buffered.
+//
+// Fields are identified by name and labels. The avg, min and max reducers
+// only apply to numeric fields, other fields use the last value instead.
+type AggregateFrameProcessor struct {
+	config AggregateFrameProcessorConfig
+	window time.Duration
+	slide  time.Duration
+
+	nowTimeFunc func() time.Time
+
+	mu        sync.Mutex
+	states    map[aggregateStateKey]*aggregateState
+	flushFunc FlushFunc
+}
+
+func NewAggregateFrameProcessor(config AggregateFrameProcessorConfig) (*AggregateFrameProcessor, error) {
+	if config.WindowMilliseconds <= 0 {
+		return nil, errors.New("aggregate window must be greater than zero")
+	}
+	slideMilliseconds := config.SlideMilliseconds
+	if slideMilliseconds == 0 {
+		slideMilliseconds = config.WindowMilliseconds
+	}
+	if slideMilliseconds < 0 || slideMilliseconds > config.WindowMilliseconds {
+		return nil, errors.New("aggregate slide must be greater than zero and not greater than the window")
+	}
+	if config.DefaultReducer != "" && !isValidAggregateReducer(config.DefaultReducer) {
+		return nil, fmt.Errorf("unknown aggregate reducer: %s", config.DefaultReducer)
+	}
+	for _, f := range config.Fields {
+		if !isValidAggregateReducer(f.Reducer) {
+			return nil, fmt.Errorf("unknown aggregate reducer for field %s: %s", f.FieldName, f.Reducer)
+		}
+	}
+	return &AggregateFrameProcessor{
+		config: config,
+		window: time.Duration(config.WindowMilliseconds) * time.Millisecond,
+		slide:  time.Duration(slideMilliseconds) * time.Millisecond,
+		states: map[aggregateStateKey]*aggregateState{},
+	}, nil
+}
+
+const FrameProcessorTypeAggregate = "aggregate"
+
+func (p *AggregateFrameProcessor) Type() string {
+	return FrameProcessorTypeAggregate
+}
+
+// SetFlushFunc sets the function which processes the frames flushed by the timer.
+func (p *AggregateFrameProcessor) SetFlushFunc(fn FlushFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.flushFunc = fn
+}
+
+func (p *AggregateFrameProcessor) now() time.Time {
+	if p.nowTimeFunc != nil {
+		return p.nowTimeFunc()
+	}
+	return time.Now()
+}
+
+func isValidAggregateReducer(r AggregateReducer) bool {
+	switch r {
+	case AggregateReducerAvg, AggregateReducerMin, AggregateReducerMax, AggregateReducerLast, AggregateReducerCount:
+		return true
+	}
+	return false
+}
+
+func (p *AggregateFrameProcessor) reducer(fieldName string) AggregateReducer {
+	for _, f := range p.config.Fields {
+		if f.FieldName == fieldName {
+			return f.Reducer
+		}
+	}
+	if p.config.DefaultReducer != "" {
+		return p.config.DefaultReducer
+	}
+	return AggregateReducerLast
+}
+
+type aggregateStateKey struct {
+	orgID   int64
+	channel string
+}
+
+// aggregateState is the window state of a single channel.
+type aggregateState struct {
+	vars          Vars
+	name          string
+	timeFieldName string
+	// nextEnd is the end of the next window to emit.
+	nextEnd    time.Time
+	fields     []*aggregateField
+	fieldIndex map[string]int
+	// latest is the latest frame time, and updated the wall clock time when the last frame arrived.
+	latest  time.Time
+	updated time.Time
+	timer   *time.Timer
+}
+
+type aggregateField struct {
+	name      string
+	labels    data.Labels
+	config    *data.FieldConfig
+	fieldType data.FieldType
+	samples   []aggregateSample
+}
+
+type aggregateSample struct {
+	t time.Time
+	// v is the concrete value or nil.
+	v any
+}
+
+func (p *AggregateFrameProcessor) ProcessFrame(_ context.Context, vars Vars, frame *data.Frame) (*data.Frame, error) {
+	rowLen, err := frame.RowLen()
+	if err != nil {
+		return nil, err
+	}
+	if rowLen == 0 {
+		return nil, nil
+	}
+
+	now := p.now()
+
+	timeIndex := -1
+	if timeIndices := frame.TypeIndices(data.FieldTypeTime, data.FieldTypeNullableTime); len(timeIndices) > 0 {
+		timeIndex = timeIndices[0]
+	}
+	times := make([]time.Time, rowLen)
+	for i := range times {
+		times[i] = now
+		if timeIndex >= 0 {
+			if t, ok := frame.Fields[timeIndex].ConcreteAt(i); ok {
+				times[i] = t.(time.Time)
+			}
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := aggregateStateKey{orgID: vars.OrgID, channel: vars.Channel}
+	state, ok := p.states[key]
+	if !ok {
+		state = &aggregateState{fieldIndex: map[string]int{}, timeFieldName: "time"}
+		p.states[key] = state
+	}
+	state.vars = vars
+	state.name = frame.Name
+	state.updated = now
+	if timeIndex >= 0 {
+		state.timeFieldName = frame.Fields[timeIndex].Name
+	}
+
+	var latest time.Time
+	for i, field := range frame.Fields {
+		if i == timeIndex {
+			continue
+		}
+		af := state.field(field)
+		for row := 0; row < rowLen; row++ {
+			t := times[row]
+			if !state.nextEnd.IsZero() && t.Before(state.nextEnd.Add(-p.window)) {
+				// Too late for any window which is not emitted yet.
+				continue
+			}
+			v, _ := field.ConcreteAt(row)
+			af.samples = append(af.samples, aggregateSample{t: t, v: v})
+		}
+	}
+	for _, t := range times {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+	if latest.After(state.latest) {
+		state.latest = latest
+	}
+	if state.nextEnd.IsZero() {
+		if earliest := state.earliest(); !earliest.IsZero() {
+			state.nextEnd = earliest.Truncate(p.slide).Add(p.slide)
+		}
+	}
+	var result *data.Frame
+	if !state.nextEnd.IsZero() {
+		result = p.emit(state, latest)
+	}
+	p.schedule(key, state, now)
+	return result, nil
+}
+
+// schedule arms the timer of the state. While samples are buffered, it fires
+// when the next window closes. Otherwise it fires when the channel has been
+// idle for a window, to remove the state.
+func (p *AggregateFrameProcessor) schedule(key aggregateStateKey, state *aggregateState, now time.Time) {
+	delay := p.window
+	if !state.nextEnd.IsZero() {
+		delay = state.nextEnd.Sub(state.latest)
+	}
+	delay -= now.Sub(state.updated)
+	if state.timer != nil {
+		state.timer.Stop()
+	}
+	state.timer = time.AfterFunc(delay, func() { p.flush(key) })
+}
+
+// flush emits the windows of the channel which closed since its last frame,
+// and removes the state when nothing is buffered after an idle window.
+func (p *AggregateFrameProcessor) flush(key aggregateStateKey) {
+	p.mu.Lock()
+	state, ok := p.states[key]
+	if !ok {
+		p.mu.Unlock()
+		return
+	}
+	now := p.now()
+	idle := now.Sub(state.updated)
+	var frame *data.Frame
+	if !state.nextEnd.IsZero() {
+		frame = p.emit(state, state.latest.Add(idle))
+	}
+	if state.nextEnd.IsZero() && idle >= p.window {
+		delete(p.states, key)
+	} else {
+		p.schedule(key, state, now)
+	}
+	flushFunc, vars := p.flushFunc, state.vars
+	p.mu.Unlock()
+
+	if frame != nil && flushFunc != nil {
+		flushFunc(context.Background(), vars, frame)
+	}
+}
+
+func (s *aggregateState) field(field *data.Field) *aggregateField {
+	key := field.Name + field.Labels.String()
+	if i, ok := s.fieldIndex[key]; ok {
+		af := s.fields[i]
+		af.config = field.Config
+		if af.fieldType.NullableType() != field.Type().NullableType() {
+			// The field type changed, values of different types can not be reduced together.
+			af.fieldType = field.Type()
+			af.samples = nil
+		}
+		return af
+	}
+	af := &aggregateField{
+		name:      field.Name,
+		labels:    field.Labels,
+		config:    field.Config,
+		fieldType: field.Type(),
+	}
+	s.fieldIndex[key] = len(s.fields)
+	s.fields = append(s.fields, af)
+	return af
+}
+
+// earliest returns the time of the earliest buffered sample.
+func (s *aggregateState) earliest() time.Time {
+	var earliest time.Time
+	for _, f := range s.fields {
+		for _, sample := range f.samples {
+			if earliest.IsZero() || sample.t.Before(earliest) {
+				earliest = sample.t
+			}
+		}
+	}
+	return earliest
+}
+
+// emit reduces all windows that ended before the latest time to a frame and
+// drops the samples which are not needed for the next windows. Windows are
+// half-open intervals [end - window, end).
+func (p *AggregateFrameProcessor) emit(state *aggregateState, latest time.Time) *data.Frame {
+	var ends []time.Time
+	var rows [][]any
+	for !state.nextEnd.After(latest) {
+		end := state.nextEnd
+		start := end.Add(-p.window)
+		row := make([]any, len(state.fields))
+		hasValues := false
+		for i, f := range state.fields {
+			var values []any
+			for _, sample := range f.samples {
+				if !sample.t.Before(start) && sample.t.Before(end) {
+					values = append(values, sample.v)
+				}
+			}
+			if len(values) > 0 {
+				hasValues = true
+			}
+			row[i] = reduceAggregateValues(p.reducer(f.name), f.fieldType, values)
+		}
+		if hasValues {
+			ends = append(ends, end)
+			rows = append(rows, row)
+		}
+
+		state.nextEnd = end.Add(p.slide)
+		state.prune(state.nextEnd.Add(-p.window))
+		earliest := state.earliest()
+		if earliest.IsZero() {
+			// Nothing is buffered, the next window starts with the next sample.
+			state.nextEnd = time.Time{}
+			break
+		}
+		if !earliest.Before(state.nextEnd) {
+			// Skip the windows without any samples.
+			state.nextEnd = earliest.Truncate(p.slide).Add(p.slide)
+		}
+	}
+	if len(ends) == 0 {
+		return nil
+	}
+
+	frame := data.NewFrame(state.name, data.NewField(state.timeFieldName, nil, ends))
+	for i, f := range state.fields {
+		reducer := p.reducer(f.name)
+		fieldType := data.FieldTypeNullableFloat64
+		if reducer == AggregateReducerLast || (reducer != AggregateReducerCount && !f.fieldType.Numeric()) {
+			fieldType = f.fieldType.NullableType()
+		}
+		field := data.NewFieldFromFieldType(fieldType, len(rows))
+		field.Name = f.name
+		field.Labels = f.labels
+		field.Config = f.config
+		for row := range rows {
+			if v := rows[row][i]; v != nil {
+				field.SetConcrete(row, v)
+			}
+		}
+		frame.Fields = append(frame.Fields, field)
+	}
+	return frame
+}
+
+// prune drops samples before the time.
+func (s *aggregateState) prune(before time.Time) {
+	for _, f := range s.fields {
+		kept := f.samples[:0]
+		for _, sample := range f.samples {
+			if !sample.t.Before(before) {
+				kept = append(kept, sample)
+			}
+		}
+		f.samples = kept
+	}
+}
+
+// reduceAggregateValues returns the concrete reduced value, or nil.
+func reduceAggregateValues(reducer AggregateReducer, fieldType data.FieldType, values []any) any {
+	if reducer == AggregateReducerCount {
+		count := 0
+		for _, v := range values {
+			if v != nil {
+				count++
+			}
+		}
+		return float64(count)
+	}
+	if reducer == AggregateReducerLast || !fieldType.Numeric() {
+		for i := len(values) - 1; i >= 0; i-- {
+			if values[i] != nil {
+				return values[i]
+			}
+		}
+		return nil
+	}
+
+	var result float64
+	count := 0
+	for _, v := range values {
+		f, ok := aggregateFloat(v)
+		if !ok {
+			continue
+		}
+		switch {
+		case count == 0:
+			result = f
+		case reducer == AggregateReducerMin && f < result:
+			result = f
+		case reducer == AggregateReducerMax && f > result:
+			result = f
+		case reducer == AggregateReducerAvg:
+			result += f
+		}
+		count++
+	}
+	if count == 0 {
+		return nil
+	}
+	if reducer == AggregateReducerAvg {
+		result /= float64(count)
+	}
+	return result
+}
+
+func aggregateFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	}
+	return 0, false
+}
@@ -0,0 +1,192 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func aggregateTestFrame(start time.Time, values ...float64) *data.Frame {
+	times := make([]time.Time, len(values))
+	for i := range values {
+		times[i] = start.Add(time.Duration(i) * 100 * time.Millisecond)
+	}
+	return data.NewFrame("test",
+		data.NewField("time", nil, times),
+		data.NewField("value", data.Labels{"sensor": "1"}, values),
+		data.NewField("state", nil, make([]string, len(values))),
+	)
+}
+
+func TestAggregateFrameProcessor_Tumbling(t *testing.T) {
+	p, err := NewAggregateFrameProcessor(AggregateFrameProcessorConfig{
+		WindowMilliseconds: 1000,
+		Fields: []AggregateFieldConfig{
+			{FieldName: "value", Reducer: AggregateReducerAvg},
+		},
+	})
+	require.NoError(t, err)
+	vars := Vars{OrgID: 1, Channel: "stream/test/aggregate"}
+	start := time.Date(2021, 01, 01, 12, 12, 12, 0, time.UTC)
+
+	// Nothing is emitted until the first window ends.
+	frame, err := p.ProcessFrame(context.Background(), vars, aggregateTestFrame(start, 1, 2, 3, 4, 5))
+	require.NoError(t, err)
+	require.Nil(t, frame)
+
+	frame, err = p.ProcessFrame(context.Background(), vars, aggregateTestFrame(start.Add(500*time.Millisecond), 6, 7, 8, 9, 10, 11))
+	require.NoError(t, err)
+	require.NotNil(t, frame)
+	require.Equal(t, "test", frame.Name)
+	require.Len(t, frame.Fields, 3)
+	require.Equal(t, start.Add(time.Second), frame.Fields[0].At(0))
+	require.Equal(t, data.Labels{"sensor": "1"}, frame.Fields[1].Labels)
+	avg, ok := frame.Fields[1].ConcreteAt(0)
+	require.True(t, ok)
+	require.Equal(t, 5.5, avg)
+	require.Equal(t, data.FieldTypeNullableString, frame.Fields[2].Type())
+
+	// Other channels have their own windows.
+	frame, err = p.ProcessFrame(context.Background(), Vars{OrgID: 1, Channel: "stream/test/other"}, aggregateTestFrame(start.Add(time.Second), 1))
+	require.NoError(t, err)
+	require.Nil(t, frame)
+}
+
+func TestAggregateFrameProcessor_Sliding(t *testing.T) {
+	p, err := NewAggregateFrameProcessor(AggregateFrameProcessorConfig{
+		WindowMilliseconds: 1000,
+		SlideMilliseconds:  500,
+		DefaultReducer:     AggregateReducerMax,
+	})
+	require.NoError(t, err)
+	vars := Vars{OrgID: 1, Channel: "stream/test/aggregate"}
+	start := time.Date(2021, 01, 01, 12, 12, 12, 0, time.UTC)
+
+	frame, err := p.ProcessFrame(context.Background(), vars, aggregateTestFrame(start, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16))
+	require.NoError(t, err)
+	require.NotNil(t, frame)
+	// Windows end at 0.5s, 1s and 1.5s.
+	require.Equal(t, 3, frame.Rows())
+	expected := []float64{5, 10, 15}
+	for i, e := range expected {
+		require.Equal(t, start.Add(time.Duration(i+1)*500*time.Millisecond), frame.Fields[0].At(i))
+		v, ok := frame.Fields[1].ConcreteAt(i)
+		require.True(t, ok)
+		require.Equal(t, e, v)
+	}
+}
+
+func TestAggregateFrameProcessor_Flush(t *testing.T) {
+	p, err := NewAggregateFrameProcessor(AggregateFrameProcessorConfig{
+		WindowMilliseconds: 1000,
+		DefaultReducer:     AggregateReducerMax,
+	})
+	require.NoError(t, err)
+	vars := Vars{OrgID: 1, Channel: "stream/test/aggregate"}
+	key := aggregateStateKey{orgID: vars.OrgID, channel: vars.Channel}
+	start := time.Date(2021, 01, 01, 12, 12, 12, 0, time.UTC)
+	now := start
+	p.nowTimeFunc = func() time.Time { return now }
+	var flushed []*data.Frame
+	p.SetFlushFunc(func(_ context.Context, flushVars Vars, frame *data.Frame) {
+		require.Equal(t, vars, flushVars)
+		flushed = append(flushed, frame)
+	})
+
+	// The stream stops in the middle of the second window.
+	frame, err := p.ProcessFrame(context.Background(), vars, aggregateTestFrame(start, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15))
+	require.NoError(t, err)
+	require.Equal(t, 1, frame.Rows())
+
+	// The second window closes when the time of the frames would have reached its end.
+	now = start.Add(500 * time.Millisecond)
+	p.flush(key)
+	require.Empty(t, flushed)
+	now = start.Add(600 * time.Millisecond)
+	p.flush(key)
+	require.Len(t, flushed, 1)
+	require.Equal(t, start.Add(2*time.Second), flushed[0].Fields[0].At(0))
+	v, ok := flushed[0].Fields[1].ConcreteAt(0)
+	require.True(t, ok)
+	require.Equal(t, 15.0, v)
+
+	// The state is removed once the channel has been idle for a window.
+	p.flush(key)
+	require.Len(t, flushed, 1)
+	require.Contains(t, p.states, key)
+	now = start.Add(time.Second)
+	p.flush(key)
+	require.NotContains(t, p.states, key)
+}
+
+func TestAggregateFrameProcessor_FlushTimer(t *testing.T) {
+	p, err := NewAggregateFrameProcessor(AggregateFrameProcessorConfig{WindowMilliseconds: 50})
+	require.NoError(t, err)
+	flushed := make(chan *data.Frame, 1)
+	p.SetFlushFunc(func(_ context.Context, _ Vars, frame *data.Frame) {
+		flushed <- frame
+	})
+
+	frame, err := p.ProcessFrame(context.Background(), Vars{OrgID: 1, Channel: "stream/test/aggregate"}, aggregateTestFrame(time.Now(), 1))
+	require.NoError(t, err)
+	require.Nil(t, frame)
+
+	select {
+	case frame := <-flushed:
+		require.Equal(t, 1, frame.Rows())
+	case <-time.After(5 * time.Second):
+		t.Fatal("window was not flushed")
+	}
+	require.Eventually(t, func() bool {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		return len(p.states) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestAggregateFrameProcessor_Reducers(t *testing.T) {
+	values := []any{nil, 4.0, 2.0, 8.0, nil}
+	testCases := []struct {
+		reducer  AggregateReducer
+		expected any
+	}{
+		{reducer: AggregateReducerAvg, expected: float64(14) / 3},
+		{reducer: AggregateReducerMin, expected: 2.0},
+		{reducer: AggregateReducerMax, expected: 8.0},
+		{reducer: AggregateReducerLast, expected: 8.0},
+		{reducer: AggregateReducerCount, expected: 3.0},
+	}
+	for _, tc := range testCases {
+		t.Run(string(tc.reducer), func(t *testing.T) {
+			require.Equal(t, tc.expected, reduceAggregateValues(tc.reducer, data.FieldTypeNullableFloat64, values))
+		})
+	}
+	t.Run("numeric reducer on string field uses last value", func(t *testing.T) {
+		require.Equal(t, "b", reduceAggregateValues(AggregateReducerAvg, data.FieldTypeNullableString, []any{"a", "b", nil}))
+	})
+	t.Run("no values", func(t *testing.T) {
+		require.Nil(t, reduceAggregateValues(AggregateReducerMax, data.FieldTypeNullableFloat64, nil))
+	})
+}
+
+func TestNewAggregateFrameProcessor_Validation(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   AggregateFrameProcessorConfig
+		expected string
+	}{
+		{name: "no window", config: AggregateFrameProcessorConfig{}, expected: "aggregate window must be greater than zero"},
+		{name: "slide greater than window", config: AggregateFrameProcessorConfig{WindowMilliseconds: 100, SlideMilliseconds: 200}, expected: "aggregate slide must be greater than zero and not greater than the window"},
+		{name: "unknown default reducer", config: AggregateFrameProcessorConfig{WindowMilliseconds: 100, DefaultReducer: "median"}, expected: "unknown aggregate reducer: median"},
+		{name: "unknown field reducer", config: AggregateFrameProcessorConfig{WindowMilliseconds: 100, Fields: []AggregateFieldConfig{{FieldName: "value", Reducer: "p99"}}}, expected: "unknown aggregate reducer for field value: p99"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewAggregateFrameProcessor(tc.config)
+			require.EqualError(t, err, tc.expected)
+		})
+	}
+}
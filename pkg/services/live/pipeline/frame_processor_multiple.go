@@ -26,10 +26,32 @@ func (p *MultipleFrameProcessor) ProcessFrame(ctx context.Context, vars Vars, fr
 			logger.Error("Error processing frame", "error", err)
 			return nil, err
 		}
+		if frame == nil {
+			return nil, nil
+		}
 	}
 	return frame, nil
 }
 
+// SetFlushFunc sets the flush function of the processors which flush frames,
+// so that their frames are processed by the processors after them first.
+func (p *MultipleFrameProcessor) SetFlushFunc(fn FlushFunc) {
+	for i, proc := range p.Processors {
+		flusher, ok := proc.(FrameFlusher)
+		if !ok {
+			continue
+		}
+		rest := NewMultipleFrameProcessor(p.Processors[i+1:]...)
+		flusher.SetFlushFunc(func(ctx context.Context, vars Vars, frame *data.Frame) {
+			frame, err := rest.ProcessFrame(ctx, vars, frame)
+			if err != nil || frame == nil {
+				return
+			}
+			fn(ctx, vars, frame)
+		})
+	}
+}
+
 func NewMultipleFrameProcessor(processors ...FrameProcessor) *MultipleFrameProcessor {
 	return &MultipleFrameProcessor{Processors: processors}
 }
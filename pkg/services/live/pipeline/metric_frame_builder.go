@@ -0,0 +1,58 @@
+package pipeline
+
+import (
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// metricFrameBuilder collects labeled metric samples into wide frames.
+// Each unique frame is identified by metric name and time, every sample
+// becomes a nullable float64 field with its labels.
+type metricFrameBuilder struct {
+	// maintain the order of frames as they appear in input.
+	keyOrder []string
+	frames   map[string]*metricFrameEntry
+}
+
+type metricFrameEntry struct {
+	name  string
+	frame *data.Frame
+}
+
+func newMetricFrameBuilder() *metricFrameBuilder {
+	return &metricFrameBuilder{frames: map[string]*metricFrameEntry{}}
+}
+
+// add appends a sample as a new field to the frame for the metric name and time.
+func (b *metricFrameBuilder) add(name string, t time.Time, fieldName string, labels data.Labels, value *float64, unit string) {
+	key := name + "_" + t.String()
+	entry, ok := b.frames[key]
+	if !ok {
+		entry = &metricFrameEntry{
+			name:  name,
+			frame: data.NewFrame(name, data.NewField("time", nil, []time.Time{t})),
+		}
+		b.frames[key] = entry
+		b.keyOrder = append(b.keyOrder, key)
+	}
+	field := data.NewField(fieldName, labels, []*float64{value})
+	if unit != "" {
+		field.Config = &data.FieldConfig{Unit: unit}
+	}
+	entry.frame.Fields = append(entry.frame.Fields, field)
+}
+
+// channelFrames returns the collected frames, the channel of every frame is
+// constructed from the original channel + / + <metric_name>.
+func (b *metricFrameBuilder) channelFrames(channel string) []*ChannelFrame {
+	channelFrames := make([]*ChannelFrame, 0, len(b.keyOrder))
+	for _, key := range b.keyOrder {
+		entry := b.frames[key]
+		channelFrames = append(channelFrames, &ChannelFrame{
+			Channel: channel + "/" + entry.name,
+			Frame:   entry.frame,
+		})
+	}
+	return channelFrames
+}
@@ -98,6 +98,18 @@ type FrameProcessor interface {
 	ProcessFrame(ctx context.Context, vars Vars, frame *data.Frame) (*data.Frame, error)
 }
 
+// FlushFunc processes a frame returned by a FrameFlusher without an incoming frame.
+type FlushFunc func(ctx context.Context, vars Vars, frame *data.Frame)
+
+// FrameFlusher is a FrameProcessor which buffers frames and can also return
+// frames later, without an incoming frame. Before it processes a frame, the
+// pipeline sets the function which applies the rest of the channel rule to
+// the flushed frames.
+type FrameFlusher interface {
+	FrameProcessor
+	SetFlushFunc(fn FlushFunc)
+}
+
 // FrameOutputter outputs data.Frame to a custom destination. Or simply
 // do nothing if some conditions not met.
 type FrameOutputter interface {
@@ -405,16 +417,25 @@ func (p *Pipeline) processFrame(ctx context.Context, orgID int64, channelID stri
 		Path:      ch.Path,
 	}
 
-	if len(rule.FrameProcessors) > 0 {
-		for _, proc := range rule.FrameProcessors {
-			frame, err = p.execProcessor(ctx, proc, vars, frame)
-			if err != nil {
-				logger.Error("Error processing frame", "error", err)
-				return nil, err
-			}
-			if frame == nil {
-				return nil, nil
-			}
+	return p.processRuleFrame(ctx, rule, vars, 0, frame)
+}
+
+// processRuleFrame applies the frame processors of the rule from the index on,
+// and then the frame outputters of the rule.
+func (p *Pipeline) processRuleFrame(ctx context.Context, rule *LiveChannelRule, vars Vars, start int, frame *data.Frame) ([]*ChannelFrame, error) {
+	for i := start; i < len(rule.FrameProcessors); i++ {
+		proc := rule.FrameProcessors[i]
+		if flusher, ok := proc.(FrameFlusher); ok {
+			flusher.SetFlushFunc(p.flushFunc(rule, i+1))
+		}
+		var err error
+		frame, err = p.execProcessor(ctx, proc, vars, frame)
+		if err != nil {
+			logger.Error("Error processing frame", "error", err)
+			return nil, err
+		}
+		if frame == nil {
+			return nil, nil
 		}
 	}
 
@@ -434,6 +455,24 @@ func (p *Pipeline) processFrame(ctx context.Context, orgID int64, channelID stri
 	return nil, nil
 }
 
+// flushFunc returns the function which applies the rule from the index on to
+// the frames flushed by the processor before it.
+func (p *Pipeline) flushFunc(rule *LiveChannelRule, next int) FlushFunc {
+	return func(ctx context.Context, vars Vars, frame *data.Frame) {
+		frames, err := p.processRuleFrame(ctx, rule, vars, next, frame)
+		if err != nil {
+			logger.Error("Error processing flushed frame", "error", err, "channel", vars.Channel)
+			return
+		}
+		if len(frames) > 0 {
+			err = p.processChannelFrames(ctx, vars.OrgID, vars.Channel, frames, map[string]struct{}{vars.Channel: {}})
+			if err != nil {
+				logger.Error("Error processing flushed frame", "error", err, "channel", vars.Channel)
+			}
+		}
+	}
+}
+
 func (p *Pipeline) execProcessor(ctx context.Context, proc FrameProcessor, vars Vars, frame *data.Frame) (*data.Frame, error) {
 	var span trace.Span
 	if p.tracer != nil {
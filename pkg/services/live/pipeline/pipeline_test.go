@@ -5,6 +5,7 @@ import (
 	"errors"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/stretchr/testify/require"
@@ -100,6 +101,49 @@ func TestPipeline(t *testing.T) {
 	require.NotNil(t, outputter.frame)
 }
 
+type testFlushOutputter struct {
+	frames chan *data.Frame
+}
+
+func (t *testFlushOutputter) Type() string {
+	return "test"
+}
+
+func (t *testFlushOutputter) OutputFrame(_ context.Context, _ Vars, frame *data.Frame) ([]*ChannelFrame, error) {
+	t.frames <- frame
+	return nil, nil
+}
+
+func TestPipeline_FlushedFrame(t *testing.T) {
+	aggregate, err := NewAggregateFrameProcessor(AggregateFrameProcessorConfig{WindowMilliseconds: 50})
+	require.NoError(t, err)
+	outputter := &testFlushOutputter{frames: make(chan *data.Frame, 1)}
+	frame := data.NewFrame("test",
+		data.NewField("time", nil, []time.Time{time.Now()}),
+		data.NewField("value", nil, []float64{1}),
+	)
+	p, err := New(&testRuleGetter{
+		rules: map[string]*LiveChannelRule{
+			"stream/test/xxx": {
+				Converter:       &testConverter{"", frame},
+				FrameProcessors: []FrameProcessor{NewMultipleFrameProcessor(aggregate, &testProcessor{})},
+				FrameOutputters: []FrameOutputter{outputter},
+			},
+		},
+	})
+	require.NoError(t, err)
+	ok, err := p.ProcessInput(context.Background(), 1, "stream/test/xxx", []byte(`{}`))
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	select {
+	case frame := <-outputter.frames:
+		require.Equal(t, 1, frame.Rows())
+	case <-time.After(5 * time.Second):
+		t.Fatal("window was not flushed to the outputter")
+	}
+}
+
 func TestPipeline_OutputError(t *testing.T) {
 	boomErr := errors.New("boom")
 	outputter := &testOutputter{err: boomErr}
@@ -66,6 +66,22 @@ var ConvertersRegistry = []EntityInfo{
 		Type:        ConverterTypeJsonFrame,
 		Description: "JSON-encoded Grafana data frame",
 	},
+	{
+		Type:        ConverterTypePrometheus,
+		Description: "accept Prometheus text exposition format",
+	},
+	{
+		Type:        ConverterTypeOtlpJson,
+		Description: "accept OTLP metrics encoded as JSON",
+	},
+	{
+		Type:        ConverterTypeCsv,
+		Description: "accept CSV with a header row",
+		Example: CsvConverterConfig{
+			Delimiter: ",",
+			TimeField: "time",
+		},
+	},
 }
 
 var FrameProcessorsRegistry = []EntityInfo{
@@ -79,6 +95,17 @@ var FrameProcessorsRegistry = []EntityInfo{
 		Description: "list the fields that should be removed",
 		Example:     DropFieldsFrameProcessorConfig{},
 	},
+	{
+		Type:        FrameProcessorTypeAggregate,
+		Description: "aggregate frames over a tumbling or sliding window",
+		Example: AggregateFrameProcessorConfig{
+			WindowMilliseconds: 1000,
+			DefaultReducer:     AggregateReducerLast,
+			Fields: []AggregateFieldConfig{
+				{FieldName: "value", Reducer: AggregateReducerAvg},
+			},
+		},
+	},
 }
 
 var DataOutputsRegistry = []EntityInfo{
@@ -69,6 +69,21 @@ func (f *StorageRuleBuilder) extractConverter(config *ConverterConfig) (Converte
 			return nil, missingConfiguration
 		}
 		return NewAutoInfluxConverter(*config.AutoInfluxConverterConfig), nil
+	case ConverterTypePrometheus:
+		if config.PrometheusConverterConfig == nil {
+			config.PrometheusConverterConfig = &PrometheusConverterConfig{}
+		}
+		return NewPrometheusConverter(*config.PrometheusConverterConfig), nil
+	case ConverterTypeOtlpJson:
+		if config.OtlpJsonConverterConfig == nil {
+			config.OtlpJsonConverterConfig = &OtlpJsonConverterConfig{}
+		}
+		return NewOtlpJsonConverter(*config.OtlpJsonConverterConfig), nil
+	case ConverterTypeCsv:
+		if config.CsvConverterConfig == nil {
+			config.CsvConverterConfig = &CsvConverterConfig{}
+		}
+		return NewCsvConverter(*config.CsvConverterConfig), nil
 	default:
 		return nil, fmt.Errorf("unknown converter type: %s", config.Type)
 	}
@@ -90,6 +105,11 @@ func (f *StorageRuleBuilder) extractFrameProcessor(config *FrameProcessorConfig)
 			return nil, missingConfiguration
 		}
 		return NewKeepFieldsFrameProcessor(*config.KeepFieldsProcessorConfig), nil
+	case FrameProcessorTypeAggregate:
+		if config.AggregateProcessorConfig == nil {
+			return nil, missingConfiguration
+		}
+		return NewAggregateFrameProcessor(*config.AggregateProcessorConfig)
 	case FrameProcessorTypeMultiple:
 		if config.MultipleProcessorConfig == nil {
 			return nil, missingConfiguration
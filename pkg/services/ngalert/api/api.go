@@ -66,12 +66,16 @@ type API struct {
 	MultiOrgAlertmanager *notifier.MultiOrgAlertmanager
 	StateManager         *state.Manager
 	Scheduler            apiprometheus.StatusReader
+	ShardingStatus       ShardingStatusReader
+	EvaluationTraces     EvaluationTraceReader
 	AccessControl        ac.AccessControl
 	Policies             *provisioning.NotificationPolicyService
 	ReceiverService      *notifier.ReceiverService
 	ContactPointService  *provisioning.ContactPointService
 	Templates            *provisioning.TemplateService
 	MuteTimings          *provisioning.MuteTimingService
+	RecurringSilences    *provisioning.RecurringSilenceService
+	RuleTemplates        *provisioning.RuleTemplateService
 	AlertRules           *provisioning.AlertRuleService
 	AlertsRouter         *sender.AlertsRouter
 	EvaluatorFactory     eval.EvaluatorFactory
@@ -94,6 +98,19 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 		ac:        api.AccessControl,
 	}
 	ruleAuthzService := accesscontrol.NewRuleService(api.AccessControl)
+	silenceService := notifier.NewSilenceService(
+		accesscontrol.NewSilenceService(api.AccessControl, api.RuleStore),
+		api.TransactionManager,
+		logger,
+		api.MultiOrgAlertmanager,
+		api.RuleStore,
+		ruleAuthzService,
+	)
+	recurringSilenceService := notifier.NewRecurringSilenceService(
+		accesscontrol.NewSilenceService(api.AccessControl, api.RuleStore),
+		api.RecurringSilences,
+		logger,
+	)
 
 	convertSrv := NewConvertPrometheusSrv(
 		&api.Cfg.UnifiedAlerting,
@@ -110,20 +127,14 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 		api.DatasourceCache,
 		NewLotexAM(proxy, logger),
 		&AlertmanagerSrv{
-			crypto:         api.MultiOrgAlertmanager.Crypto,
-			log:            logger,
-			ac:             api.AccessControl,
-			mam:            api.MultiOrgAlertmanager,
-			featureManager: api.FeatureManager,
-			silenceSvc: notifier.NewSilenceService(
-				accesscontrol.NewSilenceService(api.AccessControl, api.RuleStore),
-				api.TransactionManager,
-				logger,
-				api.MultiOrgAlertmanager,
-				api.RuleStore,
-				ruleAuthzService,
-			),
-			receiverAuthz: accesscontrol.NewReceiverAccess[ReceiverStatus](api.AccessControl, false),
+			crypto:              api.MultiOrgAlertmanager.Crypto,
+			log:                 logger,
+			ac:                  api.AccessControl,
+			mam:                 api.MultiOrgAlertmanager,
+			featureManager:      api.FeatureManager,
+			silenceSvc:          silenceService,
+			recurringSilenceSvc: recurringSilenceService,
+			receiverAuthz:       accesscontrol.NewReceiverAccess[ReceiverStatus](api.AccessControl, false),
 		},
 		convertSrv,
 		api.FeatureManager,
@@ -151,6 +162,8 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 			amRefresher:        api.MultiOrgAlertmanager,
 			featureManager:     api.FeatureManager,
 			userService:        api.UserService,
+			datasourceCache:    api.DatasourceCache,
+			traces:             api.EvaluationTraces,
 		},
 	), m)
 	api.RegisterTestingApiEndpoints(NewTestingApi(
@@ -166,6 +179,8 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 			appUrl:          api.AppUrl,
 			tracer:          api.Tracer,
 			folderService:   api.RuleStore,
+			routing:         api.MultiOrgAlertmanager,
+			silenceSvc:      silenceService,
 		}), m)
 	api.RegisterConfigurationApiEndpoints(NewConfiguration(
 		&ConfigSrv{
@@ -174,6 +189,7 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 			log:                  logger,
 			alertmanagerProvider: api.AlertsRouter,
 			featureManager:       api.FeatureManager,
+			sharding:             api.ShardingStatus,
 		},
 	), m)
 
@@ -184,6 +200,7 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 		templates:           api.Templates,
 		muteTimings:         api.MuteTimings,
 		alertRules:          api.AlertRules,
+		ruleTemplates:       api.RuleTemplates,
 		// XXX: Used to flag recording rules, remove when FT is removed
 		featureManager: api.FeatureManager,
 	}), m)
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+// RecurringSilenceService is the service for managing and authenticating access to recurring silences in Grafana AM.
+type RecurringSilenceService interface {
+	GetRecurringSilence(ctx context.Context, user identity.Requester, uid string) (*models.RecurringSilence, error)
+	ListRecurringSilences(ctx context.Context, user identity.Requester) ([]*models.RecurringSilence, error)
+	CreateRecurringSilence(ctx context.Context, user identity.Requester, s models.RecurringSilence) (*models.RecurringSilence, error)
+	UpdateRecurringSilence(ctx context.Context, user identity.Requester, s models.RecurringSilence) (*models.RecurringSilence, error)
+	DeleteRecurringSilence(ctx context.Context, user identity.Requester, uid string) error
+}
+
+// RouteGetRecurringSilences is the recurring silence list GET endpoint for Grafana AM.
+func (srv AlertmanagerSrv) RouteGetRecurringSilences(c *contextmodel.ReqContext) response.Response {
+	silences, err := srv.recurringSilenceSvc.ListRecurringSilences(c.Req.Context(), c.SignedInUser)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to list recurring silences", err)
+	}
+	result := make(apimodels.GettableRecurringSilences, 0, len(silences))
+	for _, s := range silences {
+		result = append(result, RecurringSilenceToGettable(s))
+	}
+	return response.JSON(http.StatusOK, result)
+}
+
+// RouteGetRecurringSilence is the single recurring silence GET endpoint for Grafana AM.
+func (srv AlertmanagerSrv) RouteGetRecurringSilence(c *contextmodel.ReqContext, uid string) response.Response {
+	s, err := srv.recurringSilenceSvc.GetRecurringSilence(c.Req.Context(), c.SignedInUser, uid)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get recurring silence", err)
+	}
+	return response.JSON(http.StatusOK, RecurringSilenceToGettable(s))
+}
+
+// RouteCreateRecurringSilence is the recurring silence POST endpoint for Grafana AM.
+func (srv AlertmanagerSrv) RouteCreateRecurringSilence(c *contextmodel.ReqContext, body apimodels.PostableRecurringSilence) response.Response {
+	s := PostableToRecurringSilence(body)
+	if s.CreatedBy == "" {
+		s.CreatedBy = c.SignedInUser.GetLogin()
+	}
+	created, err := srv.recurringSilenceSvc.CreateRecurringSilence(c.Req.Context(), c.SignedInUser, s)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to create recurring silence", err)
+	}
+	return response.JSON(http.StatusCreated, RecurringSilenceToGettable(created))
+}
+
+// RoutePutRecurringSilence is the recurring silence PUT endpoint for Grafana AM.
+func (srv AlertmanagerSrv) RoutePutRecurringSilence(c *contextmodel.ReqContext, body apimodels.PostableRecurringSilence, uid string) response.Response {
+	s := PostableToRecurringSilence(body)
+	s.UID = uid
+	if s.CreatedBy == "" {
+		s.CreatedBy = c.SignedInUser.GetLogin()
+	}
+	updated, err := srv.recurringSilenceSvc.UpdateRecurringSilence(c.Req.Context(), c.SignedInUser, s)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to update recurring silence", err)
+	}
+	return response.JSON(http.StatusAccepted, RecurringSilenceToGettable(updated))
+}
+
+// RouteDeleteRecurringSilence is the recurring silence DELETE endpoint for Grafana AM.
+func (srv AlertmanagerSrv) RouteDeleteRecurringSilence(c *contextmodel.ReqContext, uid string) response.Response {
+	if err := srv.recurringSilenceSvc.DeleteRecurringSilence(c.Req.Context(), c.SignedInUser, uid); err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to delete recurring silence", err)
+	}
+	return response.JSON(http.StatusOK, util.DynMap{"message": "recurring silence deleted"})
+}
+
+func PostableToRecurringSilence(s apimodels.PostableRecurringSilence) models.RecurringSilence {
+	return models.RecurringSilence{
+		UID:       s.UID,
+		Matchers:  s.Matchers,
+		Comment:   s.Comment,
+		CreatedBy: s.CreatedBy,
+		Schedule: models.RecurringSilenceSchedule{
+			Cron:          s.Schedule.Cron,
+			Duration:      time.Duration(s.Schedule.Duration),
+			TimeIntervals: s.Schedule.TimeIntervals,
+		},
+		Version: s.Version,
+	}
+}
+
+func RecurringSilenceToGettable(s *models.RecurringSilence) apimodels.GettableRecurringSilence {
+	result := apimodels.GettableRecurringSilence{
+		PostableRecurringSilence: apimodels.PostableRecurringSilence{
+			UID:       s.UID,
+			Matchers:  s.Matchers,
+			Comment:   s.Comment,
+			CreatedBy: s.CreatedBy,
+			Schedule: apimodels.RecurringSilenceSchedule{
+				Cron:          s.Schedule.Cron,
+				Duration:      model.Duration(s.Schedule.Duration),
+				TimeIntervals: s.Schedule.TimeIntervals,
+			},
+			Version: s.Version,
+		},
+		Provenance: apimodels.Provenance(s.Provenance),
+		UpdatedAt:  s.Updated,
+	}
+	// The silence of a previous version is replaced when the schedule is next checked, so it is not returned.
+	if s.Window.SilenceID != "" && s.Window.Version == s.Version {
+		result.Silence = &apimodels.RecurringSilenceWindow{
+			ID:       s.Window.SilenceID,
+			StartsAt: s.Window.StartsAt,
+			EndsAt:   s.Window.EndsAt,
+		}
+	}
+	return result
+}
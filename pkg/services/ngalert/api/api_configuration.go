@@ -15,17 +15,24 @@ import (
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/schedule"
 	"github.com/grafana/grafana/pkg/services/ngalert/store"
 	"github.com/grafana/grafana/pkg/services/org"
 	"github.com/grafana/grafana/pkg/util"
 )
 
+// ShardingStatusReader provides the assignment of rule groups to the replicas of the HA cluster.
+type ShardingStatusReader interface {
+	ShardingStatus(orgID int64) schedule.ShardingStatus
+}
+
 type ConfigSrv struct {
 	datasourceService    datasources.DataSourceService
 	alertmanagerProvider ExternalAlertmanagerProvider
 	store                store.AdminConfigurationStore
 	log                  log.Logger
 	featureManager       featuremgmt.FeatureToggles
+	sharding             ShardingStatusReader
 }
 
 func (srv ConfigSrv) RouteGetAlertmanagers(c *contextmodel.ReqContext) response.Response {
@@ -45,6 +52,29 @@ func (srv ConfigSrv) RouteGetAlertmanagers(c *contextmodel.ReqContext) response.
 	})
 }
 
+func (srv ConfigSrv) RouteGetSchedulerShards(c *contextmodel.ReqContext) response.Response {
+	resp := apimodels.GettableSchedulerShards{
+		Members: []string{},
+		Groups:  []apimodels.SchedulerShardedGroup{},
+	}
+	if srv.sharding == nil {
+		return response.JSON(http.StatusOK, resp)
+	}
+	status := srv.sharding.ShardingStatus(c.GetOrgID())
+	resp.Enabled = status.Enabled
+	resp.Self = status.Self
+	resp.Members = append(resp.Members, status.Members...)
+	for _, g := range status.Groups {
+		resp.Groups = append(resp.Groups, apimodels.SchedulerShardedGroup{
+			FolderUID: g.NamespaceUID,
+			RuleGroup: g.RuleGroup,
+			Owner:     g.Owner,
+			Rules:     g.Rules,
+		})
+	}
+	return response.JSON(http.StatusOK, resp)
+}
+
 func (srv ConfigSrv) RouteGetNGalertConfig(c *contextmodel.ReqContext) response.Response {
 	if c.GetOrgRole() != org.RoleAdmin {
 		return accessForbiddenResp()
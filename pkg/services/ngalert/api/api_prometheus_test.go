@@ -283,6 +283,19 @@ func withAlertingState() forEachState {
 	}
 }
 
+func withSuppressedState() forEachState {
+	return func(s *state.State) *state.State {
+		s.State = eval.Suppressed
+		s.LatestResult = &state.Evaluation{
+			EvaluationState: eval.Alerting,
+			EvaluationTime:  timeNow(),
+			Values:          map[string]float64{"B": float64(1.1)},
+			Condition:       "B",
+		}
+		return s
+	}
+}
+
 func withRecoveringState() forEachState {
 	return func(s *state.State) *state.State {
 		s.State = eval.Recovering
@@ -733,6 +746,38 @@ func TestRouteGetRuleStatuses(t *testing.T) {
 		})
 	})
 
+	t.Run("with a suppressed alert", func(t *testing.T) {
+		gen := ngmodels.RuleGen
+		fakeStore, fakeAIM, api := setupAPI(t)
+		rule := gen.With(gen.WithOrgID(orgID), asFixture(), withClassicConditionSingleQuery()).GenerateRef()
+		fakeAIM.GenerateAlertInstances(orgID, rule.UID, 1, withSuppressedState())
+		fakeStore.PutRule(context.Background(), rule)
+
+		req, err := http.NewRequest("GET", "/api/v1/rules?state=suppressed", nil)
+		require.NoError(t, err)
+		c := &contextmodel.ReqContext{
+			Context: &web.Context{Req: req},
+			SignedInUser: &user.SignedInUser{
+				OrgID:       orgID,
+				Permissions: queryPermissions,
+			},
+		}
+		r := api.RouteGetRuleStatuses(c)
+		require.Equal(t, http.StatusOK, r.Status())
+
+		var res apimodels.RuleResponse
+		require.NoError(t, json.Unmarshal(r.Body(), &res))
+
+		require.Equal(t, map[string]int64{"suppressed": 1}, res.Data.Totals)
+		require.Len(t, res.Data.RuleGroups, 1)
+		rg := res.Data.RuleGroups[0]
+		require.Len(t, rg.Rules, 1)
+		require.Equal(t, "suppressed", rg.Rules[0].State)
+		require.Equal(t, map[string]int64{"suppressed": 1}, rg.Rules[0].Totals)
+		require.Len(t, rg.Rules[0].Alerts, 1)
+		require.Equal(t, "Suppressed", rg.Rules[0].Alerts[0].State)
+	})
+
 	t.Run("with many rules in a group", func(t *testing.T) {
 		t.Run("should return sorted", func(t *testing.T) {
 			ruleStore := fakes.NewRuleStore(t)
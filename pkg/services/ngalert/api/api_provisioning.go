@@ -34,6 +34,7 @@ type ProvisioningSrv struct {
 	templates           TemplateService
 	muteTimings         MuteTimingService
 	alertRules          AlertRuleService
+	ruleTemplates       RuleTemplateService
 	folderSvc           folder.Service
 
 	// XXX: Used to flag recording rules, remove when FT is removed
@@ -83,6 +84,14 @@ type AlertRuleService interface {
 	GetAlertGroupsWithFolderFullpath(ctx context.Context, u identity.Requester, opts *provisioning.FilterOptions) ([]alerting_models.AlertRuleGroupWithFolderFullpath, error)
 }
 
+type RuleTemplateService interface {
+	GetRuleTemplates(ctx context.Context, orgID int64) ([]*alerting_models.RuleTemplate, error)
+	GetRuleTemplate(ctx context.Context, orgID int64, uid string) (*alerting_models.RuleTemplate, error)
+	CreateRuleTemplate(ctx context.Context, user identity.Requester, t alerting_models.RuleTemplate, provenance alerting_models.Provenance) (*alerting_models.RuleTemplate, error)
+	UpdateRuleTemplate(ctx context.Context, user identity.Requester, t alerting_models.RuleTemplate, provenance alerting_models.Provenance) (*alerting_models.RuleTemplate, error)
+	DeleteRuleTemplate(ctx context.Context, user identity.Requester, orgID int64, uid string, provenance alerting_models.Provenance) error
+}
+
 func (srv *ProvisioningSrv) RouteGetPolicyTree(c *contextmodel.ReqContext) response.Response {
 	policies, _, err := srv.policies.GetPolicyTree(c.Req.Context(), c.GetOrgID())
 	if errors.Is(err, store.ErrNoAlertmanagerConfiguration) {
@@ -517,6 +526,72 @@ func (srv *ProvisioningSrv) RouteDeleteAlertRuleGroup(c *contextmodel.ReqContext
 	return response.JSON(http.StatusNoContent, "")
 }
 
+func (srv *ProvisioningSrv) RouteGetRuleTemplates(c *contextmodel.ReqContext) response.Response {
+	templates, err := srv.ruleTemplates.GetRuleTemplates(c.Req.Context(), c.GetOrgID())
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get rule templates", err)
+	}
+	result := make(definitions.RuleTemplates, 0, len(templates))
+	for _, t := range templates {
+		result = append(result, ApiRuleTemplateFromRuleTemplate(t))
+	}
+	return response.JSON(http.StatusOK, result)
+}
+
+func (srv *ProvisioningSrv) RouteGetRuleTemplate(c *contextmodel.ReqContext, UID string) response.Response {
+	t, err := srv.ruleTemplates.GetRuleTemplate(c.Req.Context(), c.GetOrgID(), UID)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get rule template", err)
+	}
+	return response.JSON(http.StatusOK, ApiRuleTemplateFromRuleTemplate(t))
+}
+
+func (srv *ProvisioningSrv) RoutePostRuleTemplate(c *contextmodel.ReqContext, body definitions.RuleTemplate) response.Response {
+	t := RuleTemplateFromApiRuleTemplate(body)
+	t.OrgID = c.GetOrgID()
+	provenance := determineProvenance(c)
+	created, err := srv.ruleTemplates.CreateRuleTemplate(c.Req.Context(), c.SignedInUser, t, alerting_models.Provenance(provenance))
+	if err != nil {
+		return ruleTemplateErrorResponse(err, "failed to create rule template")
+	}
+	return response.JSON(http.StatusCreated, ApiRuleTemplateFromRuleTemplate(created))
+}
+
+func (srv *ProvisioningSrv) RoutePutRuleTemplate(c *contextmodel.ReqContext, body definitions.RuleTemplate, UID string) response.Response {
+	t := RuleTemplateFromApiRuleTemplate(body)
+	t.OrgID = c.GetOrgID()
+	t.UID = UID
+	provenance := determineProvenance(c)
+	updated, err := srv.ruleTemplates.UpdateRuleTemplate(c.Req.Context(), c.SignedInUser, t, alerting_models.Provenance(provenance))
+	if err != nil {
+		return ruleTemplateErrorResponse(err, "failed to update rule template")
+	}
+	return response.JSON(http.StatusOK, ApiRuleTemplateFromRuleTemplate(updated))
+}
+
+func (srv *ProvisioningSrv) RouteDeleteRuleTemplate(c *contextmodel.ReqContext, UID string) response.Response {
+	provenance := determineProvenance(c)
+	err := srv.ruleTemplates.DeleteRuleTemplate(c.Req.Context(), c.SignedInUser, c.GetOrgID(), UID, alerting_models.Provenance(provenance))
+	if err != nil {
+		return ruleTemplateErrorResponse(err, "failed to delete rule template")
+	}
+	return response.JSON(http.StatusNoContent, "")
+}
+
+// ruleTemplateErrorResponse converts the errors of the alert rules of a rule template that are not public errors.
+func ruleTemplateErrorResponse(err error, message string) response.Response {
+	if errors.Is(err, alerting_models.ErrAlertRuleFailedValidation) {
+		return ErrResp(http.StatusBadRequest, err, "")
+	}
+	if errors.Is(err, store.ErrOptimisticLock) {
+		return ErrResp(http.StatusConflict, err, "")
+	}
+	if errors.Is(err, alerting_models.ErrQuotaReached) {
+		return ErrResp(http.StatusForbidden, err, "")
+	}
+	return response.ErrOrFallback(http.StatusInternalServerError, message, err)
+}
+
 func determineProvenance(ctx *contextmodel.ReqContext) definitions.Provenance {
 	if _, disabled := ctx.Req.Header[disableProvenanceHeaderName]; disabled {
 		return definitions.Provenance(alerting_models.ProvenanceNone)
@@ -21,6 +21,7 @@ import (
 	"github.com/grafana/grafana/pkg/infra/log"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
 	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/datasources"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	authz "github.com/grafana/grafana/pkg/services/ngalert/accesscontrol"
 	. "github.com/grafana/grafana/pkg/services/ngalert/api/compat"
@@ -50,6 +51,11 @@ type AMRefresher interface {
 	ApplyConfig(ctx context.Context, orgId int64, dbConfig *ngmodels.AlertConfiguration) error
 }
 
+// EvaluationTraceReader provides the most recent evaluations of the rules evaluated by this instance.
+type EvaluationTraceReader interface {
+	EvaluationTraces(key ngmodels.AlertRuleKey) ([]ngmodels.EvaluationTrace, bool)
+}
+
 type RulerSrv struct {
 	xactManager        provisioning.TransactionManager
 	provenanceStore    provisioning.ProvisioningStore
@@ -60,10 +66,12 @@ type RulerSrv struct {
 	conditionValidator ConditionValidator
 	authz              RuleAccessControlService
 	userService        user.Service
+	datasourceCache    datasources.CacheService
 
 	amConfigStore  AMConfigStore
 	amRefresher    AMRefresher
 	featureManager featuremgmt.FeatureToggles
+	traces         EvaluationTraceReader
 }
 
 var (
@@ -392,6 +400,29 @@ func (srv RulerSrv) RouteGetRuleVersionsByUID(c *contextmodel.ReqContext, ruleUI
 	return response.JSON(http.StatusOK, result)
 }
 
+// RouteGetRuleEvaluationTracesByUID returns the most recent evaluations of the rule with the given UID.
+// It returns nothing if the rule is not evaluated by this instance or if evaluation traces are disabled.
+func (srv RulerSrv) RouteGetRuleEvaluationTracesByUID(c *contextmodel.ReqContext, ruleUID string) response.Response {
+	ctx := c.Req.Context()
+	rule, err := srv.getAuthorizedRuleByUid(ctx, c, ruleUID)
+	if err != nil {
+		if errors.Is(err, ngmodels.ErrAlertRuleNotFound) {
+			return response.Empty(http.StatusNotFound)
+		}
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get rule by UID", err)
+	}
+
+	result := apimodels.GettableEvaluationTraces{}
+	if srv.traces == nil {
+		return response.JSON(http.StatusOK, result)
+	}
+	traces, _ := srv.traces.EvaluationTraces(rule.GetKey())
+	for _, trace := range traces {
+		result = append(result, toGettableEvaluationTrace(trace))
+	}
+	return response.JSON(http.StatusOK, result)
+}
+
 func (srv RulerSrv) RoutePostNameRulesConfig(c *contextmodel.ReqContext, ruleGroupConfig apimodels.PostableRuleGroupConfig, namespaceUID string) response.Response {
 	var deletePermanently bool
 	if c.QueryBool("deletePermanently") {
@@ -670,6 +701,7 @@ func toGettableExtendedRuleNode(r ngmodels.AlertRule, provenanceRecords map[stri
 			Metadata:                    AlertRuleMetadataFromModelMetadata(r.Metadata),
 			GUID:                        r.GUID,
 			MissingSeriesEvalsToResolve: r.MissingSeriesEvalsToResolve,
+			Dependencies:                ApiDependenciesFromModelDependencies(r.Dependencies),
 		},
 	}
 	forDuration := model.Duration(r.For)
@@ -683,6 +715,33 @@ func toGettableExtendedRuleNode(r ngmodels.AlertRule, provenanceRecords map[stri
 	return gettableExtendedRuleNode
 }
 
+func toGettableEvaluationTrace(trace ngmodels.EvaluationTrace) apimodels.GettableEvaluationTrace {
+	result := apimodels.GettableEvaluationTrace{
+		EvaluatedAt:    trace.EvaluatedAt,
+		Attempt:        trace.Attempt,
+		EvaluationTime: trace.Duration.Seconds(),
+		States:         trace.States,
+		Nodes:          make([]apimodels.GettableEvaluationTraceNode, 0, len(trace.Nodes)),
+	}
+	if trace.Error != nil {
+		result.Error = trace.Error.Error()
+	}
+	for _, node := range trace.Nodes {
+		n := apimodels.GettableEvaluationTraceNode{
+			RefID:            node.RefID,
+			EvaluationTime:   node.Duration.Seconds(),
+			DatasourceFrames: node.DatasourceFrames,
+			Frames:           node.Frames,
+			Truncated:        node.Truncated,
+		}
+		if node.Error != nil {
+			n.Error = node.Error.Error()
+		}
+		result.Nodes = append(result.Nodes, n)
+	}
+	return result
+}
+
 func toNamespaceErrorResponse(err error) response.Response {
 	if errors.Is(err, ngmodels.ErrCannotEditNamespace) {
 		return ErrResp(http.StatusForbidden, err, err.Error())
@@ -855,6 +914,7 @@ func (srv RulerSrv) RouteUpdateNamespaceRules(c *contextmodel.ReqContext, body a
 					AlertRule:         *rule,
 					HasPause:          true,
 					HasEditorSettings: true,
+					HasDependencies:   true,
 				}
 				if body.IsPaused != nil {
 					paused := *body.IsPaused
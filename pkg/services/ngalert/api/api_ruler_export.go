@@ -4,15 +4,20 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+
+	"gopkg.in/yaml.v3"
 
 	"github.com/grafana/grafana/pkg/api/response"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/datasources"
 
 	authz "github.com/grafana/grafana/pkg/services/ngalert/accesscontrol"
 	. "github.com/grafana/grafana/pkg/services/ngalert/api/compat"
 	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	apivalidation "github.com/grafana/grafana/pkg/services/ngalert/api/validation"
 	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/prom"
 )
 
 // ExportFromPayload converts the rule groups from the argument `ruleGroupConfig` to export format. All rules are expected to be fully specified. The access to data sources mentioned in the rules is not enforced.
@@ -52,6 +57,28 @@ func (srv RulerSrv) ExportRules(c *contextmodel.ReqContext) response.Response {
 	// The similar method exists in provisioning (see ProvisioningSrv.RouteGetAlertRulesExport).
 	// Modification to parameters and response format should be made in these two methods at the same time.
 
+	groups, errResp := srv.getRuleGroupsForExport(c)
+	if errResp != nil {
+		return errResp
+	}
+
+	if len(groups) == 0 {
+		return response.Empty(http.StatusNotFound)
+	}
+
+	// sort result so the response is always stable
+	ngmodels.SortAlertRuleGroupWithFolderTitle(groups)
+
+	e, err := AlertingFileExportFromAlertRuleGroupWithFolderFullpath(groups)
+	if err != nil {
+		return ErrResp(http.StatusInternalServerError, err, "failed to create alerting file export")
+	}
+	return exportResponse(c, e)
+}
+
+// getRuleGroupsForExport reads the rule groups the user has access to according to the filters in the query parameters
+// folderUid, group and ruleUid.
+func (srv RulerSrv) getRuleGroupsForExport(c *contextmodel.ReqContext) ([]ngmodels.AlertRuleGroupWithFolderFullpath, response.Response) {
 	folderUIDs := c.QueryStrings("folderUid")
 	group := c.Query("group")
 	uid := c.Query("ruleUid")
@@ -59,16 +86,16 @@ func (srv RulerSrv) ExportRules(c *contextmodel.ReqContext) response.Response {
 	var groups []ngmodels.AlertRuleGroupWithFolderFullpath
 	if uid != "" {
 		if group != "" || len(folderUIDs) > 0 {
-			return ErrResp(http.StatusBadRequest, errors.New("group and folder should not be specified when a single rule is requested"), "")
+			return nil, ErrResp(http.StatusBadRequest, errors.New("group and folder should not be specified when a single rule is requested"), "")
 		}
 		rulesGroup, err := srv.getRuleWithFolderFullpathByRuleUid(c, uid)
 		if err != nil {
-			return errorToResponse(err)
+			return nil, errorToResponse(err)
 		}
 		groups = []ngmodels.AlertRuleGroupWithFolderFullpath{rulesGroup}
 	} else if group != "" {
 		if len(folderUIDs) != 1 || folderUIDs[0] == "" {
-			return ErrResp(http.StatusBadRequest,
+			return nil, ErrResp(http.StatusBadRequest,
 				fmt.Errorf("group name must be specified together with a single folder_uid parameter. Got %d", len(folderUIDs)),
 				"",
 			)
@@ -79,29 +106,18 @@ func (srv RulerSrv) ExportRules(c *contextmodel.ReqContext) response.Response {
 			RuleGroup:    group,
 		})
 		if err != nil {
-			return errorToResponse(err)
+			return nil, errorToResponse(err)
 		}
 		groups = []ngmodels.AlertRuleGroupWithFolderFullpath{rulesGroup}
 	} else {
 		var err error
 		groups, err = srv.getRulesWithFolderFullPathInFolders(c, folderUIDs)
 		if err != nil {
-			return errorToResponse(err)
+			return nil, errorToResponse(err)
 		}
 	}
 
-	if len(groups) == 0 {
-		return response.Empty(http.StatusNotFound)
-	}
-
-	// sort result so the response is always stable
-	ngmodels.SortAlertRuleGroupWithFolderTitle(groups)
-
-	e, err := AlertingFileExportFromAlertRuleGroupWithFolderFullpath(groups)
-	if err != nil {
-		return ErrResp(http.StatusInternalServerError, err, "failed to create alerting file export")
-	}
-	return exportResponse(c, e)
+	return groups, nil
 }
 
 // getRuleWithFolderFullpathByRuleUid calls getAuthorizedRuleByUid and combines its result with folder (aka namespace) title.
@@ -177,3 +193,125 @@ func (srv RulerSrv) getRulesWithFolderFullPathInFolders(c *contextmodel.ReqConte
 	}
 	return result, nil
 }
+
+// ExportRulesToPrometheus reads alert rules that user has access to from database according to the filters
+// and converts them to a Prometheus rule file. Rules that cannot be represented in the Prometheus format
+// are reported in the response instead.
+func (srv RulerSrv) ExportRulesToPrometheus(c *contextmodel.ReqContext) response.Response {
+	groups, errResp := srv.getRuleGroupsForExport(c)
+	if errResp != nil {
+		return errResp
+	}
+
+	if len(groups) == 0 {
+		return response.Empty(http.StatusNotFound)
+	}
+
+	// sort result so the response is always stable
+	ngmodels.SortAlertRuleGroupWithFolderTitle(groups)
+
+	dsType := func(uid string) (string, error) {
+		if srv.datasourceCache == nil {
+			return "", datasources.ErrDataSourceNotFound
+		}
+		ds, err := srv.datasourceCache.GetDatasourceByUID(c.Req.Context(), uid, c.SignedInUser, false)
+		if err != nil {
+			return "", err
+		}
+		return ds.Type, nil
+	}
+
+	result := apimodels.PrometheusRulesExport{
+		Groups: make([]apimodels.PrometheusRuleGroup, 0, len(groups)),
+	}
+	names := make(map[string]struct{}, len(groups))
+	for _, group := range groups {
+		promGroup, unsupported, err := prom.GrafanaRuleGroupToPrometheus(group.Title, group.Rules, dsType)
+		if err != nil {
+			return ErrResp(http.StatusInternalServerError, err, "failed to convert rules to Prometheus format")
+		}
+		for _, r := range unsupported {
+			result.Unsupported = append(result.Unsupported, apimodels.PrometheusUnsupportedRule{
+				UID:       r.UID,
+				Title:     r.Title,
+				FolderUID: group.FolderUID,
+				Group:     r.Group,
+				Reason:    r.Reason,
+			})
+		}
+		if len(promGroup.Rules) == 0 {
+			continue
+		}
+		// Group names must be unique in a Prometheus rule file, but groups in different folders can have the same name.
+		if _, ok := names[promGroup.Name]; ok {
+			promGroup.Name = fmt.Sprintf("%s (%s)", promGroup.Name, group.FolderFullpath)
+		}
+		names[promGroup.Name] = struct{}{}
+		result.Groups = append(result.Groups, prometheusRuleGroupToAPI(promGroup))
+	}
+
+	return prometheusExportResponse(c, result)
+}
+
+func prometheusRuleGroupToAPI(group prom.PrometheusRuleGroup) apimodels.PrometheusRuleGroup {
+	rules := make([]apimodels.PrometheusRule, len(group.Rules))
+	for i, r := range group.Rules {
+		rules[i] = apimodels.PrometheusRule{
+			Alert:         r.Alert,
+			Expr:          r.Expr,
+			For:           r.For,
+			KeepFiringFor: r.KeepFiringFor,
+			Labels:        r.Labels,
+			Annotations:   r.Annotations,
+			Record:        r.Record,
+		}
+	}
+	return apimodels.PrometheusRuleGroup{
+		Name:        group.Name,
+		Interval:    group.Interval,
+		QueryOffset: group.QueryOffset,
+		Limit:       group.Limit,
+		Rules:       rules,
+		Labels:      group.Labels,
+	}
+}
+
+// prometheusExportResponse returns the export as a Prometheus rule file in YAML, where the rules that
+// could not be exported are listed in a comment, or as JSON if requested.
+func prometheusExportResponse(c *contextmodel.ReqContext, body apimodels.PrometheusRulesExport) response.Response {
+	format := "yaml"
+	if strings.Contains(c.Req.Header.Get("Accept"), "json") {
+		format = "json"
+	}
+	if f := c.Query("format"); f == "yaml" || f == "json" {
+		format = f
+	}
+	download := c.QueryBoolWithDefault("download", false)
+
+	if format == "json" {
+		if download {
+			return response.JSONDownload(http.StatusOK, body, "rules.json")
+		}
+		return response.JSON(http.StatusOK, body)
+	}
+
+	b, err := yaml.Marshal(body)
+	if err != nil {
+		return ErrResp(http.StatusInternalServerError, err, "failed to marshal Prometheus rule file")
+	}
+	if len(body.Unsupported) > 0 {
+		var header strings.Builder
+		header.WriteString("# The following rules cannot be represented in the Prometheus rule format and are not exported:\n")
+		for _, r := range body.Unsupported {
+			fmt.Fprintf(&header, "# - %q (uid: %s, group: %s): %s\n", r.Title, r.UID, r.Group, r.Reason)
+		}
+		b = append([]byte(header.String()), b...)
+	}
+
+	resp := response.Respond(http.StatusOK, b).SetHeader("Content-Type", "text/yaml")
+	if download {
+		resp = resp.SetHeader("Content-Type", "application/yaml").
+			SetHeader("Content-Disposition", `attachment;filename="rules.yaml"`)
+	}
+	return resp
+}
@@ -11,14 +11,17 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/datasources"
+	dsfakes "github.com/grafana/grafana/pkg/services/datasources/fakes"
 	folder2 "github.com/grafana/grafana/pkg/services/folder"
 	. "github.com/grafana/grafana/pkg/services/ngalert/api/compat"
 	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
@@ -439,3 +442,116 @@ func TestExportRules(t *testing.T) {
 		})
 	}
 }
+
+func TestExportRulesToPrometheus(t *testing.T) {
+	orgID := int64(1)
+	f1 := randFolder()
+	f2 := randFolder()
+
+	ruleStore := fakes.NewRuleStore(t)
+
+	promQuery := ngmodels.AlertQuery{
+		RefID:         "A",
+		DatasourceUID: "prom-uid",
+		Model:         json.RawMessage(`{"refId":"A","expr":"up == 0","instant":true}`),
+	}
+	lokiQuery := ngmodels.AlertQuery{
+		RefID:         "A",
+		DatasourceUID: "loki-uid",
+		Model:         json.RawMessage(`{"refId":"A","expr":"count_over_time({job=\"app\"}[5m])","instant":true}`),
+	}
+
+	gen := ngmodels.RuleGen
+	common := gen.With(
+		gen.WithOrgID(orgID),
+		gen.WithGroupName("group"),
+		gen.WithCondition("A"),
+		gen.WithLabels(map[string]string{"severity": "critical"}),
+		gen.WithAnnotations(nil),
+		gen.WithFor(time.Minute),
+		gen.WithKeepFiringFor(0),
+		gen.WithIntervalSeconds(60),
+		gen.WithUniqueGroupIndex(),
+	)
+	supported1 := common.With(gen.WithNamespaceUID(f1.UID), gen.WithQuery(promQuery), gen.WithTitle("supported-1")).GenerateRef()
+	unsupported := common.With(gen.WithNamespaceUID(f1.UID), gen.WithQuery(lokiQuery), gen.WithTitle("unsupported")).GenerateRef()
+	supported2 := common.With(gen.WithNamespaceUID(f2.UID), gen.WithQuery(promQuery), gen.WithTitle("supported-2")).GenerateRef()
+	ruleStore.PutRule(context.Background(), supported1, unsupported, supported2)
+	ruleStore.Folders[orgID] = []*folder2.Folder{f1, f2}
+
+	srv := createService(ruleStore, nil)
+	srv.datasourceCache = &dsfakes.FakeCacheService{DataSources: []*datasources.DataSource{
+		{UID: "prom-uid", Type: datasources.DS_PROMETHEUS},
+		{UID: "loki-uid", Type: datasources.DS_LOKI},
+	}}
+
+	createContext := func(params url.Values) *contextmodel.ReqContext {
+		rc := createRequestContextWithPerms(orgID, map[int64]map[string][]string{
+			orgID: {
+				dashboards.ActionFoldersRead:         []string{dashboards.ScopeFoldersProvider.GetResourceScopeUID(f1.UID), dashboards.ScopeFoldersProvider.GetResourceScopeUID(f2.UID)},
+				accesscontrol.ActionAlertingRuleRead: []string{dashboards.ScopeFoldersProvider.GetResourceScopeUID(f1.UID), dashboards.ScopeFoldersProvider.GetResourceScopeUID(f2.UID)},
+				datasources.ActionQuery:              []string{datasources.ScopeAll},
+			},
+		}, nil)
+		rc.Req.Form = params
+		return rc
+	}
+
+	t.Run("returns JSON with unsupported rules", func(t *testing.T) {
+		rc := createContext(url.Values{"format": []string{"json"}})
+		resp := srv.ExportRulesToPrometheus(rc)
+		require.Equal(t, http.StatusOK, resp.Status())
+
+		var result apimodels.PrometheusRulesExport
+		require.NoError(t, json.Unmarshal(resp.Body(), &result))
+
+		require.Len(t, result.Groups, 2)
+		names := []string{result.Groups[0].Name, result.Groups[1].Name}
+		require.Contains(t, names, "group")
+		// The second group with the same name is renamed because group names must be unique.
+		require.Condition(t, func() bool {
+			return names[0] != names[1] && (strings.HasPrefix(names[0], "group (") || strings.HasPrefix(names[1], "group ("))
+		})
+		for _, g := range result.Groups {
+			require.Len(t, g.Rules, 1)
+			require.Equal(t, "(up == 0) != 0", g.Rules[0].Expr)
+			require.Equal(t, map[string]string{"severity": "critical"}, g.Rules[0].Labels)
+		}
+
+		require.Equal(t, []apimodels.PrometheusUnsupportedRule{{
+			UID:       unsupported.UID,
+			Title:     unsupported.Title,
+			FolderUID: f1.UID,
+			Group:     "group",
+			Reason:    `queries to data sources of type "loki" are not supported`,
+		}}, result.Unsupported)
+	})
+
+	t.Run("returns YAML rule file with unsupported rules in a comment", func(t *testing.T) {
+		rc := createContext(url.Values{"folderUid": []string{f1.UID}})
+		resp := srv.ExportRulesToPrometheus(rc)
+		require.Equal(t, http.StatusOK, resp.Status())
+
+		body := string(resp.Body())
+		require.True(t, strings.HasPrefix(body, "# The following rules cannot be represented"))
+		require.Contains(t, body, unsupported.UID)
+
+		var result apimodels.PrometheusRulesExport
+		require.NoError(t, yaml.Unmarshal(resp.Body(), &result))
+		require.Len(t, result.Groups, 1)
+		require.Equal(t, "group", result.Groups[0].Name)
+		require.Equal(t, "supported-1", result.Groups[0].Rules[0].Alert)
+
+		resp.WriteTo(rc)
+		require.Equal(t, "text/yaml", rc.Resp.Header().Get("Content-Type"))
+	})
+
+	t.Run("returns file if download is requested", func(t *testing.T) {
+		rc := createContext(url.Values{"download": []string{"true"}})
+		resp := srv.ExportRulesToPrometheus(rc)
+		require.Equal(t, http.StatusOK, resp.Status())
+
+		resp.WriteTo(rc)
+		require.Equal(t, `attachment;filename="rules.yaml"`, rc.Resp.Header().Get("Content-Disposition"))
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -654,6 +655,110 @@ func TestRouteGetRuleVersionsByUID(t *testing.T) {
 	})
 }
 
+func TestRouteGetRuleEvaluationTracesByUID(t *testing.T) {
+	orgID := rand.Int63()
+	f := randFolder()
+	groupKey := models.GenerateGroupKey(orgID)
+	groupKey.NamespaceUID = f.UID
+	gen := models.RuleGen.With(models.RuleGen.WithGroupKey(groupKey), models.RuleGen.WithUniqueID())
+
+	rule := gen.GenerateRef()
+	traces := &fakeEvaluationTraceReader{traces: map[models.AlertRuleKey][]models.EvaluationTrace{
+		rule.GetKey(): {
+			{
+				EvaluatedAt: time.Unix(20, 0).UTC(),
+				Attempt:     2,
+				Duration:    1500 * time.Millisecond,
+				States:      map[string]int{"Alerting": 1},
+				Nodes: []models.EvaluationTraceNode{
+					{RefID: "A", Duration: time.Second, DatasourceFrames: data.Frames{data.NewFrame("raw")}, Frames: data.Frames{data.NewFrame("A")}},
+					{RefID: "B", Error: errors.New("failed")},
+				},
+			},
+			{EvaluatedAt: time.Unix(10, 0).UTC(), Attempt: 1, Error: errors.New("timeout")},
+		},
+	}}
+
+	t.Run("returns evaluation traces of the rule", func(t *testing.T) {
+		ruleStore := fakes.NewRuleStore(t)
+		ruleStore.Folders[orgID] = append(ruleStore.Folders[orgID], f)
+		ruleStore.PutRule(context.Background(), rule)
+
+		req := createRequestContextWithPerms(orgID, createPermissionsForRules([]*models.AlertRule{rule}, orgID), nil)
+		svc := createService(ruleStore, nil)
+		svc.traces = traces
+		response := svc.RouteGetRuleEvaluationTracesByUID(req, rule.UID)
+
+		require.Equal(t, http.StatusOK, response.Status())
+		var result apimodels.GettableEvaluationTraces
+		require.NoError(t, json.Unmarshal(response.Body(), &result))
+		require.Len(t, result, 2)
+
+		assert.Equal(t, time.Unix(20, 0).UTC(), result[0].EvaluatedAt)
+		assert.EqualValues(t, 2, result[0].Attempt)
+		assert.Equal(t, 1.5, result[0].EvaluationTime)
+		assert.Empty(t, result[0].Error)
+		assert.Equal(t, map[string]int{"Alerting": 1}, result[0].States)
+		require.Len(t, result[0].Nodes, 2)
+		assert.Equal(t, "A", result[0].Nodes[0].RefID)
+		assert.Equal(t, 1.0, result[0].Nodes[0].EvaluationTime)
+		require.Len(t, result[0].Nodes[0].DatasourceFrames, 1)
+		assert.Equal(t, "raw", result[0].Nodes[0].DatasourceFrames[0].Name)
+		require.Len(t, result[0].Nodes[0].Frames, 1)
+		assert.Equal(t, "failed", result[0].Nodes[1].Error)
+		assert.Equal(t, "timeout", result[1].Error)
+	})
+
+	t.Run("empty result if rule is not evaluated by this instance", func(t *testing.T) {
+		ruleStore := fakes.NewRuleStore(t)
+		ruleStore.Folders[orgID] = append(ruleStore.Folders[orgID], f)
+		other := gen.GenerateRef()
+		ruleStore.PutRule(context.Background(), other)
+
+		req := createRequestContextWithPerms(orgID, createPermissionsForRules([]*models.AlertRule{other}, orgID), nil)
+		svc := createService(ruleStore, nil)
+		svc.traces = traces
+		response := svc.RouteGetRuleEvaluationTracesByUID(req, other.UID)
+
+		require.Equal(t, http.StatusOK, response.Status())
+		require.JSONEq(t, "[]", string(response.Body()))
+	})
+
+	t.Run("NotFound when rule does not exist", func(t *testing.T) {
+		ruleStore := fakes.NewRuleStore(t)
+		ruleStore.Folders[orgID] = append(ruleStore.Folders[orgID], f)
+
+		req := createRequestContextWithPerms(orgID, createPermissionsForRules([]*models.AlertRule{rule}, orgID), nil)
+		svc := createService(ruleStore, nil)
+		svc.traces = traces
+		response := svc.RouteGetRuleEvaluationTracesByUID(req, rule.UID)
+
+		require.Equal(t, http.StatusNotFound, response.Status())
+	})
+
+	t.Run("Unauthorized if user does not have access to the rule", func(t *testing.T) {
+		ruleStore := fakes.NewRuleStore(t)
+		ruleStore.Folders[orgID] = append(ruleStore.Folders[orgID], f)
+		ruleStore.PutRule(context.Background(), rule)
+
+		req := createRequestContextWithPerms(orgID, map[int64]map[string][]string{}, nil)
+		svc := createService(ruleStore, nil)
+		svc.traces = traces
+		response := svc.RouteGetRuleEvaluationTracesByUID(req, rule.UID)
+
+		require.Equal(t, http.StatusForbidden, response.Status())
+	})
+}
+
+type fakeEvaluationTraceReader struct {
+	traces map[models.AlertRuleKey][]models.EvaluationTrace
+}
+
+func (f *fakeEvaluationTraceReader) EvaluationTraces(key models.AlertRuleKey) ([]models.EvaluationTrace, bool) {
+	traces, ok := f.traces[key]
+	return traces, ok
+}
+
 func TestRouteGetRulesConfig(t *testing.T) {
 	gen := models.RuleGen
 	t.Run("fine-grained access is enabled", func(t *testing.T) {
@@ -6,10 +6,12 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/benbjohnson/clock"
 	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/common/model"
 
 	"github.com/grafana/alerting/models"
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
@@ -31,6 +33,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/ngalert/backtesting"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
 	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/notifier"
 	"github.com/grafana/grafana/pkg/services/ngalert/state"
 	"github.com/grafana/grafana/pkg/services/ngalert/store"
 	"github.com/grafana/grafana/pkg/setting"
@@ -41,6 +44,11 @@ type folderService interface {
 	GetNamespaceByUID(ctx context.Context, uid string, orgID int64, user identity.Requester) (*folder.Folder, error)
 }
 
+type routingSimulator interface {
+	SimulateRouting(ctx context.Context, orgID int64, lbls model.LabelSet, route *apimodels.Route, at time.Time) ([]apimodels.TestRoutingRoute, error)
+	GetRoutingConfiguration(ctx context.Context, orgID int64, route *apimodels.Route) (*apimodels.GettableApiAlertingConfig, error)
+}
+
 type TestingApiSrv struct {
 	*AlertingProxy
 	DatasourceCache datasources.CacheService
@@ -53,6 +61,8 @@ type TestingApiSrv struct {
 	appUrl          *url.URL
 	tracer          tracing.Tracer
 	folderService   folderService
+	routing         routingSimulator
+	silenceSvc      SilenceService
 }
 
 // RouteTestGrafanaRuleConfig returns a list of potential alerts for a given rule configuration. This is intended to be
@@ -227,28 +237,65 @@ func (srv TestingApiSrv) BacktestAlertRule(c *contextmodel.ReqContext, cmd apimo
 		return ErrResp(http.StatusNotFound, nil, "Backgtesting API is not enabled")
 	}
 
+	rule, errResp := srv.backtestRuleFromConfig(c, cmd)
+	if errResp != nil {
+		return errResp
+	}
+
+	result, err := srv.backtesting.Test(c.Req.Context(), c.SignedInUser, rule, cmd.From, cmd.To)
+	if err != nil {
+		if errors.Is(err, backtesting.ErrInvalidInputData) {
+			return ErrResp(400, err, "Failed to evaluate")
+		}
+		return ErrResp(500, err, "Failed to evaluate")
+	}
+
+	body, err := data.FrameToJSON(result, data.IncludeAll)
+	if err != nil {
+		return ErrResp(500, err, "Failed to convert frame to JSON")
+	}
+	return response.JSON(http.StatusOK, body)
+}
+
+// backtestRuleFromConfig validates the backtesting configuration and creates the alert rule to test.
+func (srv TestingApiSrv) backtestRuleFromConfig(c *contextmodel.ReqContext, cmd apimodels.BacktestConfig) (*ngmodels.AlertRule, response.Response) {
 	if cmd.From.After(cmd.To) {
-		return ErrResp(400, nil, "From cannot be greater than To")
+		return nil, ErrResp(400, nil, "From cannot be greater than To")
 	}
 
 	noDataState, err := ngmodels.NoDataStateFromString(string(cmd.NoDataState))
 
 	if err != nil {
-		return ErrResp(400, err, "")
+		return nil, ErrResp(400, err, "")
 	}
 	forInterval := time.Duration(cmd.For)
 	if forInterval < 0 {
-		return ErrResp(400, nil, "Bad For interval")
+		return nil, ErrResp(400, nil, "Bad For interval")
+	}
+
+	execErrState := ngmodels.ErrorErrState
+	if cmd.ExecErrState != "" {
+		execErrState, err = ngmodels.ErrStateFromString(string(cmd.ExecErrState))
+		if err != nil {
+			return nil, ErrResp(400, err, "")
+		}
+	}
+	keepFiringFor := time.Duration(cmd.KeepFiringFor)
+	if keepFiringFor < 0 {
+		return nil, ErrResp(400, nil, "Bad KeepFiringFor interval")
+	}
+	if cmd.MissingSeriesEvalsToResolve != nil && *cmd.MissingSeriesEvalsToResolve <= 0 {
+		return nil, ErrResp(400, nil, "MissingSeriesEvalsToResolve must be greater than 0")
 	}
 
 	intervalSeconds, err := apivalidation.ValidateInterval(time.Duration(cmd.Interval), srv.cfg.BaseInterval)
 	if err != nil {
-		return ErrResp(400, err, "")
+		return nil, ErrResp(400, err, "")
 	}
 
 	queries := AlertQueriesFromApiAlertQueries(cmd.Data)
 	if err := srv.authz.AuthorizeDatasourceAccessForRule(c.Req.Context(), c.SignedInUser, &ngmodels.AlertRule{Data: queries}); err != nil {
-		return errorToResponse(err)
+		return nil, errorToResponse(err)
 	}
 
 	rule := &ngmodels.AlertRule{
@@ -260,7 +307,6 @@ func (srv TestingApiSrv) BacktestAlertRule(c *contextmodel.ReqContext, cmd apimo
 		// PanelID:        nil,
 		// RuleGroup:      "",
 		// RuleGroupIndex: 0,
-		// ExecErrState:   "",
 		Title: cmd.Title,
 		// prefix backtesting- is to distinguish between executions of regular rule and backtesting in logs (like expression engine, evaluator, state manager etc)
 		UID:             "backtesting-" + util.GenerateShortUID(),
@@ -269,22 +315,152 @@ func (srv TestingApiSrv) BacktestAlertRule(c *contextmodel.ReqContext, cmd apimo
 		Data:            queries,
 		IntervalSeconds: intervalSeconds,
 		NoDataState:     noDataState,
+		ExecErrState:    execErrState,
 		For:             forInterval,
+		KeepFiringFor:   keepFiringFor,
 		Annotations:     cmd.Annotations,
 		Labels:          cmd.Labels,
+
+		MissingSeriesEvalsToResolve: cmd.MissingSeriesEvalsToResolve,
 	}
+	return rule, nil
+}
 
-	result, err := srv.backtesting.Test(c.Req.Context(), c.SignedInUser, rule, cmd.From, cmd.To)
+// BacktestReplay replays the rule over the time range through the state manager and a simulated notification policy tree,
+// and returns the state transitions and the notifications that would have been sent.
+func (srv TestingApiSrv) BacktestReplay(c *contextmodel.ReqContext, cmd apimodels.BacktestConfig) response.Response {
+	if !srv.featureManager.IsEnabled(c.Req.Context(), featuremgmt.FlagAlertingBacktesting) {
+		return ErrResp(http.StatusNotFound, nil, "Backgtesting API is not enabled")
+	}
+
+	rule, errResp := srv.backtestRuleFromConfig(c, cmd)
+	if errResp != nil {
+		return errResp
+	}
+
+	cfg, err := srv.routing.GetRoutingConfiguration(c.Req.Context(), c.GetOrgID(), cmd.Route)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get the notification policy tree", err)
+	}
+	router := func(lbls model.LabelSet, at time.Time) []backtesting.NotificationPolicy {
+		return notificationPoliciesFromRoutes(notifier.SimulateRouting(cfg, lbls, at))
+	}
+
+	result, err := srv.backtesting.Replay(c.Req.Context(), c.SignedInUser, rule, cmd.From, cmd.To, router)
 	if err != nil {
 		if errors.Is(err, backtesting.ErrInvalidInputData) {
 			return ErrResp(400, err, "Failed to evaluate")
 		}
 		return ErrResp(500, err, "Failed to evaluate")
 	}
+	return response.JSON(http.StatusOK, backtestReplayResultToApi(result))
+}
 
-	body, err := data.FrameToJSON(result, data.IncludeAll)
+func notificationPoliciesFromRoutes(routes []apimodels.TestRoutingRoute) []backtesting.NotificationPolicy {
+	result := make([]backtesting.NotificationPolicy, 0, len(routes))
+	for _, r := range routes {
+		path := make([]string, 0, len(r.Path))
+		for _, p := range r.Path {
+			path = append(path, strconv.Itoa(p.Index))
+		}
+		policy := backtesting.NotificationPolicy{
+			Key:            strings.Join(path, "."),
+			Receiver:       r.Receiver,
+			GroupWait:      time.Duration(r.GroupWait),
+			GroupInterval:  time.Duration(r.GroupInterval),
+			RepeatInterval: time.Duration(r.RepeatInterval),
+			Muted:          r.Muted,
+		}
+		for _, l := range r.GroupBy {
+			if l == "..." {
+				policy.GroupByAll = true
+				break
+			}
+			policy.GroupBy = append(policy.GroupBy, model.LabelName(l))
+		}
+		result = append(result, policy)
+	}
+	return result
+}
+
+func backtestReplayResultToApi(r *backtesting.ReplayResult) apimodels.BacktestReplayResult {
+	result := apimodels.BacktestReplayResult{
+		Transitions:   make([]apimodels.BacktestStateTransition, 0, len(r.Transitions)),
+		Notifications: make([]apimodels.BacktestNotification, 0, len(r.Notifications)),
+	}
+	for _, t := range r.Transitions {
+		result.Transitions = append(result.Transitions, apimodels.BacktestStateTransition{
+			Time:          t.Time,
+			Labels:        t.Labels,
+			PreviousState: t.PreviousState,
+			State:         t.State,
+		})
+	}
+	labelSetToMap := func(lbls model.LabelSet) map[string]string {
+		m := make(map[string]string, len(lbls))
+		for k, v := range lbls {
+			m[string(k)] = string(v)
+		}
+		return m
+	}
+	for _, n := range r.Notifications {
+		notification := apimodels.BacktestNotification{
+			Time:        n.Time,
+			Receiver:    n.Receiver,
+			GroupLabels: labelSetToMap(n.GroupLabels),
+			Firing:      make([]map[string]string, 0, len(n.Firing)),
+			Resolved:    make([]map[string]string, 0, len(n.Resolved)),
+		}
+		for _, a := range n.Firing {
+			notification.Firing = append(notification.Firing, labelSetToMap(a))
+		}
+		for _, a := range n.Resolved {
+			notification.Resolved = append(notification.Resolved, labelSetToMap(a))
+		}
+		result.Notifications = append(result.Notifications, notification)
+	}
+	return result
+}
+
+// RouteTestNotificationRouting returns the notification policies that an alert with the given labels is routed to,
+// and the silences that would mute it. No notifications are sent.
+func (srv TestingApiSrv) RouteTestNotificationRouting(c *contextmodel.ReqContext, body apimodels.TestRoutingPayload) response.Response {
+	if len(body.Labels) == 0 {
+		return ErrResp(http.StatusBadRequest, nil, "labels must not be empty")
+	}
+	lbls := make(model.LabelSet, len(body.Labels))
+	for name, value := range body.Labels {
+		lbls[model.LabelName(name)] = model.LabelValue(value)
+	}
+	at := body.Time
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	routes, err := srv.routing.SimulateRouting(c.Req.Context(), c.GetOrgID(), lbls, body.Route, at)
 	if err != nil {
-		return ErrResp(500, err, "Failed to convert frame to JSON")
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to simulate notification routing", err)
 	}
-	return response.JSON(http.StatusOK, body)
+
+	silences, err := srv.silenceSvc.ListSilences(c.Req.Context(), c.SignedInUser, nil)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to list silences", err)
+	}
+	matching := make([]*ngmodels.Silence, 0)
+	for _, silence := range silences {
+		ok, err := silence.Matches(lbls, at)
+		if err != nil {
+			srv.log.Warn("Skipping silence with invalid matchers", "silence", silence.ID, "error", err)
+			continue
+		}
+		if ok {
+			matching = append(matching, silence)
+		}
+	}
+
+	return response.JSON(http.StatusOK, apimodels.TestRoutingResult{
+		Time:     at,
+		Routes:   routes,
+		Silences: SilencesToGettableGrafanaSilences(withEmptyMetadata(matching...)),
+	})
 }
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"testing"
@@ -9,9 +10,13 @@ import (
 	"github.com/google/uuid"
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	prommodel "github.com/prometheus/common/model"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/infra/tracing"
 	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
 	acMock "github.com/grafana/grafana/pkg/services/accesscontrol/mock"
@@ -24,6 +29,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/ngalert/accesscontrol"
 	. "github.com/grafana/grafana/pkg/services/ngalert/api/compat"
 	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/backtesting"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval/eval_mocks"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
@@ -392,6 +398,147 @@ func TestRouteEvalQueries(t *testing.T) {
 	})
 }
 
+func TestRouteTestNotificationRouting(t *testing.T) {
+	rc := &contextmodel.ReqContext{
+		Context: &web.Context{
+			Req: &http.Request{},
+		},
+		SignedInUser: &user.SignedInUser{
+			OrgID: 1,
+		},
+	}
+
+	t.Run("should return 400 if labels are empty", func(t *testing.T) {
+		srv := &TestingApiSrv{log: log.NewNopLogger()}
+		response := srv.RouteTestNotificationRouting(rc, definitions.TestRoutingPayload{})
+		require.Equal(t, http.StatusBadRequest, response.Status())
+	})
+
+	t.Run("should return matched routes and matching silences", func(t *testing.T) {
+		at := time.Now()
+		routes := []definitions.TestRoutingRoute{{
+			Path:     []definitions.TestRoutingPathElement{{Index: 0}},
+			Receiver: "default",
+		}}
+		simulator := &fakeRoutingSimulator{routes: routes}
+		matching := models.SilenceGen(models.SilenceMuts.WithMatcher("team", "infra", labels.MatchEqual))()
+		matching.Matchers = matching.Matchers[1:]
+		other := models.SilenceGen(models.SilenceMuts.WithMatcher("team", "db", labels.MatchEqual))()
+		other.Matchers = other.Matchers[1:]
+		srv := &TestingApiSrv{
+			log:        log.NewNopLogger(),
+			routing:    simulator,
+			silenceSvc: &fakeSilenceService{silences: []*models.Silence{&matching, &other}},
+		}
+
+		response := srv.RouteTestNotificationRouting(rc, definitions.TestRoutingPayload{
+			Labels: map[string]string{"team": "infra"},
+			Time:   at,
+		})
+		require.Equal(t, http.StatusOK, response.Status())
+		require.Equal(t, prommodel.LabelSet{"team": "infra"}, simulator.labels)
+		require.Equal(t, at, simulator.at)
+
+		var result struct {
+			Routes   []definitions.TestRoutingRoute `json:"routes"`
+			Silences []struct {
+				ID string `json:"id"`
+			} `json:"silences"`
+		}
+		require.NoError(t, json.Unmarshal(response.Body(), &result))
+		require.Equal(t, routes, result.Routes)
+		require.Len(t, result.Silences, 1)
+		require.Equal(t, *matching.ID, result.Silences[0].ID)
+	})
+}
+
+func TestBacktestReplay(t *testing.T) {
+	rc := &contextmodel.ReqContext{
+		Context: &web.Context{
+			Req: &http.Request{},
+		},
+		SignedInUser: &user.SignedInUser{
+			OrgID: 1,
+		},
+	}
+
+	t.Run("should return 404 if backtesting is disabled", func(t *testing.T) {
+		srv := &TestingApiSrv{featureManager: featuremgmt.WithFeatures()}
+		response := srv.BacktestReplay(rc, definitions.BacktestConfig{})
+		require.Equal(t, http.StatusNotFound, response.Status())
+	})
+
+	t.Run("should return 400 if keep firing for is negative", func(t *testing.T) {
+		srv := &TestingApiSrv{featureManager: featuremgmt.WithFeatures(featuremgmt.FlagAlertingBacktesting)}
+		response := srv.BacktestReplay(rc, definitions.BacktestConfig{
+			NoDataState:   definitions.NoData,
+			KeepFiringFor: prommodel.Duration(-time.Minute),
+		})
+		require.Equal(t, http.StatusBadRequest, response.Status())
+	})
+}
+
+func TestNotificationPoliciesFromRoutes(t *testing.T) {
+	routes := []definitions.TestRoutingRoute{
+		{
+			Path:           []definitions.TestRoutingPathElement{{Index: 0}, {Index: 2}},
+			Receiver:       "infra",
+			GroupBy:        []string{"alertname", "team"},
+			GroupWait:      prommodel.Duration(time.Minute),
+			GroupInterval:  prommodel.Duration(5 * time.Minute),
+			RepeatInterval: prommodel.Duration(time.Hour),
+			Muted:          true,
+		},
+		{
+			Path:     []definitions.TestRoutingPathElement{{Index: 0}, {Index: 3}, {Index: 1}},
+			Receiver: "default",
+			GroupBy:  []string{"..."},
+		},
+	}
+	policies := notificationPoliciesFromRoutes(routes)
+	require.Equal(t, []backtesting.NotificationPolicy{
+		{
+			Key:            "0.2",
+			Receiver:       "infra",
+			GroupBy:        []prommodel.LabelName{"alertname", "team"},
+			GroupWait:      time.Minute,
+			GroupInterval:  5 * time.Minute,
+			RepeatInterval: time.Hour,
+			Muted:          true,
+		},
+		{
+			Key:        "0.3.1",
+			Receiver:   "default",
+			GroupByAll: true,
+		},
+	}, policies)
+}
+
+type fakeRoutingSimulator struct {
+	routes []definitions.TestRoutingRoute
+	labels prommodel.LabelSet
+	at     time.Time
+}
+
+func (f *fakeRoutingSimulator) SimulateRouting(_ context.Context, _ int64, lbls prommodel.LabelSet, _ *definitions.Route, at time.Time) ([]definitions.TestRoutingRoute, error) {
+	f.labels = lbls
+	f.at = at
+	return f.routes, nil
+}
+
+func (f *fakeRoutingSimulator) GetRoutingConfiguration(_ context.Context, _ int64, _ *definitions.Route) (*definitions.GettableApiAlertingConfig, error) {
+	return &definitions.GettableApiAlertingConfig{}, nil
+}
+
+type fakeSilenceService struct {
+	SilenceService
+	silences []*models.Silence
+}
+
+func (f *fakeSilenceService) ListSilences(_ context.Context, _ identity.Requester, _ []string) ([]*models.Silence, error) {
+	return f.silences, nil
+}
+
 func createTestingApiSrv(t *testing.T, ds *fakes.FakeCacheService, ac *acMock.Mock, evaluator eval.EvaluatorFactory, featureManager featuremgmt.FeatureToggles, ruleStore RuleStore) *TestingApiSrv {
 	if ac == nil {
 		ac = acMock.New()
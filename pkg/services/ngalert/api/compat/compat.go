@@ -36,6 +36,7 @@ func AlertRuleFromProvisionedAlertRule(a definitions.ProvisionedAlertRule) (mode
 		NotificationSettings:        NotificationSettingsFromAlertRuleNotificationSettings(a.NotificationSettings),
 		Record:                      ModelRecordFromApiRecord(a.Record),
 		MissingSeriesEvalsToResolve: a.MissingSeriesEvalsToResolve,
+		Dependencies:                ModelDependenciesFromApiDependencies(a.Dependencies),
 	}
 
 	if rule.Type() == models.RuleTypeRecording {
@@ -68,6 +69,7 @@ func ProvisionedAlertRuleFromAlertRule(rule models.AlertRule, provenance models.
 		NotificationSettings:        AlertRuleNotificationSettingsFromNotificationSettings(rule.NotificationSettings),
 		Record:                      ApiRecordFromModelRecord(rule.Record),
 		MissingSeriesEvalsToResolve: rule.MissingSeriesEvalsToResolve,
+		Dependencies:                ApiDependenciesFromModelDependencies(rule.Dependencies),
 	}
 }
 
@@ -220,6 +222,7 @@ func AlertRuleExportFromAlertRule(rule models.AlertRule) (definitions.AlertRuleE
 		IsPaused:             rule.IsPaused,
 		NotificationSettings: AlertRuleNotificationSettingsExportFromNotificationSettings(rule.NotificationSettings),
 		Record:               AlertRuleRecordExportFromRecord(rule.Record),
+		Dependencies:         AlertRuleDependenciesExportFromDependencies(rule.Dependencies),
 	}
 	if rule.For.Seconds() > 0 {
 		result.ForString = util.Pointer(model.Duration(rule.For).String())
@@ -550,6 +553,130 @@ func ApiRecordFromModelRecord(r *models.Record) *definitions.Record {
 	}
 }
 
+func ModelDependenciesFromApiDependencies(deps []definitions.AlertRuleDependency) []models.AlertRuleDependency {
+	if len(deps) == 0 {
+		return nil
+	}
+	result := make([]models.AlertRuleDependency, 0, len(deps))
+	for _, d := range deps {
+		result = append(result, models.AlertRuleDependency{
+			RuleUID:  d.RuleUID,
+			Matchers: d.Matchers,
+			Equal:    d.Equal,
+		})
+	}
+	return result
+}
+
+func ApiDependenciesFromModelDependencies(deps []models.AlertRuleDependency) []definitions.AlertRuleDependency {
+	if len(deps) == 0 {
+		return nil
+	}
+	result := make([]definitions.AlertRuleDependency, 0, len(deps))
+	for _, d := range deps {
+		result = append(result, definitions.AlertRuleDependency{
+			RuleUID:  d.RuleUID,
+			Matchers: d.Matchers,
+			Equal:    d.Equal,
+		})
+	}
+	return result
+}
+
+func AlertRuleDependenciesExportFromDependencies(deps []models.AlertRuleDependency) []definitions.AlertRuleDependencyExport {
+	if len(deps) == 0 {
+		return nil
+	}
+	result := make([]definitions.AlertRuleDependencyExport, 0, len(deps))
+	for _, d := range deps {
+		result = append(result, definitions.AlertRuleDependencyExport{
+			RuleUID:  d.RuleUID,
+			Matchers: d.Matchers,
+			Equal:    d.Equal,
+		})
+	}
+	return result
+}
+
+// RuleTemplateFromApiRuleTemplate converts definitions.RuleTemplate to models.RuleTemplate
+func RuleTemplateFromApiRuleTemplate(t definitions.RuleTemplate) models.RuleTemplate {
+	result := models.RuleTemplate{
+		UID:   t.UID,
+		Title: t.Title,
+		Rule: models.RuleTemplateRule{
+			Title:                       t.Rule.Title,
+			Condition:                   t.Rule.Condition,
+			Data:                        AlertQueriesFromApiAlertQueries(t.Rule.Data),
+			NoDataState:                 models.NoDataState(t.Rule.NoDataState),
+			ExecErrState:                models.ExecutionErrorState(t.Rule.ExecErrState),
+			For:                         time.Duration(t.Rule.For),
+			KeepFiringFor:               time.Duration(t.Rule.KeepFiringFor),
+			Annotations:                 t.Rule.Annotations,
+			Labels:                      t.Rule.Labels,
+			IsPaused:                    t.Rule.IsPaused,
+			NotificationSettings:        NotificationSettingsFromAlertRuleNotificationSettings(t.Rule.NotificationSettings),
+			MissingSeriesEvalsToResolve: t.Rule.MissingSeriesEvalsToResolve,
+		},
+		Version: t.Version,
+	}
+	for _, p := range t.Parameters {
+		result.Parameters = append(result.Parameters, models.RuleTemplateParameter{
+			Name:        p.Name,
+			Description: p.Description,
+			Default:     p.Default,
+		})
+	}
+	for _, i := range t.Instances {
+		result.Instances = append(result.Instances, models.RuleTemplateInstance{
+			RuleUID:    i.RuleUID,
+			FolderUID:  i.FolderUID,
+			RuleGroup:  i.RuleGroup,
+			Parameters: i.Parameters,
+		})
+	}
+	return result
+}
+
+// ApiRuleTemplateFromRuleTemplate converts models.RuleTemplate to definitions.RuleTemplate
+func ApiRuleTemplateFromRuleTemplate(t *models.RuleTemplate) definitions.RuleTemplate {
+	result := definitions.RuleTemplate{
+		UID:   t.UID,
+		Title: t.Title,
+		Rule: definitions.RuleTemplateRule{
+			Title:                       t.Rule.Title,
+			Condition:                   t.Rule.Condition,
+			Data:                        ApiAlertQueriesFromAlertQueries(t.Rule.Data),
+			NoDataState:                 definitions.NoDataState(t.Rule.NoDataState),
+			ExecErrState:                definitions.ExecutionErrorState(t.Rule.ExecErrState),
+			For:                         model.Duration(t.Rule.For),
+			KeepFiringFor:               model.Duration(t.Rule.KeepFiringFor),
+			Annotations:                 t.Rule.Annotations,
+			Labels:                      t.Rule.Labels,
+			IsPaused:                    t.Rule.IsPaused,
+			NotificationSettings:        AlertRuleNotificationSettingsFromNotificationSettings(t.Rule.NotificationSettings),
+			MissingSeriesEvalsToResolve: t.Rule.MissingSeriesEvalsToResolve,
+		},
+		Version:    t.Version,
+		Provenance: definitions.Provenance(t.Provenance),
+	}
+	for _, p := range t.Parameters {
+		result.Parameters = append(result.Parameters, definitions.RuleTemplateParameter{
+			Name:        p.Name,
+			Description: p.Description,
+			Default:     p.Default,
+		})
+	}
+	for _, i := range t.Instances {
+		result.Instances = append(result.Instances, definitions.RuleTemplateInstance{
+			RuleUID:    i.RuleUID,
+			FolderUID:  i.FolderUID,
+			RuleGroup:  i.RuleGroup,
+			Parameters: i.Parameters,
+		})
+	}
+	return result
+}
+
 func GettableGrafanaReceiverFromReceiver(r *models.Integration, provenance models.Provenance) (definitions.GettableGrafanaReceiver, error) {
 	out := definitions.GettableGrafanaReceiver{
 		UID:                   r.UID,
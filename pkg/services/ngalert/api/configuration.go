@@ -33,6 +33,10 @@ func (f *ConfigurationApiHandler) handleRouteDeleteNGalertConfig(c *contextmodel
 	return f.grafana.RouteDeleteNGalertConfig(c)
 }
 
+func (f *ConfigurationApiHandler) handleRouteGetSchedulerShards(c *contextmodel.ReqContext) response.Response {
+	return f.grafana.RouteGetSchedulerShards(c)
+}
+
 func (f *ConfigurationApiHandler) handleRouteGetStatus(c *contextmodel.ReqContext) response.Response {
 	return f.grafana.RouteGetAlertingStatus(c)
 }
@@ -269,6 +269,26 @@ func (f *AlertmanagerApiHandler) handleRouteGetGrafanaSilences(ctx *contextmodel
 	return f.GrafanaSvc.RouteGetSilences(ctx)
 }
 
+func (f *AlertmanagerApiHandler) handleRouteGetGrafanaRecurringSilence(ctx *contextmodel.ReqContext, uid string) response.Response {
+	return f.GrafanaSvc.RouteGetRecurringSilence(ctx, uid)
+}
+
+func (f *AlertmanagerApiHandler) handleRouteGetGrafanaRecurringSilences(ctx *contextmodel.ReqContext) response.Response {
+	return f.GrafanaSvc.RouteGetRecurringSilences(ctx)
+}
+
+func (f *AlertmanagerApiHandler) handleRouteCreateGrafanaRecurringSilence(ctx *contextmodel.ReqContext, body apimodels.PostableRecurringSilence) response.Response {
+	return f.GrafanaSvc.RouteCreateRecurringSilence(ctx, body)
+}
+
+func (f *AlertmanagerApiHandler) handleRoutePutGrafanaRecurringSilence(ctx *contextmodel.ReqContext, body apimodels.PostableRecurringSilence, uid string) response.Response {
+	return f.GrafanaSvc.RoutePutRecurringSilence(ctx, body, uid)
+}
+
+func (f *AlertmanagerApiHandler) handleRouteDeleteGrafanaRecurringSilence(ctx *contextmodel.ReqContext, uid string) response.Response {
+	return f.GrafanaSvc.RouteDeleteRecurringSilence(ctx, uid)
+}
+
 func (f *AlertmanagerApiHandler) handleRouteGetGrafanaReceivers(ctx *contextmodel.ReqContext) response.Response {
 	return f.GrafanaSvc.RouteGetReceivers(ctx)
 }
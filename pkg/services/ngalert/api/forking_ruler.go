@@ -117,6 +117,10 @@ func (f *RulerApiHandler) handleRouteGetRulesForExport(ctx *contextmodel.ReqCont
 	return f.GrafanaRuler.ExportRules(ctx)
 }
 
+func (f *RulerApiHandler) handleRouteGetRulesForPrometheusExport(ctx *contextmodel.ReqContext) response.Response {
+	return f.GrafanaRuler.ExportRulesToPrometheus(ctx)
+}
+
 func (f *RulerApiHandler) getService(ctx *contextmodel.ReqContext) (*LotexRuler, error) {
 	_, err := getDatasourceByUID(ctx, f.DatasourceCache, apimodels.LoTexRulerBackend)
 	if err != nil {
@@ -125,6 +129,10 @@ func (f *RulerApiHandler) getService(ctx *contextmodel.ReqContext) (*LotexRuler,
 	return f.LotexRuler, nil
 }
 
+func (f *RulerApiHandler) handleRouteGetRuleEvaluationTracesByUID(ctx *contextmodel.ReqContext, ruleUID string) response.Response {
+	return f.GrafanaRuler.RouteGetRuleEvaluationTracesByUID(ctx, ruleUID)
+}
+
 func (f *RulerApiHandler) handleRouteGetRuleVersionsByUID(ctx *contextmodel.ReqContext, ruleUID string) response.Response {
 	return f.GrafanaRuler.RouteGetRuleVersionsByUID(ctx, ruleUID)
 }
@@ -23,6 +23,7 @@ type ConfigurationApi interface {
 	RouteDeleteNGalertConfig(*contextmodel.ReqContext) response.Response
 	RouteGetAlertmanagers(*contextmodel.ReqContext) response.Response
 	RouteGetNGalertConfig(*contextmodel.ReqContext) response.Response
+	RouteGetSchedulerShards(*contextmodel.ReqContext) response.Response
 	RouteGetStatus(*contextmodel.ReqContext) response.Response
 	RoutePostNGalertConfig(*contextmodel.ReqContext) response.Response
 }
@@ -36,6 +37,9 @@ func (f *ConfigurationApiHandler) RouteGetAlertmanagers(ctx *contextmodel.ReqCon
 func (f *ConfigurationApiHandler) RouteGetNGalertConfig(ctx *contextmodel.ReqContext) response.Response {
 	return f.handleRouteGetNGalertConfig(ctx)
 }
+func (f *ConfigurationApiHandler) RouteGetSchedulerShards(ctx *contextmodel.ReqContext) response.Response {
+	return f.handleRouteGetSchedulerShards(ctx)
+}
 func (f *ConfigurationApiHandler) RouteGetStatus(ctx *contextmodel.ReqContext) response.Response {
 	return f.handleRouteGetStatus(ctx)
 }
@@ -86,6 +90,18 @@ func (api *API) RegisterConfigurationApiEndpoints(srv ConfigurationApi, m *metri
 				m,
 			),
 		)
+		group.Get(
+			toMacaronPath("/api/v1/ngalert/scheduler/shards"),
+			requestmeta.SetOwner(requestmeta.TeamAlerting),
+			requestmeta.SetSLOGroup(requestmeta.SLOGroupHighSlow),
+			api.authorize(http.MethodGet, "/api/v1/ngalert/scheduler/shards"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/v1/ngalert/scheduler/shards",
+				api.Hooks.Wrap(srv.RouteGetSchedulerShards),
+				m,
+			),
+		)
 		group.Get(
 			toMacaronPath("/api/v1/ngalert"),
 			requestmeta.SetOwner(requestmeta.TeamAlerting),
@@ -212,6 +212,8 @@ func getStatesFromQuery(v url.Values) (map[eval.State]struct{}, error) {
 			states[eval.Error] = struct{}{}
 		case "recovering":
 			states[eval.Recovering] = struct{}{}
+		case "suppressed":
+			states[eval.Suppressed] = struct{}{}
 		default:
 			return states, fmt.Errorf("unknown state '%s'", s)
 		}
@@ -361,7 +363,7 @@ func RuleAlertStateMutatorGenerator(manager state.AlertInstanceManager) RuleAler
 			}
 
 			// Set the state of the rule based on the state of its alerts.
-			// Only update the rule state with 'pending' or 'recovering' if the current state is 'inactive'.
+			// Only update the rule state with 'pending', 'recovering' or 'suppressed' if the current state is 'inactive'.
 			// This prevents overwriting a higher-severity 'firing' state in the case of a rule with multiple alerts.
 			switch alertState.State {
 			case eval.Normal:
@@ -378,6 +380,10 @@ func RuleAlertStateMutatorGenerator(manager state.AlertInstanceManager) RuleAler
 					toMutate.ActiveAt = &activeAt
 				}
 				toMutate.State = "firing"
+			case eval.Suppressed:
+				if toMutate.State == "inactive" {
+					toMutate.State = "suppressed"
+				}
 			case eval.Error:
 			case eval.NoData:
 			}
@@ -790,6 +796,8 @@ func filterRulesByState(ruleGroup *apimodels.RuleGroup, withStatesFast map[eval.
 			state = util.Pointer(eval.Pending)
 		case "recovering":
 			state = util.Pointer(eval.Recovering)
+		case "suppressed":
+			state = util.Pointer(eval.Suppressed)
 		}
 		if state != nil {
 			if _, ok := withStatesFast[*state]; ok {
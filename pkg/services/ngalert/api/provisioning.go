@@ -64,6 +64,26 @@ func (f *ProvisioningApiHandler) handleRouteDeleteTemplate(ctx *contextmodel.Req
 	return f.svc.RouteDeleteTemplate(ctx, name)
 }
 
+func (f *ProvisioningApiHandler) handleRouteGetRuleTemplates(ctx *contextmodel.ReqContext) response.Response {
+	return f.svc.RouteGetRuleTemplates(ctx)
+}
+
+func (f *ProvisioningApiHandler) handleRouteGetRuleTemplate(ctx *contextmodel.ReqContext, UID string) response.Response {
+	return f.svc.RouteGetRuleTemplate(ctx, UID)
+}
+
+func (f *ProvisioningApiHandler) handleRoutePostRuleTemplate(ctx *contextmodel.ReqContext, t apimodels.RuleTemplate) response.Response {
+	return f.svc.RoutePostRuleTemplate(ctx, t)
+}
+
+func (f *ProvisioningApiHandler) handleRoutePutRuleTemplate(ctx *contextmodel.ReqContext, t apimodels.RuleTemplate, UID string) response.Response {
+	return f.svc.RoutePutRuleTemplate(ctx, t, UID)
+}
+
+func (f *ProvisioningApiHandler) handleRouteDeleteRuleTemplate(ctx *contextmodel.ReqContext, UID string) response.Response {
+	return f.svc.RouteDeleteRuleTemplate(ctx, UID)
+}
+
 func (f *ProvisioningApiHandler) handleRouteGetMuteTiming(ctx *contextmodel.ReqContext, name string) response.Response {
 	return f.svc.RouteGetMuteTiming(ctx, name)
 }
@@ -32,3 +32,11 @@ func (f *TestingApiHandler) handleRouteEvalQueries(c *contextmodel.ReqContext, b
 func (f *TestingApiHandler) handleBacktestConfig(ctx *contextmodel.ReqContext, conf apimodels.BacktestConfig) response.Response {
 	return f.svc.BacktestAlertRule(ctx, conf)
 }
+
+func (f *TestingApiHandler) handleBacktestReplay(ctx *contextmodel.ReqContext, conf apimodels.BacktestConfig) response.Response {
+	return f.svc.BacktestReplay(ctx, conf)
+}
+
+func (f *TestingApiHandler) handleRouteTestNotificationRouting(ctx *contextmodel.ReqContext, body apimodels.TestRoutingPayload) response.Response {
+	return f.svc.RouteTestNotificationRouting(ctx, body)
+}
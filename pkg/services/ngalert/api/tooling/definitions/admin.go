@@ -24,6 +24,16 @@ import (
 //     Responses:
 //		 200: GettableAlertmanagers
 
+// swagger:route GET /v1/ngalert/scheduler/shards configuration RouteGetSchedulerShards
+//
+//  Get the replicas of the HA cluster that evaluate the rule groups of the user's organization.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//		 200: GettableSchedulerShards
+
 // swagger:route GET /v1/ngalert/admin_config configuration RouteGetNGalertConfig
 //
 //  Get the NGalert configuration of the user's organization, returns 404 if no configuration is present.
@@ -95,3 +105,23 @@ type AlertingStatus struct {
 	AlertmanagersChoice      AlertmanagersChoice `json:"alertmanagersChoice"`
 	NumExternalAlertmanagers int                 `json:"numExternalAlertmanagers"`
 }
+
+// swagger:model
+type GettableSchedulerShards struct {
+	// Enabled is true if the evaluation of alert rules is split between the replicas of the HA cluster.
+	Enabled bool `json:"enabled"`
+	// Self is the name of the replica that served the request.
+	Self string `json:"self"`
+	// Members are the names of the replicas the alert rules are split between.
+	Members []string                `json:"members"`
+	Groups  []SchedulerShardedGroup `json:"groups"`
+}
+
+// swagger:model
+type SchedulerShardedGroup struct {
+	FolderUID string `json:"folderUid"`
+	RuleGroup string `json:"ruleGroup"`
+	// Owner is the name of the replica that evaluates the rule group.
+	Owner string `json:"owner"`
+	Rules int    `json:"rules"`
+}
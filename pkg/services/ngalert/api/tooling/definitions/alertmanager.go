@@ -12,6 +12,7 @@ import (
 	amv2 "github.com/prometheus/alertmanager/api/v2/models"
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/alertmanager/timeinterval"
 	"github.com/prometheus/common/model"
 	"gopkg.in/yaml.v3"
 
@@ -255,6 +256,48 @@ import (
 //       400: ValidationError
 //       404: NotFound
 
+// swagger:route GET /alertmanager/grafana/api/v2/recurring-silences alertmanager RouteGetGrafanaRecurringSilences
+//
+// get recurring silences
+//
+//     Responses:
+//       200: gettableRecurringSilences
+
+// swagger:route POST /alertmanager/grafana/api/v2/recurring-silences alertmanager RouteCreateGrafanaRecurringSilence
+//
+// create a recurring silence that creates a silence ahead of each window of its schedule
+//
+//     Responses:
+//       201: gettableRecurringSilence
+//       400: ValidationError
+//       409: PublicError
+
+// swagger:route GET /alertmanager/grafana/api/v2/recurring-silence/{RecurringSilenceUID} alertmanager RouteGetGrafanaRecurringSilence
+//
+// get recurring silence
+//
+//     Responses:
+//       200: gettableRecurringSilence
+//       404: NotFound
+
+// swagger:route PUT /alertmanager/grafana/api/v2/recurring-silence/{RecurringSilenceUID} alertmanager RoutePutGrafanaRecurringSilence
+//
+// update recurring silence
+//
+//     Responses:
+//       202: gettableRecurringSilence
+//       400: ValidationError
+//       404: NotFound
+//       409: PublicError
+
+// swagger:route DELETE /alertmanager/grafana/api/v2/recurring-silence/{RecurringSilenceUID} alertmanager RouteDeleteGrafanaRecurringSilence
+//
+// delete recurring silence and expire the silence of its current window
+//
+//     Responses:
+//       200: Ack
+//       404: NotFound
+
 // Alias all the needed Alertmanager types, functions and constants so that they can be imported directly from grafana/alerting
 // without having to modify any of the usage within Grafana.
 type (
@@ -442,6 +485,18 @@ type GetDeleteSilenceParams struct {
 	SilenceId string
 }
 
+// swagger:parameters RouteCreateGrafanaRecurringSilence RoutePutGrafanaRecurringSilence
+type RecurringSilenceParams struct {
+	// in:body
+	Body PostableRecurringSilence
+}
+
+// swagger:parameters RouteGetGrafanaRecurringSilence RoutePutGrafanaRecurringSilence RouteDeleteGrafanaRecurringSilence
+type RecurringSilenceUIDParams struct {
+	// in:path
+	RecurringSilenceUID string
+}
+
 // swagger:parameters RouteGetSilences RouteGetGrafanaSilences
 type GetSilencesParams struct {
 	// in:query
@@ -593,6 +648,54 @@ func (s GettableGrafanaSilence) MarshalJSON() ([]byte, error) {
 // swagger:model gettableGrafanaSilences
 type GettableGrafanaSilences []*GettableGrafanaSilence
 
+// swagger:model postableRecurringSilence
+type PostableRecurringSilence struct {
+	// UID of the recurring silence. It is generated if it is empty.
+	UID string `json:"uid,omitempty"`
+	// Matchers of the silences that are created for the windows of the schedule.
+	// required: true
+	Matchers amv2.Matchers `json:"matchers"`
+	Comment  string        `json:"comment"`
+	// Defaults to the login of the user.
+	CreatedBy string `json:"createdBy,omitempty"`
+	// required: true
+	Schedule RecurringSilenceSchedule `json:"schedule"`
+	// Version of the recurring silence that is updated. If it is set and does not match the current version, the update is rejected.
+	Version int64 `json:"version,omitempty"`
+}
+
+// RecurringSilenceSchedule defines the windows of a recurring silence either with a cron expression and a duration or with time intervals.
+type RecurringSilenceSchedule struct {
+	// Cron expression in the standard five-field format that defines the start of each window.
+	// The times are in UTC unless the expression is prefixed with CRON_TZ=<location>.
+	// example: CRON_TZ=Europe/Berlin 0 22 * * 1-5
+	Cron string `json:"cron,omitempty" yaml:"cron,omitempty"`
+	// Duration of each window that starts at a cron occurrence.
+	// example: 8h
+	Duration model.Duration `json:"duration,omitempty" yaml:"duration,omitempty"`
+	// Time intervals in the same format as mute timings. Cannot be combined with a cron expression.
+	TimeIntervals []timeinterval.TimeInterval `json:"time_intervals,omitempty" yaml:"time_intervals,omitempty"`
+}
+
+// swagger:model gettableRecurringSilence
+type GettableRecurringSilence struct {
+	PostableRecurringSilence `json:",inline"`
+	Provenance               Provenance `json:"provenance,omitempty"`
+	UpdatedAt                time.Time  `json:"updatedAt"`
+	// The silence that was created for the current or next window of the schedule.
+	Silence *RecurringSilenceWindow `json:"silence,omitempty"`
+}
+
+// RecurringSilenceWindow is a window of the schedule of a recurring silence and the silence that was created for it.
+type RecurringSilenceWindow struct {
+	ID       string    `json:"id"`
+	StartsAt time.Time `json:"startsAt"`
+	EndsAt   time.Time `json:"endsAt"`
+}
+
+// swagger:model gettableRecurringSilences
+type GettableRecurringSilences []GettableRecurringSilence
+
 // GettableAlerts gettable alerts
 //
 // swagger:model gettableAlerts
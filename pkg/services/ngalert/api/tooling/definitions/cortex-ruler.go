@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/prometheus/common/model"
 )
 
@@ -44,6 +45,19 @@ import (
 //       403: ForbiddenError
 //       404: description: Not found.
 
+// swagger:route Get /ruler/grafana/api/v1/rule/{RuleUID}/traces ruler RouteGetRuleEvaluationTracesByUID
+//
+// Get the most recent evaluations of the rule with the results of its queries and expressions.
+// Evaluations are kept in memory of the instance that evaluates the rule only if evaluation_trace_size is set.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       200: GettableEvaluationTraces
+//       403: ForbiddenError
+//       404: description: Not found.
+
 // swagger:route Get /ruler/grafana/api/v1/rules ruler RouteGetGrafanaRulesConfig
 //
 // List rule groups
@@ -72,6 +86,20 @@ import (
 //       403: ForbiddenError
 //       404: description: Not found.
 
+// swagger:route Get /ruler/grafana/api/v1/export/rules/prometheus ruler RouteGetRulesForPrometheusExport
+//
+// List rules in Prometheus rule file format
+//
+//     Produces:
+//     - application/json
+//     - application/yaml
+//     - text/yaml
+//
+//     Responses:
+//       200: PrometheusRulesExport
+//       403: ForbiddenError
+//       404: description: Not found.
+
 // swagger:route Get /ruler/{DatasourceUID}/api/v1/rules ruler RouteGetRulesConfig
 //
 // List rule groups
@@ -256,7 +284,7 @@ type PathGetRulesParams struct {
 	PanelID int64
 }
 
-// swagger:parameters RouteGetRuleByUID RouteGetRuleVersionsByUID
+// swagger:parameters RouteGetRuleByUID RouteGetRuleVersionsByUID RouteGetRuleEvaluationTracesByUID
 type PathGetRuleByUIDParams struct {
 	// in: path
 	RuleUID string
@@ -268,6 +296,55 @@ type PathDeleteRuleFromTrashByGUIDParams struct {
 	RuleGUID string
 }
 
+// swagger:parameters RouteGetRulesForPrometheusExport
+type PrometheusRulesExportParameters struct {
+	// Whether to initiate a download of the file or not.
+	// in: query
+	// required: false
+	// default: false
+	Download bool `json:"download"`
+
+	// Format of the downloaded file. Supported yaml or json. Accept header can also be used, but the query parameter will take precedence.
+	// in: query
+	// required: false
+	// default: yaml
+	// enum: yaml,json
+	Format string `json:"format"`
+
+	// UIDs of folders from which to export rules
+	// in:query
+	// required:false
+	FolderUID []string `json:"folderUid"`
+
+	// Name of group of rules to export. Must be specified only together with a single folder UID
+	// in:query
+	// required: false
+	GroupName string `json:"group"`
+
+	// UID of alert rule to export. If specified, parameters folderUid and group must be empty.
+	// in:query
+	// required: false
+	RuleUID string `json:"ruleUid"`
+}
+
+// PrometheusRulesExport is a Prometheus rule file with the rules that can be represented in the Prometheus format.
+// swagger:model
+type PrometheusRulesExport struct {
+	Groups []PrometheusRuleGroup `yaml:"groups" json:"groups"`
+	// Rules that cannot be represented in the Prometheus format and are not exported.
+	// In YAML, they are listed in a comment at the top of the file, so the file remains a valid rule file.
+	Unsupported []PrometheusUnsupportedRule `yaml:"-" json:"unsupported,omitempty"`
+}
+
+// swagger:model
+type PrometheusUnsupportedRule struct {
+	UID       string `json:"uid"`
+	Title     string `json:"title"`
+	FolderUID string `json:"folderUid"`
+	Group     string `json:"group"`
+	Reason    string `json:"reason"`
+}
+
 // swagger:model
 type RuleGroupConfigResponse struct {
 	GettableRuleGroupConfig
@@ -337,6 +414,35 @@ func (c *PostableRuleGroupConfig) validate() error {
 // swagger:model
 type GettableRuleVersions []GettableExtendedRuleNode
 
+// swagger:model
+type GettableEvaluationTraces []GettableEvaluationTrace
+
+// GettableEvaluationTrace is a single evaluation of a rule.
+type GettableEvaluationTrace struct {
+	EvaluatedAt time.Time `json:"evaluatedAt"`
+	Attempt     int64     `json:"attempt"`
+	// EvaluationTime is how long it took to execute all queries and expressions, in seconds.
+	EvaluationTime float64 `json:"evaluationTime"`
+	Error          string  `json:"error,omitempty"`
+	// States is the number of alert instances for each evaluation state.
+	States map[string]int                `json:"states,omitempty"`
+	Nodes  []GettableEvaluationTraceNode `json:"nodes"`
+}
+
+// GettableEvaluationTraceNode is the result of a query or expression within an evaluation.
+type GettableEvaluationTraceNode struct {
+	RefID string `json:"refId"`
+	// EvaluationTime is how long it took to execute the node, in seconds.
+	EvaluationTime float64 `json:"evaluationTime"`
+	// DatasourceFrames are the frames returned by the data source before they were converted.
+	DatasourceFrames data.Frames `json:"datasourceFrames,omitempty"`
+	// Frames is the output of the node.
+	Frames data.Frames `json:"frames,omitempty"`
+	Error  string      `json:"error,omitempty"`
+	// Truncated is true if frames or rows were dropped to limit the size of the trace.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
 // swagger:model
 type GettableRuleGroupConfig struct {
 	Name     string                     `yaml:"name" json:"name"`
@@ -576,6 +682,22 @@ type Record struct {
 	TargetDatasourceUID string `json:"target_datasource_uid,omitempty" yaml:"target_datasource_uid,omitempty"`
 }
 
+// swagger:model
+type AlertRuleDependency struct {
+	// UID of the rule in the same organization the alert rule depends on.
+	// While an alert instance of this rule is firing, the alert instances of the dependent rule are suppressed.
+	// required: true
+	// example: cluster-down
+	RuleUID string `json:"rule_uid" yaml:"rule_uid"`
+	// Matchers in the Prometheus matcher syntax that select the alert instances of the dependency.
+	// If empty, all alert instances of the dependency are selected.
+	// example: ["cluster=~\"prod-.*\""]
+	Matchers []string `json:"matchers,omitempty" yaml:"matchers,omitempty"`
+	// Labels that must have the same value in the alert instance of the dependency and the suppressed alert instance.
+	// example: ["cluster"]
+	Equal []string `json:"equal,omitempty" yaml:"equal,omitempty"`
+}
+
 // swagger:model
 type PostableGrafanaRule struct {
 	Title                string                         `json:"title" yaml:"title"`
@@ -594,6 +716,9 @@ type PostableGrafanaRule struct {
 	// required: false
 	// example: 3
 	MissingSeriesEvalsToResolve *int64 `json:"missing_series_evals_to_resolve,omitempty" yaml:"missing_series_evals_to_resolve,omitempty"`
+	// Rules the alert rule depends on. If not specified, the dependencies of an existing rule are kept.
+	// required: false
+	Dependencies []AlertRuleDependency `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
 }
 
 // swagger:model
@@ -617,6 +742,7 @@ type GettableGrafanaRule struct {
 	Metadata                    *AlertRuleMetadata             `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 	GUID                        string                         `json:"guid" yaml:"guid"`
 	MissingSeriesEvalsToResolve *int64                         `json:"missing_series_evals_to_resolve,omitempty" yaml:"missing_series_evals_to_resolve,omitempty"`
+	Dependencies                []AlertRuleDependency          `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
 }
 
 // UserInfo represents user-related information, including a unique identifier and a name.
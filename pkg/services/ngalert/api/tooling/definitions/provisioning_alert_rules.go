@@ -175,6 +175,8 @@ type ProvisionedAlertRule struct {
 	Record *Record `json:"record"`
 	// example: 2
 	MissingSeriesEvalsToResolve *int64 `json:"missingSeriesEvalsToResolve,omitempty"`
+	// example: [{"rule_uid":"cluster-down","equal":["cluster"]}]
+	Dependencies []AlertRuleDependency `json:"dependencies,omitempty"`
 }
 
 // swagger:route GET /v1/provisioning/folder/{FolderUID}/rule-groups/{Group} provisioning stable RouteGetAlertRuleGroup
@@ -285,6 +287,7 @@ type AlertRuleExport struct {
 	NotificationSettings        *AlertRuleNotificationSettingsExport `json:"notification_settings,omitempty" yaml:"notification_settings,omitempty" hcl:"notification_settings,block"`
 	Record                      *AlertRuleRecordExport               `json:"record,omitempty" yaml:"record,omitempty" hcl:"record,block"`
 	MissingSeriesEvalsToResolve *int64                               `json:"missing_series_evals_to_resolve,omitempty" yaml:"missing_series_evals_to_resolve,omitempty" hcl:"missing_series_evals_to_resolve"`
+	Dependencies                []AlertRuleDependencyExport          `json:"dependencies,omitempty" yaml:"dependencies,omitempty" hcl:"dependency,block"`
 }
 
 // AlertQueryExport is the provisioned export of models.AlertQuery.
@@ -315,6 +318,13 @@ type AlertRuleNotificationSettingsExport struct {
 	ActiveTimeIntervals []string `yaml:"active_time_intervals,omitempty" json:"active_time_intervals,omitempty" hcl:"active_timings"` // TF -> `active_timings`
 }
 
+// AlertRuleDependencyExport is the provisioned export of models.AlertRuleDependency.
+type AlertRuleDependencyExport struct {
+	RuleUID  string   `json:"rule_uid" yaml:"rule_uid" hcl:"rule_uid"`
+	Matchers []string `json:"matchers,omitempty" yaml:"matchers,omitempty" hcl:"matchers"`
+	Equal    []string `json:"equal,omitempty" yaml:"equal,omitempty" hcl:"equal"`
+}
+
 // Record is the provisioned export of models.Record.
 type AlertRuleRecordExport struct {
 	Metric              string  `json:"metric" yaml:"metric" hcl:"metric"`
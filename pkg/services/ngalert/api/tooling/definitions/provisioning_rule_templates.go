@@ -0,0 +1,154 @@
+package definitions
+
+import (
+	"github.com/prometheus/common/model"
+)
+
+// swagger:route GET /v1/provisioning/rule-templates provisioning stable RouteGetRuleTemplates
+//
+// Get all rule templates.
+//
+//     Responses:
+//       200: RuleTemplates
+
+// swagger:route GET /v1/provisioning/rule-templates/{UID} provisioning stable RouteGetRuleTemplate
+//
+// Get a rule template.
+//
+//     Responses:
+//       200: RuleTemplate
+//       404: description: Not found.
+
+// swagger:route POST /v1/provisioning/rule-templates provisioning stable RoutePostRuleTemplate
+//
+// Create a new rule template and an alert rule for each of its instances.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       201: RuleTemplate
+//       400: ValidationError
+//       403: ForbiddenError
+//       409: PublicError
+
+// swagger:route PUT /v1/provisioning/rule-templates/{UID} provisioning stable RoutePutRuleTemplate
+//
+// Replace an existing rule template and update the alert rules of its instances. Alert rules of instances that are
+// removed are deleted. Instances without a rule UID are added as new instances.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       200: RuleTemplate
+//       400: ValidationError
+//       403: ForbiddenError
+//       404: description: Not found.
+//       409: PublicError
+
+// swagger:route DELETE /v1/provisioning/rule-templates/{UID} provisioning stable RouteDeleteRuleTemplate
+//
+// Delete a rule template and the alert rules of its instances.
+//
+//     Responses:
+//       204: description: The rule template was deleted successfully.
+//       403: ForbiddenError
+//       409: PublicError
+
+// swagger:parameters RouteGetRuleTemplate RoutePutRuleTemplate RouteDeleteRuleTemplate
+type RuleTemplateUIDParam struct {
+	// Rule template UID
+	// in:path
+	UID string
+}
+
+// swagger:parameters RoutePostRuleTemplate RoutePutRuleTemplate
+type RuleTemplatePayload struct {
+	// in:body
+	Body RuleTemplate
+}
+
+// swagger:parameters RoutePostRuleTemplate RoutePutRuleTemplate RouteDeleteRuleTemplate
+type RuleTemplateHeaders struct {
+	// in:header
+	XDisableProvenance string `json:"X-Disable-Provenance"`
+}
+
+// swagger:model
+type RuleTemplates []RuleTemplate
+
+// RuleTemplate is a parameterized alert rule. An alert rule is created for each instance by replacing the references
+// to parameters in the form of ${name} with the values of the instance. The alert rules can only be changed through
+// the template.
+// swagger:model
+type RuleTemplate struct {
+	// UID of the rule template. It is generated if it is empty.
+	// pattern: ^[a-zA-Z0-9-_]+$
+	UID string `json:"uid"`
+	// required: true
+	Title      string                  `json:"title"`
+	Parameters []RuleTemplateParameter `json:"parameters,omitempty"`
+	// required: true
+	Rule      RuleTemplateRule       `json:"rule"`
+	Instances []RuleTemplateInstance `json:"instances,omitempty"`
+	// Version of the rule template that is updated. If it is set and does not match the current version, the update
+	// is rejected.
+	Version int64 `json:"version,omitempty"`
+	// readonly: true
+	Provenance Provenance `json:"provenance,omitempty"`
+}
+
+// RuleTemplateParameter is a parameter of a rule template.
+type RuleTemplateParameter struct {
+	// required: true
+	// example: threshold
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Default value of the parameter. Instances must set a value for parameters without a default.
+	Default *string `json:"default,omitempty"`
+}
+
+// RuleTemplateRule is the alert rule of a rule template. Parameters can be referenced in the title, labels,
+// annotations, data source UIDs and string values of query models, and in the receiver of the notification settings.
+// A string value of a query model that consists of a single reference to a parameter is replaced with a number or
+// a boolean if the value of the parameter is a number or a boolean.
+type RuleTemplateRule struct {
+	// required: true
+	// example: High CPU usage on ${cluster}
+	Title string `json:"title"`
+	// required: true
+	// example: B
+	Condition string `json:"condition"`
+	// required: true
+	Data []AlertQuery `json:"data"`
+	// required: true
+	NoDataState NoDataState `json:"noDataState"`
+	// required: true
+	ExecErrState ExecutionErrorState `json:"execErrState"`
+	// required: true
+	// swagger:strfmt duration
+	For model.Duration `json:"for"`
+	// swagger:strfmt duration
+	KeepFiringFor model.Duration `json:"keep_firing_for,omitempty"`
+	// example: {"runbook_url": "https://example.com/runbooks/${cluster}"}
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// example: {"cluster": "${cluster}"}
+	Labels                      map[string]string              `json:"labels,omitempty"`
+	IsPaused                    bool                           `json:"isPaused,omitempty"`
+	NotificationSettings        *AlertRuleNotificationSettings `json:"notification_settings,omitempty"`
+	MissingSeriesEvalsToResolve *int64                         `json:"missingSeriesEvalsToResolve,omitempty"`
+}
+
+// RuleTemplateInstance is an alert rule created from a rule template.
+type RuleTemplateInstance struct {
+	// UID of the alert rule created for the instance. It is generated if it is empty.
+	RuleUID string `json:"ruleUid,omitempty"`
+	// required: true
+	FolderUID string `json:"folderUID"`
+	// required: true
+	RuleGroup string `json:"ruleGroup"`
+	// Values of the parameters.
+	// example: {"cluster": "eu-west-1", "threshold": "80"}
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
@@ -68,6 +68,34 @@ import (
 //     Responses:
 //       200: BacktestResult
 
+// swagger:route Post /v1/rule/backtest/replay testing BacktestReplay
+//
+// Replay a rule over a time range and return the state transitions and the notifications that would have been sent
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       200: BacktestReplayResult
+//       400: ValidationError
+
+// swagger:route Post /v1/notifications/routing/test testing RouteTestNotificationRouting
+//
+// Simulate how an alert with the given labels is routed by the notification policy tree. No notifications are sent.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       200: TestRoutingResult
+//       400: ValidationError
+
 // swagger:parameters RouteTestReceiverConfig
 type TestReceiverRequest struct {
 	// in:body
@@ -209,7 +237,7 @@ type ResponseDetails struct {
 	Msg string `json:"msg"`
 }
 
-// swagger:parameters BacktestConfig
+// swagger:parameters BacktestConfig BacktestReplay
 type BacktestConfigRequest struct {
 	// in:body
 	Body BacktestConfig
@@ -229,8 +257,105 @@ type BacktestConfig struct {
 	Labels      map[string]string `json:"labels,omitempty"`
 	Annotations map[string]string `json:"annotations,omitempty"`
 
-	NoDataState NoDataState `json:"no_data_state"`
+	NoDataState  NoDataState         `json:"no_data_state"`
+	ExecErrState ExecutionErrorState `json:"exec_err_state,omitempty"`
+
+	KeepFiringFor model.Duration `json:"keep_firing_for,omitempty"`
+	// Number of consecutive evaluation intervals with no data for a dimension must pass
+	// before the alert state is considered stale and automatically resolved.
+	// required: false
+	// example: 3
+	MissingSeriesEvalsToResolve *int64 `json:"missing_series_evals_to_resolve,omitempty"`
+
+	// Notification policy tree used to simulate notifications instead of the current one. Used only by the replay.
+	// required: false
+	Route *Route `json:"route,omitempty"`
 }
 
 // swagger:model
 type BacktestResult data.Frame
+
+// swagger:model
+type BacktestReplayResult struct {
+	// State transitions of the alert instances in chronological order.
+	Transitions []BacktestStateTransition `json:"transitions"`
+	// Notifications that would have been sent in chronological order.
+	Notifications []BacktestNotification `json:"notifications"`
+}
+
+type BacktestStateTransition struct {
+	Time          time.Time         `json:"time"`
+	Labels        map[string]string `json:"labels"`
+	PreviousState string            `json:"previous_state"`
+	State         string            `json:"state"`
+}
+
+type BacktestNotification struct {
+	Time     time.Time `json:"time"`
+	Receiver string    `json:"receiver"`
+	// Labels the alerts of the notification are grouped by.
+	GroupLabels map[string]string   `json:"group_labels"`
+	Firing      []map[string]string `json:"firing"`
+	Resolved    []map[string]string `json:"resolved"`
+}
+
+// swagger:parameters RouteTestNotificationRouting
+type TestRoutingRequest struct {
+	// in:body
+	Body TestRoutingPayload
+}
+
+// swagger:model
+type TestRoutingPayload struct {
+	// Labels of the alert to route.
+	// required: true
+	// example: {"alertname": "HighCPU", "team": "infra", "severity": "critical"}
+	Labels map[string]string `json:"labels"`
+	// Notification policy tree to simulate instead of the current one.
+	Route *Route `json:"route,omitempty"`
+	// Time at which time intervals and silences are checked. Defaults to the current time.
+	Time time.Time `json:"time,omitempty"`
+}
+
+// swagger:model
+type TestRoutingResult struct {
+	Time time.Time `json:"time"`
+	// Notification policies the alert is routed to. More than one policy matches if a policy has continue set.
+	Routes []TestRoutingRoute `json:"routes"`
+	// Silences that are active at the given time and match the labels of the alert.
+	Silences GettableGrafanaSilences `json:"silences"`
+}
+
+// TestRoutingRoute is a notification policy that matches the labels of the alert.
+type TestRoutingRoute struct {
+	// Path from the root of the policy tree to the matched policy. The first element is the root policy.
+	Path []TestRoutingPathElement `json:"path"`
+	// Name of the contact point.
+	Receiver string `json:"receiver"`
+	// Types of the integrations of the contact point.
+	// example: ["email", "slack"]
+	Integrations   []string       `json:"integrations"`
+	GroupBy        []string       `json:"group_by"`
+	GroupWait      model.Duration `json:"group_wait"`
+	GroupInterval  model.Duration `json:"group_interval"`
+	RepeatInterval model.Duration `json:"repeat_interval"`
+	// Mute time intervals of the policy and whether they are active at the given time.
+	MuteTimeIntervals []TestRoutingTimeInterval `json:"mute_time_intervals,omitempty"`
+	// Active time intervals of the policy and whether they are active at the given time.
+	ActiveTimeIntervals []TestRoutingTimeInterval `json:"active_time_intervals,omitempty"`
+	// Muted is true if notifications are muted at the given time by the time intervals of the policy.
+	Muted bool `json:"muted"`
+}
+
+// TestRoutingPathElement is a policy on the path to the matched notification policy.
+type TestRoutingPathElement struct {
+	// Position of the policy among the nested policies of its parent. The root policy has index 0.
+	Index          int            `json:"index"`
+	ObjectMatchers ObjectMatchers `json:"object_matchers,omitempty"`
+	Continue       bool           `json:"continue,omitempty"`
+}
+
+type TestRoutingTimeInterval struct {
+	Name   string `json:"name"`
+	Active bool   `json:"active"`
+}
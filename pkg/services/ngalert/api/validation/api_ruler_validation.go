@@ -157,6 +157,8 @@ func validateAlertingRuleFields(in *apimodels.PostableExtendedRuleNode, newRule
 		return ngmodels.AlertRule{}, err
 	}
 
+	newRule.Dependencies = ModelDependenciesFromApiDependencies(in.GrafanaManagedAlert.Dependencies)
+
 	newRule.For, err = validateForInterval(in)
 	if err != nil {
 		return ngmodels.AlertRule{}, err
@@ -360,7 +362,7 @@ func ValidateRuleGroup(
 			uids[rule.UID] = idx
 		}
 
-		var hasPause, isPaused, hasEditorSettings bool
+		var hasPause, isPaused, hasEditorSettings, hasDependencies bool
 		original := ruleGroupConfig.Rules[idx]
 		if alert := original.GrafanaManagedAlert; alert != nil {
 			if alert.IsPaused != nil {
@@ -370,6 +372,7 @@ func ValidateRuleGroup(
 			if alert.Metadata != nil {
 				hasEditorSettings = true
 			}
+			hasDependencies = alert.Dependencies != nil
 		}
 
 		ruleWithOptionals := ngmodels.AlertRuleWithOptionals{}
@@ -378,6 +381,7 @@ func ValidateRuleGroup(
 		ruleWithOptionals.AlertRule = *rule
 		ruleWithOptionals.HasPause = hasPause
 		ruleWithOptionals.HasEditorSettings = hasEditorSettings
+		ruleWithOptionals.HasDependencies = hasDependencies
 
 		result = append(result, &ruleWithOptionals)
 	}
@@ -0,0 +1,220 @@
+package backtesting
+
+import (
+	"sort"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+// NotificationPolicy is a notification policy that an alert is routed to.
+type NotificationPolicy struct {
+	// Key identifies the policy in the notification policy tree.
+	Key            string
+	Receiver       string
+	GroupBy        []model.LabelName
+	GroupByAll     bool
+	GroupWait      time.Duration
+	GroupInterval  time.Duration
+	RepeatInterval time.Duration
+	// Muted is true if the time intervals of the policy mute notifications at the given time.
+	Muted bool
+}
+
+// NotificationRouter returns the notification policies that an alert with the given labels is routed to at the given time.
+type NotificationRouter func(lbls model.LabelSet, at time.Time) []NotificationPolicy
+
+// Notification is a notification that the Alertmanager would have sent.
+type Notification struct {
+	Time        time.Time
+	Receiver    string
+	GroupLabels model.LabelSet
+	Firing      []model.LabelSet
+	Resolved    []model.LabelSet
+}
+
+type simulatedAlert struct {
+	labels model.LabelSet
+	endsAt time.Time
+}
+
+// aggregationGroup mirrors the aggregation group of the Alertmanager dispatcher together with its notification log entry.
+type aggregationGroup struct {
+	key       string
+	policy    NotificationPolicy
+	labels    model.LabelSet
+	alerts    map[model.Fingerprint]simulatedAlert
+	nextFlush time.Time
+
+	notified     bool
+	lastFiring   map[model.Fingerprint]struct{}
+	lastResolved map[model.Fingerprint]struct{}
+	lastNotified time.Time
+}
+
+// notificationSimulator replays alerts through the grouping, timing and deduplication of the Alertmanager
+// without sending any notification. It assumes that all integrations send resolved notifications.
+type notificationSimulator struct {
+	router        NotificationRouter
+	groups        map[string]*aggregationGroup
+	notifications []Notification
+}
+
+func newNotificationSimulator(router NotificationRouter) *notificationSimulator {
+	return &notificationSimulator{
+		router: router,
+		groups: make(map[string]*aggregationGroup),
+	}
+}
+
+// receive flushes the aggregation groups that are due at the given time and then adds the alerts
+// sent to the Alertmanager at that time.
+func (s *notificationSimulator) receive(at time.Time, alerts []simulatedAlert) {
+	s.flush(at)
+	for _, alert := range alerts {
+		for _, policy := range s.router(alert.labels, at) {
+			groupLabels := groupLabels(policy, alert.labels)
+			key := policy.Key + ":" + groupLabels.String()
+			g, ok := s.groups[key]
+			if !ok {
+				if !alert.endsAt.After(at) {
+					// A resolved alert of an unknown group is dropped after the first flush without a notification.
+					continue
+				}
+				g = &aggregationGroup{
+					key:       key,
+					policy:    policy,
+					labels:    groupLabels,
+					alerts:    make(map[model.Fingerprint]simulatedAlert),
+					nextFlush: at.Add(policy.GroupWait),
+				}
+				s.groups[key] = g
+			}
+			g.alerts[alert.labels.Fingerprint()] = alert
+		}
+	}
+}
+
+// flush flushes the aggregation groups that are due until the given time in chronological order.
+func (s *notificationSimulator) flush(until time.Time) {
+	for {
+		var next *aggregationGroup
+		for _, g := range s.groups {
+			if g.nextFlush.After(until) {
+				continue
+			}
+			if next == nil || g.nextFlush.Before(next.nextFlush) || (g.nextFlush.Equal(next.nextFlush) && g.key < next.key) {
+				next = g
+			}
+		}
+		if next == nil {
+			return
+		}
+		s.flushGroup(next)
+	}
+}
+
+func (s *notificationSimulator) flushGroup(g *aggregationGroup) {
+	now := g.nextFlush
+	g.nextFlush = now.Add(g.policy.GroupInterval)
+
+	var firing, resolved []model.Fingerprint
+	for fp, alert := range g.alerts {
+		if alert.endsAt.After(now) {
+			firing = append(firing, fp)
+		} else {
+			resolved = append(resolved, fp)
+		}
+	}
+	sort.Slice(firing, func(i, j int) bool { return firing[i] < firing[j] })
+	sort.Slice(resolved, func(i, j int) bool { return resolved[i] < resolved[j] })
+
+	if !s.muted(g, now) && g.needsUpdate(firing, resolved, now) {
+		n := Notification{
+			Time:        now,
+			Receiver:    g.policy.Receiver,
+			GroupLabels: g.labels,
+		}
+		for _, fp := range firing {
+			n.Firing = append(n.Firing, g.alerts[fp].labels)
+		}
+		for _, fp := range resolved {
+			n.Resolved = append(n.Resolved, g.alerts[fp].labels)
+		}
+		s.notifications = append(s.notifications, n)
+
+		g.notified = true
+		g.lastFiring = fingerprintSet(firing)
+		g.lastResolved = fingerprintSet(resolved)
+		g.lastNotified = now
+	}
+
+	// Resolved alerts are removed after the flush, and empty groups are removed as well.
+	for _, fp := range resolved {
+		delete(g.alerts, fp)
+	}
+	if len(g.alerts) == 0 {
+		delete(s.groups, g.key)
+	}
+}
+
+// muted returns true if the time intervals of the policy of the group mute notifications at the given time.
+func (s *notificationSimulator) muted(g *aggregationGroup, at time.Time) bool {
+	for _, alert := range g.alerts {
+		for _, policy := range s.router(alert.labels, at) {
+			if policy.Key == g.policy.Key {
+				return policy.Muted
+			}
+		}
+		break
+	}
+	return false
+}
+
+// needsUpdate mirrors the deduplication stage of the Alertmanager notification pipeline.
+func (g *aggregationGroup) needsUpdate(firing, resolved []model.Fingerprint, now time.Time) bool {
+	if !g.notified {
+		return len(firing) > 0
+	}
+	if !isSubset(firing, g.lastFiring) {
+		return true
+	}
+	// Notify about the resolved alerts once all alerts are resolved.
+	if len(firing) == 0 {
+		return len(g.lastFiring) > 0
+	}
+	if !isSubset(resolved, g.lastResolved) {
+		return true
+	}
+	return !g.lastNotified.Add(g.policy.RepeatInterval).After(now)
+}
+
+func groupLabels(policy NotificationPolicy, lbls model.LabelSet) model.LabelSet {
+	if policy.GroupByAll {
+		return lbls.Clone()
+	}
+	result := make(model.LabelSet, len(policy.GroupBy))
+	for _, name := range policy.GroupBy {
+		if v, ok := lbls[name]; ok {
+			result[name] = v
+		}
+	}
+	return result
+}
+
+func fingerprintSet(fps []model.Fingerprint) map[model.Fingerprint]struct{} {
+	result := make(map[model.Fingerprint]struct{}, len(fps))
+	for _, fp := range fps {
+		result[fp] = struct{}{}
+	}
+	return result
+}
+
+func isSubset(fps []model.Fingerprint, set map[model.Fingerprint]struct{}) bool {
+	for _, fp := range fps {
+		if _, ok := set[fp]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,98 @@
+package backtesting
+
+import (
+	"context"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+)
+
+// Transition is a change of the state of an alert instance.
+type Transition struct {
+	Time          time.Time
+	Labels        data.Labels
+	PreviousState string
+	State         string
+}
+
+// ReplayResult is the result of replaying an alert rule over a time range.
+type ReplayResult struct {
+	Transitions   []Transition
+	Notifications []Notification
+}
+
+// Replay evaluates the rule over the time range and processes the results by the state manager the same way the scheduler does.
+// The alerts that the scheduler would have sent to the Alertmanager are routed by the router, and grouped and deduplicated
+// the same way the Alertmanager does. It returns the state transitions and the notifications that would have been sent.
+func (e *Engine) Replay(ctx context.Context, user identity.Requester, rule *models.AlertRule, from, to time.Time, router NotificationRouter) (*ReplayResult, error) {
+	ruleCtx := models.WithRuleKey(ctx, rule.GetKey())
+	logger := logger.FromContext(ctx)
+
+	length, err := evaluations(rule, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	stateManager := e.createStateManager()
+
+	evaluator, err := createEvaluator(ruleCtx, e.evalFactory, user, rule, stateManager)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Start replaying alert rule", "from", from, "to", to, "interval", rule.IntervalSeconds, "evaluations", length)
+
+	start := time.Now()
+	result := &ReplayResult{}
+	notifications := newNotificationSimulator(router)
+	features := featuremgmt.WithFeatures()
+	// The alerts have the same built-in labels as the alerts sent by the scheduler, except for the folder title.
+	extraLabels := state.GetRuleExtraLabels(logger, rule, "", false)
+
+	err = evaluator.Eval(ruleCtx, from, time.Duration(rule.IntervalSeconds)*time.Second, length, func(idx int, currentTime time.Time, results eval.Results) error {
+		if idx >= length {
+			logger.Info("Unexpected evaluation. Skipping", "from", from, "to", to, "interval", rule.IntervalSeconds, "evaluationTime", currentTime, "evaluationIndex", idx, "expectedEvaluations", length)
+			return nil
+		}
+		var alerts []simulatedAlert
+		send := func(_ context.Context, states state.StateTransitions) {
+			for _, s := range states {
+				alert := state.StateToPostableAlert(s, nil, features)
+				lbls := make(model.LabelSet, len(alert.Labels))
+				for name, value := range alert.Labels {
+					lbls[model.LabelName(name)] = model.LabelValue(value)
+				}
+				alerts = append(alerts, simulatedAlert{labels: lbls, endsAt: time.Time(alert.EndsAt)})
+			}
+		}
+		states := stateManager.ProcessEvalResults(ruleCtx, currentTime, rule, results, extraLabels, send)
+		for _, s := range states {
+			if !s.Changed() {
+				continue
+			}
+			result.Transitions = append(result.Transitions, Transition{
+				Time:          currentTime,
+				Labels:        s.Labels,
+				PreviousState: state.FormatStateAndReason(s.PreviousState, s.PreviousStateReason),
+				State:         s.Formatted(),
+			})
+		}
+		notifications.receive(currentTime, alerts)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	notifications.flush(to)
+	result.Notifications = notifications.notifications
+
+	logger.Info("Rule replay finished successfully", "duration", time.Since(start), "transitions", len(result.Transitions), "notifications", len(result.Notifications))
+	return result, nil
+}
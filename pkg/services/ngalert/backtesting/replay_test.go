@@ -0,0 +1,133 @@
+package backtesting
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	prommodel "github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+func TestEngineReplay(t *testing.T) {
+	const interval = 10 * time.Second
+	from := time.Unix(1700000000, 0)
+
+	values := []int64{0, 1, 1, 1, 0, 0, 0, 0, 0}
+	frame := data.NewFrame("test",
+		data.NewField("time", nil, make([]time.Time, len(values))),
+		data.NewField("value", data.Labels{"instance": "a"}, make([]int64, len(values))),
+	)
+	for i, v := range values {
+		frame.SetRow(i, from.Add(time.Duration(i)*interval), v)
+	}
+	model, err := json.Marshal(map[string]any{"data": frame})
+	require.NoError(t, err)
+
+	rule := &models.AlertRule{
+		OrgID:           1,
+		UID:             "test-rule",
+		Title:           "HighValue",
+		Condition:       "A",
+		Data:            []models.AlertQuery{{RefID: "A", DatasourceUID: "__data__", Model: model}},
+		IntervalSeconds: int64(interval.Seconds()),
+		For:             interval,
+		NoDataState:     models.NoData,
+		ExecErrState:    models.ErrorErrState,
+		Labels:          map[string]string{"team": "infra"},
+	}
+
+	var routed []time.Time
+	router := func(lbls prommodel.LabelSet, at time.Time) []NotificationPolicy {
+		routed = append(routed, at)
+		return []NotificationPolicy{{
+			Key:            "0",
+			Receiver:       "infra",
+			GroupBy:        []prommodel.LabelName{prommodel.AlertNameLabel},
+			GroupWait:      5 * time.Second,
+			GroupInterval:  20 * time.Second,
+			RepeatInterval: time.Hour,
+		}}
+	}
+
+	engine := NewEngine(nil, nil, tracing.InitializeTracerForTest())
+	result, err := engine.Replay(context.Background(), nil, rule, from, from.Add(time.Duration(len(values))*interval), router)
+	require.NoError(t, err)
+	require.NotEmpty(t, routed)
+
+	states := make([]string, 0, len(result.Transitions))
+	for _, tr := range result.Transitions {
+		require.Equal(t, "a", tr.Labels["instance"])
+		states = append(states, tr.PreviousState+" -> "+tr.State)
+	}
+	require.Equal(t, []string{"Normal -> Pending", "Pending -> Alerting", "Alerting -> Normal"}, states)
+	require.Equal(t, from.Add(interval), result.Transitions[0].Time)
+	require.Equal(t, from.Add(2*interval), result.Transitions[1].Time)
+	require.Equal(t, from.Add(4*interval), result.Transitions[2].Time)
+
+	require.Len(t, result.Notifications, 2)
+	firing := result.Notifications[0]
+	require.Equal(t, from.Add(2*interval+5*time.Second), firing.Time)
+	require.Equal(t, "infra", firing.Receiver)
+	require.Equal(t, prommodel.LabelSet{prommodel.AlertNameLabel: "HighValue"}, firing.GroupLabels)
+	require.Len(t, firing.Firing, 1)
+	require.Equal(t, prommodel.LabelValue("a"), firing.Firing[0]["instance"])
+	require.Empty(t, firing.Resolved)
+
+	resolved := result.Notifications[1]
+	require.Equal(t, from.Add(2*interval+25*time.Second), resolved.Time)
+	require.Empty(t, resolved.Firing)
+	require.Len(t, resolved.Resolved, 1)
+}
+
+func TestNotificationSimulator(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	policy := NotificationPolicy{
+		Key:            "0",
+		Receiver:       "default",
+		GroupByAll:     true,
+		GroupWait:      30 * time.Second,
+		GroupInterval:  5 * time.Minute,
+		RepeatInterval: 4 * time.Hour,
+	}
+	alert := simulatedAlert{labels: prommodel.LabelSet{"alertname": "test"}}
+	firingAt := func(at time.Time) simulatedAlert {
+		a := alert
+		a.endsAt = at.Add(time.Minute)
+		return a
+	}
+
+	t.Run("repeats notifications after the repeat interval", func(t *testing.T) {
+		s := newNotificationSimulator(func(prommodel.LabelSet, time.Time) []NotificationPolicy { return []NotificationPolicy{policy} })
+		for at := start; at.Before(start.Add(9 * time.Hour)); at = at.Add(time.Minute) {
+			s.receive(at, []simulatedAlert{firingAt(at)})
+		}
+		times := make([]time.Time, 0, len(s.notifications))
+		for _, n := range s.notifications {
+			times = append(times, n.Time)
+		}
+		first := start.Add(30 * time.Second)
+		require.Equal(t, []time.Time{first, first.Add(4 * time.Hour), first.Add(8 * time.Hour)}, times)
+	})
+
+	t.Run("does not notify while muted", func(t *testing.T) {
+		muted := policy
+		muted.Muted = true
+		s := newNotificationSimulator(func(_ prommodel.LabelSet, at time.Time) []NotificationPolicy {
+			if at.Before(start.Add(time.Hour)) {
+				return []NotificationPolicy{muted}
+			}
+			return []NotificationPolicy{policy}
+		})
+		for at := start; at.Before(start.Add(2 * time.Hour)); at = at.Add(time.Minute) {
+			s.receive(at, []simulatedAlert{firingAt(at)})
+		}
+		require.Len(t, s.notifications, 1)
+		require.False(t, s.notifications[0].Time.Before(start.Add(time.Hour)))
+	})
+}
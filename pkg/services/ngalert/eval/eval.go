@@ -300,6 +300,11 @@ const (
 	// that evaluated to false (Normal) but has not yet met
 	// the KeepFiringFor duration defined in AlertRule.
 	Recovering
+
+	// Suppressed is the eval state for an alert instance condition
+	// that did not evaluate to false (Normal) while a rule the
+	// AlertRule depends on was firing.
+	Suppressed
 )
 
 func (s State) IsValid() bool {
@@ -307,7 +312,7 @@ func (s State) IsValid() bool {
 }
 
 func (s State) String() string {
-	return [...]string{"Normal", "Alerting", "Pending", "NoData", "Error", "Recovering"}[s]
+	return [...]string{"Normal", "Alerting", "Pending", "NoData", "Error", "Recovering", "Suppressed"}[s]
 }
 
 func ParseStateString(repr string) (State, error) {
@@ -324,6 +329,8 @@ func ParseStateString(repr string) (State, error) {
 		return Error, nil
 	case "recovering":
 		return Recovering, nil
+	case "suppressed":
+		return Suppressed, nil
 	default:
 		return -1, fmt.Errorf("invalid state: %s", repr)
 	}
@@ -857,6 +864,9 @@ func (e *evaluatorImpl) create(ctx context.Context, condition models.Condition,
 	if err != nil {
 		return nil, err
 	}
+	if err := validateAlertPipeline(pipeline); err != nil {
+		return nil, err
+	}
 	conditions := make([]string, 0, len(pipeline))
 	for _, node := range pipeline {
 		if node.RefID() == condition.Condition {
@@ -872,3 +882,19 @@ func (e *evaluatorImpl) create(ctx context.Context, condition models.Condition,
 	}
 	return nil, models.ErrConditionNotExist(condition.Condition, conditions)
 }
+
+// validateAlertPipeline returns an error if the pipeline has expressions that can not be used in alert rules.
+// Anomaly detection with bands returns the band series in the same result as the anomaly flags, so every band
+// would become an alert instance of the rule.
+func validateAlertPipeline(pipeline expr.DataPipeline) error {
+	for _, node := range pipeline {
+		cmdNode, ok := node.(*expr.CMDNode)
+		if !ok {
+			continue
+		}
+		if anomaly, ok := cmdNode.Command.(*expr.AnomalyCommand); ok && anomaly.Bands {
+			return fmt.Errorf("anomaly detection '%s' is not allowed to return bands in an alert rule", node.RefID())
+		}
+	}
+	return nil
+}
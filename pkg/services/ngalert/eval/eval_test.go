@@ -2,6 +2,7 @@ package eval
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/rand"
@@ -753,6 +754,97 @@ func TestCreate_HysteresisCommand(t *testing.T) {
 	}
 }
 
+func TestCreate_AnomalyBands(t *testing.T) {
+	anomaly := func(refID, inputRefID string, bands bool) models.AlertQuery {
+		return models.AlertQuery{
+			RefID:         refID,
+			QueryType:     expr.DatasourceType,
+			DatasourceUID: expr.DatasourceUID,
+			Model: json.RawMessage(fmt.Sprintf(`{
+				"refId": "%s",
+				"type": "anomaly",
+				"datasource": {"uid": "%s", "type": "%s"},
+				"expression": "%s",
+				"algorithm": "zscore",
+				"window": "1h",
+				"bands": %t
+			}`, refID, expr.DatasourceUID, expr.DatasourceType, inputRefID, bands)),
+		}
+	}
+
+	testCases := []struct {
+		name      string
+		condition func(dsQuery models.AlertQuery) models.Condition
+		error     string
+	}{
+		{
+			name: "fail if anomaly detection with bands is the condition",
+			condition: func(dsQuery models.AlertQuery) models.Condition {
+				return models.Condition{
+					Condition: "B",
+					Data:      []models.AlertQuery{dsQuery, anomaly("B", dsQuery.RefID, true)},
+				}
+			},
+			error: "anomaly detection 'B' is not allowed to return bands in an alert rule",
+		},
+		{
+			name: "fail if the condition reduces anomaly detection with bands",
+			condition: func(dsQuery models.AlertQuery) models.Condition {
+				return models.Condition{
+					Condition: "C",
+					Data: []models.AlertQuery{
+						dsQuery,
+						anomaly("B", dsQuery.RefID, true),
+						models.CreateReduceExpression("C", "B", "last"),
+					},
+				}
+			},
+			error: "anomaly detection 'B' is not allowed to return bands in an alert rule",
+		},
+		{
+			name: "anomaly detection without bands can be the condition",
+			condition: func(dsQuery models.AlertQuery) models.Condition {
+				return models.Condition{
+					Condition: "B",
+					Data:      []models.AlertQuery{dsQuery, anomaly("B", dsQuery.RefID, false)},
+				}
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dsQuery := models.GenerateAlertQuery()
+			ds := &datasources.DataSource{
+				UID:  dsQuery.DatasourceUID,
+				Type: util.GenerateShortUID(),
+			}
+			cacheService := &fakes.FakeCacheService{DataSources: []*datasources.DataSource{ds}}
+			evaluator := NewEvaluatorFactory(
+				setting.UnifiedAlertingSettings{},
+				cacheService,
+				expr.ProvideService(
+					&setting.Cfg{ExpressionsEnabled: true},
+					nil,
+					nil,
+					featuremgmt.WithFeatures(),
+					nil,
+					tracing.InitializeTracerForTest(),
+					dsquerierclient.NewNullQSDatasourceClientBuilder(),
+				),
+			)
+			evalCtx := NewContext(context.Background(), &user.SignedInUser{})
+
+			_, err := evaluator.Create(evalCtx, testCase.condition(dsQuery))
+			if testCase.error != "" {
+				require.ErrorContains(t, err, testCase.error)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestQueryDataResponseToExecutionResults(t *testing.T) {
 	t.Run("should set datasource type for captured values", func(t *testing.T) {
 		c := models.Condition{
@@ -54,6 +54,9 @@ func (e *ConditionValidator) Validate(ctx EvaluationContext, condition models.Co
 	if err != nil {
 		return err
 	}
+	if err := validateAlertPipeline(pipeline); err != nil {
+		return err
+	}
 	refIDs := make([]string, 0, len(pipeline))
 	for _, node := range pipeline {
 		if node.RefID() == condition.Condition {
@@ -317,6 +317,9 @@ type AlertRule struct {
 	// If nil, alerts resolve after 2 missing evaluation intervals
 	// (i.e., resolution occurs during the second evaluation where data is absent).
 	MissingSeriesEvalsToResolve *int64
+	// Dependencies are the rules the alert rule depends on. While any of them is firing,
+	// the alert instances of the rule are suppressed.
+	Dependencies []AlertRuleDependency
 }
 
 type AlertRuleMetadata struct {
@@ -354,6 +357,7 @@ type AlertRuleWithOptionals struct {
 	// DB in case it was not sent.
 	HasPause          bool
 	HasEditorSettings bool
+	HasDependencies   bool
 }
 
 // AlertsRulesBy is a function that defines the ordering of alert rules.
@@ -726,6 +730,10 @@ func validateAlertRuleFields(rule *AlertRule) error {
 		return errors.New("field `missing_series_evals_to_resolve` must be greater than 0")
 	}
 
+	if err := validateDependencies(rule); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -850,6 +858,10 @@ func (alertRule *AlertRule) Copy() *AlertRule {
 		result.NotificationSettings = append(result.NotificationSettings, CopyNotificationSettings(s))
 	}
 
+	for _, d := range alertRule.Dependencies {
+		result.Dependencies = append(result.Dependencies, CopyAlertRuleDependency(d))
+	}
+
 	return &result
 }
 
@@ -861,6 +873,7 @@ func ClearRecordingRuleIgnoredFields(rule *AlertRule) {
 	rule.KeepFiringFor = 0
 	rule.NotificationSettings = nil
 	rule.MissingSeriesEvalsToResolve = nil
+	rule.Dependencies = nil
 }
 
 // GetAlertRuleByUIDQuery is the query for retrieving/deleting an alert rule by UID and organisation ID.
@@ -1066,6 +1079,9 @@ func PatchPartialAlertRule(existingRule *AlertRule, ruleToPatch *AlertRuleWithOp
 	if !ruleToPatch.HasEditorSettings {
 		ruleToPatch.Metadata.EditorSettings = existingRule.Metadata.EditorSettings
 	}
+	if !ruleToPatch.HasDependencies {
+		ruleToPatch.Dependencies = existingRule.Dependencies
+	}
 	if ruleToPatch.MissingSeriesEvalsToResolve != nil && *ruleToPatch.MissingSeriesEvalsToResolve == -1 {
 		ruleToPatch.MissingSeriesEvalsToResolve = existingRule.MissingSeriesEvalsToResolve
 	}
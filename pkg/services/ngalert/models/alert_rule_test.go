@@ -1040,9 +1040,10 @@ func TestAlertRuleCopy(t *testing.T) {
 // This test makes sure the default generator
 func TestGeneratorFillsAllFields(t *testing.T) {
 	ignoredFields := map[string]struct{}{
-		"ID":       {},
-		"IsPaused": {},
-		"Record":   {},
+		"ID":           {},
+		"IsPaused":     {},
+		"Record":       {},
+		"Dependencies": {},
 	}
 
 	tpe := reflect.TypeOf(AlertRule{})
@@ -1085,6 +1086,7 @@ func TestGeneratorFillsAllRecordingRuleFields(t *testing.T) {
 		"MissingSeriesEvalsToResolve": {},
 		"For":                         {},
 		"NotificationSettings":        {},
+		"Dependencies":                {},
 	}
 
 	tpe := reflect.TypeOf(AlertRule{})
@@ -1210,6 +1212,70 @@ func TestValidateAlertRule(t *testing.T) {
 		}
 	})
 
+	t.Run("dependencies", func(t *testing.T) {
+		testCases := []struct {
+			name                  string
+			dependencies          []AlertRuleDependency
+			expectedErrorContains string
+		}{
+			{
+				name: "should accept dependency with matchers and equal labels",
+				dependencies: []AlertRuleDependency{
+					{RuleUID: "other", Matchers: []string{`cluster=~"prod-.*"`}, Equal: []string{"cluster"}},
+				},
+			},
+			{
+				name:                  "should reject empty rule UID",
+				dependencies:          []AlertRuleDependency{{}},
+				expectedErrorContains: "rule UID must not be empty",
+			},
+			{
+				name: "should reject invalid matcher",
+				dependencies: []AlertRuleDependency{
+					{RuleUID: "other", Matchers: []string{`cluster=~"(`}},
+				},
+				expectedErrorContains: "invalid matcher",
+			},
+			{
+				name: "should reject invalid label name",
+				dependencies: []AlertRuleDependency{
+					{RuleUID: "other", Equal: []string{""}},
+				},
+				expectedErrorContains: "invalid label name",
+			},
+			{
+				name:                  "should reject dependency on itself",
+				dependencies:          []AlertRuleDependency{{RuleUID: "rule"}},
+				expectedErrorContains: "rule cannot depend on itself",
+			},
+			{
+				name:                  "should reject duplicate dependencies",
+				dependencies:          []AlertRuleDependency{{RuleUID: "other"}, {RuleUID: "other"}},
+				expectedErrorContains: "rule other is a dependency more than once",
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				rule := RuleGen.With(
+					RuleMuts.WithUID("rule"),
+					RuleMuts.WithIntervalSeconds(10),
+					RuleMuts.WithDependencies(tc.dependencies...),
+				).Generate()
+
+				err := rule.ValidateAlertRule(setting.UnifiedAlertingSettings{BaseInterval: 10 * time.Second})
+
+				if tc.expectedErrorContains != "" {
+					require.Error(t, err)
+					require.ErrorIs(t, err, ErrAlertRuleFailedValidation)
+					require.Contains(t, err.Error(), tc.expectedErrorContains)
+				} else {
+					require.NoError(t, err)
+				}
+			})
+		}
+	})
+
 	t.Run("ExecErrState & NoDataState", func(t *testing.T) {
 		testCases := []struct {
 			name         string
@@ -0,0 +1,106 @@
+package models
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"unsafe"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/common/model"
+)
+
+// AlertRuleDependency is a rule that an alert rule depends on. While an alert instance of the dependency is firing,
+// the alert instances of the dependent rule are suppressed instead of firing.
+type AlertRuleDependency struct {
+	// RuleUID is the UID of the rule in the same organization the alert rule depends on.
+	RuleUID string `json:"rule_uid"`
+	// Matchers select the alert instances of the dependency that suppress the dependent rule.
+	// They use the Prometheus matcher syntax, for example `cluster=~"prod-.*"`. If empty, all alert instances are selected.
+	Matchers []string `json:"matchers,omitempty"`
+	// Equal is the list of labels that must have the same value in the alert instance of the dependency
+	// and the suppressed alert instance. If empty, an alert instance of the dependency suppresses all alert instances.
+	Equal []string `json:"equal,omitempty"`
+}
+
+// ParseMatchers parses the matchers of the dependency.
+func (d AlertRuleDependency) ParseMatchers() (labels.Matchers, error) {
+	result := make(labels.Matchers, 0, len(d.Matchers))
+	for _, s := range d.Matchers {
+		m, err := labels.ParseMatcher(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid matcher %q: %w", s, err)
+		}
+		result = append(result, m)
+	}
+	return result, nil
+}
+
+// Validate checks that the dependency refers to a rule and has valid matchers and label names.
+func (d AlertRuleDependency) Validate() error {
+	if d.RuleUID == "" {
+		return errors.New("rule UID must not be empty")
+	}
+	if _, err := d.ParseMatchers(); err != nil {
+		return err
+	}
+	for _, l := range d.Equal {
+		if !model.LabelName(l).IsValid() {
+			return fmt.Errorf("invalid label name %q in equal", l)
+		}
+	}
+	return nil
+}
+
+func (d AlertRuleDependency) Fingerprint() data.Fingerprint {
+	h := fnv.New64()
+	tmp := make([]byte, 8)
+
+	writeString := func(s string) {
+		// save on extra slice allocation when string is converted to bytes.
+		_, _ = h.Write(unsafe.Slice(unsafe.StringData(s), len(s))) //nolint:gosec
+		// ignore errors returned by Write method because fnv never returns them.
+		_, _ = h.Write([]byte{255}) // use an invalid utf-8 sequence as separator
+	}
+	writeStrings := func(ss []string) {
+		binary.LittleEndian.PutUint64(tmp, uint64(len(ss)))
+		_, _ = h.Write(tmp)
+		for _, s := range ss {
+			writeString(s)
+		}
+	}
+
+	writeString(d.RuleUID)
+	writeStrings(d.Matchers)
+	writeStrings(d.Equal)
+	return data.Fingerprint(h.Sum64())
+}
+
+// CopyAlertRuleDependency creates a deep copy of the AlertRuleDependency.
+func CopyAlertRuleDependency(d AlertRuleDependency) AlertRuleDependency {
+	return AlertRuleDependency{
+		RuleUID:  d.RuleUID,
+		Matchers: slices.Clone(d.Matchers),
+		Equal:    slices.Clone(d.Equal),
+	}
+}
+
+func validateDependencies(rule *AlertRule) error {
+	seen := make(map[string]struct{}, len(rule.Dependencies))
+	for _, d := range rule.Dependencies {
+		if err := d.Validate(); err != nil {
+			return fmt.Errorf("invalid dependency: %w", err)
+		}
+		if rule.UID != "" && d.RuleUID == rule.UID {
+			return errors.New("rule cannot depend on itself")
+		}
+		if _, ok := seen[d.RuleUID]; ok {
+			return fmt.Errorf("rule %s is a dependency more than once", d.RuleUID)
+		}
+		seen[d.RuleUID] = struct{}{}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// EvaluationTrace is a record of a single evaluation of an alert rule. It holds what every query and expression of
+// the rule returned, so that the evaluation can be inspected when the rule does not behave as expected.
+type EvaluationTrace struct {
+	// EvaluatedAt is the time for which the rule was evaluated.
+	EvaluatedAt time.Time
+	// Attempt is the number of the attempt, starting with 1. Failed evaluations are retried.
+	Attempt int64
+	// Duration is how long it took to execute all queries and expressions.
+	Duration time.Duration
+	// Error is the error that made the whole evaluation fail, if any.
+	Error error
+	// Nodes contains a node for each query and expression of the rule, in the order in which they were executed.
+	Nodes []EvaluationTraceNode
+	// States is the number of alert instances for each evaluation state. It is empty for recording rules.
+	States map[string]int
+}
+
+// EvaluationTraceNode is the result of a query or expression within an evaluation.
+type EvaluationTraceNode struct {
+	RefID string
+	// Duration is how long it took to execute the node. Queries to the same data source are sent in a single
+	// request and have the same duration.
+	Duration time.Duration
+	// DatasourceFrames are the frames that the data source returned for a query, before they were converted.
+	// It is empty for expressions.
+	DatasourceFrames data.Frames
+	// Frames is the output of the node.
+	Frames data.Frames
+	Error  error
+	// Truncated is true if frames or rows were dropped from DatasourceFrames or Frames to limit the size of the trace.
+	Truncated bool
+}
@@ -13,6 +13,8 @@ type HistoryQuery struct {
 	DashboardUID string
 	PanelID      int64
 	Labels       map[string]string
+	// Contains are words that the log lines of the entries must contain, ignoring case.
+	Contains     []string
 	From         time.Time
 	To           time.Time
 	Limit        int
@@ -42,6 +42,8 @@ const (
 	InstanceStateError InstanceStateType = "Error"
 	// InstanceStateRecovering is for a recovering alert.
 	InstanceStateRecovering InstanceStateType = "Recovering"
+	// InstanceStateSuppressed is for an alert suppressed by a firing dependency.
+	InstanceStateSuppressed InstanceStateType = "Suppressed"
 )
 
 // IsValid checks that the value of InstanceStateType is a valid
@@ -52,7 +54,8 @@ func (i InstanceStateType) IsValid() bool {
 		i == InstanceStateNoData ||
 		i == InstanceStatePending ||
 		i == InstanceStateError ||
-		i == InstanceStateRecovering
+		i == InstanceStateRecovering ||
+		i == InstanceStateSuppressed
 }
 
 // ListAlertInstancesQuery is the query list alert Instances.
@@ -10,10 +10,12 @@ const (
 	ProvenanceFile Provenance = "file"
 	// ProvenanceConvertedPrometheus is used for objects converted from Prometheus definitions.
 	ProvenanceConvertedPrometheus Provenance = "converted_prometheus"
+	// ProvenanceTemplate is used for alert rules created from rule templates.
+	ProvenanceTemplate Provenance = "template"
 )
 
 var (
-	KnownProvenances = []Provenance{ProvenanceNone, ProvenanceAPI, ProvenanceFile, ProvenanceConvertedPrometheus}
+	KnownProvenances = []Provenance{ProvenanceNone, ProvenanceAPI, ProvenanceFile, ProvenanceConvertedPrometheus, ProvenanceTemplate}
 )
 
 // Provisionable represents a resource that can be created through a provisioning mechanism, such as Terraform or config file.
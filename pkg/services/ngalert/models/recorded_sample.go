@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// RecordedSample is a sample of a recording rule that writes to the Grafana database.
+type RecordedSample struct {
+	OrgID  int64
+	Metric string
+	Labels data.Labels
+	Time   time.Time
+	Value  float64
+}
+
+// GetRecordedSamplesQuery is the query to get the samples of a metric that were written to the Grafana database.
+type GetRecordedSamplesQuery struct {
+	OrgID  int64
+	Metric string
+	// Labels filters the samples to the series that have all of the labels.
+	Labels data.Labels
+	From   time.Time
+	To     time.Time
+	// Limit is the maximum number of samples to return. The latest samples are returned if the limit is exceeded.
+	Limit int
+}
@@ -0,0 +1,287 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/robfig/cron/v3"
+
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+const (
+	// MaxRecurringSilenceWindow is the longest window that is materialized as a single silence. Longer windows are
+	// split into consecutive silences.
+	MaxRecurringSilenceWindow = 7 * 24 * time.Hour
+
+	// recurringSilenceSearchHorizon is how far ahead the schedule is searched for the next window.
+	recurringSilenceSearchHorizon = 366 * 24 * time.Hour
+)
+
+var (
+	ErrRecurringSilenceNotFound = errutil.NotFound("alerting.notifications.recurring-silences.notFound", errutil.WithPublicMessage("Recurring silence not found"))
+	ErrRecurringSilenceExists   = errutil.Conflict("alerting.notifications.recurring-silences.exists", errutil.WithPublicMessage("Recurring silence with this UID already exists. Use a different UID or update the existing one."))
+	ErrRecurringSilenceInvalid  = errutil.BadRequest("alerting.notifications.recurring-silences.invalidFormat").MustTemplate("Invalid recurring silence: {{ .Public.Reason }}", errutil.WithPublic("Invalid recurring silence: {{ .Public.Reason }}"))
+	ErrRecurringSilenceConflict = errutil.Conflict("alerting.notifications.recurring-silences.conflict").MustTemplate("Provided version {{ .Public.Version }} of recurring silence {{ .Public.UID }} does not match current version {{ .Public.CurrentVersion }}", errutil.WithPublic("Provided version {{ .Public.Version }} of recurring silence {{ .Public.UID }} does not match current version {{ .Public.CurrentVersion }}"))
+)
+
+func MakeErrRecurringSilenceInvalid(err error) error {
+	return ErrRecurringSilenceInvalid.Build(errutil.TemplateData{Public: map[string]any{"Reason": err.Error()}, Error: err})
+}
+
+func MakeErrRecurringSilenceConflict(uid string, version, currentVersion int64) error {
+	return ErrRecurringSilenceConflict.Build(errutil.TemplateData{Public: map[string]any{"UID": uid, "Version": version, "CurrentVersion": currentVersion}})
+}
+
+// RecurringSilence is a silence that repeats on a schedule. A regular silence with the same matchers is created
+// for each window of the schedule shortly before the window starts.
+type RecurringSilence struct {
+	UID       string
+	OrgID     int64
+	Matchers  amv2.Matchers
+	Comment   string
+	CreatedBy string
+	Schedule  RecurringSilenceSchedule
+	// Version is incremented every time the recurring silence is changed.
+	Version    int64
+	Updated    time.Time
+	Provenance Provenance
+	// Window is the window of the schedule that was last created as a silence.
+	Window RecurringSilenceWindow
+}
+
+// RecurringSilenceSchedule defines the windows of a recurring silence either with a cron expression and a duration
+// or with time intervals.
+type RecurringSilenceSchedule struct {
+	// Cron is a cron expression in the standard five-field format that defines the start of each window. The times
+	// are in UTC unless the expression is prefixed with CRON_TZ=<location>.
+	Cron string `json:"cron,omitempty"`
+	// Duration is the length of each window that starts at a cron occurrence.
+	Duration time.Duration `json:"duration,omitempty"`
+	// TimeIntervals define the windows in the same way as time intervals of mute timings.
+	TimeIntervals []timeinterval.TimeInterval `json:"time_intervals,omitempty"`
+}
+
+// RecurringSilenceWindow is a window of the schedule of a recurring silence and the silence that was created for it.
+type RecurringSilenceWindow struct {
+	SilenceID string
+	StartsAt  time.Time
+	EndsAt    time.Time
+	// Version is the version of the recurring silence that the silence was created for.
+	Version int64
+}
+
+func (s *RecurringSilence) ResourceType() string {
+	return "recurringSilence"
+}
+
+func (s *RecurringSilence) ResourceID() string {
+	return s.UID
+}
+
+// Validate checks the recurring silence and generates a UID if it does not have one.
+func (s *RecurringSilence) Validate() error {
+	if s.UID == "" {
+		s.UID = util.GenerateShortUID()
+	} else if !util.IsValidShortUID(s.UID) {
+		return MakeErrRecurringSilenceInvalid(errors.New("uid must contain only alphanumeric characters, dashes and underscores and be at most 40 characters long"))
+	}
+	if err := validateRecurringSilenceMatchers(s.Matchers); err != nil {
+		return MakeErrRecurringSilenceInvalid(err)
+	}
+	if err := s.Schedule.Validate(); err != nil {
+		return MakeErrRecurringSilenceInvalid(err)
+	}
+	return nil
+}
+
+func validateRecurringSilenceMatchers(matchers amv2.Matchers) error {
+	if len(matchers) == 0 {
+		return errors.New("at least one matcher is required")
+	}
+	for _, m := range matchers {
+		if m == nil {
+			return errors.New("matcher cannot be empty")
+		}
+	}
+	if err := matchers.Validate(strfmt.Default); err != nil {
+		return err
+	}
+	// The same rule as for silences applies, otherwise the recurring silence would mute all alerts.
+	matchesNonEmpty := false
+	for _, m := range matchers {
+		matcher, err := labels.NewMatcher(matchType(*m), *m.Name, *m.Value)
+		if err != nil {
+			return fmt.Errorf("invalid matcher %s: %w", *m.Name, err)
+		}
+		if !matcher.Matches("") {
+			matchesNonEmpty = true
+		}
+	}
+	if !matchesNonEmpty {
+		return errors.New("at least one matcher must not match the empty string")
+	}
+	return nil
+}
+
+// Validate checks that the schedule has either a cron expression with a duration or time intervals.
+func (s RecurringSilenceSchedule) Validate() error {
+	if s.Cron == "" && len(s.TimeIntervals) == 0 {
+		return errors.New("schedule must have either a cron expression or time intervals")
+	}
+	if s.Cron != "" && len(s.TimeIntervals) > 0 {
+		return errors.New("schedule cannot have both a cron expression and time intervals")
+	}
+	if s.Cron != "" {
+		if _, err := cron.ParseStandard(s.Cron); err != nil {
+			return fmt.Errorf("invalid cron expression: %w", err)
+		}
+		if s.Duration <= 0 {
+			return errors.New("duration must be positive when a cron expression is used")
+		}
+	} else if s.Duration != 0 {
+		return errors.New("duration can only be used with a cron expression")
+	}
+	return nil
+}
+
+// NextWindow returns the first window of the schedule that ends after from. If the window is active at from then
+// it starts at from. Overlapping and adjacent windows are merged. Windows longer than MaxRecurringSilenceWindow are
+// split. It returns false if the schedule has no window that starts within a year after from.
+func (s RecurringSilenceSchedule) NextWindow(from time.Time) (time.Time, time.Time, bool) {
+	if s.Cron != "" {
+		return s.nextCronWindow(from)
+	}
+	return s.nextTimeIntervalsWindow(from)
+}
+
+func (s RecurringSilenceSchedule) nextCronWindow(from time.Time) (time.Time, time.Time, bool) {
+	schedule, err := cron.ParseStandard(s.Cron)
+	if err != nil || s.Duration <= 0 {
+		return time.Time{}, time.Time{}, false
+	}
+	// The first occurrence after from-duration is either active at from or the next one.
+	occurrence := schedule.Next(from.Add(-s.Duration))
+	if occurrence.IsZero() || occurrence.Sub(from) > recurringSilenceSearchHorizon {
+		return time.Time{}, time.Time{}, false
+	}
+	start := occurrence
+	if start.Before(from) {
+		start = from
+	}
+	limit := start.Add(MaxRecurringSilenceWindow)
+	end := occurrence.Add(s.Duration)
+	for end.Before(limit) {
+		next := schedule.Next(occurrence)
+		if next.IsZero() || next.After(end) {
+			break
+		}
+		occurrence = next
+		end = occurrence.Add(s.Duration)
+	}
+	if end.After(limit) {
+		end = limit
+	}
+	return start, end, true
+}
+
+func (s RecurringSilenceSchedule) nextTimeIntervalsWindow(from time.Time) (time.Time, time.Time, bool) {
+	if len(s.TimeIntervals) == 0 {
+		return time.Time{}, time.Time{}, false
+	}
+	// Whether a time is contained in the intervals can only change at the boundaries of time ranges and at midnight,
+	// so it is enough to check these times.
+	start := from
+	horizon := from.Add(recurringSilenceSearchHorizon)
+	for !s.containsTime(start) {
+		start = s.nextBoundary(start)
+		if start.After(horizon) {
+			return time.Time{}, time.Time{}, false
+		}
+	}
+	limit := start.Add(MaxRecurringSilenceWindow)
+	end := start
+	for end.Before(limit) && s.containsTime(end) {
+		end = s.nextBoundary(end)
+	}
+	if end.After(limit) {
+		end = limit
+	}
+	return start, end, true
+}
+
+func (s RecurringSilenceSchedule) containsTime(t time.Time) bool {
+	for _, ti := range s.TimeIntervals {
+		if ti.ContainsTime(t.UTC()) {
+			return true
+		}
+	}
+	return false
+}
+
+// nextBoundary returns the earliest time after t at which a time range of one of the intervals starts or ends,
+// or a day starts.
+func (s RecurringSilenceSchedule) nextBoundary(t time.Time) time.Time {
+	var next time.Time
+	for _, ti := range s.TimeIntervals {
+		loc := time.UTC
+		if ti.Location != nil {
+			loc = ti.Location.Location
+		}
+		lt := t.In(loc)
+		year, month, day := lt.Date()
+		candidates := []time.Time{time.Date(year, month, day+1, 0, 0, 0, 0, loc)}
+		for _, tr := range ti.Times {
+			candidates = append(candidates,
+				time.Date(year, month, day, 0, tr.StartMinute, 0, 0, loc),
+				time.Date(year, month, day, 0, tr.EndMinute, 0, 0, loc),
+			)
+		}
+		for _, c := range candidates {
+			if c.After(t) && (next.IsZero() || c.Before(next)) {
+				next = c
+			}
+		}
+	}
+	return next
+}
+
+// DefinitionEquals returns true if both recurring silences create the same silences.
+func (s *RecurringSilence) DefinitionEquals(other *RecurringSilence) bool {
+	if s.Comment != other.Comment || s.CreatedBy != other.CreatedBy {
+		return false
+	}
+	a, errA := json.Marshal([]any{s.Matchers, s.Schedule})
+	b, errB := json.Marshal([]any{other.Matchers, other.Schedule})
+	return errA == nil && errB == nil && bytes.Equal(a, b)
+}
+
+// Silence returns the silence for the window of the recurring silence.
+func (s *RecurringSilence) Silence(startsAt, endsAt time.Time) Silence {
+	start := strfmt.DateTime(startsAt)
+	end := strfmt.DateTime(endsAt)
+	return Silence{
+		Silence: amv2.Silence{
+			Comment:   util.Pointer(s.Comment),
+			CreatedBy: util.Pointer(s.CreatedBy),
+			Matchers:  s.Matchers,
+			StartsAt:  &start,
+			EndsAt:    &end,
+		},
+	}
+}
+
+// AsSilence returns a silence with the matchers of the recurring silence. It is used to authorize access to the
+// recurring silence in the same way as to the silences that are created for it.
+func (s *RecurringSilence) AsSilence() *Silence {
+	silence := s.Silence(s.Window.StartsAt, s.Window.EndsAt)
+	return &silence
+}
@@ -0,0 +1,181 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/grafana/grafana/pkg/util"
+)
+
+func TestRecurringSilenceValidate(t *testing.T) {
+	matchers := amv2.Matchers{{Name: util.Pointer("env"), Value: util.Pointer("staging"), IsEqual: util.Pointer(true), IsRegex: util.Pointer(false)}}
+	cron := RecurringSilenceSchedule{Cron: "0 22 * * *", Duration: 8 * time.Hour}
+
+	testCases := []struct {
+		name        string
+		silence     RecurringSilence
+		errContains string
+	}{
+		{
+			name:    "valid cron schedule",
+			silence: RecurringSilence{Matchers: matchers, Schedule: cron},
+		},
+		{
+			name:    "valid time intervals schedule",
+			silence: RecurringSilence{Matchers: matchers, Schedule: RecurringSilenceSchedule{TimeIntervals: parseTimeIntervals(t, "- weekdays: [saturday, sunday]")}},
+		},
+		{
+			name:        "invalid uid",
+			silence:     RecurringSilence{UID: "not/valid", Matchers: matchers, Schedule: cron},
+			errContains: "uid must contain only",
+		},
+		{
+			name:        "no matchers",
+			silence:     RecurringSilence{Schedule: cron},
+			errContains: "at least one matcher is required",
+		},
+		{
+			name: "matchers match everything",
+			silence: RecurringSilence{
+				Matchers: amv2.Matchers{{Name: util.Pointer("env"), Value: util.Pointer(".*"), IsEqual: util.Pointer(true), IsRegex: util.Pointer(true)}},
+				Schedule: cron,
+			},
+			errContains: "must not match the empty string",
+		},
+		{
+			name:        "empty schedule",
+			silence:     RecurringSilence{Matchers: matchers},
+			errContains: "either a cron expression or time intervals",
+		},
+		{
+			name:        "invalid cron expression",
+			silence:     RecurringSilence{Matchers: matchers, Schedule: RecurringSilenceSchedule{Cron: "0 25 * * *", Duration: time.Hour}},
+			errContains: "invalid cron expression",
+		},
+		{
+			name:        "cron without duration",
+			silence:     RecurringSilence{Matchers: matchers, Schedule: RecurringSilenceSchedule{Cron: "0 22 * * *"}},
+			errContains: "duration must be positive",
+		},
+		{
+			name:        "duration without cron",
+			silence:     RecurringSilence{Matchers: matchers, Schedule: RecurringSilenceSchedule{Duration: time.Hour, TimeIntervals: parseTimeIntervals(t, "- weekdays: [monday]")}},
+			errContains: "duration can only be used with a cron expression",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.silence.Validate()
+			if tc.errContains == "" {
+				require.NoError(t, err)
+				require.NotEmpty(t, tc.silence.UID)
+				return
+			}
+			require.ErrorIs(t, err, ErrRecurringSilenceInvalid)
+			require.ErrorContains(t, err, tc.errContains)
+		})
+	}
+}
+
+func TestRecurringSilenceScheduleNextWindow(t *testing.T) {
+	at := func(s string) time.Time {
+		t.Helper()
+		ts, err := time.Parse(time.RFC3339, s)
+		require.NoError(t, err)
+		return ts
+	}
+
+	testCases := []struct {
+		name     string
+		schedule RecurringSilenceSchedule
+		from     time.Time
+		start    time.Time
+		end      time.Time
+	}{
+		{
+			name:     "cron window after from",
+			schedule: RecurringSilenceSchedule{Cron: "0 22 * * *", Duration: 8 * time.Hour},
+			from:     at("2024-03-04T12:00:00Z"),
+			start:    at("2024-03-04T22:00:00Z"),
+			end:      at("2024-03-05T06:00:00Z"),
+		},
+		{
+			name:     "cron window active at from starts at from",
+			schedule: RecurringSilenceSchedule{Cron: "0 22 * * *", Duration: 8 * time.Hour},
+			from:     at("2024-03-05T02:00:00Z"),
+			start:    at("2024-03-05T02:00:00Z"),
+			end:      at("2024-03-05T06:00:00Z"),
+		},
+		{
+			name:     "cron window ending at from is skipped",
+			schedule: RecurringSilenceSchedule{Cron: "0 22 * * *", Duration: 8 * time.Hour},
+			from:     at("2024-03-05T06:00:00Z"),
+			start:    at("2024-03-05T22:00:00Z"),
+			end:      at("2024-03-06T06:00:00Z"),
+		},
+		{
+			name:     "overlapping cron windows are merged",
+			schedule: RecurringSilenceSchedule{Cron: "0 * * * *", Duration: 90 * time.Minute},
+			from:     at("2024-03-04T12:30:00Z"),
+			start:    at("2024-03-04T12:30:00Z"),
+			end:      at("2024-03-04T12:30:00Z").Add(MaxRecurringSilenceWindow),
+		},
+		{
+			name:     "cron with time zone",
+			schedule: RecurringSilenceSchedule{Cron: "CRON_TZ=Europe/Berlin 0 22 * * *", Duration: time.Hour},
+			from:     at("2024-03-04T12:00:00Z"),
+			start:    at("2024-03-04T21:00:00Z"),
+			end:      at("2024-03-04T22:00:00Z"),
+		},
+		{
+			name:     "time interval window",
+			schedule: RecurringSilenceSchedule{TimeIntervals: parseTimeIntervals(t, "- times: [{start_time: '09:00', end_time: '17:00'}]\n  weekdays: [monday]")},
+			from:     at("2024-03-03T12:00:00Z"),
+			start:    at("2024-03-04T09:00:00Z"),
+			end:      at("2024-03-04T17:00:00Z"),
+		},
+		{
+			name:     "time interval window spanning days is merged",
+			schedule: RecurringSilenceSchedule{TimeIntervals: parseTimeIntervals(t, "- weekdays: [saturday, sunday]")},
+			from:     at("2024-03-06T12:00:00Z"),
+			start:    at("2024-03-09T00:00:00Z"),
+			end:      at("2024-03-11T00:00:00Z"),
+		},
+		{
+			name:     "time interval with location",
+			schedule: RecurringSilenceSchedule{TimeIntervals: parseTimeIntervals(t, "- times: [{start_time: '09:00', end_time: '10:00'}]\n  location: Europe/Berlin")},
+			from:     at("2024-03-04T09:30:00Z"),
+			start:    at("2024-03-05T08:00:00Z"),
+			end:      at("2024-03-05T09:00:00Z"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			start, end, ok := tc.schedule.NextWindow(tc.from)
+			require.True(t, ok)
+			assert.Equal(t, tc.start.UTC(), start.UTC())
+			assert.Equal(t, tc.end.UTC(), end.UTC())
+		})
+	}
+
+	t.Run("no window within a year", func(t *testing.T) {
+		schedule := RecurringSilenceSchedule{TimeIntervals: parseTimeIntervals(t, "- years: ['2020']")}
+		_, _, ok := schedule.NextWindow(at("2024-03-04T12:00:00Z"))
+		require.False(t, ok)
+	})
+}
+
+func parseTimeIntervals(t *testing.T, s string) []timeinterval.TimeInterval {
+	t.Helper()
+	var result []timeinterval.TimeInterval
+	require.NoError(t, yaml.Unmarshal([]byte(s), &result))
+	return result
+}
@@ -0,0 +1,312 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+var (
+	ErrRuleTemplateNotFound = errutil.NotFound("alerting.rule-templates.notFound", errutil.WithPublicMessage("Rule template not found"))
+	ErrRuleTemplateExists   = errutil.Conflict("alerting.rule-templates.exists", errutil.WithPublicMessage("Rule template with this UID already exists. Use a different UID or update the existing one."))
+	ErrRuleTemplateInvalid  = errutil.BadRequest("alerting.rule-templates.invalidFormat").MustTemplate("Invalid rule template: {{ .Public.Reason }}", errutil.WithPublic("Invalid rule template: {{ .Public.Reason }}"))
+	ErrRuleTemplateConflict = errutil.Conflict("alerting.rule-templates.conflict").MustTemplate("Provided version {{ .Public.Version }} of rule template {{ .Public.UID }} does not match current version {{ .Public.CurrentVersion }}", errutil.WithPublic("Provided version {{ .Public.Version }} of rule template {{ .Public.UID }} does not match current version {{ .Public.CurrentVersion }}"))
+)
+
+func MakeErrRuleTemplateInvalid(err error) error {
+	return ErrRuleTemplateInvalid.Build(errutil.TemplateData{Public: map[string]any{"Reason": err.Error()}, Error: err})
+}
+
+func MakeErrRuleTemplateConflict(uid string, version, currentVersion int64) error {
+	return ErrRuleTemplateConflict.Build(errutil.TemplateData{Public: map[string]any{"UID": uid, "Version": version, "CurrentVersion": currentVersion}})
+}
+
+var (
+	ruleTemplateParameterName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	// ruleTemplatePlaceholder matches references to parameters in the form of ${name}.
+	ruleTemplatePlaceholder = regexp.MustCompile(`\$\{([^}]*)\}`)
+)
+
+// RuleTemplate is a parameterized alert rule. A rule is created for each of its instances by replacing the
+// references to the parameters in the rule with the values of the instance. The rules are updated when the template
+// changes and have the provenance ProvenanceTemplate, so that they can be changed only through the template.
+type RuleTemplate struct {
+	UID        string
+	OrgID      int64
+	Title      string
+	Parameters []RuleTemplateParameter
+	Rule       RuleTemplateRule
+	Instances  []RuleTemplateInstance
+	// Version is incremented every time the rule template is changed.
+	Version    int64
+	Updated    time.Time
+	Provenance Provenance
+}
+
+// RuleTemplateParameter is a parameter of a rule template. It is referenced in the rule as ${name}.
+type RuleTemplateParameter struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	Default     *string `json:"default,omitempty"`
+}
+
+// RuleTemplateRule is the alert rule of a rule template. The parameters can be referenced in the title, labels,
+// annotations, data source UIDs and string values of query models of the rule, and in the receiver of its
+// notification settings. A string value of a query model that consists of a single reference to a parameter whose
+// value is a number or a boolean is replaced with the number or the boolean, so that thresholds can be parameterized.
+type RuleTemplateRule struct {
+	Title                       string                 `json:"title"`
+	Condition                   string                 `json:"condition"`
+	Data                        []AlertQuery           `json:"data"`
+	NoDataState                 NoDataState            `json:"no_data_state"`
+	ExecErrState                ExecutionErrorState    `json:"exec_err_state"`
+	For                         time.Duration          `json:"for"`
+	KeepFiringFor               time.Duration          `json:"keep_firing_for,omitempty"`
+	Annotations                 map[string]string      `json:"annotations,omitempty"`
+	Labels                      map[string]string      `json:"labels,omitempty"`
+	IsPaused                    bool                   `json:"is_paused,omitempty"`
+	NotificationSettings        []NotificationSettings `json:"notification_settings,omitempty"`
+	MissingSeriesEvalsToResolve *int64                 `json:"missing_series_evals_to_resolve,omitempty"`
+}
+
+// RuleTemplateInstance is an alert rule created from a rule template.
+type RuleTemplateInstance struct {
+	// RuleUID is the UID of the rule created for the instance. It is generated if it is empty.
+	RuleUID    string            `json:"rule_uid"`
+	FolderUID  string            `json:"folder_uid"`
+	RuleGroup  string            `json:"rule_group"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+func (t *RuleTemplate) ResourceType() string {
+	return "ruleTemplate"
+}
+
+func (t *RuleTemplate) ResourceID() string {
+	return t.UID
+}
+
+// Validate checks the rule template and that a rule can be created for each of its instances. It generates the UID
+// of the template and the rule UIDs of the instances if they are empty.
+func (t *RuleTemplate) Validate() error {
+	if t.UID == "" {
+		t.UID = util.GenerateShortUID()
+	} else if err := util.ValidateUID(t.UID); err != nil {
+		return MakeErrRuleTemplateInvalid(fmt.Errorf("uid must contain only letters, digits, '-' and '_' and be at most 40 characters: %w", err))
+	}
+	if t.Title == "" {
+		return MakeErrRuleTemplateInvalid(errors.New("title is required"))
+	}
+
+	names := make(map[string]struct{}, len(t.Parameters))
+	for _, p := range t.Parameters {
+		if !ruleTemplateParameterName.MatchString(p.Name) {
+			return MakeErrRuleTemplateInvalid(fmt.Errorf("invalid parameter name '%s': it must start with a letter or '_' and contain only letters, digits and '_'", p.Name))
+		}
+		if _, ok := names[p.Name]; ok {
+			return MakeErrRuleTemplateInvalid(fmt.Errorf("parameter '%s' is defined more than once", p.Name))
+		}
+		names[p.Name] = struct{}{}
+	}
+
+	if t.Rule.Title == "" {
+		return MakeErrRuleTemplateInvalid(errors.New("rule title is required"))
+	}
+	if len(t.Rule.Data) == 0 {
+		return MakeErrRuleTemplateInvalid(errors.New("rule must have at least one query or expression"))
+	}
+	// Check the references to the parameters even if there are no instances.
+	values := make(map[string]string, len(names))
+	for name := range names {
+		values[name] = ""
+	}
+	if _, err := t.expand(RuleTemplateInstance{}, values); err != nil {
+		return MakeErrRuleTemplateInvalid(err)
+	}
+
+	ruleUIDs := make(map[string]struct{}, len(t.Instances))
+	titles := make(map[string]struct{}, len(t.Instances))
+	for i := range t.Instances {
+		instance := &t.Instances[i]
+		if instance.RuleUID == "" {
+			instance.RuleUID = util.GenerateShortUID()
+		} else if err := util.ValidateUID(instance.RuleUID); err != nil {
+			return MakeErrRuleTemplateInvalid(fmt.Errorf("instance %d: invalid rule UID '%s': %w", i, instance.RuleUID, err))
+		}
+		if _, ok := ruleUIDs[instance.RuleUID]; ok {
+			return MakeErrRuleTemplateInvalid(fmt.Errorf("instance %d: rule UID '%s' is used by more than one instance", i, instance.RuleUID))
+		}
+		ruleUIDs[instance.RuleUID] = struct{}{}
+		if instance.FolderUID == "" {
+			return MakeErrRuleTemplateInvalid(fmt.Errorf("instance %d: folder UID is required", i))
+		}
+		if instance.RuleGroup == "" {
+			return MakeErrRuleTemplateInvalid(fmt.Errorf("instance %d: rule group is required", i))
+		}
+		rule, err := t.Expand(*instance)
+		if err != nil {
+			return MakeErrRuleTemplateInvalid(fmt.Errorf("instance %d: %w", i, err))
+		}
+		// Rules in the same folder must have unique titles.
+		titleKey := instance.FolderUID + "/" + rule.Title
+		if _, ok := titles[titleKey]; ok {
+			return MakeErrRuleTemplateInvalid(fmt.Errorf("instance %d: rule title '%s' is used by more than one instance in the same folder", i, rule.Title))
+		}
+		titles[titleKey] = struct{}{}
+	}
+	return nil
+}
+
+// Expand creates the alert rule for the instance by replacing the references to the parameters with the values
+// of the instance or the default values of the parameters.
+func (t *RuleTemplate) Expand(instance RuleTemplateInstance) (AlertRule, error) {
+	values := make(map[string]string, len(t.Parameters))
+	for _, p := range t.Parameters {
+		if v, ok := instance.Parameters[p.Name]; ok {
+			values[p.Name] = v
+		} else if p.Default != nil {
+			values[p.Name] = *p.Default
+		} else {
+			return AlertRule{}, fmt.Errorf("no value for parameter '%s'", p.Name)
+		}
+	}
+	for name := range instance.Parameters {
+		if _, ok := values[name]; !ok {
+			return AlertRule{}, fmt.Errorf("parameter '%s' is not defined in the template", name)
+		}
+	}
+	return t.expand(instance, values)
+}
+
+func (t *RuleTemplate) expand(instance RuleTemplateInstance, values map[string]string) (AlertRule, error) {
+	e := ruleTemplateExpander{values: values}
+	rule := AlertRule{
+		UID:                         instance.RuleUID,
+		OrgID:                       t.OrgID,
+		NamespaceUID:                instance.FolderUID,
+		RuleGroup:                   instance.RuleGroup,
+		Title:                       e.expand(t.Rule.Title),
+		Condition:                   t.Rule.Condition,
+		NoDataState:                 t.Rule.NoDataState,
+		ExecErrState:                t.Rule.ExecErrState,
+		For:                         t.Rule.For,
+		KeepFiringFor:               t.Rule.KeepFiringFor,
+		Annotations:                 e.expandMap(t.Rule.Annotations),
+		Labels:                      e.expandMap(t.Rule.Labels),
+		IsPaused:                    t.Rule.IsPaused,
+		MissingSeriesEvalsToResolve: t.Rule.MissingSeriesEvalsToResolve,
+	}
+	rule.Data = make([]AlertQuery, 0, len(t.Rule.Data))
+	for _, q := range t.Rule.Data {
+		model, err := e.expandModel(q.Model)
+		if err != nil {
+			return AlertRule{}, fmt.Errorf("invalid model of query '%s': %w", q.RefID, err)
+		}
+		rule.Data = append(rule.Data, AlertQuery{
+			RefID:             q.RefID,
+			QueryType:         q.QueryType,
+			RelativeTimeRange: q.RelativeTimeRange,
+			DatasourceUID:     e.expand(q.DatasourceUID),
+			Model:             model,
+		})
+	}
+	for _, ns := range t.Rule.NotificationSettings {
+		ns.Receiver = e.expand(ns.Receiver)
+		rule.NotificationSettings = append(rule.NotificationSettings, ns)
+	}
+
+	if len(e.undefined) > 0 {
+		undefined := slices.Sorted(maps.Keys(e.undefined))
+		return AlertRule{}, fmt.Errorf("rule references parameters that are not defined in the template: %s", strings.Join(undefined, ", "))
+	}
+	return rule, nil
+}
+
+type ruleTemplateExpander struct {
+	values    map[string]string
+	undefined map[string]struct{}
+}
+
+func (e *ruleTemplateExpander) expand(s string) string {
+	return ruleTemplatePlaceholder.ReplaceAllStringFunc(s, func(ref string) string {
+		name := ref[2 : len(ref)-1]
+		v, ok := e.values[name]
+		if !ok {
+			if e.undefined == nil {
+				e.undefined = map[string]struct{}{}
+			}
+			e.undefined[name] = struct{}{}
+			return ref
+		}
+		return v
+	})
+}
+
+func (e *ruleTemplateExpander) expandMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		result[k] = e.expand(v)
+	}
+	return result
+}
+
+func (e *ruleTemplateExpander) expandModel(model json.RawMessage) (json.RawMessage, error) {
+	if len(model) == 0 {
+		return model, nil
+	}
+	d := json.NewDecoder(bytes.NewReader(model))
+	d.UseNumber()
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(e.expandValue(v))
+}
+
+func (e *ruleTemplateExpander) expandValue(v any) any {
+	switch value := v.(type) {
+	case string:
+		expanded := e.expand(value)
+		if loc := ruleTemplatePlaceholder.FindStringIndex(value); loc != nil && loc[0] == 0 && loc[1] == len(value) {
+			switch {
+			case expanded == "true":
+				return true
+			case expanded == "false":
+				return false
+			case isJSONNumber(expanded):
+				return json.Number(expanded)
+			}
+		}
+		return expanded
+	case map[string]any:
+		for k, item := range value {
+			value[k] = e.expandValue(item)
+		}
+		return value
+	case []any:
+		for i, item := range value {
+			value[i] = e.expandValue(item)
+		}
+		return value
+	default:
+		return v
+	}
+}
+
+func isJSONNumber(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil && json.Valid([]byte(s))
+}
@@ -0,0 +1,184 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/util"
+)
+
+func ruleTemplateForTest() RuleTemplate {
+	threshold := "80"
+	return RuleTemplate{
+		UID:   "cpu",
+		OrgID: 1,
+		Title: "CPU usage",
+		Parameters: []RuleTemplateParameter{
+			{Name: "cluster"},
+			{Name: "threshold", Default: &threshold},
+		},
+		Rule: RuleTemplateRule{
+			Title:     "High CPU usage on ${cluster}",
+			Condition: "B",
+			Data: []AlertQuery{
+				{
+					RefID:         "A",
+					DatasourceUID: "prom-${cluster}",
+					Model:         json.RawMessage(`{"expr":"cpu_usage{cluster=\"${cluster}\"}","intervalMs":1000}`),
+				},
+				{
+					RefID:         "B",
+					DatasourceUID: "__expr__",
+					Model:         json.RawMessage(`{"type":"threshold","expression":"A","conditions":[{"evaluator":{"params":["${threshold}"],"type":"gt"}}]}`),
+				},
+			},
+			NoDataState:  NoData,
+			ExecErrState: ErrorErrState,
+			For:          5 * time.Minute,
+			Annotations:  map[string]string{"summary": "CPU usage is above ${threshold}%"},
+			Labels:       map[string]string{"cluster": "${cluster}"},
+		},
+		Instances: []RuleTemplateInstance{
+			{RuleUID: "cpu-eu", FolderUID: "folder", RuleGroup: "group", Parameters: map[string]string{"cluster": "eu"}},
+			{RuleUID: "cpu-us", FolderUID: "folder", RuleGroup: "group", Parameters: map[string]string{"cluster": "us", "threshold": "90"}},
+		},
+	}
+}
+
+func TestRuleTemplateExpand(t *testing.T) {
+	tmpl := ruleTemplateForTest()
+
+	rule, err := tmpl.Expand(tmpl.Instances[1])
+	require.NoError(t, err)
+
+	assert.Equal(t, "cpu-us", rule.UID)
+	assert.EqualValues(t, 1, rule.OrgID)
+	assert.Equal(t, "folder", rule.NamespaceUID)
+	assert.Equal(t, "group", rule.RuleGroup)
+	assert.Equal(t, "High CPU usage on us", rule.Title)
+	assert.Equal(t, "B", rule.Condition)
+	assert.Equal(t, 5*time.Minute, rule.For)
+	assert.Equal(t, map[string]string{"summary": "CPU usage is above 90%"}, rule.Annotations)
+	assert.Equal(t, map[string]string{"cluster": "us"}, rule.Labels)
+	require.Len(t, rule.Data, 2)
+	assert.Equal(t, "prom-us", rule.Data[0].DatasourceUID)
+	assert.JSONEq(t, `{"expr":"cpu_usage{cluster=\"us\"}","intervalMs":1000}`, string(rule.Data[0].Model))
+	// The threshold consists of a single reference and is replaced with a number.
+	assert.JSONEq(t, `{"type":"threshold","expression":"A","conditions":[{"evaluator":{"params":[90],"type":"gt"}}]}`, string(rule.Data[1].Model))
+
+	t.Run("uses default values", func(t *testing.T) {
+		rule, err := tmpl.Expand(tmpl.Instances[0])
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"summary": "CPU usage is above 80%"}, rule.Annotations)
+	})
+
+	t.Run("does not modify the template", func(t *testing.T) {
+		assert.Equal(t, ruleTemplateForTest(), tmpl)
+	})
+
+	t.Run("fails if a parameter has no value", func(t *testing.T) {
+		_, err := tmpl.Expand(RuleTemplateInstance{FolderUID: "folder", RuleGroup: "group"})
+		assert.ErrorContains(t, err, "no value for parameter 'cluster'")
+	})
+
+	t.Run("fails if a parameter is not defined", func(t *testing.T) {
+		_, err := tmpl.Expand(RuleTemplateInstance{Parameters: map[string]string{"cluster": "eu", "region": "eu"}})
+		assert.ErrorContains(t, err, "parameter 'region' is not defined in the template")
+	})
+
+	t.Run("replaces booleans and keeps other strings", func(t *testing.T) {
+		tmpl := ruleTemplateForTest()
+		tmpl.Parameters = append(tmpl.Parameters, RuleTemplateParameter{Name: "hide"})
+		tmpl.Rule.Data[0].Model = json.RawMessage(`{"hide":"${hide}","legend":"${threshold}%","refId":"${cluster}"}`)
+		rule, err := tmpl.Expand(RuleTemplateInstance{Parameters: map[string]string{"cluster": "1e3", "hide": "true"}})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"hide":true,"legend":"80%","refId":1e3}`, string(rule.Data[0].Model))
+	})
+}
+
+func TestRuleTemplateValidate(t *testing.T) {
+	t.Run("valid template", func(t *testing.T) {
+		tmpl := ruleTemplateForTest()
+		require.NoError(t, tmpl.Validate())
+	})
+
+	t.Run("generates UIDs", func(t *testing.T) {
+		tmpl := ruleTemplateForTest()
+		tmpl.UID = ""
+		tmpl.Instances[0].RuleUID = ""
+		require.NoError(t, tmpl.Validate())
+		assert.NoError(t, util.ValidateUID(tmpl.UID))
+		assert.NoError(t, util.ValidateUID(tmpl.Instances[0].RuleUID))
+		assert.NotEqual(t, tmpl.Instances[0].RuleUID, tmpl.Instances[1].RuleUID)
+	})
+
+	testCases := []struct {
+		name   string
+		mutate func(*RuleTemplate)
+		err    string
+	}{
+		{
+			name:   "empty title",
+			mutate: func(t *RuleTemplate) { t.Title = "" },
+			err:    "title is required",
+		},
+		{
+			name:   "invalid parameter name",
+			mutate: func(t *RuleTemplate) { t.Parameters = append(t.Parameters, RuleTemplateParameter{Name: "1st"}) },
+			err:    "invalid parameter name '1st'",
+		},
+		{
+			name:   "duplicate parameter",
+			mutate: func(t *RuleTemplate) { t.Parameters = append(t.Parameters, RuleTemplateParameter{Name: "cluster"}) },
+			err:    "parameter 'cluster' is defined more than once",
+		},
+		{
+			name:   "no data",
+			mutate: func(t *RuleTemplate) { t.Rule.Data = nil },
+			err:    "rule must have at least one query or expression",
+		},
+		{
+			name:   "undefined reference",
+			mutate: func(t *RuleTemplate) { t.Rule.Labels["region"] = "${region}" },
+			err:    "rule references parameters that are not defined in the template: region",
+		},
+		{
+			name:   "undefined reference without instances",
+			mutate: func(t *RuleTemplate) { t.Instances = nil; t.Rule.Title = "${region}" },
+			err:    "rule references parameters that are not defined in the template: region",
+		},
+		{
+			name:   "duplicate rule UID",
+			mutate: func(t *RuleTemplate) { t.Instances[1].RuleUID = t.Instances[0].RuleUID },
+			err:    "instance 1: rule UID 'cpu-eu' is used by more than one instance",
+		},
+		{
+			name:   "missing folder",
+			mutate: func(t *RuleTemplate) { t.Instances[0].FolderUID = "" },
+			err:    "instance 0: folder UID is required",
+		},
+		{
+			name:   "missing value",
+			mutate: func(t *RuleTemplate) { t.Instances[0].Parameters = nil },
+			err:    "instance 0: no value for parameter 'cluster'",
+		},
+		{
+			name:   "duplicate title in folder",
+			mutate: func(t *RuleTemplate) { t.Instances[1].Parameters["cluster"] = "eu" },
+			err:    "instance 1: rule title 'High CPU usage on eu' is used by more than one instance in the same folder",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpl := ruleTemplateForTest()
+			tc.mutate(&tmpl)
+			err := tmpl.Validate()
+			require.ErrorIs(t, err, ErrRuleTemplateInvalid)
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
@@ -1,7 +1,12 @@
 package models
 
 import (
+	"fmt"
+	"time"
+
 	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/common/model"
 	"golang.org/x/exp/maps"
 
 	alertingModels "github.com/grafana/alerting/models"
@@ -18,6 +23,44 @@ func (s Silence) GetRuleUID() *string {
 	return getRuleUIDLabelValue(s.Silence)
 }
 
+// Matches returns true if the silence is active at the given time and its matchers match the labels.
+func (s Silence) Matches(lbls model.LabelSet, at time.Time) (bool, error) {
+	if s.StartsAt == nil || s.EndsAt == nil {
+		return false, nil
+	}
+	if at.Before(time.Time(*s.StartsAt)) || !at.Before(time.Time(*s.EndsAt)) {
+		return false, nil
+	}
+	for _, m := range s.Matchers {
+		if m == nil || m.Name == nil || m.Value == nil {
+			continue
+		}
+		matcher, err := labels.NewMatcher(matchType(*m), *m.Name, *m.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid matcher in silence: %w", err)
+		}
+		if !matcher.Matches(string(lbls[model.LabelName(*m.Name)])) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func matchType(m amv2.Matcher) labels.MatchType {
+	isEqual := m.IsEqual == nil || *m.IsEqual
+	isRegex := m.IsRegex != nil && *m.IsRegex
+	switch {
+	case isEqual && isRegex:
+		return labels.MatchRegexp
+	case isRegex:
+		return labels.MatchNotRegexp
+	case isEqual:
+		return labels.MatchEqual
+	default:
+		return labels.MatchNotEqual
+	}
+}
+
 // getRuleUIDLabelValue returns the value of the RuleUIDLabel matcher in the given silence, if it exists.
 func getRuleUIDLabelValue(silence notify.Silence) *string {
 	for _, m := range silence.Matchers {
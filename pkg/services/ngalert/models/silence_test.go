@@ -2,9 +2,13 @@ package models
 
 import (
 	"testing"
+	"time"
 
+	"github.com/go-openapi/strfmt"
 	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/grafana/alerting/models"
 	"github.com/grafana/grafana/pkg/util"
@@ -49,6 +53,65 @@ func TestSilenceGetRuleUID(t *testing.T) {
 	}
 }
 
+func TestSilenceMatches(t *testing.T) {
+	now := time.Now()
+	lbls := model.LabelSet{"team": "infra", "severity": "critical"}
+	withMatchers := func(muts ...Mutator[Silence]) Silence {
+		s := SilenceGen(muts...)()
+		s.Matchers = s.Matchers[1:]
+		return s
+	}
+
+	testCases := []struct {
+		name     string
+		silence  Silence
+		at       time.Time
+		expected bool
+	}{
+		{
+			name:     "matching silence",
+			silence:  withMatchers(SilenceMuts.WithMatcher("team", "infra", labels.MatchEqual), SilenceMuts.WithMatcher("severity", "crit.*", labels.MatchRegexp)),
+			at:       now,
+			expected: true,
+		},
+		{
+			name:     "negative matchers",
+			silence:  withMatchers(SilenceMuts.WithMatcher("team", "db", labels.MatchNotEqual), SilenceMuts.WithMatcher("env", ".+", labels.MatchNotRegexp)),
+			at:       now,
+			expected: true,
+		},
+		{
+			name:     "one matcher does not match",
+			silence:  withMatchers(SilenceMuts.WithMatcher("team", "infra", labels.MatchEqual), SilenceMuts.WithMatcher("severity", "warning", labels.MatchEqual)),
+			at:       now,
+			expected: false,
+		},
+		{
+			name:     "silence has not started",
+			silence:  withMatchers(SilenceMuts.WithMatcher("team", "infra", labels.MatchEqual)),
+			at:       now.Add(-time.Hour),
+			expected: false,
+		},
+		{
+			name: "silence has ended",
+			silence: func() Silence {
+				s := withMatchers(SilenceMuts.WithMatcher("team", "infra", labels.MatchEqual))
+				s.EndsAt = util.Pointer(strfmt.DateTime(now))
+				return s
+			}(),
+			at:       now,
+			expected: false,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := tt.silence.Matches(lbls, tt.at)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, matches)
+		})
+	}
+}
+
 func TestSilencePermissionSet(t *testing.T) {
 	t.Run("Clone", func(t *testing.T) {
 		perms := SilencePermissionSet{
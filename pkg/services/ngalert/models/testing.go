@@ -540,6 +540,12 @@ func (a *AlertRuleMutators) WithNoNotificationSettings() AlertRuleMutator {
 	}
 }
 
+func (a *AlertRuleMutators) WithDependencies(dependencies ...AlertRuleDependency) AlertRuleMutator {
+	return func(rule *AlertRule) {
+		rule.Dependencies = dependencies
+	}
+}
+
 func (a *AlertRuleMutators) WithIsPaused(paused bool) AlertRuleMutator {
 	return func(rule *AlertRule) {
 		rule.IsPaused = paused
@@ -878,6 +884,7 @@ func AlertInstanceGen(mutators ...AlertInstanceMutator) *AlertInstance {
 			InstanceStateNoData,
 			InstanceStateError,
 			InstanceStateRecovering,
+			InstanceStateSuppressed,
 		}
 		return s[rand.Intn(len(s))]
 	}
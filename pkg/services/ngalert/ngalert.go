@@ -2,6 +2,7 @@ package ngalert
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"time"
@@ -46,6 +47,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/ngalert/state"
 	"github.com/grafana/grafana/pkg/services/ngalert/state/historian"
 	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/grafana/grafana/pkg/services/ngalert/store/recordedsample"
 	"github.com/grafana/grafana/pkg/services/ngalert/writer"
 	"github.com/grafana/grafana/pkg/services/notifications"
 	"github.com/grafana/grafana/pkg/services/pluginsintegration/plugincontext"
@@ -161,6 +163,7 @@ type AlertNG struct {
 	// Alerting notification services
 	MultiOrgAlertmanager *notifier.MultiOrgAlertmanager
 	AlertsRouter         *sender.AlertsRouter
+	recurringSilences    *notifier.RecurringSilenceMaterializer
 	accesscontrol        accesscontrol.AccessControl
 	AccesscontrolService accesscontrol.Service
 	ResourcePermissions  accesscontrol.ReceiverPermissionsService
@@ -310,11 +313,12 @@ func (ng *AlertNG) init() error {
 	}
 
 	ng.AlertsRouter = alertsRouter
+	ng.recurringSilences = notifier.NewRecurringSilenceMaterializer(ng.store, ng.MultiOrgAlertmanager, clk, log.New("ngalert.recurring-silences"))
 
 	evalFactory := eval.NewEvaluatorFactory(ng.Cfg.UnifiedAlerting, ng.DataSourceCache, ng.ExpressionService)
 	conditionValidator := eval.NewConditionValidator(ng.DataSourceCache, ng.ExpressionService, ng.pluginsStore)
 
-	recordingWriter, err := createRecordingWriter(ng.Cfg.UnifiedAlerting.RecordingRules, ng.httpClientProvider, ng.DataSourceService, ng.pluginContextProvider, clk, ng.Metrics.GetRemoteWriterMetrics())
+	recordingWriter, err := createRecordingWriter(ng.Cfg.UnifiedAlerting.RecordingRules, ng.httpClientProvider, ng.DataSourceService, ng.pluginContextProvider, recordedsample.NewStore(ng.SQLStore), clk, ng.Metrics.GetRemoteWriterMetrics())
 	if err != nil {
 		return fmt.Errorf("failed to initialize recording writer: %w", err)
 	}
@@ -337,6 +341,15 @@ func (ng *AlertNG) init() error {
 		Log:                  log.New("ngalert.scheduler"),
 		RecordingWriter:      ng.RecordingWriter,
 		FeatureToggles:       ng.FeatureToggles,
+		EvaluationTraceSize:  ng.Cfg.UnifiedAlerting.EvaluationTraceSize,
+	}
+	if ng.Cfg.UnifiedAlerting.HARuleShardingEnabled {
+		// The periodic state persister overwrites the state of all rules, including the rules evaluated by other replicas.
+		if ng.FeatureToggles.IsEnabledGlobally(featuremgmt.FlagAlertingSaveStatePeriodic) && !ng.FeatureToggles.IsEnabledGlobally(featuremgmt.FlagAlertingSaveStateCompressed) {
+			return errors.New("alert rule sharding is not supported with the alertingSaveStatePeriodic feature toggle")
+		}
+		ng.Log.Info("Alert rule evaluation is split between the replicas of the HA cluster")
+		schedCfg.ClusterMembership = ng.MultiOrgAlertmanager
 	}
 
 	history, err := configureHistorianBackend(
@@ -417,11 +430,13 @@ func (ng *AlertNG) init() error {
 	contactPointService := provisioning.NewContactPointService(configStore, ng.SecretsService, ng.store, ng.store, provisioningReceiverService, ng.Log, ng.store, ng.ResourcePermissions)
 	templateService := provisioning.NewTemplateService(configStore, ng.store, ng.store, ng.Log)
 	muteTimingService := provisioning.NewMuteTimingService(configStore, ng.store, ng.store, ng.Log, ng.store)
+	recurringSilenceService := provisioning.NewRecurringSilenceService(ng.store, ng.store, ng.store, ng.Log)
 	alertRuleService := provisioning.NewAlertRuleService(ng.store, ng.store, ng.folderService, ng.QuotaService, ng.store,
 		int64(ng.Cfg.UnifiedAlerting.DefaultRuleEvaluationInterval.Seconds()),
 		int64(ng.Cfg.UnifiedAlerting.BaseInterval.Seconds()),
 		ng.Cfg.UnifiedAlerting.RulesPerRuleGroupLimit, ng.Log, notifier.NewNotificationSettingsValidationService(ng.store),
 		ac.NewRuleService(ng.accesscontrol))
+	ruleTemplateService := provisioning.NewRuleTemplateService(ng.store, alertRuleService, ng.store, ng.store, ng.Log)
 
 	ng.Api = &api.API{
 		Cfg:                  ng.Cfg,
@@ -438,13 +453,17 @@ func (ng *AlertNG) init() error {
 		MultiOrgAlertmanager: ng.MultiOrgAlertmanager,
 		StateManager:         ng.stateManager,
 		Scheduler:            scheduler,
+		ShardingStatus:       scheduler,
+		EvaluationTraces:     scheduler,
 		AccessControl:        ng.accesscontrol,
 		Policies:             policyService,
 		ReceiverService:      receiverService,
 		ContactPointService:  contactPointService,
 		Templates:            templateService,
 		MuteTimings:          muteTimingService,
+		RecurringSilences:    recurringSilenceService,
 		AlertRules:           alertRuleService,
+		RuleTemplates:        ruleTemplateService,
 		AlertsRouter:         alertsRouter,
 		EvaluatorFactory:     evalFactory,
 		ConditionValidator:   conditionValidator,
@@ -555,6 +574,9 @@ func (ng *AlertNG) Run(ctx context.Context) error {
 	children.Go(func() error {
 		return ng.AlertsRouter.Run(subCtx)
 	})
+	children.Go(func() error {
+		return ng.recurringSilences.Run(subCtx)
+	})
 
 	if ng.Cfg.UnifiedAlerting.ExecuteAlerts {
 		// Only Warm() the state manager if we are actually executing alerts.
@@ -685,7 +707,8 @@ func configureHistorianBackend(
 		if w == nil {
 			return nil, fmt.Errorf("failed to create alert state metrics writer")
 		}
-		backend := historian.NewRemotePrometheusBackend(pcfg, w, prometheusBackendLogger, met)
+		r := writer.NewDatasourceReader(datasourceService, httpClientProvider, pluginContextProvider, prometheusBackendLogger)
+		backend := historian.NewRemotePrometheusBackend(pcfg, w, r, rs, ac, prometheusBackendLogger, met)
 
 		return backend, nil
 	}
@@ -723,7 +746,7 @@ func configureNotificationHistorian(
 	return notificationHistorian, nil
 }
 
-func createRecordingWriter(settings setting.RecordingRuleSettings, httpClientProvider httpclient.Provider, datasourceService datasources.DataSourceService, pluginContextProvider *plugincontext.Provider, clock clock.Clock, m *metrics.RemoteWriter) (schedule.RecordingWriter, error) {
+func createRecordingWriter(settings setting.RecordingRuleSettings, httpClientProvider httpclient.Provider, datasourceService datasources.DataSourceService, pluginContextProvider *plugincontext.Provider, samples writer.RecordedSampleStore, clock clock.Clock, m *metrics.RemoteWriter) (schedule.RecordingWriter, error) {
 	logger := log.New("ngalert.writer")
 
 	if settings.Enabled {
@@ -731,10 +754,11 @@ func createRecordingWriter(settings setting.RecordingRuleSettings, httpClientPro
 			Timeout:              settings.Timeout,
 			CustomHeaders:        settings.CustomHeaders,
 			DefaultDatasourceUID: settings.DefaultDatasourceUID,
+			SQLWriter:            writer.NewSQLWriter(samples, settings.DatabaseRetention, clock, logger, m),
 		}
 
 		logger.Info("Setting up remote write using data sources",
-			"timeout", cfg.Timeout, "default_datasource_uid", cfg.DefaultDatasourceUID)
+			"timeout", cfg.Timeout, "default_datasource_uid", cfg.DefaultDatasourceUID, "database_retention", settings.DatabaseRetention)
 
 		return writer.NewDatasourceWriter(cfg, datasourceService, httpClientProvider, pluginContextProvider, clock, logger, m), nil
 	}
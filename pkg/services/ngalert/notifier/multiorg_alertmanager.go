@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -249,6 +250,23 @@ func (moa *MultiOrgAlertmanager) setupClustering(cfg *setting.Cfg) error {
 	return nil
 }
 
+// ClusterMembers returns the name of this instance and the names of all alive instances in the
+// Alertmanager cluster, including this one. It returns an empty name if clustering is not configured.
+func (moa *MultiOrgAlertmanager) ClusterMembers() (string, []string) {
+	switch peer := moa.peer.(type) {
+	case *redisPeer:
+		return peer.Name(), slices.Clone(peer.Members())
+	case *alertingCluster.Peer:
+		members := peer.Peers()
+		names := make([]string, 0, len(members))
+		for _, m := range members {
+			names = append(names, m.Name())
+		}
+		return peer.Name(), names
+	}
+	return "", nil
+}
+
 func (moa *MultiOrgAlertmanager) Run(ctx context.Context) error {
 	moa.logger.Info("Starting MultiOrg Alertmanager")
 
@@ -0,0 +1,120 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// RecurringSilenceProvider manages recurring silences without access control.
+type RecurringSilenceProvider interface {
+	GetRecurringSilences(ctx context.Context, orgID int64) ([]*models.RecurringSilence, error)
+	GetRecurringSilence(ctx context.Context, orgID int64, uid string) (*models.RecurringSilence, error)
+	CreateRecurringSilence(ctx context.Context, s models.RecurringSilence, provenance models.Provenance) (*models.RecurringSilence, error)
+	UpdateRecurringSilence(ctx context.Context, s models.RecurringSilence, provenance models.Provenance) (*models.RecurringSilence, error)
+	DeleteRecurringSilence(ctx context.Context, orgID int64, uid string, provenance models.Provenance) error
+}
+
+// RecurringSilenceService is the authenticated service for managing recurring silences. Access to a recurring silence
+// is authorized in the same way as access to the silences that are created for it.
+type RecurringSilenceService struct {
+	authz    SilenceAccessControlService
+	provider RecurringSilenceProvider
+	log      log.Logger
+}
+
+func NewRecurringSilenceService(authz SilenceAccessControlService, provider RecurringSilenceProvider, log log.Logger) *RecurringSilenceService {
+	return &RecurringSilenceService{
+		authz:    authz,
+		provider: provider,
+		log:      log,
+	}
+}
+
+// GetRecurringSilence retrieves a recurring silence by its UID.
+func (s *RecurringSilenceService) GetRecurringSilence(ctx context.Context, user identity.Requester, uid string) (*models.RecurringSilence, error) {
+	silence, err := s.provider.GetRecurringSilence(ctx, user.GetOrgID(), uid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authz.AuthorizeReadSilence(ctx, user, silence.AsSilence()); err != nil {
+		return nil, err
+	}
+
+	return silence, nil
+}
+
+// ListRecurringSilences retrieves all recurring silences the user has access to.
+func (s *RecurringSilenceService) ListRecurringSilences(ctx context.Context, user identity.Requester) ([]*models.RecurringSilence, error) {
+	silences, err := s.provider.GetRecurringSilences(ctx, user.GetOrgID())
+	if err != nil {
+		return nil, err
+	}
+
+	bySilence := make(map[*models.Silence]*models.RecurringSilence, len(silences))
+	asSilences := make([]*models.Silence, 0, len(silences))
+	for _, silence := range silences {
+		asSilence := silence.AsSilence()
+		bySilence[asSilence] = silence
+		asSilences = append(asSilences, asSilence)
+	}
+
+	allowed, err := s.authz.FilterByAccess(ctx, user, asSilences...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.RecurringSilence, 0, len(allowed))
+	for _, silence := range allowed {
+		result = append(result, bySilence[silence])
+	}
+	return result, nil
+}
+
+// CreateRecurringSilence creates a new recurring silence.
+// For rule-specific recurring silences, the user needs permission to create silences in the folder that the associated rule is in.
+// For general recurring silences, the user needs broader permissions.
+func (s *RecurringSilenceService) CreateRecurringSilence(ctx context.Context, user identity.Requester, silence models.RecurringSilence) (*models.RecurringSilence, error) {
+	silence.OrgID = user.GetOrgID()
+	if err := s.authz.AuthorizeCreateSilence(ctx, user, silence.AsSilence()); err != nil {
+		return nil, err
+	}
+
+	return s.provider.CreateRecurringSilence(ctx, silence, models.ProvenanceNone)
+}
+
+// UpdateRecurringSilence updates an existing recurring silence.
+// The user needs permission to update both the existing and the updated recurring silence.
+func (s *RecurringSilenceService) UpdateRecurringSilence(ctx context.Context, user identity.Requester, silence models.RecurringSilence) (*models.RecurringSilence, error) {
+	silence.OrgID = user.GetOrgID()
+	existing, err := s.provider.GetRecurringSilence(ctx, silence.OrgID, silence.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authz.AuthorizeUpdateSilence(ctx, user, existing.AsSilence()); err != nil {
+		return nil, err
+	}
+	if err := s.authz.AuthorizeUpdateSilence(ctx, user, silence.AsSilence()); err != nil {
+		return nil, err
+	}
+
+	return s.provider.UpdateRecurringSilence(ctx, silence, models.ProvenanceNone)
+}
+
+// DeleteRecurringSilence deletes a recurring silence by its UID. The silence created for the current window is expired.
+func (s *RecurringSilenceService) DeleteRecurringSilence(ctx context.Context, user identity.Requester, uid string) error {
+	existing, err := s.GetRecurringSilence(ctx, user, uid)
+	if err != nil {
+		return err
+	}
+
+	if err := s.authz.AuthorizeUpdateSilence(ctx, user, existing.AsSilence()); err != nil {
+		return err
+	}
+
+	return s.provider.DeleteRecurringSilence(ctx, user.GetOrgID(), uid, models.ProvenanceNone)
+}
@@ -0,0 +1,159 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	alertingNotify "github.com/grafana/alerting/notify"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+const (
+	// recurringSilenceInterval is how often the schedules of recurring silences are checked.
+	recurringSilenceInterval = time.Minute
+	// recurringSilenceLookahead is how long before a window starts the silence for it is created.
+	recurringSilenceLookahead = time.Hour
+)
+
+// RecurringSilenceStore stores recurring silences and the windows for which silences were created.
+type RecurringSilenceStore interface {
+	ListRecurringSilences(ctx context.Context, orgID int64) ([]*models.RecurringSilence, error)
+	ListDeletedRecurringSilences(ctx context.Context) ([]*models.RecurringSilence, error)
+	UpdateRecurringSilenceWindow(ctx context.Context, orgID int64, uid string, previousSilenceID string, window models.RecurringSilenceWindow) (bool, error)
+	PurgeRecurringSilence(ctx context.Context, orgID int64, uid string, version int64) error
+}
+
+// RecurringSilenceMaterializer creates a silence for each window of the schedules of recurring silences shortly
+// before the window starts. It expires the silence when the recurring silence is changed or deleted.
+//
+// Every Grafana instance runs the materializer. The store makes sure that only one silence is kept for each window,
+// the silences created by the other instances are expired right away.
+type RecurringSilenceMaterializer struct {
+	store     RecurringSilenceStore
+	silences  SilenceStore
+	clock     clock.Clock
+	interval  time.Duration
+	lookahead time.Duration
+	log       log.Logger
+}
+
+func NewRecurringSilenceMaterializer(store RecurringSilenceStore, silences SilenceStore, clock clock.Clock, log log.Logger) *RecurringSilenceMaterializer {
+	return &RecurringSilenceMaterializer{
+		store:     store,
+		silences:  silences,
+		clock:     clock,
+		interval:  recurringSilenceInterval,
+		lookahead: recurringSilenceLookahead,
+		log:       log,
+	}
+}
+
+// Run checks the schedules of the recurring silences periodically until the context is cancelled.
+func (m *RecurringSilenceMaterializer) Run(ctx context.Context) error {
+	m.log.Info("Starting recurring silence materializer", "interval", m.interval, "lookahead", m.lookahead)
+	ticker := m.clock.Ticker(m.interval)
+	defer ticker.Stop()
+	for {
+		m.Materialize(ctx)
+		select {
+		case <-ctx.Done():
+			m.log.Info("Stopping recurring silence materializer")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Materialize creates the silences for the windows that start soon, replaces the silences of changed recurring
+// silences and expires the silences of deleted ones.
+func (m *RecurringSilenceMaterializer) Materialize(ctx context.Context) {
+	now := m.clock.Now()
+
+	deleted, err := m.store.ListDeletedRecurringSilences(ctx)
+	if err != nil {
+		m.log.Error("Failed to list deleted recurring silences", "error", err)
+	}
+	for _, s := range deleted {
+		if err := m.expire(ctx, s.OrgID, s.Window.SilenceID); err != nil {
+			m.log.Warn("Failed to expire silence of deleted recurring silence", "org_id", s.OrgID, "uid", s.UID, "silence_id", s.Window.SilenceID, "error", err)
+			continue
+		}
+		if err := m.store.PurgeRecurringSilence(ctx, s.OrgID, s.UID, s.Version); err != nil {
+			m.log.Warn("Failed to purge deleted recurring silence", "org_id", s.OrgID, "uid", s.UID, "error", err)
+		}
+	}
+
+	silences, err := m.store.ListRecurringSilences(ctx, 0)
+	if err != nil {
+		m.log.Error("Failed to list recurring silences", "error", err)
+		return
+	}
+	for _, s := range silences {
+		if err := m.materialize(ctx, s, now); err != nil {
+			m.log.Warn("Failed to create silence for recurring silence", "org_id", s.OrgID, "uid", s.UID, "error", err)
+		}
+	}
+}
+
+func (m *RecurringSilenceMaterializer) materialize(ctx context.Context, s *models.RecurringSilence, now time.Time) error {
+	previous := s.Window
+	// The silence was created for a previous version of the recurring silence and must be replaced.
+	stale := previous.SilenceID != "" && previous.Version != s.Version
+
+	from := now
+	if previous.SilenceID != "" && !stale {
+		if previous.EndsAt.After(now.Add(m.lookahead)) {
+			return nil
+		}
+		// The silence of the next window is created while the silence of the current one is still active
+		// so that consecutive windows are silenced without a gap.
+		if previous.EndsAt.After(from) {
+			from = previous.EndsAt
+		}
+	}
+
+	window := models.RecurringSilenceWindow{Version: s.Version}
+	start, end, ok := s.Schedule.NextWindow(from)
+	if ok && !start.After(now.Add(m.lookahead)) {
+		silenceID, err := m.silences.CreateSilence(ctx, s.OrgID, s.Silence(start, end))
+		if err != nil {
+			return err
+		}
+		window = models.RecurringSilenceWindow{SilenceID: silenceID, StartsAt: start, EndsAt: end, Version: s.Version}
+	} else if !stale {
+		return nil
+	}
+
+	saved, err := m.store.UpdateRecurringSilenceWindow(ctx, s.OrgID, s.UID, previous.SilenceID, window)
+	if err != nil || !saved {
+		// Another instance created the silence for the window, or the recurring silence was changed in the meantime.
+		if expireErr := m.expire(ctx, s.OrgID, window.SilenceID); expireErr != nil {
+			m.log.Warn("Failed to expire duplicate silence of recurring silence", "org_id", s.OrgID, "uid", s.UID, "silence_id", window.SilenceID, "error", expireErr)
+		}
+		return err
+	}
+
+	if window.SilenceID != "" {
+		m.log.Debug("Created silence for recurring silence", "org_id", s.OrgID, "uid", s.UID, "silence_id", window.SilenceID, "starts_at", start, "ends_at", end)
+	}
+	if stale {
+		return m.expire(ctx, s.OrgID, previous.SilenceID)
+	}
+	return nil
+}
+
+// expire expires the silence. Silences that do not exist anymore are ignored.
+func (m *RecurringSilenceMaterializer) expire(ctx context.Context, orgID int64, silenceID string) error {
+	if silenceID == "" {
+		return nil
+	}
+	err := m.silences.DeleteSilence(ctx, orgID, silenceID)
+	if errors.Is(err, ErrSilenceNotFound) || errors.Is(err, alertingNotify.ErrSilenceNotFound) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,186 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	ngfakes "github.com/grafana/grafana/pkg/services/ngalert/tests/fakes"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+func TestRecurringSilenceMaterializer(t *testing.T) {
+	// Monday, 12:00 UTC.
+	now := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+	setup := func(silences ...*models.RecurringSilence) (*RecurringSilenceMaterializer, *fakeRecurringSilenceStore, *ngfakes.FakeSilenceStore, *clock.Mock) {
+		clk := clock.NewMock()
+		clk.Set(now)
+		store := &fakeRecurringSilenceStore{silences: map[string]*models.RecurringSilence{}}
+		for _, s := range silences {
+			store.silences[s.UID] = s
+		}
+		silenceStore := &ngfakes.FakeSilenceStore{Silences: map[string]*models.Silence{}}
+		return NewRecurringSilenceMaterializer(store, silenceStore, clk, log.NewNopLogger()), store, silenceStore, clk
+	}
+	nightly := func() *models.RecurringSilence {
+		return &models.RecurringSilence{
+			UID:       "nightly",
+			OrgID:     1,
+			Matchers:  amv2.Matchers{{Name: util.Pointer("env"), Value: util.Pointer("staging"), IsEqual: util.Pointer(true), IsRegex: util.Pointer(false)}},
+			Comment:   "nightly maintenance",
+			CreatedBy: "admin",
+			Schedule:  models.RecurringSilenceSchedule{Cron: "0 22 * * *", Duration: 8 * time.Hour},
+			Version:   1,
+		}
+	}
+
+	t.Run("does not create silence before the lookahead", func(t *testing.T) {
+		m, store, silences, _ := setup(nightly())
+
+		m.Materialize(context.Background())
+
+		assert.Empty(t, silences.Silences)
+		assert.Empty(t, store.silences["nightly"].Window.SilenceID)
+	})
+
+	t.Run("creates silence for the window within the lookahead", func(t *testing.T) {
+		m, store, silences, clk := setup(nightly())
+		clk.Set(now.Add(9 * time.Hour))
+
+		m.Materialize(context.Background())
+
+		window := store.silences["nightly"].Window
+		require.Contains(t, silences.Silences, window.SilenceID)
+		assert.Equal(t, time.Date(2024, 3, 4, 22, 0, 0, 0, time.UTC), window.StartsAt)
+		assert.Equal(t, time.Date(2024, 3, 5, 6, 0, 0, 0, time.UTC), window.EndsAt)
+		assert.EqualValues(t, 1, window.Version)
+		silence := silences.Silences[window.SilenceID]
+		assert.Equal(t, "nightly maintenance", *silence.Comment)
+		assert.Equal(t, "admin", *silence.CreatedBy)
+
+		t.Run("does not create silence again", func(t *testing.T) {
+			m.Materialize(context.Background())
+			assert.Len(t, silences.Silences, 1)
+			assert.Equal(t, window, store.silences["nightly"].Window)
+		})
+
+		t.Run("creates silence for the next window within the lookahead", func(t *testing.T) {
+			clk.Set(time.Date(2024, 3, 5, 5, 30, 0, 0, time.UTC))
+			m.Materialize(context.Background())
+			assert.Len(t, silences.Silences, 1)
+
+			clk.Set(time.Date(2024, 3, 5, 21, 30, 0, 0, time.UTC))
+			m.Materialize(context.Background())
+			assert.Len(t, silences.Silences, 2)
+			next := store.silences["nightly"].Window
+			assert.Equal(t, time.Date(2024, 3, 5, 22, 0, 0, 0, time.UTC), next.StartsAt)
+		})
+	})
+
+	t.Run("replaces silence when recurring silence is changed", func(t *testing.T) {
+		m, store, silences, clk := setup(nightly())
+		clk.Set(now.Add(11 * time.Hour))
+		m.Materialize(context.Background())
+		previous := store.silences["nightly"].Window.SilenceID
+		require.NotEmpty(t, previous)
+
+		store.silences["nightly"].Comment = "changed"
+		store.silences["nightly"].Version++
+		m.Materialize(context.Background())
+
+		window := store.silences["nightly"].Window
+		assert.NotContains(t, silences.Silences, previous)
+		require.Contains(t, silences.Silences, window.SilenceID)
+		assert.Equal(t, "changed", *silences.Silences[window.SilenceID].Comment)
+		assert.EqualValues(t, 2, window.Version)
+	})
+
+	t.Run("expires silence of changed recurring silence without window in the lookahead", func(t *testing.T) {
+		m, store, silences, clk := setup(nightly())
+		clk.Set(now.Add(11 * time.Hour))
+		m.Materialize(context.Background())
+		require.Len(t, silences.Silences, 1)
+
+		store.silences["nightly"].Schedule = models.RecurringSilenceSchedule{Cron: "0 22 * * 0", Duration: time.Hour}
+		store.silences["nightly"].Version++
+		m.Materialize(context.Background())
+
+		assert.Empty(t, silences.Silences)
+		assert.Equal(t, models.RecurringSilenceWindow{Version: 2}, store.silences["nightly"].Window)
+	})
+
+	t.Run("expires silence of deleted recurring silence and purges it", func(t *testing.T) {
+		m, store, silences, clk := setup(nightly())
+		clk.Set(now.Add(11 * time.Hour))
+		m.Materialize(context.Background())
+		require.Len(t, silences.Silences, 1)
+
+		store.silences["nightly"].Version++
+		store.deleted = map[string]bool{"nightly": true}
+		m.Materialize(context.Background())
+
+		assert.Empty(t, silences.Silences)
+		assert.NotContains(t, store.silences, "nightly")
+	})
+
+	t.Run("expires duplicate silence when window was saved concurrently", func(t *testing.T) {
+		m, store, silences, clk := setup(nightly())
+		clk.Set(now.Add(11 * time.Hour))
+		store.conflict = true
+
+		m.Materialize(context.Background())
+
+		assert.Empty(t, silences.Silences)
+		assert.Empty(t, store.silences["nightly"].Window.SilenceID)
+	})
+}
+
+type fakeRecurringSilenceStore struct {
+	silences map[string]*models.RecurringSilence
+	deleted  map[string]bool
+	conflict bool
+}
+
+func (f *fakeRecurringSilenceStore) ListRecurringSilences(_ context.Context, _ int64) ([]*models.RecurringSilence, error) {
+	result := make([]*models.RecurringSilence, 0, len(f.silences))
+	for uid, s := range f.silences {
+		if !f.deleted[uid] {
+			c := *s
+			result = append(result, &c)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeRecurringSilenceStore) ListDeletedRecurringSilences(_ context.Context) ([]*models.RecurringSilence, error) {
+	result := make([]*models.RecurringSilence, 0, len(f.deleted))
+	for uid := range f.deleted {
+		c := *f.silences[uid]
+		result = append(result, &c)
+	}
+	return result, nil
+}
+
+func (f *fakeRecurringSilenceStore) UpdateRecurringSilenceWindow(_ context.Context, _ int64, uid string, previousSilenceID string, window models.RecurringSilenceWindow) (bool, error) {
+	s, ok := f.silences[uid]
+	if f.conflict || !ok || s.Version != window.Version || s.Window.SilenceID != previousSilenceID {
+		return false, nil
+	}
+	s.Window = window
+	return true, nil
+}
+
+func (f *fakeRecurringSilenceStore) PurgeRecurringSilence(_ context.Context, _ int64, uid string, version int64) error {
+	if s, ok := f.silences[uid]; ok && s.Version == version && f.deleted[uid] {
+		delete(f.silences, uid)
+		delete(f.deleted, uid)
+	}
+	return nil
+}
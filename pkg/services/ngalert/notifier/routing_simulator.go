@@ -0,0 +1,213 @@
+package notifier
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/prometheus/alertmanager/dispatch"
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+)
+
+var ErrRoutingSimulationInvalidRoute = errutil.BadRequest("alerting.notifications.routing.invalidRoute").MustTemplate(
+	"Invalid notification policy tree: {{ .Public.Error }}",
+	errutil.WithPublic("Invalid notification policy tree: {{ .Public.Error }}"),
+)
+
+func makeErrRoutingSimulationInvalidRoute(err error) error {
+	return ErrRoutingSimulationInvalidRoute.Build(errutil.TemplateData{
+		Public: map[string]any{
+			"Error": err.Error(),
+		},
+		Error: err,
+	})
+}
+
+// SimulateRouting returns the notification policies that an alert with the given labels is routed to at the given time.
+// If route is not nil, it is used instead of the notification policy tree of the organization.
+// No notifications are sent.
+func (moa *MultiOrgAlertmanager) SimulateRouting(ctx context.Context, orgID int64, lbls model.LabelSet, route *definitions.Route, at time.Time) ([]definitions.TestRoutingRoute, error) {
+	cfg, err := moa.GetRoutingConfiguration(ctx, orgID, route)
+	if err != nil {
+		return nil, err
+	}
+	return SimulateRouting(cfg, lbls, at), nil
+}
+
+// GetRoutingConfiguration returns the configuration used to route alerts of the organization, including the autogenerated policies.
+// If route is not nil, it is validated and used instead of the notification policy tree of the organization.
+func (moa *MultiOrgAlertmanager) GetRoutingConfiguration(ctx context.Context, orgID int64, route *definitions.Route) (*definitions.GettableApiAlertingConfig, error) {
+	cfg, err := moa.GetAlertmanagerConfiguration(ctx, orgID, false, false)
+	if err != nil {
+		return nil, err
+	}
+	amConfig := &cfg.AlertmanagerConfig
+
+	if route != nil {
+		if err := validateSimulatedRoute(amConfig, route); err != nil {
+			return nil, makeErrRoutingSimulationInvalidRoute(err)
+		}
+		amConfig.Route = route
+	}
+
+	// Rules with simplified routing are routed by the autogenerated policies.
+	if err := AddAutogenConfig(ctx, moa.logger, moa.configStore, orgID, amConfig, true); err != nil {
+		return nil, err
+	}
+	return amConfig, nil
+}
+
+func validateSimulatedRoute(cfg *definitions.GettableApiAlertingConfig, route *definitions.Route) error {
+	if err := route.Validate(); err != nil {
+		return err
+	}
+
+	receivers := map[string]struct{}{}
+	receivers[""] = struct{}{} // Allow empty receiver (inheriting from parent)
+	for _, receiver := range cfg.Receivers {
+		receivers[receiver.Name] = struct{}{}
+	}
+	if err := route.ValidateReceivers(receivers); err != nil {
+		return err
+	}
+
+	timeIntervals := map[string]struct{}{}
+	for _, mt := range cfg.MuteTimeIntervals {
+		timeIntervals[mt.Name] = struct{}{}
+	}
+	for _, mt := range cfg.TimeIntervals {
+		timeIntervals[mt.Name] = struct{}{}
+	}
+	return route.ValidateTimeIntervals(timeIntervals)
+}
+
+// SimulateRouting walks the notification policy tree of the configuration the same way the dispatcher of the Alertmanager does,
+// and returns the notification policies that match the labels, with the effective settings and the state of their time intervals at the given time.
+func SimulateRouting(cfg *definitions.GettableApiAlertingConfig, lbls model.LabelSet, at time.Time) []definitions.TestRoutingRoute {
+	if cfg.Route == nil {
+		return nil
+	}
+
+	integrations := make(map[string][]string, len(cfg.Receivers))
+	for _, receiver := range cfg.Receivers {
+		types := make([]string, 0, len(receiver.GrafanaManagedReceivers))
+		for _, integration := range receiver.GrafanaManagedReceivers {
+			types = append(types, integration.Type)
+		}
+		integrations[receiver.Name] = types
+	}
+
+	intervals := make(map[string][]timeinterval.TimeInterval, len(cfg.MuteTimeIntervals)+len(cfg.TimeIntervals))
+	for _, mt := range cfg.MuteTimeIntervals {
+		intervals[mt.Name] = mt.TimeIntervals
+	}
+	for _, ti := range cfg.TimeIntervals {
+		intervals[ti.Name] = ti.TimeIntervals
+	}
+
+	s := routingSimulator{
+		labels:       lbls,
+		at:           at,
+		integrations: integrations,
+		intervals:    intervals,
+	}
+	root := dispatch.NewRoute(cfg.Route.AsAMRoute(), nil)
+	path := []definitions.TestRoutingPathElement{{Index: 0, ObjectMatchers: cfg.Route.ObjectMatchers, Continue: cfg.Route.Continue}}
+	return s.match(root, cfg.Route, path)
+}
+
+type routingSimulator struct {
+	labels       model.LabelSet
+	at           time.Time
+	integrations map[string][]string
+	intervals    map[string][]timeinterval.TimeInterval
+}
+
+// match mirrors dispatch.Route.Match. The Grafana route is walked alongside the Alertmanager route to report
+// the matchers of the policies on the path.
+func (s routingSimulator) match(r *dispatch.Route, gr *definitions.Route, path []definitions.TestRoutingPathElement) []definitions.TestRoutingRoute {
+	if !r.Matchers.Matches(s.labels) {
+		return nil
+	}
+
+	var result []definitions.TestRoutingRoute
+	for i, child := range r.Routes {
+		grChild := gr.Routes[i]
+		childPath := append(path[:len(path):len(path)], definitions.TestRoutingPathElement{
+			Index:          i,
+			ObjectMatchers: grChild.ObjectMatchers,
+			Continue:       grChild.Continue,
+		})
+		matches := s.match(child, grChild, childPath)
+		result = append(result, matches...)
+		if matches != nil && !child.Continue {
+			break
+		}
+	}
+
+	// If no child nodes were matched, the current node itself is a match.
+	if len(result) == 0 {
+		result = append(result, s.routeResult(r, path))
+	}
+	return result
+}
+
+func (s routingSimulator) routeResult(r *dispatch.Route, path []definitions.TestRoutingPathElement) definitions.TestRoutingRoute {
+	opts := r.RouteOpts
+	result := definitions.TestRoutingRoute{
+		Path:           path,
+		Receiver:       opts.Receiver,
+		Integrations:   s.integrations[opts.Receiver],
+		GroupBy:        groupByStr(opts),
+		GroupWait:      model.Duration(opts.GroupWait),
+		GroupInterval:  model.Duration(opts.GroupInterval),
+		RepeatInterval: model.Duration(opts.RepeatInterval),
+	}
+	if result.Integrations == nil {
+		result.Integrations = []string{}
+	}
+
+	for _, name := range opts.MuteTimeIntervals {
+		active := s.intervalActive(name)
+		result.MuteTimeIntervals = append(result.MuteTimeIntervals, definitions.TestRoutingTimeInterval{Name: name, Active: active})
+		// Notifications are muted while any of the mute time intervals is active.
+		result.Muted = result.Muted || active
+	}
+
+	anyActive := false
+	for _, name := range opts.ActiveTimeIntervals {
+		active := s.intervalActive(name)
+		result.ActiveTimeIntervals = append(result.ActiveTimeIntervals, definitions.TestRoutingTimeInterval{Name: name, Active: active})
+		anyActive = anyActive || active
+	}
+	// Notifications are muted outside the active time intervals.
+	if len(opts.ActiveTimeIntervals) > 0 && !anyActive {
+		result.Muted = true
+	}
+	return result
+}
+
+func (s routingSimulator) intervalActive(name string) bool {
+	for _, ti := range s.intervals[name] {
+		if ti.ContainsTime(s.at) {
+			return true
+		}
+	}
+	return false
+}
+
+func groupByStr(opts dispatch.RouteOpts) []string {
+	if opts.GroupByAll {
+		return []string{"..."}
+	}
+	result := make([]string, 0, len(opts.GroupBy))
+	for l := range opts.GroupBy {
+		result = append(result, string(l))
+	}
+	slices.Sort(result)
+	return result
+}
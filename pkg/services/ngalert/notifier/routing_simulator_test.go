@@ -0,0 +1,122 @@
+package notifier
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+)
+
+func TestSimulateRouting(t *testing.T) {
+	const rawConfig = `{
+		"alertmanager_config": {
+			"route": {
+				"receiver": "default",
+				"group_by": ["alertname"],
+				"routes": [
+					{
+						"receiver": "infra",
+						"object_matchers": [["team", "=", "infra"]],
+						"group_wait": "1m",
+						"continue": true,
+						"routes": [
+							{
+								"object_matchers": [["severity", "=", "critical"]],
+								"receiver": "pager",
+								"group_by": ["..."],
+								"repeat_interval": "1h",
+								"mute_time_intervals": ["weekends"]
+							}
+						]
+					},
+					{
+						"object_matchers": [["team", "=~", "infra|db"]],
+						"active_time_intervals": ["business-hours"]
+					},
+					{
+						"object_matchers": [["team", "=", "db"]],
+						"receiver": "infra"
+					}
+				]
+			},
+			"time_intervals": [
+				{"name": "business-hours", "time_intervals": [{"times": [{"start_time": "09:00", "end_time": "17:00"}]}]}
+			],
+			"mute_time_intervals": [
+				{"name": "weekends", "time_intervals": [{"weekdays": ["saturday", "sunday"]}]}
+			],
+			"receivers": [
+				{"name": "default", "grafana_managed_receiver_configs": [{"name": "default", "type": "email"}]},
+				{"name": "infra", "grafana_managed_receiver_configs": [{"name": "infra", "type": "slack"}, {"name": "infra", "type": "webhook"}]},
+				{"name": "pager", "grafana_managed_receiver_configs": [{"name": "pager", "type": "pagerduty"}]}
+			]
+		}
+	}`
+	var cfg definitions.GettableUserConfig
+	require.NoError(t, json.Unmarshal([]byte(rawConfig), &cfg))
+
+	saturdayNight := time.Date(2024, 6, 1, 22, 0, 0, 0, time.UTC)
+	mondayMorning := time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC)
+
+	t.Run("no policy matches", func(t *testing.T) {
+		result := SimulateRouting(&cfg.AlertmanagerConfig, model.LabelSet{"team": "web"}, mondayMorning)
+		require.Equal(t, []definitions.TestRoutingRoute{{
+			Path:           []definitions.TestRoutingPathElement{{Index: 0}},
+			Receiver:       "default",
+			Integrations:   []string{"email"},
+			GroupBy:        []string{"alertname"},
+			GroupWait:      model.Duration(30 * time.Second),
+			GroupInterval:  model.Duration(5 * time.Minute),
+			RepeatInterval: model.Duration(4 * time.Hour),
+		}}, result)
+	})
+
+	t.Run("continue matches the next policy", func(t *testing.T) {
+		result := SimulateRouting(&cfg.AlertmanagerConfig, model.LabelSet{"team": "infra", "severity": "critical"}, saturdayNight)
+		require.Len(t, result, 2)
+
+		require.Equal(t, []int{0, 0, 0}, pathIndexes(result[0].Path))
+		require.True(t, result[0].Path[1].Continue)
+		require.Equal(t, "pager", result[0].Receiver)
+		require.Equal(t, []string{"pagerduty"}, result[0].Integrations)
+		require.Equal(t, []string{"..."}, result[0].GroupBy)
+		require.Equal(t, model.Duration(time.Minute), result[0].GroupWait)
+		require.Equal(t, model.Duration(time.Hour), result[0].RepeatInterval)
+		require.Equal(t, []definitions.TestRoutingTimeInterval{{Name: "weekends", Active: true}}, result[0].MuteTimeIntervals)
+		require.True(t, result[0].Muted)
+
+		require.Equal(t, []int{0, 1}, pathIndexes(result[1].Path))
+		require.Equal(t, "default", result[1].Receiver)
+		require.Equal(t, []definitions.TestRoutingTimeInterval{{Name: "business-hours", Active: false}}, result[1].ActiveTimeIntervals)
+		require.True(t, result[1].Muted)
+	})
+
+	t.Run("time intervals are checked at the given time", func(t *testing.T) {
+		result := SimulateRouting(&cfg.AlertmanagerConfig, model.LabelSet{"team": "infra", "severity": "critical"}, mondayMorning)
+		require.Len(t, result, 2)
+		require.Equal(t, []definitions.TestRoutingTimeInterval{{Name: "weekends", Active: false}}, result[0].MuteTimeIntervals)
+		require.False(t, result[0].Muted)
+		require.Equal(t, []definitions.TestRoutingTimeInterval{{Name: "business-hours", Active: true}}, result[1].ActiveTimeIntervals)
+		require.False(t, result[1].Muted)
+	})
+
+	t.Run("first match without continue stops", func(t *testing.T) {
+		result := SimulateRouting(&cfg.AlertmanagerConfig, model.LabelSet{"team": "db"}, mondayMorning)
+		require.Len(t, result, 1)
+		require.Equal(t, []int{0, 1}, pathIndexes(result[0].Path))
+		require.Len(t, result[0].Path[1].ObjectMatchers, 1)
+		require.Equal(t, "team", result[0].Path[1].ObjectMatchers[0].Name)
+	})
+}
+
+func pathIndexes(path []definitions.TestRoutingPathElement) []int {
+	result := make([]int, 0, len(path))
+	for _, p := range path {
+		result = append(result, p.Index)
+	}
+	return result
+}
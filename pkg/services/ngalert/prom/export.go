@@ -0,0 +1,432 @@
+package prom
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"time"
+
+	prommodel "github.com/prometheus/common/model"
+	"gopkg.in/yaml.v3"
+
+	"github.com/grafana/grafana/pkg/expr"
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// DatasourceTypeResolver returns the type of the data source with the given UID.
+type DatasourceTypeResolver func(uid string) (string, error)
+
+// UnsupportedRule is a Grafana rule that cannot be represented in the Prometheus rule format.
+type UnsupportedRule struct {
+	UID    string
+	Title  string
+	Group  string
+	Reason string
+}
+
+// errUnsupportedRule is returned when a Grafana rule cannot be represented in the Prometheus rule format.
+type errUnsupportedRule string
+
+func (e errUnsupportedRule) Error() string {
+	return string(e)
+}
+
+func unsupported(format string, args ...any) error {
+	return errUnsupportedRule(fmt.Sprintf(format, args...))
+}
+
+// prometheusMathExpression is the math expression created for the rules imported from Prometheus.
+var prometheusMathExpression = fmt.Sprintf("is_number($%[1]s) || is_nan($%[1]s) || is_inf($%[1]s)", queryRefID)
+
+// GrafanaRuleGroupToPrometheus converts the rules of a Grafana rule group to a Prometheus rule group.
+//
+// Rules imported from Prometheus are exported with their original definition, unless they were changed in Grafana
+// after the import. Other rules are converted if they query a single Prometheus data source and their condition is
+// the query itself, or a threshold of the query or of an instant query reduced to a single value. The rules that cannot be represented in the
+// Prometheus format are left out of the group and returned with the reason.
+func GrafanaRuleGroupToPrometheus(group string, rules []models.AlertRule, dsType DatasourceTypeResolver) (PrometheusRuleGroup, []UnsupportedRule, error) {
+	promGroup := PrometheusRuleGroup{
+		Name:  group,
+		Rules: make([]PrometheusRule, 0, len(rules)),
+	}
+	if len(rules) > 0 {
+		promGroup.Interval = prommodel.Duration(time.Duration(rules[0].IntervalSeconds) * time.Second)
+	}
+
+	var skipped []UnsupportedRule
+	for _, rule := range rules {
+		promRule, err := GrafanaRuleToPrometheus(&rule, dsType)
+		if err != nil {
+			var reason errUnsupportedRule
+			if !errors.As(err, &reason) {
+				return PrometheusRuleGroup{}, nil, fmt.Errorf("failed to convert the rule with UID %s: %w", rule.UID, err)
+			}
+			skipped = append(skipped, UnsupportedRule{
+				UID:    rule.UID,
+				Title:  rule.Title,
+				Group:  group,
+				Reason: reason.Error(),
+			})
+			continue
+		}
+		promGroup.Rules = append(promGroup.Rules, promRule)
+	}
+	return promGroup, skipped, nil
+}
+
+// GrafanaRuleToPrometheus converts a Grafana rule to a Prometheus rule.
+// It returns errUnsupportedRule if the rule cannot be represented in the Prometheus format.
+func GrafanaRuleToPrometheus(rule *models.AlertRule, dsType DatasourceTypeResolver) (PrometheusRule, error) {
+	result, err := convertGrafanaRule(rule, dsType)
+	if err != nil || !rule.HasPrometheusRuleDefinition() {
+		return result, err
+	}
+
+	definition, err := rule.PrometheusRuleDefinition()
+	if err != nil {
+		return PrometheusRule{}, err
+	}
+	var original PrometheusRule
+	if err := yaml.Unmarshal([]byte(definition), &original); err != nil {
+		return PrometheusRule{}, fmt.Errorf("failed to unmarshal Prometheus rule definition: %w", err)
+	}
+	// The original definition is outdated if the rule was changed in Grafana after it was imported.
+	if !samePrometheusRule(original, result) {
+		return result, nil
+	}
+	return original, nil
+}
+
+func convertGrafanaRule(rule *models.AlertRule, dsType DatasourceTypeResolver) (PrometheusRule, error) {
+	nodes := make(map[string]*models.AlertQuery, len(rule.Data))
+	var dsQuery *models.AlertQuery
+	for i := range rule.Data {
+		q := &rule.Data[i]
+		nodes[q.RefID] = q
+		isExpr, err := q.IsExpression()
+		if err != nil {
+			return PrometheusRule{}, err
+		}
+		if isExpr {
+			if expressionType(q) == expr.QueryTypeSQL {
+				return PrometheusRule{}, unsupported("SQL expressions are not supported")
+			}
+			continue
+		}
+		if dsQuery != nil {
+			if dsQuery.DatasourceUID != q.DatasourceUID {
+				return PrometheusRule{}, unsupported("queries to multiple data sources are not supported")
+			}
+			return PrometheusRule{}, unsupported("multiple queries are not supported")
+		}
+		dsQuery = q
+	}
+	if dsQuery == nil {
+		return PrometheusRule{}, unsupported("the rule does not query a data source")
+	}
+
+	typ, err := queryDatasourceType(dsQuery, dsType)
+	if err != nil {
+		return PrometheusRule{}, err
+	}
+	if typ != datasources.DS_PROMETHEUS {
+		return PrometheusRule{}, unsupported("queries to data sources of type %q are not supported", typ)
+	}
+	promQL, err := dsQuery.GetQuery()
+	if err != nil {
+		return PrometheusRule{}, unsupported("the query does not have a PromQL expression")
+	}
+
+	labels := exportedLabels(rule.Labels)
+	if rule.Type() == models.RuleTypeRecording {
+		if rule.Record.From != dsQuery.RefID {
+			return PrometheusRule{}, unsupported("recording the result of an expression is not supported")
+		}
+		return PrometheusRule{
+			Record: rule.Record.Metric,
+			Expr:   promQL,
+			Labels: labels,
+		}, nil
+	}
+
+	c := &conditionConverter{nodes: nodes, query: dsQuery, promQL: promQL}
+	condition, err := c.condition(rule.Condition)
+	if err != nil {
+		return PrometheusRule{}, err
+	}
+
+	annotations := exportedLabels(rule.Annotations)
+	for _, m := range []map[string]string{labels, annotations} {
+		for _, v := range m {
+			if strings.Contains(v, "$values") {
+				return PrometheusRule{}, unsupported("templates that use $values are not supported")
+			}
+		}
+	}
+
+	result := PrometheusRule{
+		Alert:       rule.Title,
+		Expr:        condition,
+		Labels:      labels,
+		Annotations: annotations,
+	}
+	if rule.For > 0 {
+		result.For = durationPtr(rule.For)
+	}
+	if rule.KeepFiringFor > 0 {
+		result.KeepFiringFor = durationPtr(rule.KeepFiringFor)
+	}
+	return result, nil
+}
+
+// conditionConverter translates the condition of an alert rule to PromQL.
+type conditionConverter struct {
+	nodes  map[string]*models.AlertQuery
+	query  *models.AlertQuery
+	promQL string
+}
+
+func (c *conditionConverter) node(refID string) (*models.AlertQuery, error) {
+	node, ok := c.nodes[strings.TrimPrefix(refID, "$")]
+	if !ok {
+		return nil, unsupported("the rule refers to query %q that does not exist", refID)
+	}
+	return node, nil
+}
+
+// condition returns the PromQL expression that returns the alerting series of the condition node.
+func (c *conditionConverter) condition(refID string) (string, error) {
+	node, err := c.node(refID)
+	if err != nil {
+		return "", err
+	}
+	if node == c.query {
+		// Grafana alerts on the series with a value other than zero.
+		return fmt.Sprintf("(%s) != 0", c.promQL), nil
+	}
+
+	switch expressionType(node) {
+	case expr.QueryTypeThreshold:
+		return c.threshold(node)
+	case expr.QueryTypeReduce:
+		value, err := c.value(node)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("(%s) != 0", value), nil
+	default:
+		return "", unsupported("%s expressions are not supported", expressionType(node))
+	}
+}
+
+func (c *conditionConverter) threshold(node *models.AlertQuery) (string, error) {
+	var model expr.ThresholdQuery
+	if err := json.Unmarshal(node.Model, &model); err != nil {
+		return "", fmt.Errorf("failed to parse the threshold expression: %w", err)
+	}
+	if len(model.Conditions) != 1 {
+		return "", unsupported("threshold expressions with %d conditions are not supported", len(model.Conditions))
+	}
+	cond := model.Conditions[0]
+	if cond.UnloadEvaluator != nil {
+		return "", unsupported("threshold expressions with a recovery threshold are not supported")
+	}
+	if cond.Window != nil {
+		return "", unsupported("threshold expressions with a window are not supported")
+	}
+
+	input, err := c.node(model.Expression)
+	if err != nil {
+		return "", err
+	}
+	// The rules imported from Prometheus alert on any series returned by the query.
+	if expressionType(input) == expr.QueryTypeMath {
+		var math expr.MathQuery
+		if err := json.Unmarshal(input.Model, &math); err != nil {
+			return "", fmt.Errorf("failed to parse the math expression: %w", err)
+		}
+		if math.Expression == strings.ReplaceAll(prometheusMathExpression, queryRefID, c.query.RefID) &&
+			cond.Evaluator.Type == expr.ThresholdIsAbove && len(cond.Evaluator.Params) == 1 && cond.Evaluator.Params[0] == 0 {
+			return c.promQL, nil
+		}
+		return "", unsupported("math expressions are not supported")
+	}
+
+	value, err := c.value(input)
+	if err != nil {
+		return "", err
+	}
+	return thresholdToPromQL(value, cond.Evaluator)
+}
+
+// value returns the PromQL expression that returns the values of the node.
+func (c *conditionConverter) value(node *models.AlertQuery) (string, error) {
+	if node == c.query {
+		return c.promQL, nil
+	}
+	if expressionType(node) != expr.QueryTypeReduce {
+		return "", unsupported("%s expressions are not supported", expressionType(node))
+	}
+
+	var model expr.ReduceQuery
+	if err := json.Unmarshal(node.Model, &model); err != nil {
+		return "", fmt.Errorf("failed to parse the reduce expression: %w", err)
+	}
+	input, err := c.node(model.Expression)
+	if err != nil {
+		return "", err
+	}
+	if input != c.query {
+		return "", unsupported("reducing the result of an expression is not supported")
+	}
+	if !isInstantQuery(c.query) {
+		return "", unsupported("reducing the result of a range query is not supported")
+	}
+	if model.Settings != nil && model.Settings.Mode == expr.ReduceModeReplace {
+		return "", unsupported("replacing non-numeric values is not supported")
+	}
+	// An instant query returns a single value per series, which most reducers return as is.
+	switch model.Reducer {
+	case mathexp.ReducerLast, mathexp.ReducerFirst, mathexp.ReducerMean, mathexp.ReducerMin, mathexp.ReducerMax, mathexp.ReducerMedian, mathexp.ReducerSum:
+		return c.promQL, nil
+	default:
+		return "", unsupported("the %s reducer is not supported", model.Reducer)
+	}
+}
+
+func thresholdToPromQL(value string, evaluator expr.ConditionEvalJSON) (string, error) {
+	params := make([]string, len(evaluator.Params))
+	for i, p := range evaluator.Params {
+		params[i] = strconv.FormatFloat(p, 'f', -1, 64)
+	}
+	expectParams := func(n int) error {
+		if len(params) < n {
+			return unsupported("threshold %s requires %d parameters, got %d", evaluator.Type, n, len(params))
+		}
+		return nil
+	}
+
+	var operator string
+	switch evaluator.Type {
+	case expr.ThresholdIsAbove:
+		operator = ">"
+	case expr.ThresholdIsBelow:
+		operator = "<"
+	case expr.ThresholdIsEqual:
+		operator = "=="
+	case expr.ThresholdIsNotEqual:
+		operator = "!="
+	case expr.ThresholdIsGreaterThanEqual:
+		operator = ">="
+	case expr.ThresholdIsLessThanEqual:
+		operator = "<="
+	case expr.ThresholdIsWithinRange:
+		if err := expectParams(2); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("(%s) > %s < %s", value, params[0], params[1]), nil
+	case expr.ThresholdIsWithinRangeIncluded:
+		if err := expectParams(2); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("(%s) >= %s <= %s", value, params[0], params[1]), nil
+	case expr.ThresholdIsOutsideRange:
+		if err := expectParams(2); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("(%[1]s) < %[2]s or (%[1]s) > %[3]s", value, params[0], params[1]), nil
+	case expr.ThresholdIsOutsideRangeIncluded:
+		if err := expectParams(2); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("(%[1]s) <= %[2]s or (%[1]s) >= %[3]s", value, params[0], params[1]), nil
+	default:
+		return "", unsupported("threshold type %q is not supported", evaluator.Type)
+	}
+	if err := expectParams(1); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("(%s) %s %s", value, operator, params[0]), nil
+}
+
+func expressionType(q *models.AlertQuery) expr.QueryType {
+	var model struct {
+		Type expr.QueryType `json:"type"`
+	}
+	_ = json.Unmarshal(q.Model, &model)
+	return model.Type
+}
+
+func isInstantQuery(q *models.AlertQuery) bool {
+	var model struct {
+		Instant bool `json:"instant"`
+		Range   bool `json:"range"`
+	}
+	_ = json.Unmarshal(q.Model, &model)
+	return model.Instant && !model.Range
+}
+
+func queryDatasourceType(q *models.AlertQuery, dsType DatasourceTypeResolver) (string, error) {
+	var model struct {
+		Datasource struct {
+			Type string `json:"type"`
+		} `json:"datasource"`
+	}
+	_ = json.Unmarshal(q.Model, &model)
+	if model.Datasource.Type != "" {
+		return model.Datasource.Type, nil
+	}
+	if dsType == nil {
+		return "", unsupported("the type of the data source %s is unknown", q.DatasourceUID)
+	}
+	typ, err := dsType(q.DatasourceUID)
+	if err != nil {
+		return "", unsupported("failed to get the data source %s: %s", q.DatasourceUID, err)
+	}
+	return typ, nil
+}
+
+// exportedLabels returns the labels without the labels that Grafana uses internally.
+func exportedLabels(labels map[string]string) map[string]string {
+	if len(labels) == 0 {
+		return nil
+	}
+	result := maps.Clone(labels)
+	for k := range result {
+		if strings.HasPrefix(k, "__") && strings.HasSuffix(k, "__") {
+			delete(result, k)
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// samePrometheusRule returns true if the rules have the same definition. Missing and empty
+// durations, labels and annotations are the same.
+func samePrometheusRule(a, b PrometheusRule) bool {
+	duration := func(d *prommodel.Duration) prommodel.Duration {
+		if d == nil {
+			return 0
+		}
+		return *d
+	}
+	return a.Alert == b.Alert &&
+		a.Record == b.Record &&
+		a.Expr == b.Expr &&
+		duration(a.For) == duration(b.For) &&
+		duration(a.KeepFiringFor) == duration(b.KeepFiringFor) &&
+		maps.Equal(exportedLabels(a.Labels), exportedLabels(b.Labels)) &&
+		maps.Equal(exportedLabels(a.Annotations), exportedLabels(b.Annotations))
+}
+
+func durationPtr(d time.Duration) *prommodel.Duration {
+	result := prommodel.Duration(d)
+	return &result
+}
@@ -0,0 +1,369 @@
+package prom
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"testing"
+	"time"
+
+	prommodel "github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/expr"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+func TestGrafanaRuleGroupToPrometheus_RoundTrip(t *testing.T) {
+	promGroup := PrometheusRuleGroup{
+		Name:     "test-group",
+		Interval: prommodel.Duration(time.Minute),
+		Rules: []PrometheusRule{
+			{
+				Alert:         "HighCPU",
+				Expr:          "cpu_usage > 80",
+				For:           util.Pointer(prommodel.Duration(5 * time.Minute)),
+				KeepFiringFor: util.Pointer(prommodel.Duration(time.Minute)),
+				Labels:        map[string]string{"severity": "critical"},
+				Annotations:   map[string]string{"summary": "CPU usage is {{ $value }}"},
+			},
+			{
+				Record: "job:cpu_usage:sum",
+				Expr:   "sum by (job) (cpu_usage)",
+				Labels: map[string]string{"team": "infra"},
+			},
+		},
+	}
+
+	for _, keep := range []bool{true, false} {
+		t.Run(fmt.Sprintf("keep original rule definition %t", keep), func(t *testing.T) {
+			converter, err := NewConverter(Config{
+				DatasourceUID:              "datasource-uid",
+				DatasourceType:             datasources.DS_PROMETHEUS,
+				DefaultInterval:            time.Minute,
+				KeepOriginalRuleDefinition: util.Pointer(keep),
+			})
+			require.NoError(t, err)
+
+			grafanaGroup, err := converter.PrometheusRulesToGrafana(1, "namespace", promGroup)
+			require.NoError(t, err)
+
+			result, skipped, err := GrafanaRuleGroupToPrometheus(promGroup.Name, grafanaGroup.Rules, nil)
+			require.NoError(t, err)
+			require.Empty(t, skipped)
+			require.Equal(t, promGroup, result)
+		})
+	}
+}
+
+func TestGrafanaRuleToPrometheus(t *testing.T) {
+	dsType := func(uid string) (string, error) {
+		switch uid {
+		case "prom":
+			return datasources.DS_PROMETHEUS, nil
+		case "loki":
+			return datasources.DS_LOKI, nil
+		}
+		return "", errors.New("not found")
+	}
+
+	testCases := []struct {
+		name        string
+		rule        models.AlertRule
+		expected    PrometheusRule
+		unsupported string
+	}{
+		{
+			name: "query as condition",
+			rule: alertRule("A", promQuery("A", "prom", "up", true)),
+			expected: PrometheusRule{
+				Alert: "test-rule",
+				Expr:  "(up) != 0",
+			},
+		},
+		{
+			name: "threshold of the query",
+			rule: alertRule("B",
+				promQuery("A", "prom", "rate(errors_total[5m])", false),
+				thresholdNode("B", "A", expr.ThresholdIsAbove, 0.5),
+			),
+			expected: PrometheusRule{
+				Alert: "test-rule",
+				Expr:  "(rate(errors_total[5m])) > 0.5",
+			},
+		},
+		{
+			name: "threshold of a reduced instant query",
+			rule: alertRule("C",
+				promQuery("A", "prom", "up", true),
+				reduceNode("B", "A", "last"),
+				thresholdNode("C", "B", expr.ThresholdIsOutsideRange, 1, 10),
+			),
+			expected: PrometheusRule{
+				Alert: "test-rule",
+				Expr:  "(up) < 1 or (up) > 10",
+			},
+		},
+		{
+			name: "internal labels and annotations are not exported",
+			rule: func() models.AlertRule {
+				r := alertRule("A", promQuery("A", "prom", "up", true))
+				r.Labels = map[string]string{"severity": "warning", models.AutogeneratedRouteLabel: "true"}
+				r.Annotations = map[string]string{models.DashboardUIDAnnotation: "dash"}
+				r.For = time.Minute
+				return r
+			}(),
+			expected: PrometheusRule{
+				Alert:  "test-rule",
+				Expr:   "(up) != 0",
+				Labels: map[string]string{"severity": "warning"},
+				For:    util.Pointer(prommodel.Duration(time.Minute)),
+			},
+		},
+		{
+			name: "recording rule",
+			rule: func() models.AlertRule {
+				r := alertRule("", promQuery("A", "prom", "sum(up)", true))
+				r.Record = &models.Record{Metric: "total_up", From: "A"}
+				return r
+			}(),
+			expected: PrometheusRule{
+				Record: "total_up",
+				Expr:   "sum(up)",
+			},
+		},
+		{
+			name:        "non-Prometheus data source",
+			rule:        alertRule("A", promQuery("A", "loki", "up", true)),
+			unsupported: `queries to data sources of type "loki" are not supported`,
+		},
+		{
+			name:        "unknown data source",
+			rule:        alertRule("A", promQuery("A", "missing", "up", true)),
+			unsupported: "failed to get the data source missing: not found",
+		},
+		{
+			name: "multiple queries",
+			rule: alertRule("A",
+				promQuery("A", "prom", "up", true),
+				promQuery("B", "prom", "down", true),
+			),
+			unsupported: "multiple queries are not supported",
+		},
+		{
+			name: "reducer of a range query",
+			rule: alertRule("C",
+				promQuery("A", "prom", "up", false),
+				reduceNode("B", "A", "mean"),
+				thresholdNode("C", "B", expr.ThresholdIsAbove, 1),
+			),
+			unsupported: "reducing the result of a range query is not supported",
+		},
+		{
+			name: "count reducer",
+			rule: alertRule("C",
+				promQuery("A", "prom", "up", true),
+				reduceNode("B", "A", "count"),
+				thresholdNode("C", "B", expr.ThresholdIsAbove, 1),
+			),
+			unsupported: "the count reducer is not supported",
+		},
+		{
+			name: "templates with $values",
+			rule: func() models.AlertRule {
+				r := alertRule("A", promQuery("A", "prom", "up", true))
+				r.Annotations = map[string]string{"summary": "{{ $values.A }}"}
+				return r
+			}(),
+			unsupported: "templates that use $values are not supported",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := GrafanaRuleToPrometheus(&tc.rule, dsType)
+			if tc.unsupported != "" {
+				var reason errUnsupportedRule
+				require.ErrorAs(t, err, &reason)
+				require.Equal(t, tc.unsupported, reason.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestGrafanaRuleToPrometheus_ChangedAfterImport(t *testing.T) {
+	original := PrometheusRule{
+		Alert:       "HighCPU",
+		Expr:        "cpu_usage > 80",
+		For:         util.Pointer(prommodel.Duration(5 * time.Minute)),
+		Labels:      map[string]string{"severity": "critical"},
+		Annotations: map[string]string{"summary": "CPU usage is {{ $value }}"},
+	}
+	converter, err := NewConverter(Config{
+		DatasourceUID:              "datasource-uid",
+		DatasourceType:             datasources.DS_PROMETHEUS,
+		DefaultInterval:            time.Minute,
+		KeepOriginalRuleDefinition: util.Pointer(true),
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name        string
+		change      func(t *testing.T, rule *models.AlertRule)
+		expected    func(r *PrometheusRule)
+		unsupported string
+	}{
+		{
+			name:     "unchanged",
+			change:   func(*testing.T, *models.AlertRule) {},
+			expected: func(*PrometheusRule) {},
+		},
+		{
+			name:     "title",
+			change:   func(_ *testing.T, rule *models.AlertRule) { rule.Title = "VeryHighCPU" },
+			expected: func(r *PrometheusRule) { r.Alert = "VeryHighCPU" },
+		},
+		{
+			name: "labels and annotations",
+			change: func(_ *testing.T, rule *models.AlertRule) {
+				rule.Labels["team"] = "infra"
+				rule.Annotations = nil
+			},
+			expected: func(r *PrometheusRule) {
+				r.Labels = map[string]string{"severity": "critical", "team": "infra"}
+				r.Annotations = nil
+			},
+		},
+		{
+			name:     "pending period",
+			change:   func(_ *testing.T, rule *models.AlertRule) { rule.For = 0 },
+			expected: func(r *PrometheusRule) { r.For = nil },
+		},
+		{
+			name: "query",
+			change: func(t *testing.T, rule *models.AlertRule) {
+				var model map[string]any
+				require.NoError(t, json.Unmarshal(rule.Data[0].Model, &model))
+				model["expr"] = "cpu_usage > 90"
+				changed, err := json.Marshal(model)
+				require.NoError(t, err)
+				q := rule.Data[0]
+				rule.Data[0] = models.AlertQuery{
+					RefID:             q.RefID,
+					QueryType:         q.QueryType,
+					RelativeTimeRange: q.RelativeTimeRange,
+					DatasourceUID:     q.DatasourceUID,
+					Model:             changed,
+				}
+			},
+			expected: func(r *PrometheusRule) { r.Expr = "cpu_usage > 90" },
+		},
+		{
+			name: "unsupported query",
+			change: func(_ *testing.T, rule *models.AlertRule) {
+				rule.Data = append(rule.Data, promQuery("B", "datasource-uid", "up", true))
+			},
+			unsupported: "multiple queries are not supported",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			group, err := converter.PrometheusRulesToGrafana(1, "namespace", PrometheusRuleGroup{Name: "group", Rules: []PrometheusRule{original}})
+			require.NoError(t, err)
+			rule := group.Rules[0]
+			require.True(t, rule.HasPrometheusRuleDefinition())
+			tc.change(t, &rule)
+
+			result, err := GrafanaRuleToPrometheus(&rule, nil)
+			if tc.unsupported != "" {
+				var reason errUnsupportedRule
+				require.ErrorAs(t, err, &reason)
+				require.Equal(t, tc.unsupported, reason.Error())
+				return
+			}
+			require.NoError(t, err)
+			expected := original
+			expected.Labels = maps.Clone(original.Labels)
+			tc.expected(&expected)
+			require.Equal(t, expected, result)
+		})
+	}
+}
+
+func TestGrafanaRuleGroupToPrometheus_Unsupported(t *testing.T) {
+	rules := []models.AlertRule{
+		alertRule("A", promQuery("A", "prom", "up", true)),
+		alertRule("A", promQuery("A", "loki", "up", true)),
+	}
+	rules[0].UID, rules[1].UID = "supported", "unsupported"
+	rules[0].IntervalSeconds, rules[1].IntervalSeconds = 30, 30
+
+	dsType := func(uid string) (string, error) {
+		if uid == "prom" {
+			return datasources.DS_PROMETHEUS, nil
+		}
+		return uid, nil
+	}
+	group, skipped, err := GrafanaRuleGroupToPrometheus("group", rules, dsType)
+	require.NoError(t, err)
+	require.Equal(t, prommodel.Duration(30*time.Second), group.Interval)
+	require.Len(t, group.Rules, 1)
+	require.Equal(t, []UnsupportedRule{{
+		UID:    "unsupported",
+		Title:  "test-rule",
+		Group:  "group",
+		Reason: `queries to data sources of type "loki" are not supported`,
+	}}, skipped)
+}
+
+func alertRule(condition string, queries ...models.AlertQuery) models.AlertRule {
+	return models.AlertRule{
+		Title:     "test-rule",
+		Condition: condition,
+		Data:      queries,
+	}
+}
+
+func promQuery(refID, dsUID, promQL string, instant bool) models.AlertQuery {
+	return testAlertQuery(refID, dsUID, map[string]any{
+		"refId":   refID,
+		"expr":    promQL,
+		"instant": instant,
+		"range":   !instant,
+	})
+}
+
+func reduceNode(refID, input string, reducer string) models.AlertQuery {
+	return testAlertQuery(refID, expr.DatasourceUID, map[string]any{
+		"refId":      refID,
+		"type":       expr.QueryTypeReduce,
+		"expression": input,
+		"reducer":    reducer,
+	})
+}
+
+func thresholdNode(refID, input string, typ expr.ThresholdType, params ...float64) models.AlertQuery {
+	return testAlertQuery(refID, expr.DatasourceUID, map[string]any{
+		"refId":      refID,
+		"type":       expr.QueryTypeThreshold,
+		"expression": input,
+		"conditions": []map[string]any{{
+			"evaluator": map[string]any{"type": typ, "params": params},
+		}},
+	})
+}
+
+func testAlertQuery(refID, dsUID string, model map[string]any) models.AlertQuery {
+	data, err := json.Marshal(model)
+	if err != nil {
+		panic(err)
+	}
+	return models.AlertQuery{RefID: refID, DatasourceUID: dsUID, Model: data}
+}
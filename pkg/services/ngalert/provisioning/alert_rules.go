@@ -600,7 +600,7 @@ func (service *AlertRuleService) calcDelta(ctx context.Context, user identity.Re
 		if err := group.Rules[i].SetDashboardAndPanelFromAnnotations(); err != nil {
 			return nil, err
 		}
-		rules = append(rules, &models.AlertRuleWithOptionals{AlertRule: group.Rules[i], HasPause: true})
+		rules = append(rules, &models.AlertRuleWithOptionals{AlertRule: group.Rules[i], HasPause: true, HasDependencies: true})
 	}
 	delta, err := store.CalculateChanges(ctx, service.ruleStore, key, rules)
 	if err != nil {
@@ -0,0 +1,153 @@
+package provisioning
+
+import (
+	"context"
+	"errors"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/provisioning/validation"
+)
+
+type RecurringSilenceStore interface {
+	ListRecurringSilences(ctx context.Context, orgID int64) ([]*models.RecurringSilence, error)
+	GetRecurringSilence(ctx context.Context, orgID int64, uid string) (*models.RecurringSilence, error)
+	InsertRecurringSilence(ctx context.Context, s *models.RecurringSilence) error
+	UpdateRecurringSilence(ctx context.Context, s *models.RecurringSilence) error
+	DeleteRecurringSilence(ctx context.Context, orgID int64, uid string) error
+}
+
+// RecurringSilenceService manages recurring silences. The silences for the windows of their schedules are created
+// by the recurring silence materializer of the Alertmanager.
+type RecurringSilenceService struct {
+	store           RecurringSilenceStore
+	provenanceStore ProvisioningStore
+	xact            TransactionManager
+	log             log.Logger
+	validator       validation.ProvenanceStatusTransitionValidator
+}
+
+func NewRecurringSilenceService(store RecurringSilenceStore, prov ProvisioningStore, xact TransactionManager, log log.Logger) *RecurringSilenceService {
+	return &RecurringSilenceService{
+		store:           store,
+		provenanceStore: prov,
+		xact:            xact,
+		log:             log,
+		validator:       validation.ValidateProvenanceRelaxed,
+	}
+}
+
+// GetRecurringSilences returns all recurring silences within the specified org.
+func (svc *RecurringSilenceService) GetRecurringSilences(ctx context.Context, orgID int64) ([]*models.RecurringSilence, error) {
+	silences, err := svc.store.ListRecurringSilences(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if len(silences) == 0 {
+		return silences, nil
+	}
+
+	provenances, err := svc.provenanceStore.GetProvenances(ctx, orgID, (&models.RecurringSilence{}).ResourceType())
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range silences {
+		s.Provenance = provenances[s.ResourceID()]
+	}
+	return silences, nil
+}
+
+// GetRecurringSilence returns the recurring silence with the UID.
+func (svc *RecurringSilenceService) GetRecurringSilence(ctx context.Context, orgID int64, uid string) (*models.RecurringSilence, error) {
+	s, err := svc.store.GetRecurringSilence(ctx, orgID, uid)
+	if err != nil {
+		return nil, err
+	}
+	s.Provenance, err = svc.provenanceStore.GetProvenance(ctx, s, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// CreateRecurringSilence validates and creates a new recurring silence. A UID is generated if it is empty.
+func (svc *RecurringSilenceService) CreateRecurringSilence(ctx context.Context, s models.RecurringSilence, provenance models.Provenance) (*models.RecurringSilence, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	s.Provenance = provenance
+	s.Window = models.RecurringSilenceWindow{}
+
+	err := svc.xact.InTransaction(ctx, func(ctx context.Context) error {
+		if err := svc.store.InsertRecurringSilence(ctx, &s); err != nil {
+			return err
+		}
+		return svc.provenanceStore.SetProvenance(ctx, &s, s.OrgID, provenance)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// UpdateRecurringSilence validates and updates the recurring silence with the UID. If the version is set, it must
+// match the current version. The silence created for the current window is replaced when the schedule is next checked.
+func (svc *RecurringSilenceService) UpdateRecurringSilence(ctx context.Context, s models.RecurringSilence, provenance models.Provenance) (*models.RecurringSilence, error) {
+	if s.UID == "" {
+		return nil, models.MakeErrRecurringSilenceInvalid(errors.New("uid is required"))
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := svc.GetRecurringSilence(ctx, s.OrgID, s.UID)
+	if err != nil {
+		return nil, err
+	}
+	if err := svc.validator(existing.Provenance, provenance); err != nil {
+		return nil, err
+	}
+	if s.Version == 0 {
+		s.Version = existing.Version
+	}
+	s.Provenance = provenance
+	s.Window = existing.Window
+	// Changing the version replaces the silence of the current window, so unchanged recurring silences are not saved.
+	if s.Version == existing.Version && existing.Provenance == provenance && s.DefinitionEquals(existing) {
+		return existing, nil
+	}
+
+	err = svc.xact.InTransaction(ctx, func(ctx context.Context) error {
+		if err := svc.store.UpdateRecurringSilence(ctx, &s); err != nil {
+			return err
+		}
+		return svc.provenanceStore.SetProvenance(ctx, &s, s.OrgID, provenance)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// DeleteRecurringSilence deletes the recurring silence with the UID. The silence created for the current window is
+// expired when the schedule is next checked.
+func (svc *RecurringSilenceService) DeleteRecurringSilence(ctx context.Context, orgID int64, uid string, provenance models.Provenance) error {
+	existing, err := svc.GetRecurringSilence(ctx, orgID, uid)
+	if err != nil {
+		if errors.Is(err, models.ErrRecurringSilenceNotFound) {
+			svc.log.FromContext(ctx).Debug("Recurring silence was not found. Skip deleting", "uid", uid)
+			return nil
+		}
+		return err
+	}
+	if err := svc.validator(existing.Provenance, provenance); err != nil {
+		return err
+	}
+
+	return svc.xact.InTransaction(ctx, func(ctx context.Context) error {
+		if err := svc.store.DeleteRecurringSilence(ctx, orgID, uid); err != nil {
+			return err
+		}
+		return svc.provenanceStore.DeleteProvenance(ctx, existing, orgID)
+	})
+}
@@ -0,0 +1,239 @@
+package provisioning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/provisioning/validation"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+)
+
+type RuleTemplateStore interface {
+	ListRuleTemplates(ctx context.Context, orgID int64) ([]*models.RuleTemplate, error)
+	GetRuleTemplate(ctx context.Context, orgID int64, uid string) (*models.RuleTemplate, error)
+	InsertRuleTemplate(ctx context.Context, t *models.RuleTemplate) error
+	UpdateRuleTemplate(ctx context.Context, t *models.RuleTemplate) error
+	DeleteRuleTemplate(ctx context.Context, orgID int64, uid string) error
+}
+
+// templateRuleService manages the alert rules created from rule templates.
+type templateRuleService interface {
+	GetAlertRule(ctx context.Context, user identity.Requester, ruleUID string) (models.AlertRule, models.Provenance, error)
+	CreateAlertRule(ctx context.Context, user identity.Requester, rule models.AlertRule, provenance models.Provenance) (models.AlertRule, error)
+	UpdateAlertRule(ctx context.Context, user identity.Requester, rule models.AlertRule, provenance models.Provenance) (models.AlertRule, error)
+	DeleteAlertRule(ctx context.Context, user identity.Requester, ruleUID string, provenance models.Provenance) error
+}
+
+// RuleTemplateService manages rule templates and keeps the alert rules created for their instances in sync with
+// them. The rules have the provenance models.ProvenanceTemplate, so that they cannot be changed in any other way.
+type RuleTemplateService struct {
+	store           RuleTemplateStore
+	rules           templateRuleService
+	provenanceStore ProvisioningStore
+	xact            TransactionManager
+	log             log.Logger
+	validator       validation.ProvenanceStatusTransitionValidator
+}
+
+func NewRuleTemplateService(store RuleTemplateStore, rules *AlertRuleService, prov ProvisioningStore, xact TransactionManager, log log.Logger) *RuleTemplateService {
+	return &RuleTemplateService{
+		store:           store,
+		rules:           rules,
+		provenanceStore: prov,
+		xact:            xact,
+		log:             log,
+		validator:       validation.ValidateProvenanceRelaxed,
+	}
+}
+
+// GetRuleTemplates returns all rule templates within the specified org.
+func (svc *RuleTemplateService) GetRuleTemplates(ctx context.Context, orgID int64) ([]*models.RuleTemplate, error) {
+	templates, err := svc.store.ListRuleTemplates(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if len(templates) == 0 {
+		return templates, nil
+	}
+
+	provenances, err := svc.provenanceStore.GetProvenances(ctx, orgID, (&models.RuleTemplate{}).ResourceType())
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range templates {
+		t.Provenance = provenances[t.ResourceID()]
+	}
+	return templates, nil
+}
+
+// GetRuleTemplate returns the rule template with the UID.
+func (svc *RuleTemplateService) GetRuleTemplate(ctx context.Context, orgID int64, uid string) (*models.RuleTemplate, error) {
+	t, err := svc.store.GetRuleTemplate(ctx, orgID, uid)
+	if err != nil {
+		return nil, err
+	}
+	t.Provenance, err = svc.provenanceStore.GetProvenance(ctx, t, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// CreateRuleTemplate validates and creates a new rule template, and creates a rule for each of its instances.
+// The UID of the template and the rule UIDs of the instances are generated if they are empty.
+func (svc *RuleTemplateService) CreateRuleTemplate(ctx context.Context, user identity.Requester, t models.RuleTemplate, provenance models.Provenance) (*models.RuleTemplate, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	t.Provenance = provenance
+
+	err := svc.xact.InTransaction(ctx, func(ctx context.Context) error {
+		if err := svc.store.InsertRuleTemplate(ctx, &t); err != nil {
+			return err
+		}
+		if err := svc.provenanceStore.SetProvenance(ctx, &t, t.OrgID, provenance); err != nil {
+			return err
+		}
+		return svc.syncRules(ctx, user, nil, &t)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// UpdateRuleTemplate validates and updates the rule template with the UID. If the version is set, it must match
+// the current version. The rules of the instances are updated, the rules of new instances are created and the rules
+// of removed instances are deleted. Instances without a rule UID are considered new.
+func (svc *RuleTemplateService) UpdateRuleTemplate(ctx context.Context, user identity.Requester, t models.RuleTemplate, provenance models.Provenance) (*models.RuleTemplate, error) {
+	if t.UID == "" {
+		return nil, models.MakeErrRuleTemplateInvalid(errors.New("uid is required"))
+	}
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := svc.GetRuleTemplate(ctx, t.OrgID, t.UID)
+	if err != nil {
+		return nil, err
+	}
+	if err := svc.validator(existing.Provenance, provenance); err != nil {
+		return nil, err
+	}
+	if t.Version == 0 {
+		t.Version = existing.Version
+	}
+	t.Provenance = provenance
+
+	err = svc.xact.InTransaction(ctx, func(ctx context.Context) error {
+		if err := svc.store.UpdateRuleTemplate(ctx, &t); err != nil {
+			return err
+		}
+		if err := svc.provenanceStore.SetProvenance(ctx, &t, t.OrgID, provenance); err != nil {
+			return err
+		}
+		return svc.syncRules(ctx, user, existing.Instances, &t)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// DeleteRuleTemplate deletes the rule template with the UID and the rules of its instances.
+func (svc *RuleTemplateService) DeleteRuleTemplate(ctx context.Context, user identity.Requester, orgID int64, uid string, provenance models.Provenance) error {
+	existing, err := svc.GetRuleTemplate(ctx, orgID, uid)
+	if err != nil {
+		if errors.Is(err, models.ErrRuleTemplateNotFound) {
+			svc.log.FromContext(ctx).Debug("Rule template was not found. Skip deleting", "uid", uid)
+			return nil
+		}
+		return err
+	}
+	if err := svc.validator(existing.Provenance, provenance); err != nil {
+		return err
+	}
+
+	return svc.xact.InTransaction(ctx, func(ctx context.Context) error {
+		for _, instance := range existing.Instances {
+			if err := svc.rules.DeleteAlertRule(ctx, user, instance.RuleUID, models.ProvenanceTemplate); err != nil {
+				return fmt.Errorf("failed to delete rule %s: %w", instance.RuleUID, err)
+			}
+		}
+		if err := svc.store.DeleteRuleTemplate(ctx, orgID, uid); err != nil {
+			return err
+		}
+		return svc.provenanceStore.DeleteProvenance(ctx, existing, orgID)
+	})
+}
+
+// syncRules creates or updates the rules of the instances of the template and deletes the rules of the previous
+// instances that were removed.
+func (svc *RuleTemplateService) syncRules(ctx context.Context, user identity.Requester, previous []models.RuleTemplateInstance, t *models.RuleTemplate) error {
+	logger := svc.log.FromContext(ctx).New("template_uid", t.UID)
+	existing := make(map[string]struct{}, len(previous))
+	for _, instance := range previous {
+		existing[instance.RuleUID] = struct{}{}
+	}
+
+	for _, instance := range t.Instances {
+		rule, err := t.Expand(instance)
+		if err != nil {
+			return models.MakeErrRuleTemplateInvalid(err)
+		}
+		if _, ok := existing[instance.RuleUID]; ok {
+			delete(existing, instance.RuleUID)
+			updated, err := svc.updateRule(ctx, user, rule)
+			if err == nil {
+				if updated {
+					logger.Debug("Updated rule of rule template", "rule_uid", rule.UID)
+				}
+				continue
+			}
+			if !errors.Is(err, models.ErrAlertRuleNotFound) {
+				return fmt.Errorf("failed to update rule %s: %w", rule.UID, err)
+			}
+			// The rule was deleted in another way, for example, with its folder. It is created again.
+		}
+		if _, err := svc.rules.CreateAlertRule(ctx, user, rule, models.ProvenanceTemplate); err != nil {
+			return fmt.Errorf("failed to create rule %s: %w", rule.UID, err)
+		}
+		logger.Debug("Created rule of rule template", "rule_uid", rule.UID)
+	}
+
+	for ruleUID := range existing {
+		if err := svc.rules.DeleteAlertRule(ctx, user, ruleUID, models.ProvenanceTemplate); err != nil {
+			return fmt.Errorf("failed to delete rule %s: %w", ruleUID, err)
+		}
+		logger.Debug("Deleted rule of removed instance of rule template", "rule_uid", ruleUID)
+	}
+	return nil
+}
+
+// updateRule updates the rule if it differs from the stored rule. It returns false if nothing was changed.
+func (svc *RuleTemplateService) updateRule(ctx context.Context, user identity.Requester, rule models.AlertRule) (bool, error) {
+	stored, _, err := svc.rules.GetAlertRule(ctx, user, rule.UID)
+	if err != nil {
+		return false, err
+	}
+	// Keep the fields that are not defined by the template.
+	rule.ID = stored.ID
+	rule.GUID = stored.GUID
+	rule.IntervalSeconds = stored.IntervalSeconds
+	rule.RuleGroupIndex = stored.RuleGroupIndex
+	rule.Metadata = stored.Metadata
+	if err := rule.SetDashboardAndPanelFromAnnotations(); err != nil {
+		return false, err
+	}
+	if len(stored.Diff(&rule, store.AlertRuleFieldsToIgnoreInDiff[:]...)) == 0 {
+		return false, nil
+	}
+	if _, err := svc.rules.UpdateAlertRule(ctx, user, rule, models.ProvenanceTemplate); err != nil {
+		return false, err
+	}
+	return true, nil
+}
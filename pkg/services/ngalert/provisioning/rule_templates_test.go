@@ -0,0 +1,286 @@
+package provisioning
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/provisioning/validation"
+	"github.com/grafana/grafana/pkg/services/user"
+)
+
+func TestRuleTemplateService(t *testing.T) {
+	ctx := context.Background()
+	usr := &user.SignedInUser{OrgID: 1}
+
+	t.Run("create creates a rule for each instance", func(t *testing.T) {
+		sut, templates, rules := createRuleTemplateServiceSut(t)
+
+		created, err := sut.CreateRuleTemplate(ctx, usr, ruleTemplateForServiceTest(), models.ProvenanceAPI)
+		require.NoError(t, err)
+		assert.Equal(t, models.ProvenanceAPI, created.Provenance)
+		assert.Contains(t, templates.templates, created.UID)
+
+		require.Len(t, rules.rules, 2)
+		assert.Equal(t, "High CPU usage on eu", rules.rules["cpu-eu"].Title)
+		assert.Equal(t, "High CPU usage on us", rules.rules["cpu-us"].Title)
+		assert.Equal(t, models.ProvenanceTemplate, rules.provenances["cpu-eu"])
+		assert.Equal(t, models.ProvenanceTemplate, rules.provenances["cpu-us"])
+	})
+
+	t.Run("create fails if the template is invalid", func(t *testing.T) {
+		sut, templates, rules := createRuleTemplateServiceSut(t)
+		tmpl := ruleTemplateForServiceTest()
+		tmpl.Instances[0].Parameters = nil
+
+		_, err := sut.CreateRuleTemplate(ctx, usr, tmpl, models.ProvenanceAPI)
+		require.ErrorIs(t, err, models.ErrRuleTemplateInvalid)
+		assert.Empty(t, templates.templates)
+		assert.Empty(t, rules.rules)
+	})
+
+	t.Run("update syncs the rules of the instances", func(t *testing.T) {
+		sut, _, rules := createRuleTemplateServiceSut(t)
+		_, err := sut.CreateRuleTemplate(ctx, usr, ruleTemplateForServiceTest(), models.ProvenanceAPI)
+		require.NoError(t, err)
+		rules.calls = nil
+
+		tmpl := ruleTemplateForServiceTest()
+		tmpl.Rule.Title = "CPU usage on ${cluster} is too high"
+		tmpl.Instances = []models.RuleTemplateInstance{
+			tmpl.Instances[0],
+			{RuleUID: "cpu-ap", FolderUID: "folder", RuleGroup: "group", Parameters: map[string]string{"cluster": "ap"}},
+		}
+		updated, err := sut.UpdateRuleTemplate(ctx, usr, tmpl, models.ProvenanceAPI)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, updated.Version)
+
+		require.Len(t, rules.rules, 2)
+		assert.Equal(t, "CPU usage on eu is too high", rules.rules["cpu-eu"].Title)
+		assert.Equal(t, "CPU usage on ap is too high", rules.rules["cpu-ap"].Title)
+		assert.ElementsMatch(t, []string{"update cpu-eu", "create cpu-ap", "delete cpu-us"}, rules.calls)
+	})
+
+	t.Run("update skips rules that did not change", func(t *testing.T) {
+		sut, _, rules := createRuleTemplateServiceSut(t)
+		_, err := sut.CreateRuleTemplate(ctx, usr, ruleTemplateForServiceTest(), models.ProvenanceAPI)
+		require.NoError(t, err)
+		rules.calls = nil
+
+		tmpl := ruleTemplateForServiceTest()
+		tmpl.Instances[1].Parameters["threshold"] = "95"
+		_, err = sut.UpdateRuleTemplate(ctx, usr, tmpl, models.ProvenanceAPI)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"update cpu-us"}, rules.calls)
+	})
+
+	t.Run("update recreates rules that were deleted", func(t *testing.T) {
+		sut, _, rules := createRuleTemplateServiceSut(t)
+		_, err := sut.CreateRuleTemplate(ctx, usr, ruleTemplateForServiceTest(), models.ProvenanceAPI)
+		require.NoError(t, err)
+		delete(rules.rules, "cpu-eu")
+
+		_, err = sut.UpdateRuleTemplate(ctx, usr, ruleTemplateForServiceTest(), models.ProvenanceAPI)
+		require.NoError(t, err)
+		assert.Contains(t, rules.rules, "cpu-eu")
+	})
+
+	t.Run("update fails if the version does not match", func(t *testing.T) {
+		sut, _, _ := createRuleTemplateServiceSut(t)
+		_, err := sut.CreateRuleTemplate(ctx, usr, ruleTemplateForServiceTest(), models.ProvenanceAPI)
+		require.NoError(t, err)
+
+		tmpl := ruleTemplateForServiceTest()
+		tmpl.Version = 5
+		_, err = sut.UpdateRuleTemplate(ctx, usr, tmpl, models.ProvenanceAPI)
+		require.ErrorIs(t, err, models.ErrRuleTemplateConflict)
+	})
+
+	t.Run("update fails if the template does not exist", func(t *testing.T) {
+		sut, _, _ := createRuleTemplateServiceSut(t)
+		_, err := sut.UpdateRuleTemplate(ctx, usr, ruleTemplateForServiceTest(), models.ProvenanceAPI)
+		require.ErrorIs(t, err, models.ErrRuleTemplateNotFound)
+	})
+
+	t.Run("delete deletes the rules of the instances", func(t *testing.T) {
+		sut, templates, rules := createRuleTemplateServiceSut(t)
+		created, err := sut.CreateRuleTemplate(ctx, usr, ruleTemplateForServiceTest(), models.ProvenanceAPI)
+		require.NoError(t, err)
+
+		require.NoError(t, sut.DeleteRuleTemplate(ctx, usr, 1, created.UID, models.ProvenanceAPI))
+		assert.Empty(t, templates.templates)
+		assert.Empty(t, rules.rules)
+
+		t.Run("does nothing if the template does not exist", func(t *testing.T) {
+			require.NoError(t, sut.DeleteRuleTemplate(ctx, usr, 1, created.UID, models.ProvenanceAPI))
+		})
+	})
+
+	t.Run("delete fails if the provenance does not match", func(t *testing.T) {
+		sut, _, rules := createRuleTemplateServiceSut(t)
+		created, err := sut.CreateRuleTemplate(ctx, usr, ruleTemplateForServiceTest(), models.ProvenanceFile)
+		require.NoError(t, err)
+
+		err = sut.DeleteRuleTemplate(ctx, usr, 1, created.UID, models.ProvenanceNone)
+		require.ErrorIs(t, err, validation.ErrProvenanceChangeNotAllowed)
+		assert.Len(t, rules.rules, 2)
+	})
+}
+
+func ruleTemplateForServiceTest() models.RuleTemplate {
+	return models.RuleTemplate{
+		UID:        "cpu",
+		OrgID:      1,
+		Title:      "CPU usage",
+		Parameters: []models.RuleTemplateParameter{{Name: "cluster"}, {Name: "threshold", Default: stringPtr("80")}},
+		Rule: models.RuleTemplateRule{
+			Title:     "High CPU usage on ${cluster}",
+			Condition: "A",
+			Data: []models.AlertQuery{{
+				RefID:         "A",
+				DatasourceUID: "__expr__",
+				Model:         json.RawMessage(`{"type":"math","expression":"1 > ${threshold}"}`),
+			}},
+			NoDataState:  models.NoData,
+			ExecErrState: models.ErrorErrState,
+			For:          time.Minute,
+		},
+		Instances: []models.RuleTemplateInstance{
+			{RuleUID: "cpu-eu", FolderUID: "folder", RuleGroup: "group", Parameters: map[string]string{"cluster": "eu"}},
+			{RuleUID: "cpu-us", FolderUID: "folder", RuleGroup: "group", Parameters: map[string]string{"cluster": "us"}},
+		},
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func createRuleTemplateServiceSut(t *testing.T) (*RuleTemplateService, *fakeRuleTemplateStore, *fakeTemplateRuleService) {
+	templates := &fakeRuleTemplateStore{templates: map[string]models.RuleTemplate{}}
+	rules := &fakeTemplateRuleService{rules: map[string]models.AlertRule{}, provenances: map[string]models.Provenance{}}
+	return &RuleTemplateService{
+		store:           templates,
+		rules:           rules,
+		provenanceStore: &fakeProvenanceStore{provenances: map[string]models.Provenance{}},
+		xact:            newNopTransactionManager(),
+		log:             log.NewNopLogger(),
+		validator:       validation.ValidateProvenanceRelaxed,
+	}, templates, rules
+}
+
+type fakeRuleTemplateStore struct {
+	templates map[string]models.RuleTemplate
+}
+
+func (f *fakeRuleTemplateStore) ListRuleTemplates(_ context.Context, orgID int64) ([]*models.RuleTemplate, error) {
+	result := make([]*models.RuleTemplate, 0, len(f.templates))
+	for _, t := range f.templates {
+		if t.OrgID == orgID {
+			result = append(result, &t)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeRuleTemplateStore) GetRuleTemplate(_ context.Context, orgID int64, uid string) (*models.RuleTemplate, error) {
+	t, ok := f.templates[uid]
+	if !ok || t.OrgID != orgID {
+		return nil, models.ErrRuleTemplateNotFound
+	}
+	return &t, nil
+}
+
+func (f *fakeRuleTemplateStore) InsertRuleTemplate(_ context.Context, t *models.RuleTemplate) error {
+	if _, ok := f.templates[t.UID]; ok {
+		return models.ErrRuleTemplateExists
+	}
+	t.Version = 1
+	f.templates[t.UID] = *t
+	return nil
+}
+
+func (f *fakeRuleTemplateStore) UpdateRuleTemplate(_ context.Context, t *models.RuleTemplate) error {
+	existing, ok := f.templates[t.UID]
+	if !ok {
+		return models.ErrRuleTemplateNotFound
+	}
+	if existing.Version != t.Version {
+		return models.MakeErrRuleTemplateConflict(t.UID, t.Version, existing.Version)
+	}
+	t.Version++
+	f.templates[t.UID] = *t
+	return nil
+}
+
+func (f *fakeRuleTemplateStore) DeleteRuleTemplate(_ context.Context, _ int64, uid string) error {
+	delete(f.templates, uid)
+	return nil
+}
+
+type fakeTemplateRuleService struct {
+	rules       map[string]models.AlertRule
+	provenances map[string]models.Provenance
+	calls       []string
+}
+
+func (f *fakeTemplateRuleService) GetAlertRule(_ context.Context, _ identity.Requester, ruleUID string) (models.AlertRule, models.Provenance, error) {
+	rule, ok := f.rules[ruleUID]
+	if !ok {
+		return models.AlertRule{}, models.ProvenanceNone, models.ErrAlertRuleNotFound
+	}
+	return rule, f.provenances[ruleUID], nil
+}
+
+func (f *fakeTemplateRuleService) CreateAlertRule(_ context.Context, _ identity.Requester, rule models.AlertRule, provenance models.Provenance) (models.AlertRule, error) {
+	f.calls = append(f.calls, "create "+rule.UID)
+	f.rules[rule.UID] = rule
+	f.provenances[rule.UID] = provenance
+	return rule, nil
+}
+
+func (f *fakeTemplateRuleService) UpdateAlertRule(_ context.Context, _ identity.Requester, rule models.AlertRule, provenance models.Provenance) (models.AlertRule, error) {
+	f.calls = append(f.calls, "update "+rule.UID)
+	if _, ok := f.rules[rule.UID]; !ok {
+		return models.AlertRule{}, models.ErrAlertRuleNotFound
+	}
+	f.rules[rule.UID] = rule
+	f.provenances[rule.UID] = provenance
+	return rule, nil
+}
+
+func (f *fakeTemplateRuleService) DeleteAlertRule(_ context.Context, _ identity.Requester, ruleUID string, _ models.Provenance) error {
+	f.calls = append(f.calls, "delete "+ruleUID)
+	delete(f.rules, ruleUID)
+	delete(f.provenances, ruleUID)
+	return nil
+}
+
+type fakeProvenanceStore struct {
+	provenances map[string]models.Provenance
+}
+
+func (f *fakeProvenanceStore) GetProvenance(_ context.Context, o models.Provisionable, _ int64) (models.Provenance, error) {
+	return f.provenances[o.ResourceID()], nil
+}
+
+func (f *fakeProvenanceStore) GetProvenances(_ context.Context, _ int64, _ string) (map[string]models.Provenance, error) {
+	return f.provenances, nil
+}
+
+func (f *fakeProvenanceStore) SetProvenance(_ context.Context, o models.Provisionable, _ int64, p models.Provenance) error {
+	f.provenances[o.ResourceID()] = p
+	return nil
+}
+
+func (f *fakeProvenanceStore) DeleteProvenance(_ context.Context, o models.Provisionable, _ int64) error {
+	delete(f.provenances, o.ResourceID())
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/prometheus/alertmanager/api/v2/models"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -45,6 +46,9 @@ type Rule interface {
 	Status() ngmodels.RuleStatus
 	// Identifier returns the identifier of the rule.
 	Identifier() ngmodels.AlertRuleKeyWithGroup
+	// EvaluationTraces returns the most recent evaluations of the rule, the most recent first.
+	// It returns nothing if tracing of evaluations is disabled.
+	EvaluationTraces() []ngmodels.EvaluationTrace
 }
 
 type ruleFactoryFunc func(context.Context, *ngmodels.AlertRule) Rule
@@ -67,6 +71,7 @@ func newRuleFactory(
 	tracer tracing.Tracer,
 	featureToggles featuremgmt.FeatureToggles,
 	recordingWriter RecordingWriter,
+	evaluationTraceSize int,
 	evalAppliedHook evalAppliedFunc,
 	stopAppliedHook stopAppliedFunc,
 ) ruleFactoryFunc {
@@ -83,6 +88,7 @@ func newRuleFactory(
 				met,
 				tracer,
 				recordingWriter,
+				evaluationTraceSize,
 				evalAppliedHook,
 				stopAppliedHook,
 			)
@@ -101,6 +107,7 @@ func newRuleFactory(
 			logger,
 			tracer,
 			featureToggles,
+			evaluationTraceSize,
 			evalAppliedHook,
 			stopAppliedHook,
 		)
@@ -135,6 +142,9 @@ type alertRule struct {
 	logger         log.Logger
 	tracer         tracing.Tracer
 	featureToggles featuremgmt.FeatureToggles
+
+	// traces keeps the most recent evaluations of the rule. It is nil if tracing is disabled.
+	traces *evaluationTraces
 }
 
 func newAlertRule(
@@ -151,6 +161,7 @@ func newAlertRule(
 	logger log.Logger,
 	tracer tracing.Tracer,
 	featureToggles featuremgmt.FeatureToggles,
+	evaluationTraceSize int,
 	evalAppliedHook func(ngmodels.AlertRuleKey, time.Time),
 	stopAppliedHook func(ngmodels.AlertRuleKey),
 ) *alertRule {
@@ -174,6 +185,7 @@ func newAlertRule(
 		logger:               logger.FromContext(ctx),
 		tracer:               tracer,
 		featureToggles:       featureToggles,
+		traces:               newEvaluationTraces(evaluationTraceSize),
 	}
 }
 
@@ -189,6 +201,10 @@ func (a *alertRule) Status() ngmodels.RuleStatus {
 	return a.stateManager.GetStatusForRuleUID(a.key.OrgID, a.key.UID)
 }
 
+func (a *alertRule) EvaluationTraces() []ngmodels.EvaluationTrace {
+	return a.traces.list()
+}
+
 // eval signals the rule evaluation routine to perform the evaluation of the rule. Does nothing if the loop is stopped.
 // Before sending a message into the channel, it does non-blocking read to make sure that there is no concurrent send operation.
 // Returns a tuple where first element is
@@ -328,7 +344,7 @@ func (a *alertRule) Run() error {
 						return
 					}
 					retry := attempt < a.maxAttempts
-					err := a.evaluate(tracingCtx, ctx, span, retry, logger)
+					err := a.evaluate(tracingCtx, ctx, span, attempt, retry, logger)
 					// This is extremely confusing - when we exhaust all retry attempts, or we have no retryable errors
 					// we return nil - so technically, this is meaningless to know whether the evaluation has errors or not.
 					span.End()
@@ -365,8 +381,9 @@ func (a *alertRule) Run() error {
 				// the evaluation loop is that the rule was deleted.
 				stateTransitions := a.stateManager.DeleteStateByRuleUID(ngmodels.WithRuleKey(ctx, a.key.AlertRuleKey), a.key, ngmodels.StateReasonRuleDeleted)
 				a.expireAndSend(grafanaCtx, stateTransitions)
-			} else {
-				// Otherwise, just clean up the cache.
+			} else if !errors.Is(reason, errRuleReassigned) {
+				// Otherwise, just clean up the cache. The state of rules that are evaluated by another replica is kept,
+				// and the scheduler refreshes it from the database.
 				a.stateManager.ForgetStateByRuleUID(ngmodels.WithRuleKey(ctx, a.key.AlertRuleKey), a.key)
 			}
 
@@ -376,7 +393,7 @@ func (a *alertRule) Run() error {
 	}
 }
 
-func (a *alertRule) evaluate(ctx context.Context, e *Evaluation, span trace.Span, retry bool, logger log.Logger) error {
+func (a *alertRule) evaluate(ctx context.Context, e *Evaluation, span trace.Span, attempt int64, retry bool, logger log.Logger) error {
 	orgID := fmt.Sprint(a.key.OrgID)
 	evalAttemptTotal := a.metrics.EvalAttemptTotal.WithLabelValues(orgID)
 	evalAttemptFailures := a.metrics.EvalAttemptFailures.WithLabelValues(orgID)
@@ -386,20 +403,28 @@ func (a *alertRule) evaluate(ctx context.Context, e *Evaluation, span trace.Span
 
 	start := a.clock.Now()
 
+	ctx, recorder := a.traces.withRecorder(ctx)
 	evalCtx := eval.NewContextWithPreviousResults(ctx, SchedulerUserFor(e.rule.OrgID), a.newLoadedMetricsReader(e.rule))
-	ruleEval, err := a.evalFactory.Create(evalCtx, e.rule.GetEvalCondition().WithSource("scheduler").WithFolder(e.folderTitle))
+	condition := e.rule.GetEvalCondition().WithSource("scheduler").WithFolder(e.folderTitle)
+	ruleEval, err := a.evalFactory.Create(evalCtx, condition)
+	var resp *backend.QueryDataResponse
 	var results eval.Results
 	var dur time.Duration
 	if err != nil {
 		dur = a.clock.Now().Sub(start)
 		logger.Error("Failed to build rule evaluator", "error", err)
 	} else {
-		results, err = ruleEval.Evaluate(ctx, e.scheduledAt)
+		resp, err = ruleEval.EvaluateRaw(ctx, e.scheduledAt)
 		dur = a.clock.Now().Sub(start)
 		if err != nil {
 			logger.Error("Failed to evaluate rule", "error", err, "duration", dur)
+		} else {
+			results = eval.EvaluateAlert(resp, condition, e.scheduledAt)
 		}
 	}
+	if recorder != nil {
+		a.traces.add(recorder.trace(e, attempt, dur, resp, results, err))
+	}
 
 	evalAttemptTotal.Inc()
 
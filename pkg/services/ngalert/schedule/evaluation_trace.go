@@ -0,0 +1,187 @@
+package schedule
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"golang.org/x/exp/maps"
+
+	"github.com/grafana/grafana/pkg/expr"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+const (
+	// maxEvaluationTraceFrames is the maximum number of frames that are kept for each query and expression of a trace.
+	maxEvaluationTraceFrames = 100
+	// maxEvaluationTraceRows is the maximum number of rows that are kept for each frame of a trace.
+	maxEvaluationTraceRows = 1000
+)
+
+// evaluationTraces keeps the most recent evaluation traces of a rule in a ring buffer.
+// A nil *evaluationTraces keeps nothing, which is used when tracing is disabled.
+type evaluationTraces struct {
+	mtx    sync.Mutex
+	traces []ngmodels.EvaluationTrace
+	next   int
+}
+
+func newEvaluationTraces(size int) *evaluationTraces {
+	if size <= 0 {
+		return nil
+	}
+	return &evaluationTraces{traces: make([]ngmodels.EvaluationTrace, 0, size)}
+}
+
+// add stores the trace and drops the oldest one if the buffer is full.
+func (t *evaluationTraces) add(trace ngmodels.EvaluationTrace) {
+	if t == nil {
+		return
+	}
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if len(t.traces) < cap(t.traces) {
+		t.traces = append(t.traces, trace)
+		return
+	}
+	t.traces[t.next] = trace
+	t.next = (t.next + 1) % len(t.traces)
+}
+
+// list returns the stored traces, the most recent first.
+func (t *evaluationTraces) list() []ngmodels.EvaluationTrace {
+	if t == nil {
+		return nil
+	}
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	result := make([]ngmodels.EvaluationTrace, 0, len(t.traces))
+	for i := len(t.traces) - 1; i >= 0; i-- {
+		result = append(result, t.traces[(t.next+i)%len(t.traces)])
+	}
+	return result
+}
+
+// evaluationTraceRecorder collects the raw data source responses and durations of the nodes while the queries and
+// expressions of a rule are executed.
+type evaluationTraceRecorder struct {
+	mtx              sync.Mutex
+	order            []string
+	durations        map[string]time.Duration
+	datasourceFrames map[string]data.Frames
+}
+
+// withRecorder returns a context that records the execution of the pipeline if tracing is enabled.
+// The returned recorder is nil otherwise.
+func (t *evaluationTraces) withRecorder(ctx context.Context) (context.Context, *evaluationTraceRecorder) {
+	if t == nil {
+		return ctx, nil
+	}
+	r := &evaluationTraceRecorder{
+		durations:        map[string]time.Duration{},
+		datasourceFrames: map[string]data.Frames{},
+	}
+	return expr.WithExecutionRecorder(ctx, r), r
+}
+
+func (r *evaluationTraceRecorder) RecordDatasourceResponse(refID string, frames data.Frames) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.datasourceFrames[refID] = frames
+}
+
+func (r *evaluationTraceRecorder) RecordNodeDuration(refID string, duration time.Duration) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if _, ok := r.durations[refID]; !ok {
+		r.order = append(r.order, refID)
+	}
+	r.durations[refID] = duration
+}
+
+// trace builds the trace of an evaluation from what was recorded and the response of the pipeline.
+func (r *evaluationTraceRecorder) trace(ev *Evaluation, attempt int64, duration time.Duration, resp *backend.QueryDataResponse, results eval.Results, err error) ngmodels.EvaluationTrace {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	trace := ngmodels.EvaluationTrace{
+		EvaluatedAt: ev.scheduledAt,
+		Attempt:     attempt,
+		Duration:    duration,
+		Error:       err,
+	}
+
+	// Nodes that were not executed because a node they depend on failed are added after the executed ones.
+	refIDs := slices.Clone(r.order)
+	if resp != nil {
+		remaining := maps.Keys(resp.Responses)
+		slices.Sort(remaining)
+		for _, refID := range remaining {
+			if !slices.Contains(refIDs, refID) {
+				refIDs = append(refIDs, refID)
+			}
+		}
+	}
+	for _, refID := range refIDs {
+		node := ngmodels.EvaluationTraceNode{
+			RefID:    refID,
+			Duration: r.durations[refID],
+		}
+		var truncatedDatasourceFrames, truncatedFrames bool
+		node.DatasourceFrames, truncatedDatasourceFrames = truncateTraceFrames(r.datasourceFrames[refID])
+		if resp != nil {
+			if res, ok := resp.Responses[refID]; ok {
+				node.Frames, truncatedFrames = truncateTraceFrames(res.Frames)
+				node.Error = res.Error
+			}
+		}
+		node.Truncated = truncatedDatasourceFrames || truncatedFrames
+		trace.Nodes = append(trace.Nodes, node)
+	}
+
+	if len(results) > 0 {
+		trace.States = make(map[string]int)
+		for _, result := range results {
+			trace.States[result.State.String()]++
+		}
+	}
+	return trace
+}
+
+// truncateTraceFrames limits the frames to maxEvaluationTraceFrames frames of at most maxEvaluationTraceRows rows,
+// so that a single evaluation of a rule that returns a lot of data does not use too much memory. It returns true if
+// any frame or row was dropped. Truncated frames are copies, the frames of the evaluation are not modified.
+func truncateTraceFrames(frames data.Frames) (data.Frames, bool) {
+	truncated := false
+	if len(frames) > maxEvaluationTraceFrames {
+		frames = frames[:maxEvaluationTraceFrames]
+		truncated = true
+	}
+	var result data.Frames
+	for i, frame := range frames {
+		if frame == nil || frame.Rows() <= maxEvaluationTraceRows {
+			continue
+		}
+		if result == nil {
+			result = slices.Clone(frames)
+		}
+		truncatedFrame := frame.EmptyCopy()
+		truncatedFrame.Meta = frame.Meta
+		for j, field := range frame.Fields {
+			truncatedFrame.Fields[j].Config = field.Config
+		}
+		for row := 0; row < maxEvaluationTraceRows; row++ {
+			truncatedFrame.AppendRow(frame.RowCopy(row)...)
+		}
+		result[i] = truncatedFrame
+		truncated = true
+	}
+	if result == nil {
+		result = frames
+	}
+	return result, truncated
+}
@@ -0,0 +1,116 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+func TestEvaluationTraces(t *testing.T) {
+	t.Run("disabled if size is not positive", func(t *testing.T) {
+		traces := newEvaluationTraces(0)
+		require.Nil(t, traces)
+
+		ctx := context.Background()
+		recordCtx, recorder := traces.withRecorder(ctx)
+		assert.Nil(t, recorder)
+		assert.Equal(t, ctx, recordCtx)
+
+		traces.add(ngmodels.EvaluationTrace{Attempt: 1})
+		assert.Empty(t, traces.list())
+	})
+
+	t.Run("keeps the most recent traces", func(t *testing.T) {
+		traces := newEvaluationTraces(3)
+		assert.Empty(t, traces.list())
+
+		for i := int64(1); i <= 5; i++ {
+			traces.add(ngmodels.EvaluationTrace{Attempt: i})
+		}
+
+		result := traces.list()
+		require.Len(t, result, 3)
+		assert.EqualValues(t, 5, result[0].Attempt)
+		assert.EqualValues(t, 4, result[1].Attempt)
+		assert.EqualValues(t, 3, result[2].Attempt)
+	})
+}
+
+func TestEvaluationTraceRecorder(t *testing.T) {
+	traces := newEvaluationTraces(1)
+	_, recorder := traces.withRecorder(context.Background())
+	require.NotNil(t, recorder)
+
+	rawA := data.Frames{data.NewFrame("raw")}
+	recorder.RecordDatasourceResponse("A", rawA)
+	recorder.RecordNodeDuration("A", time.Second)
+	recorder.RecordNodeDuration("B", 2*time.Second)
+
+	frameA := data.Frames{data.NewFrame("A")}
+	frameB := data.Frames{data.NewFrame("B")}
+	resp := &backend.QueryDataResponse{Responses: backend.Responses{
+		"A": {Frames: frameA},
+		"B": {Frames: frameB},
+		"D": {Error: errors.New("dependency failed")},
+		"C": {Error: errors.New("dependency failed")},
+	}}
+	results := eval.Results{{State: eval.Alerting}, {State: eval.Alerting}, {State: eval.Normal}}
+	ev := &Evaluation{scheduledAt: time.Unix(100, 0)}
+
+	trace := recorder.trace(ev, 2, 3*time.Second, resp, results, nil)
+
+	assert.Equal(t, time.Unix(100, 0), trace.EvaluatedAt)
+	assert.EqualValues(t, 2, trace.Attempt)
+	assert.Equal(t, 3*time.Second, trace.Duration)
+	assert.NoError(t, trace.Error)
+	assert.Equal(t, map[string]int{"Alerting": 2, "Normal": 1}, trace.States)
+	require.Len(t, trace.Nodes, 4)
+	assert.Equal(t, ngmodels.EvaluationTraceNode{RefID: "A", Duration: time.Second, DatasourceFrames: rawA, Frames: frameA}, trace.Nodes[0])
+	assert.Equal(t, ngmodels.EvaluationTraceNode{RefID: "B", Duration: 2 * time.Second, Frames: frameB}, trace.Nodes[1])
+	assert.Equal(t, "C", trace.Nodes[2].RefID)
+	assert.ErrorContains(t, trace.Nodes[2].Error, "dependency failed")
+	assert.Equal(t, "D", trace.Nodes[3].RefID)
+
+	t.Run("failed evaluation", func(t *testing.T) {
+		trace := recorder.trace(ev, 1, time.Second, nil, nil, errors.New("failed"))
+		assert.ErrorContains(t, trace.Error, "failed")
+		assert.Empty(t, trace.States)
+		assert.Len(t, trace.Nodes, 2)
+	})
+}
+
+func TestTruncateTraceFrames(t *testing.T) {
+	small := data.NewFrame("small", data.NewField("value", nil, []float64{1, 2}))
+	frames, truncated := truncateTraceFrames(data.Frames{small})
+	assert.False(t, truncated)
+	assert.Same(t, small, frames[0])
+
+	values := make([]float64, maxEvaluationTraceRows+1)
+	large := data.NewFrame("large", data.NewField("value", data.Labels{"a": "b"}, values))
+	large.SetMeta(&data.FrameMeta{Type: data.FrameTypeNumericLong})
+	frames, truncated = truncateTraceFrames(data.Frames{small, large})
+	assert.True(t, truncated)
+	require.Len(t, frames, 2)
+	assert.Same(t, small, frames[0])
+	assert.Equal(t, maxEvaluationTraceRows, frames[1].Rows())
+	assert.Equal(t, data.Labels{"a": "b"}, frames[1].Fields[0].Labels)
+	assert.Equal(t, data.FrameTypeNumericLong, frames[1].Meta.Type)
+	assert.Equal(t, maxEvaluationTraceRows+1, large.Rows(), "the original frame should not be modified")
+
+	many := make(data.Frames, maxEvaluationTraceFrames+1)
+	for i := range many {
+		many[i] = small
+	}
+	frames, truncated = truncateTraceFrames(many)
+	assert.True(t, truncated)
+	assert.Len(t, frames, maxEvaluationTraceFrames)
+}
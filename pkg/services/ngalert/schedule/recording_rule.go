@@ -54,9 +54,12 @@ type recordingRule struct {
 	logger  log.Logger
 	metrics *metrics.Scheduler
 	tracer  tracing.Tracer
+
+	// traces keeps the most recent evaluations of the rule. It is nil if tracing is disabled.
+	traces *evaluationTraces
 }
 
-func newRecordingRule(parent context.Context, key ngmodels.AlertRuleKeyWithGroup, maxAttempts int64, clock clock.Clock, evalFactory eval.EvaluatorFactory, cfg setting.RecordingRuleSettings, logger log.Logger, metrics *metrics.Scheduler, tracer tracing.Tracer, writer RecordingWriter, evalAppliedHook evalAppliedFunc, stopAppliedHook stopAppliedFunc) *recordingRule {
+func newRecordingRule(parent context.Context, key ngmodels.AlertRuleKeyWithGroup, maxAttempts int64, clock clock.Clock, evalFactory eval.EvaluatorFactory, cfg setting.RecordingRuleSettings, logger log.Logger, metrics *metrics.Scheduler, tracer tracing.Tracer, writer RecordingWriter, evaluationTraceSize int, evalAppliedHook evalAppliedFunc, stopAppliedHook stopAppliedFunc) *recordingRule {
 	ctx, stop := util.WithCancelCause(ngmodels.WithRuleKey(parent, key.AlertRuleKey))
 	return &recordingRule{
 		key:                 key,
@@ -77,6 +80,7 @@ func newRecordingRule(parent context.Context, key ngmodels.AlertRuleKeyWithGroup
 		metrics:             metrics,
 		tracer:              tracer,
 		writer:              writer,
+		traces:              newEvaluationTraces(evaluationTraceSize),
 	}
 }
 
@@ -97,6 +101,10 @@ func (r *recordingRule) Status() ngmodels.RuleStatus {
 	}
 }
 
+func (r *recordingRule) EvaluationTraces() []ngmodels.EvaluationTrace {
+	return r.traces.list()
+}
+
 func (r *recordingRule) Eval(eval *Evaluation) (bool, *Evaluation) {
 	// read the channel in unblocking manner to make sure that there is no concurrent send operation.
 	var droppedMsg *Evaluation
@@ -200,7 +208,7 @@ func (r *recordingRule) doEvaluate(ctx context.Context, ev *Evaluation) {
 		}
 
 		evalAttemptTotal.Inc()
-		err := r.tryEvaluation(ctx, ev, logger)
+		err := r.tryEvaluation(ctx, ev, attempt, logger)
 		latestError = err
 		if err == nil {
 			break
@@ -241,11 +249,15 @@ func (r *recordingRule) doEvaluate(ctx context.Context, ev *Evaluation) {
 	r.health.Store("ok")
 }
 
-func (r *recordingRule) tryEvaluation(ctx context.Context, ev *Evaluation, logger log.Logger) error {
+func (r *recordingRule) tryEvaluation(ctx context.Context, ev *Evaluation, attempt int64, logger log.Logger) error {
 	evalStart := r.clock.Now()
-	evalCtx := eval.NewContext(ctx, SchedulerUserFor(ev.rule.OrgID))
-	result, err := r.buildAndExecutePipeline(ctx, evalCtx, ev, logger)
+	pipelineCtx, recorder := r.traces.withRecorder(ctx)
+	evalCtx := eval.NewContext(pipelineCtx, SchedulerUserFor(ev.rule.OrgID))
+	result, err := r.buildAndExecutePipeline(pipelineCtx, evalCtx, ev, logger)
 	evalDur := r.clock.Now().Sub(evalStart)
+	if recorder != nil {
+		r.traces.add(recorder.trace(ev, attempt, evalDur, result, nil, err))
+	}
 	if err != nil {
 		return fmt.Errorf("server side expressions pipeline returned an error: %w", err)
 	}
@@ -18,6 +18,8 @@ import (
 var (
 	errRuleDeleted   = errors.New("rule deleted")
 	errRuleRestarted = errors.New("rule restarted")
+	// errRuleReassigned is the reason to stop a rule that is now evaluated by another replica.
+	errRuleReassigned = errors.New("rule reassigned to another replica")
 )
 
 type ruleFactory interface {
@@ -308,6 +310,11 @@ func (r ruleWithFolder) Fingerprint() fingerprint {
 		writeBytes(tmp)
 	}
 
+	for _, dependency := range rule.Dependencies {
+		binary.LittleEndian.PutUint64(tmp, uint64(dependency.Fingerprint()))
+		writeBytes(tmp)
+	}
+
 	// fields that do not affect the state.
 	// TODO consider removing fields below from the fingerprint
 	writeInt(int64(rule.For))
@@ -215,6 +215,9 @@ func TestRuleWithFolderFingerprint(t *testing.T) {
 				},
 			},
 			MissingSeriesEvalsToResolve: util.Pointer[int64](2),
+			Dependencies: []models.AlertRuleDependency{
+				{RuleUID: "dependency-uid", Matchers: []string{`cluster="prod"`}},
+			},
 		}
 		r2 := &models.AlertRule{
 			ID:        2,
@@ -261,6 +264,9 @@ func TestRuleWithFolderFingerprint(t *testing.T) {
 				},
 			},
 			MissingSeriesEvalsToResolve: util.Pointer[int64](1),
+			Dependencies: []models.AlertRuleDependency{
+				{RuleUID: "dependency-uid-2", Equal: []string{"cluster"}},
+			},
 		}
 
 		excludedFields := map[string]struct{}{
@@ -110,6 +110,16 @@ type schedule struct {
 	tracer          tracing.Tracer
 	featureToggles  featuremgmt.FeatureToggles
 	recordingWriter RecordingWriter
+
+	// evaluationTraceSize is the number of evaluations that are kept in memory for each rule.
+	evaluationTraceSize int
+
+	// sharding decides which rules are evaluated by this replica. If nil, all rules are evaluated.
+	sharding *ruleSharding
+	// remoteRules are the rules that were evaluated by other replicas in the last tick.
+	remoteRules map[ngmodels.AlertRuleKey]*ngmodels.AlertRule
+	// remoteStateLoadedAt is the time the state of the remote rules was last loaded from the database.
+	remoteStateLoadedAt time.Time
 }
 
 // SchedulerCfg is the scheduler configuration.
@@ -131,6 +141,10 @@ type SchedulerCfg struct {
 	RecordingWriter        RecordingWriter
 	RuleStopReasonProvider AlertRuleStopReasonProvider
 	FeatureToggles         featuremgmt.FeatureToggles
+	// EvaluationTraceSize is the number of evaluations that are kept in memory for each rule. Zero disables it.
+	EvaluationTraceSize int
+	// ClusterMembership is optional. If set, the rules are split between the replicas of the cluster.
+	ClusterMembership ClusterMembership
 }
 
 // NewScheduler returns a new scheduler.
@@ -162,6 +176,10 @@ func NewScheduler(cfg SchedulerCfg, stateManager *state.Manager) *schedule {
 		recordingWriter:        cfg.RecordingWriter,
 		ruleStopReasonProvider: cfg.RuleStopReasonProvider,
 		featureToggles:         cfg.FeatureToggles,
+		evaluationTraceSize:    cfg.EvaluationTraceSize,
+	}
+	if cfg.ClusterMembership != nil {
+		sch.sharding = newRuleSharding(cfg.ClusterMembership)
 	}
 
 	return &sch
@@ -186,13 +204,37 @@ func (sch *schedule) Rules() ([]*ngmodels.AlertRule, map[ngmodels.FolderKey]stri
 }
 
 // Status fetches the health of a given scheduled rule, by key.
+// The status of rules that are evaluated by other replicas is taken from the state loaded from the database.
 func (sch *schedule) Status(key ngmodels.AlertRuleKey) (ngmodels.RuleStatus, bool) {
 	if rule, ok := sch.registry.get(key); ok {
 		return rule.Status(), true
 	}
+	if sch.sharding != nil {
+		if rule := sch.schedulableAlertRules.get(key); rule != nil && !sch.sharding.owns(rule) {
+			return sch.stateManager.GetStatusForRuleUID(key.OrgID, key.UID), true
+		}
+	}
 	return ngmodels.RuleStatus{}, false
 }
 
+// EvaluationTraces returns the most recent evaluations of the rule, the most recent first.
+// It returns false if the rule is not scheduled.
+func (sch *schedule) EvaluationTraces(key ngmodels.AlertRuleKey) ([]ngmodels.EvaluationTrace, bool) {
+	if rule, ok := sch.registry.get(key); ok {
+		return rule.EvaluationTraces(), true
+	}
+	return nil, false
+}
+
+// ShardingStatus returns the replicas that evaluate the rule groups of the organization.
+func (sch *schedule) ShardingStatus(orgID int64) ShardingStatus {
+	if sch.sharding == nil {
+		return ShardingStatus{}
+	}
+	alertRules, _ := sch.schedulableAlertRules.all()
+	return sch.sharding.status(alertRules, orgID)
+}
+
 // deleteAlertRule stops evaluation of the rule, deletes it from active rules, and cleans up state cache.
 func (sch *schedule) deleteAlertRule(ctx context.Context, keys ...ngmodels.AlertRuleKey) {
 	for _, key := range keys {
@@ -292,9 +334,21 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 
 	sch.updateRulesMetrics(alertRules)
 
+	// The state of all rules is loaded on startup. After that, the state has to be loaded
+	// for the rules that were evaluated by other replicas.
+	loadState := false
+	if sch.sharding != nil {
+		loadState = sch.sharding.initialized()
+		if rebalanced, members := sch.sharding.refresh(); rebalanced {
+			sch.log.Info("Cluster members changed, rebalancing rule groups", "members", members)
+		}
+	}
+
 	readyToRun := make([]readyToRunItem, 0)
 	updatedRules := make([]ngmodels.AlertRuleKeyWithVersion, 0, len(updated)) // this is needed for tests only
 	restartedRules := make([]Rule, 0)
+	reassignedRules := make([]Rule, 0)
+	remoteRules := make(map[ngmodels.AlertRuleKey]*ngmodels.AlertRule)
 	missingFolder := make(map[string][]string)
 	ruleFactory := newRuleFactory(
 		sch.appURL,
@@ -310,14 +364,32 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 		sch.tracer,
 		sch.featureToggles,
 		sch.recordingWriter,
+		sch.evaluationTraceSize,
 		sch.evalAppliedFunc,
 		sch.stopAppliedFunc,
 	)
 	for _, item := range alertRules {
-		ruleRoutine, newRoutine := sch.registry.getOrCreate(ctx, item, ruleFactory)
 		key := item.GetKey()
 		logger := sch.log.FromContext(ctx).New(key.LogContext()...)
 
+		if sch.sharding != nil && !sch.sharding.owns(item) {
+			// The rule is evaluated by another replica.
+			if ruleRoutine, ok := sch.registry.del(key); ok {
+				logger.Debug("Rule reassigned to another replica")
+				reassignedRules = append(reassignedRules, ruleRoutine)
+			}
+			remoteRules[key] = item
+			delete(registeredDefinitions, key)
+			continue
+		}
+
+		ruleRoutine, newRoutine := sch.registry.getOrCreate(ctx, item, ruleFactory)
+		if newRoutine && loadState {
+			if err := sch.stateManager.LoadStateByRule(ctx, item); err != nil {
+				logger.Error("Failed to load the state of the rule", "error", err)
+			}
+		}
+
 		// enforce minimum evaluation interval
 		if item.IntervalSeconds < int64(sch.minRuleInterval.Seconds()) {
 			logger.Debug("Interval adjusted", "originalInterval", item.IntervalSeconds, "adjustedInterval", sch.minRuleInterval.Seconds())
@@ -406,6 +478,16 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 		oldRoutine.Stop(errRuleRestarted)
 	}
 
+	// Stop routines for rules that are now evaluated by other replicas. Unlike deleted rules,
+	// their state is kept in the cache and in the database, so the new owner can continue from it.
+	for _, oldRoutine := range reassignedRules {
+		oldRoutine.Stop(errRuleReassigned)
+	}
+
+	if sch.sharding != nil {
+		sch.loadRemoteState(ctx, tick, remoteRules)
+	}
+
 	// unregister and stop routines of the deleted alert rules
 	toDelete := make([]ngmodels.AlertRuleKey, 0, len(registeredDefinitions))
 	for key := range registeredDefinitions {
@@ -416,6 +498,35 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 	return readyToRun, registeredDefinitions, updatedRules
 }
 
+// loadRemoteState keeps the state of the rules that are evaluated by other replicas in the cache, so that the APIs
+// of this replica return the alerts and the status of all rules. The state is loaded from the database every
+// remoteStateLoadInterval, so it can lag behind the state on the replica that evaluates the rule.
+func (sch *schedule) loadRemoteState(ctx context.Context, tick time.Time, remoteRules map[ngmodels.AlertRuleKey]*ngmodels.AlertRule) {
+	for key, rule := range sch.remoteRules {
+		if _, ok := remoteRules[key]; ok || sch.registry.exists(key) {
+			continue
+		}
+		// The rule was deleted by another replica.
+		sch.stateManager.ForgetStateByRuleUID(ctx, rule.GetKeyWithGroup())
+	}
+	sch.remoteRules = remoteRules
+
+	if len(remoteRules) == 0 || (!sch.remoteStateLoadedAt.IsZero() && tick.Sub(sch.remoteStateLoadedAt) < remoteStateLoadInterval) {
+		return
+	}
+	sch.remoteStateLoadedAt = tick
+
+	rulesByOrg := make(map[int64][]*ngmodels.AlertRule)
+	for _, rule := range remoteRules {
+		rulesByOrg[rule.OrgID] = append(rulesByOrg[rule.OrgID], rule)
+	}
+	for orgID, rules := range rulesByOrg {
+		if err := sch.stateManager.LoadStateByRules(ctx, orgID, rules); err != nil {
+			sch.log.Error("Failed to load the state of the rules evaluated by other replicas", "org_id", orgID, "error", err)
+		}
+	}
+}
+
 // runJobFn sends the scheduled evaluation to the evaluation routine, optionally with a previous item to log the trigger source.
 func (sch *schedule) runJobFn(next readyToRunItem, prev ...readyToRunItem) func() {
 	return func() {
@@ -49,6 +49,10 @@ func (r *fakeSequenceRule) Status() models.RuleStatus {
 	return models.RuleStatus{}
 }
 
+func (r *fakeSequenceRule) EvaluationTraces() []models.EvaluationTrace {
+	return nil
+}
+
 func TestSequence(t *testing.T) {
 	ruleStore := newFakeRulesStore()
 	reg := prometheus.NewPedanticRegistry()
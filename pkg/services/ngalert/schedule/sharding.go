@@ -0,0 +1,191 @@
+package schedule
+
+import (
+	"hash/fnv"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// ClusterMembership provides the replicas of the HA cluster that share the evaluation of alert rules.
+type ClusterMembership interface {
+	// ClusterMembers returns the name of this replica and the names of all alive replicas in the cluster,
+	// including this one. The name is empty if the replica is not part of a cluster.
+	ClusterMembers() (string, []string)
+}
+
+// ringVirtualNodes is the number of points every replica gets on the ring.
+// More points make the distribution of rule groups between replicas more even.
+const ringVirtualNodes = 128
+
+// remoteStateLoadInterval is how often the state of the rules evaluated by other replicas is loaded from the database.
+const remoteStateLoadInterval = time.Minute
+
+type ringPoint struct {
+	hash   uint64
+	member string
+}
+
+// ruleRing assigns rule groups to cluster members with consistent hashing, so only
+// about 1/N of the rule groups move when a member joins or leaves a cluster of N members.
+// Rules of a group are always assigned to the same member because they can depend on each
+// other and are evaluated sequentially.
+type ruleRing struct {
+	members []string
+	points  []ringPoint
+}
+
+func newRuleRing(members []string) *ruleRing {
+	r := &ruleRing{
+		members: members,
+		points:  make([]ringPoint, 0, len(members)*ringVirtualNodes),
+	}
+	for _, m := range members {
+		for i := 0; i < ringVirtualNodes; i++ {
+			r.points = append(r.points, ringPoint{hash: ringHash(m + "-" + strconv.Itoa(i)), member: m})
+		}
+	}
+	sort.Slice(r.points, func(i, j int) bool {
+		if r.points[i].hash == r.points[j].hash {
+			return r.points[i].member < r.points[j].member
+		}
+		return r.points[i].hash < r.points[j].hash
+	})
+	return r
+}
+
+// owner returns the member the rule group is assigned to.
+func (r *ruleRing) owner(key ngmodels.AlertRuleGroupKey) string {
+	if len(r.points) == 0 {
+		return ""
+	}
+	h := ringHash(strconv.FormatInt(key.OrgID, 10) + "/" + key.NamespaceUID + "/" + key.RuleGroup)
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i].hash >= h })
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.points[i].member
+}
+
+func ringHash(s string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+	// FNV does not spread similar strings well, mix the bits with the finalizer of MurmurHash3.
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+// ruleSharding keeps track of the cluster members and decides which rules are evaluated by this replica.
+type ruleSharding struct {
+	membership ClusterMembership
+
+	mu   sync.RWMutex
+	self string
+	ring *ruleRing
+}
+
+func newRuleSharding(membership ClusterMembership) *ruleSharding {
+	return &ruleSharding{membership: membership}
+}
+
+// initialized returns true if the ring has been built.
+func (s *ruleSharding) initialized() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.ring != nil
+}
+
+// refresh rebuilds the ring if the cluster members have changed since the last call.
+// It returns true and the new members if the ring was rebuilt after it had already been built once.
+func (s *ruleSharding) refresh() (bool, []string) {
+	self, members := s.membership.ClusterMembers()
+	if self == "" {
+		// Not part of a cluster, this replica evaluates all rules.
+		members = nil
+	} else if !slices.Contains(members, self) {
+		// The replica can be missing from the list while the cluster is settling.
+		members = append(slices.Clone(members), self)
+	}
+	slices.Sort(members)
+	members = slices.Compact(members)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ring != nil && s.self == self && slices.Equal(s.ring.members, members) {
+		return false, nil
+	}
+	rebalanced := s.ring != nil
+	s.self = self
+	s.ring = newRuleRing(members)
+	return rebalanced, members
+}
+
+// owns returns true if the rule is evaluated by this replica.
+func (s *ruleSharding) owns(rule *ngmodels.AlertRule) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.ring == nil || len(s.ring.members) == 0 {
+		return true
+	}
+	return s.ring.owner(rule.GetGroupKey()) == s.self
+}
+
+// ShardingStatus describes the assignment of rule groups to the replicas of the HA cluster.
+type ShardingStatus struct {
+	Enabled bool
+	// Self is the name of this replica.
+	Self string
+	// Members are the names of all replicas the rules are split between.
+	Members []string
+	Groups  []RuleGroupShard
+}
+
+// RuleGroupShard is the assignment of a rule group to a replica.
+type RuleGroupShard struct {
+	ngmodels.AlertRuleGroupKey
+	Owner string
+	Rules int
+}
+
+func (s *ruleSharding) status(rules []*ngmodels.AlertRule, orgID int64) ShardingStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	result := ShardingStatus{Enabled: true, Self: s.self}
+	if s.ring == nil {
+		return result
+	}
+	result.Members = slices.Clone(s.ring.members)
+
+	groups := make(map[ngmodels.AlertRuleGroupKey]int)
+	for _, rule := range rules {
+		if rule.OrgID != orgID {
+			continue
+		}
+		groups[rule.GetGroupKey()]++
+	}
+	result.Groups = make([]RuleGroupShard, 0, len(groups))
+	for key, count := range groups {
+		owner := s.ring.owner(key)
+		if owner == "" {
+			owner = s.self
+		}
+		result.Groups = append(result.Groups, RuleGroupShard{AlertRuleGroupKey: key, Owner: owner, Rules: count})
+	}
+	sort.Slice(result.Groups, func(i, j int) bool {
+		a, b := result.Groups[i], result.Groups[j]
+		if a.NamespaceUID != b.NamespaceUID {
+			return a.NamespaceUID < b.NamespaceUID
+		}
+		return a.RuleGroup < b.RuleGroup
+	})
+	return result
+}
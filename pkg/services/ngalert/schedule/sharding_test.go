@@ -0,0 +1,186 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+)
+
+type fakeClusterMembership struct {
+	mu      sync.Mutex
+	self    string
+	members []string
+}
+
+func (f *fakeClusterMembership) ClusterMembers() (string, []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.self, f.members
+}
+
+func (f *fakeClusterMembership) setMembers(members ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.members = members
+}
+
+func testGroupKeys(n int) []models.AlertRuleGroupKey {
+	keys := make([]models.AlertRuleGroupKey, 0, n)
+	for i := 0; i < n; i++ {
+		keys = append(keys, models.AlertRuleGroupKey{OrgID: 1, NamespaceUID: fmt.Sprintf("folder-%d", i%10), RuleGroup: fmt.Sprintf("group-%d", i)})
+	}
+	return keys
+}
+
+func TestRuleRing(t *testing.T) {
+	keys := testGroupKeys(3000)
+
+	t.Run("should split groups evenly between members", func(t *testing.T) {
+		ring := newRuleRing([]string{"a", "b", "c"})
+		counts := map[string]int{}
+		for _, key := range keys {
+			counts[ring.owner(key)]++
+		}
+		require.Len(t, counts, 3)
+		for member, count := range counts {
+			assert.InDeltaf(t, 1000, count, 250, "member %s owns %d groups", member, count)
+		}
+	})
+
+	t.Run("should only move groups to the new member when a member joins", func(t *testing.T) {
+		before := newRuleRing([]string{"a", "b", "c"})
+		after := newRuleRing([]string{"a", "b", "c", "d"})
+		moved := 0
+		for _, key := range keys {
+			if before.owner(key) == after.owner(key) {
+				continue
+			}
+			require.Equal(t, "d", after.owner(key))
+			moved++
+		}
+		assert.InDelta(t, len(keys)/4, moved, float64(len(keys))/10)
+	})
+
+	t.Run("should return empty owner without members", func(t *testing.T) {
+		require.Empty(t, newRuleRing(nil).owner(keys[0]))
+	})
+}
+
+func TestRuleSharding(t *testing.T) {
+	t.Run("should own all rules when not part of a cluster", func(t *testing.T) {
+		s := newRuleSharding(&fakeClusterMembership{})
+		rebalanced, _ := s.refresh()
+		require.False(t, rebalanced)
+		for _, key := range testGroupKeys(10) {
+			require.True(t, s.owns(models.RuleGen.With(models.RuleGen.WithGroupKey(key)).GenerateRef()))
+		}
+	})
+
+	t.Run("should add itself to members while the cluster is settling", func(t *testing.T) {
+		s := newRuleSharding(&fakeClusterMembership{self: "b", members: []string{"a"}})
+		s.refresh()
+		status := s.status(nil, 1)
+		require.Equal(t, []string{"a", "b"}, status.Members)
+	})
+
+	t.Run("should rebalance only when members change", func(t *testing.T) {
+		membership := &fakeClusterMembership{self: "a", members: []string{"a", "b"}}
+		s := newRuleSharding(membership)
+		rebalanced, _ := s.refresh()
+		require.False(t, rebalanced)
+		rebalanced, _ = s.refresh()
+		require.False(t, rebalanced)
+
+		membership.setMembers("b", "a", "c")
+		rebalanced, members := s.refresh()
+		require.True(t, rebalanced)
+		require.Equal(t, []string{"a", "b", "c"}, members)
+	})
+}
+
+func TestProcessTickWithSharding(t *testing.T) {
+	ctx := context.Background()
+	dispatcherGroup, ctx := errgroup.WithContext(ctx)
+
+	ruleStore := newFakeRulesStore()
+	instanceStore := &state.FakeInstanceStore{}
+	sch := setupScheduler(t, ruleStore, instanceStore, nil, nil, nil, nil)
+	membership := &fakeClusterMembership{self: "a", members: []string{"a"}}
+	sch.sharding = newRuleSharding(membership)
+
+	gen := models.RuleGen
+	rules := make([]*models.AlertRule, 0, 20)
+	for _, key := range testGroupKeys(20) {
+		rule := gen.With(gen.WithGroupKey(key), gen.WithInterval(time.Second)).GenerateRef()
+		rules = append(rules, rule)
+	}
+	ruleStore.PutRule(ctx, rules...)
+
+	tick := time.Time{}.Add(time.Second)
+	scheduled, _, _ := sch.processTick(ctx, dispatcherGroup, tick)
+	require.Len(t, scheduled, len(rules))
+	require.Empty(t, instanceStore.RecordedOps(), "state of the rules should not be loaded on startup")
+
+	// Another replica joins the cluster.
+	membership.setMembers("a", "b")
+	tick = tick.Add(time.Second)
+	scheduled, _, _ = sch.processTick(ctx, dispatcherGroup, tick)
+
+	ring := newRuleRing([]string{"a", "b"})
+	owned := 0
+	for _, rule := range rules {
+		if ring.owner(rule.GetGroupKey()) == "a" {
+			owned++
+			require.True(t, sch.registry.exists(rule.GetKey()))
+			continue
+		}
+		require.False(t, sch.registry.exists(rule.GetKey()), "rule owned by another replica should be stopped")
+	}
+	require.Len(t, scheduled, owned)
+	require.Positive(t, owned)
+	require.Less(t, owned, len(rules))
+	// Rules evaluated by other replicas are still known to the scheduler.
+	all, _ := sch.Rules()
+	require.Len(t, all, len(rules))
+
+	status := sch.ShardingStatus(1)
+	require.True(t, status.Enabled)
+	require.Equal(t, "a", status.Self)
+	require.Equal(t, []string{"a", "b"}, status.Members)
+	require.Len(t, status.Groups, len(rules))
+
+	// The state of the rules evaluated by the other replica is loaded from the database for the whole organization.
+	require.Contains(t, instanceStore.RecordedOps(), models.ListAlertInstancesQuery{RuleOrgID: 1})
+	for _, rule := range rules {
+		_, ok := sch.Status(rule.GetKey())
+		require.True(t, ok, "status of rules evaluated by other replicas should be known")
+	}
+
+	// The other replica leaves the cluster, its rules are taken over with their state.
+	membership.setMembers("a")
+	tick = tick.Add(time.Second)
+	scheduled, _, _ = sch.processTick(ctx, dispatcherGroup, tick)
+	require.Len(t, scheduled, len(rules))
+
+	loaded := map[string]struct{}{}
+	for _, op := range instanceStore.RecordedOps() {
+		if q, ok := op.(models.ListAlertInstancesQuery); ok && q.RuleUID != "" {
+			loaded[q.RuleUID] = struct{}{}
+		}
+	}
+	require.Len(t, loaded, len(rules)-owned)
+	for _, rule := range rules {
+		if ring.owner(rule.GetGroupKey()) == "b" {
+			require.Contains(t, loaded, rule.UID)
+		}
+	}
+}
@@ -191,6 +191,12 @@ func TestCacheMetrics(t *testing.T) {
 				CacheID:      data.Fingerprint(rand.Int63()),
 				State:        eval.Recovering,
 			},
+			{
+				OrgID:        orgID,
+				AlertRuleUID: "rule1",
+				CacheID:      data.Fingerprint(rand.Int63()),
+				State:        eval.Suppressed,
+			},
 		}
 		expectedMetrics := `
 			# HELP grafana_alerting_alerts How many alerts by state are in the scheduler.
@@ -201,6 +207,7 @@ func TestCacheMetrics(t *testing.T) {
 			grafana_alerting_alerts{state="normal"} 1
 			grafana_alerting_alerts{state="pending"} 1
 			grafana_alerting_alerts{state="recovering"} 1
+			grafana_alerting_alerts{state="suppressed"} 1
 		`
 
 		reg := prometheus.NewPedanticRegistry()
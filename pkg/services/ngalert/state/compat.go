@@ -153,7 +153,7 @@ func FromAlertsStateToStoppedAlert(firingStates []StateTransition, appURL *url.U
 	alerts := apimodels.PostableAlerts{PostableAlerts: make([]models.PostableAlert, 0, len(firingStates))}
 	ts := clock.Now()
 	for _, transition := range firingStates {
-		if transition.PreviousState == eval.Normal || transition.PreviousState == eval.Pending {
+		if transition.PreviousState == eval.Normal || transition.PreviousState == eval.Pending || transition.PreviousState == eval.Suppressed {
 			continue
 		}
 		postableAlert := StateToPostableAlert(transition, appURL, featureToggles)
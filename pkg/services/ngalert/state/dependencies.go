@@ -0,0 +1,94 @@
+package state
+
+import (
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/alertmanager/pkg/labels"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	ngModels "github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// dependencySource is the set of firing alert instances of a rule that another rule depends on.
+type dependencySource struct {
+	dependency ngModels.AlertRuleDependency
+	firing     []data.Labels
+}
+
+// dependencySuppressor decides whether an alert instance of a rule is suppressed
+// because a rule it depends on is firing.
+type dependencySuppressor struct {
+	sources []dependencySource
+}
+
+// newDependencySuppressor takes a snapshot of the firing alert instances of the rules the alert rule depends on.
+// It returns nil if none of the dependencies is firing. If the rules are split between the replicas of the HA cluster,
+// the state of dependencies that are evaluated by other replicas is the state the scheduler loads from the database,
+// which can be up to a minute old.
+func newDependencySuppressor(c *cache, rule *ngModels.AlertRule, logger log.Logger) *dependencySuppressor {
+	if len(rule.Dependencies) == 0 {
+		return nil
+	}
+	var sources []dependencySource
+	for _, dependency := range rule.Dependencies {
+		matchers, err := dependency.ParseMatchers()
+		if err != nil {
+			// Dependencies are validated when the rule is saved, this should not happen.
+			logger.Warn("Skipping dependency with invalid matchers", "dependency", dependency.RuleUID, "error", err)
+			continue
+		}
+		var firing []data.Labels
+		for _, s := range c.getStatesForRuleUID(rule.OrgID, dependency.RuleUID) {
+			if s.State != eval.Alerting && s.State != eval.Recovering {
+				continue
+			}
+			if !matchLabels(matchers, s.Labels) {
+				continue
+			}
+			firing = append(firing, s.Labels)
+		}
+		if len(firing) > 0 {
+			sources = append(sources, dependencySource{dependency: dependency, firing: firing})
+		}
+	}
+	if len(sources) == 0 {
+		return nil
+	}
+	return &dependencySuppressor{sources: sources}
+}
+
+// suppresses returns true if any firing alert instance of a dependency has the same values
+// of the labels listed in the dependency as the given alert instance.
+func (d *dependencySuppressor) suppresses(lbs data.Labels) bool {
+	if d == nil {
+		return false
+	}
+	for _, source := range d.sources {
+		for _, firing := range source.firing {
+			if equalLabels(source.dependency.Equal, firing, lbs) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchLabels(matchers labels.Matchers, lbs data.Labels) bool {
+	for _, m := range matchers {
+		if !m.Matches(lbs[m.Name]) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalLabels returns true if the labels have the same value in both sets.
+// A label that is missing in both sets is considered equal, the same as in inhibition rules of the Alertmanager.
+func equalLabels(names []string, a, b data.Labels) bool {
+	for _, name := range names {
+		if a[name] != b[name] {
+			return false
+		}
+	}
+	return true
+}
@@ -441,10 +441,18 @@ func BuildLogQuery(query models.HistoryQuery, folderUIDs []string, maxQuerySize
 }
 
 func buildQueryTail(query models.HistoryQuery) (string, error) {
+	b := strings.Builder{}
+	// Line filters go before the parser, so that Loki skips the lines that do not match without parsing them.
+	for _, word := range query.Contains {
+		b.WriteString(" |~ ")
+		_, err := fmt.Fprintf(&b, "%q", "(?i)"+regexp.QuoteMeta(word))
+		if err != nil {
+			return "", err
+		}
+	}
 	if !queryHasLogFilters(query) {
-		return "", nil
+		return b.String(), nil
 	}
-	b := strings.Builder{}
 	b.WriteString(" | json")
 
 	if query.RuleUID != "" {
@@ -272,6 +272,23 @@ func TestBuildLogQuery(t *testing.T) {
 			},
 			exp: []string{`{orgID="123",from="state-history"} | json | ruleUID="rule-uid" | labels_customlabel="customvalue"`},
 		},
+		{
+			name: "filters words in log line ignoring case",
+			query: models.HistoryQuery{
+				OrgID:    123,
+				RuleUID:  "rule-uid",
+				Contains: []string{"cpu", "a.b"},
+			},
+			exp: []string{`{orgID="123",from="state-history"} |~ "(?i)cpu" |~ "(?i)a\\.b" | json | ruleUID="rule-uid"`},
+		},
+		{
+			name: "filters words without other log filters",
+			query: models.HistoryQuery{
+				OrgID:    123,
+				Contains: []string{"cpu"},
+			},
+			exp: []string{`{orgID="123",from="state-history"} |~ "(?i)cpu"`},
+		},
 		{
 			name: "should return if query does not exceed max limit",
 			query: models.HistoryQuery{
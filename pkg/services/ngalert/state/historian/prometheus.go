@@ -2,17 +2,21 @@ package historian
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/grafana/dataplane/sdata/numeric"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/common/model"
 	promValue "github.com/prometheus/prometheus/model/value"
 	"github.com/prometheus/prometheus/util/strutil"
 
+	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
 	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
@@ -34,6 +38,13 @@ const (
 	alertRuleUIDLabel      = "grafana_rule_uid"
 )
 
+const (
+	// prometheusMinQueryStep is the finest resolution state history is queried with.
+	prometheusMinQueryStep = 15 * time.Second
+	// prometheusMaxQueryPoints is the maximum number of points per series Prometheus returns for a range query.
+	prometheusMaxQueryPoints = 11000
+)
+
 // isMetricEmittingState defines which evaluation states should emit ALERTS metrics.
 // Basically every state that is not Normal should emit metrics currently,
 // and is defined here as an allowed state.
@@ -66,6 +77,10 @@ type seriesWriter interface {
 	WriteDatasource(ctx context.Context, dsUID string, name string, t time.Time, frames data.Frames, orgID int64, extraLabels map[string]string) error
 }
 
+type seriesQuerier interface {
+	QueryRange(ctx context.Context, dsUID string, orgID int64, query string, start, end time.Time, step time.Duration) (model.Matrix, error)
+}
+
 type PrometheusConfig struct {
 	DatasourceUID string
 	MetricName    string
@@ -87,25 +102,296 @@ func NewPrometheusConfig(cfg setting.UnifiedAlertingStateHistorySettings) (Prome
 }
 
 type RemotePrometheusBackend struct {
-	cfg        PrometheusConfig
-	promWriter seriesWriter
-	logger     log.Logger
-	metrics    *metrics.Historian
+	cfg         PrometheusConfig
+	promWriter  seriesWriter
+	promQuerier seriesQuerier
+	ruleStore   RuleStore
+	ac          AccessControl
+	logger      log.Logger
+	metrics     *metrics.Historian
 }
 
-func NewRemotePrometheusBackend(cfg PrometheusConfig, promWriter seriesWriter, logger log.Logger, metrics *metrics.Historian) *RemotePrometheusBackend {
+func NewRemotePrometheusBackend(cfg PrometheusConfig, promWriter seriesWriter, promQuerier seriesQuerier, ruleStore RuleStore, ac AccessControl, logger log.Logger, metrics *metrics.Historian) *RemotePrometheusBackend {
 	logger.Info("Initializing remote Prometheus backend", "datasourceUID", cfg.DatasourceUID)
 
 	return &RemotePrometheusBackend{
-		cfg:        cfg,
-		promWriter: promWriter,
-		logger:     logger,
-		metrics:    metrics,
+		cfg:         cfg,
+		promWriter:  promWriter,
+		promQuerier: promQuerier,
+		ruleStore:   ruleStore,
+		ac:          ac,
+		logger:      logger,
+		metrics:     metrics,
 	}
 }
 
+// Query reconstructs the state history from the alert state metric in the configured data source
+// and formats it into the same dataframe as the Loki backend.
+//
+// The metric only records the time an alert instance spends in each of the metric-emitting states,
+// so transitions are detected at the resolution of the query step, and transitions of rules that no
+// longer exist are not returned because the series do not identify the organization of the rule.
 func (b *RemotePrometheusBackend) Query(ctx context.Context, query models.HistoryQuery) (*data.Frame, error) {
-	return nil, fmt.Errorf("prometheus historian backend does not support querying")
+	rules, err := b.getRulesForFilter(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if query.To.IsZero() {
+		query.To = now
+	}
+	if query.From.IsZero() {
+		query.From = now.Add(-defaultQueryRange)
+	}
+	if len(rules) == 0 || !query.From.Before(query.To) {
+		return newPrometheusStatesFrame(nil)
+	}
+
+	// Use whole seconds, so the timestamps of the returned samples are on the grid computed below.
+	start, end := query.From.Truncate(time.Second), query.To
+	step := prometheusQueryStep(start, end)
+	promQL := b.buildPromQuery(query)
+
+	res, err := b.promQuerier.QueryRange(ctx, b.cfg.DatasourceUID, query.OrgID, promQL, start, end, step)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query alert state metric: %w", err)
+	}
+
+	transitions := seriesToTransitions(res, rules, start, end, step)
+	if query.Limit > 0 && len(transitions) > query.Limit {
+		// Keep the latest transitions, like the Loki backend does.
+		transitions = transitions[len(transitions)-query.Limit:]
+	}
+	return newPrometheusStatesFrame(transitions)
+}
+
+// getRulesForFilter returns the rules matching the query that the user can read, by rule UID.
+func (b *RemotePrometheusBackend) getRulesForFilter(ctx context.Context, query models.HistoryQuery) (map[string]*models.AlertRule, error) {
+	q := &models.ListAlertRulesQuery{
+		OrgID:        query.OrgID,
+		DashboardUID: query.DashboardUID,
+		PanelID:      query.PanelID,
+	}
+	if query.RuleUID != "" {
+		q.RuleUIDs = []string{query.RuleUID}
+	}
+	rules, err := b.ruleStore.ListAlertRules(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch alert rules: %w", err)
+	}
+	if query.RuleUID != "" && len(rules) == 0 {
+		return nil, models.ErrAlertRuleNotFound
+	}
+
+	bypass, err := b.ac.CanReadAllRules(ctx, query.SignedInUser)
+	if err != nil {
+		return nil, err
+	}
+	if !bypass && query.RuleUID != "" {
+		if err := b.ac.AuthorizeAccessInFolder(ctx, query.SignedInUser, rules[0]); err != nil {
+			return nil, err
+		}
+		bypass = true
+	}
+
+	result := make(map[string]*models.AlertRule, len(rules))
+	folderAccess := make(map[string]bool)
+	for _, rule := range rules {
+		if !bypass {
+			hasAccess, ok := folderAccess[rule.NamespaceUID]
+			if !ok {
+				hasAccess, err = b.ac.HasAccessInFolder(ctx, query.SignedInUser, rule)
+				if err != nil {
+					return nil, err
+				}
+				folderAccess[rule.NamespaceUID] = hasAccess
+			}
+			if !hasAccess {
+				continue
+			}
+		}
+		result[rule.UID] = rule
+	}
+	return result, nil
+}
+
+// buildPromQuery returns a selector of the alert state metric with the rule and instance label filters of the query.
+func (b *RemotePrometheusBackend) buildPromQuery(query models.HistoryQuery) string {
+	matchers := []string{fmt.Sprintf("%s=%q", model.MetricNameLabel, b.cfg.MetricName)}
+	if query.RuleUID != "" {
+		matchers = append(matchers, fmt.Sprintf("%s=%q", alertRuleUIDLabel, query.RuleUID))
+	}
+	keys := make([]string, 0, len(query.Labels))
+	for k := range query.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		matchers = append(matchers, fmt.Sprintf("%s=%q", strutil.SanitizeFullLabelName(k), query.Labels[k]))
+	}
+	return "{" + strings.Join(matchers, ",") + "}"
+}
+
+// prometheusQueryStep returns the resolution of the query, in whole seconds, that keeps the number of points
+// per series within the limits of Prometheus.
+func prometheusQueryStep(start, end time.Time) time.Duration {
+	step := end.Sub(start) / prometheusMaxQueryPoints
+	if step < prometheusMinQueryStep {
+		return prometheusMinQueryStep
+	}
+	if rounded := step.Truncate(time.Second); rounded < step {
+		return rounded + time.Second
+	}
+	return step
+}
+
+type promTransition struct {
+	time           time.Time
+	rule           *models.AlertRule
+	instanceLabels data.Labels
+	fingerprint    string
+	previous       string
+	current        string
+}
+
+// promStateNames maps the values of the grafana_alertstate label back to state names.
+var promStateNames = func() map[string]string {
+	result := make(map[string]string)
+	for _, s := range []eval.State{eval.Normal, eval.Alerting, eval.Pending, eval.NoData, eval.Error, eval.Recovering, eval.Suppressed} {
+		result[strings.ToLower(s.String())] = s.String()
+	}
+	return result
+}()
+
+// seriesToTransitions groups the series of the alert state metric by alert instance and returns the
+// transitions between states in the order they happened. An instance is Normal at the steps at which
+// none of its series has a sample.
+func seriesToTransitions(matrix model.Matrix, rules map[string]*models.AlertRule, start, end time.Time, step time.Duration) []promTransition {
+	type instance struct {
+		rule   *models.AlertRule
+		labels data.Labels
+		states map[model.Time]string
+	}
+	instances := make(map[string]*instance)
+	for _, series := range matrix {
+		rule, ok := rules[string(series.Metric[alertRuleUIDLabel])]
+		if !ok {
+			continue
+		}
+		stateName, ok := promStateNames[string(series.Metric[grafanaAlertStateLabel])]
+		if !ok {
+			continue
+		}
+
+		lbls := make(data.Labels, len(series.Metric))
+		for k, v := range series.Metric {
+			switch k {
+			case model.MetricNameLabel, alertStateLabel, grafanaAlertStateLabel, alertRuleUIDLabel:
+				continue
+			}
+			lbls[string(k)] = string(v)
+		}
+		key := rule.UID + "/" + labelFingerprint(lbls)
+		inst, ok := instances[key]
+		if !ok {
+			inst = &instance{rule: rule, labels: lbls, states: make(map[model.Time]string)}
+			instances[key] = inst
+		}
+		for _, v := range series.Values {
+			inst.states[v.Timestamp] = stateName
+		}
+	}
+
+	normal := eval.Normal.String()
+	first, last, interval := model.TimeFromUnixNano(start.UnixNano()), model.TimeFromUnixNano(end.UnixNano()), model.Time(step.Milliseconds())
+
+	var result []promTransition
+	for _, inst := range instances {
+		fingerprint := labelFingerprint(inst.labels)
+		previous := ""
+		for ts := first; ts <= last; ts += interval {
+			current, ok := inst.states[ts]
+			if !ok {
+				current = normal
+			}
+			// The state at the beginning of the range is not a transition.
+			if previous != "" && current != previous {
+				result = append(result, promTransition{
+					time:           ts.Time(),
+					rule:           inst.rule,
+					instanceLabels: inst.labels,
+					fingerprint:    fingerprint,
+					previous:       previous,
+					current:        current,
+				})
+			}
+			previous = current
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].time.Equal(result[j].time) {
+			return result[i].time.Before(result[j].time)
+		}
+		if result[i].rule.UID != result[j].rule.UID {
+			return result[i].rule.UID < result[j].rule.UID
+		}
+		return result[i].fingerprint < result[j].fingerprint
+	})
+	return result
+}
+
+// newPrometheusStatesFrame formats the transitions into the dataframe returned by the Loki backend.
+func newPrometheusStatesFrame(transitions []promTransition) (*data.Frame, error) {
+	frame := data.NewFrame("states")
+	lbls := data.Labels(map[string]string{})
+
+	times := make([]time.Time, 0, len(transitions))
+	lines := make([]json.RawMessage, 0, len(transitions))
+	labels := make([]json.RawMessage, 0, len(transitions))
+	for _, t := range transitions {
+		entry := LokiEntry{
+			SchemaVersion:  1,
+			Previous:       t.previous,
+			Current:        t.current,
+			Values:         simplejson.New(),
+			Condition:      t.rule.Condition,
+			Fingerprint:    t.fingerprint,
+			RuleTitle:      t.rule.Title,
+			RuleID:         t.rule.ID,
+			RuleUID:        t.rule.UID,
+			InstanceLabels: t.instanceLabels,
+		}
+		if t.rule.DashboardUID != nil {
+			entry.DashboardUID = *t.rule.DashboardUID
+		}
+		if t.rule.PanelID != nil {
+			entry.PanelID = *t.rule.PanelID
+		}
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize state transition: %w", err)
+		}
+		streamLbls, err := json.Marshal(map[string]string{
+			StateHistoryLabelKey: StateHistoryLabelValue,
+			OrgIDLabel:           fmt.Sprint(t.rule.OrgID),
+			GroupLabel:           t.rule.RuleGroup,
+			FolderUIDLabel:       t.rule.NamespaceUID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize stream labels: %w", err)
+		}
+
+		times = append(times, t.time)
+		lines = append(lines, line)
+		labels = append(labels, streamLbls)
+	}
+
+	frame.Fields = append(frame.Fields, data.NewField(dfTime, lbls, times))
+	frame.Fields = append(frame.Fields, data.NewField(dfLine, lbls, lines))
+	frame.Fields = append(frame.Fields, data.NewField(dfLabels, lbls, labels))
+	return frame, nil
 }
 
 func (b *RemotePrometheusBackend) Record(ctx context.Context, rule history_model.RuleMeta, transitions []state.StateTransition) <-chan error {
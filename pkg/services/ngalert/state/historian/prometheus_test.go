@@ -3,6 +3,7 @@ package historian
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"math"
 	"testing"
@@ -12,16 +13,23 @@ import (
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
 	promValue "github.com/prometheus/prometheus/model/value"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
 	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	acfakes "github.com/grafana/grafana/pkg/services/ngalert/accesscontrol/fakes"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
 	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
 	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/services/ngalert/state"
 	history_model "github.com/grafana/grafana/pkg/services/ngalert/state/historian/model"
+	"github.com/grafana/grafana/pkg/services/ngalert/tests/fakes"
+	"github.com/grafana/grafana/pkg/services/org"
 	"github.com/grafana/grafana/pkg/setting"
 )
 
@@ -59,7 +67,7 @@ func TestNewRemotePrometheusBackend(t *testing.T) {
 	logger := log.NewNopLogger()
 	met := metrics.NewHistorianMetrics(prometheus.NewRegistry(), "test")
 
-	backend := NewRemotePrometheusBackend(cfg, fakeWriter, logger, met)
+	backend := NewRemotePrometheusBackend(cfg, fakeWriter, nil, nil, nil, logger, met)
 
 	require.NotNil(t, backend)
 	require.Equal(t, cfg.DatasourceUID, backend.cfg.DatasourceUID)
@@ -287,7 +295,7 @@ func TestPrometheusBackend_Record(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			fakeWriter := new(fakeRemoteWriter)
 			met := metrics.NewHistorianMetrics(prometheus.NewRegistry(), "test")
-			backend := NewRemotePrometheusBackend(cfg, fakeWriter, logger, met)
+			backend := NewRemotePrometheusBackend(cfg, fakeWriter, nil, nil, nil, logger, met)
 
 			if tc.expectedFrames != nil {
 				var extraLabels map[string]string
@@ -319,20 +327,6 @@ func TestPrometheusBackend_Record(t *testing.T) {
 	}
 }
 
-func TestPrometheusBackend_Query(t *testing.T) {
-	cfg := PrometheusConfig{DatasourceUID: "test-ds-uid", MetricName: testMetricName}
-	logger := log.NewNopLogger()
-	fakeWriter := new(fakeRemoteWriter)
-	met := metrics.NewHistorianMetrics(prometheus.NewRegistry(), "test")
-
-	backend := NewRemotePrometheusBackend(cfg, fakeWriter, logger, met)
-
-	frame, err := backend.Query(context.Background(), ngmodels.HistoryQuery{})
-	require.Error(t, err)
-	require.Nil(t, frame)
-	require.Contains(t, err.Error(), "prometheus historian backend does not support querying")
-}
-
 func TestPrometheusBackend_Record_Metrics(t *testing.T) {
 	cfg := PrometheusConfig{DatasourceUID: "test-ds-uid", MetricName: testMetricName}
 	logger := log.NewNopLogger()
@@ -347,7 +341,7 @@ func TestPrometheusBackend_Record_Metrics(t *testing.T) {
 
 		registry := prometheus.NewRegistry()
 		met := metrics.NewHistorianMetrics(registry, "test")
-		backend := NewRemotePrometheusBackend(cfg, fakeWriter, logger, met)
+		backend := NewRemotePrometheusBackend(cfg, fakeWriter, nil, nil, nil, logger, met)
 
 		states := []state.StateTransition{
 			{State: &state.State{AlertRuleUID: "rule-uid", OrgID: orgID, Labels: data.Labels{}, State: eval.Alerting, LastEvaluationTime: now}},
@@ -384,7 +378,7 @@ func TestPrometheusBackend_Record_Metrics(t *testing.T) {
 
 		registry := prometheus.NewRegistry()
 		met := metrics.NewHistorianMetrics(registry, "test")
-		backend := NewRemotePrometheusBackend(cfg, fakeWriter, logger, met)
+		backend := NewRemotePrometheusBackend(cfg, fakeWriter, nil, nil, nil, logger, met)
 
 		states := []state.StateTransition{
 			{State: &state.State{AlertRuleUID: "rule-uid", OrgID: orgID, Labels: data.Labels{}, State: eval.Alerting, LastEvaluationTime: now}},
@@ -434,7 +428,7 @@ func TestPrometheusBackend_Record_PanicRecovery(t *testing.T) {
 	panicWriter.On("WriteDatasource", ctx, cfg.DatasourceUID, testMetricName, now, mock.Anything, orgID, mock.Anything).Once()
 
 	met := metrics.NewHistorianMetrics(prometheus.NewRegistry(), "test")
-	backend := NewRemotePrometheusBackend(cfg, panicWriter, logger, met)
+	backend := NewRemotePrometheusBackend(cfg, panicWriter, nil, nil, nil, logger, met)
 
 	states := []state.StateTransition{
 		{State: &state.State{
@@ -456,3 +450,184 @@ func TestPrometheusBackend_Record_PanicRecovery(t *testing.T) {
 
 	panicWriter.AssertExpectations(t)
 }
+
+type fakeSeriesQuerier struct {
+	result  model.Matrix
+	err     error
+	queries []string
+	steps   []time.Duration
+}
+
+func (f *fakeSeriesQuerier) QueryRange(_ context.Context, _ string, _ int64, query string, _, _ time.Time, step time.Duration) (model.Matrix, error) {
+	f.queries = append(f.queries, query)
+	f.steps = append(f.steps, step)
+	return f.result, f.err
+}
+
+func TestPrometheusBackend_Query(t *testing.T) {
+	cfg := PrometheusConfig{DatasourceUID: "test-ds-uid", MetricName: testMetricName}
+	orgID := int64(1)
+	usr := accesscontrol.BackgroundUser("test", orgID, org.RoleNone, nil)
+	rule1 := ngmodels.RuleGen.With(ngmodels.RuleMuts.WithOrgID(orgID), ngmodels.RuleMuts.WithNamespaceUID("folder-1")).GenerateRef()
+	rule2 := ngmodels.RuleGen.With(ngmodels.RuleMuts.WithOrgID(orgID), ngmodels.RuleMuts.WithNamespaceUID("folder-2")).GenerateRef()
+
+	from := time.Unix(1000, 0)
+	to := from.Add(10 * prometheusMinQueryStep)
+	at := func(step int) model.Time {
+		return model.TimeFromUnixNano(from.Add(time.Duration(step) * prometheusMinQueryStep).UnixNano())
+	}
+	series := func(ruleUID, grafanaState string, steps ...int) *model.SampleStream {
+		s := &model.SampleStream{
+			Metric: model.Metric{
+				model.MetricNameLabel:  testMetricName,
+				alertRuleUIDLabel:      model.LabelValue(ruleUID),
+				alertNameLabel:         "test",
+				alertStateLabel:        "firing",
+				grafanaAlertStateLabel: model.LabelValue(grafanaState),
+				"instance":             "a",
+			},
+		}
+		for _, step := range steps {
+			s.Values = append(s.Values, model.SamplePair{Timestamp: at(step), Value: 1})
+		}
+		return s
+	}
+	matrix := model.Matrix{
+		series(rule1.UID, "pending", 2, 3),
+		series(rule1.UID, "alerting", 4, 5, 6),
+		series(rule2.UID, "alerting", 0, 1, 2),
+		series("unknown-rule", "alerting", 3),
+	}
+
+	createBackend := func(querier seriesQuerier, canReadAll bool) *RemotePrometheusBackend {
+		rules := fakes.NewRuleStore(t)
+		rules.Rules = map[int64][]*ngmodels.AlertRule{orgID: {rule1, rule2}}
+		ac := &acfakes.FakeRuleService{}
+		ac.CanReadAllRulesFunc = func(ctx context.Context, requester identity.Requester) (bool, error) {
+			return canReadAll, nil
+		}
+		ac.HasAccessInFolderFunc = func(ctx context.Context, requester identity.Requester, namespaced ngmodels.Namespaced) (bool, error) {
+			return namespaced.GetNamespaceUID() == "folder-1", nil
+		}
+		met := metrics.NewHistorianMetrics(prometheus.NewRegistry(), "test")
+		return NewRemotePrometheusBackend(cfg, nil, querier, rules, ac, log.NewNopLogger(), met)
+	}
+
+	readEntries := func(t *testing.T, frame *data.Frame) ([]time.Time, []LokiEntry) {
+		t.Helper()
+		require.Len(t, frame.Fields, 3)
+		var times []time.Time
+		var entries []LokiEntry
+		for i := 0; i < frame.Rows(); i++ {
+			times = append(times, frame.Fields[0].At(i).(time.Time))
+			var entry LokiEntry
+			require.NoError(t, json.Unmarshal(frame.Fields[1].At(i).(json.RawMessage), &entry))
+			entries = append(entries, entry)
+		}
+		return times, entries
+	}
+
+	t.Run("should reconstruct transitions from the state series", func(t *testing.T) {
+		querier := &fakeSeriesQuerier{result: matrix}
+		backend := createBackend(querier, true)
+
+		frame, err := backend.Query(context.Background(), ngmodels.HistoryQuery{OrgID: orgID, From: from, To: to, SignedInUser: usr})
+		require.NoError(t, err)
+
+		times, entries := readEntries(t, frame)
+		require.Len(t, entries, 4)
+		expected := []struct {
+			step     int
+			ruleUID  string
+			previous string
+			current  string
+		}{
+			{2, rule1.UID, "Normal", "Pending"},
+			{3, rule2.UID, "Alerting", "Normal"},
+			{4, rule1.UID, "Pending", "Alerting"},
+			{7, rule1.UID, "Alerting", "Normal"},
+		}
+		for i, e := range expected {
+			assert.Equal(t, at(e.step).Time(), times[i])
+			assert.Equal(t, e.ruleUID, entries[i].RuleUID)
+			assert.Equal(t, e.previous, entries[i].Previous)
+			assert.Equal(t, e.current, entries[i].Current)
+			assert.Equal(t, map[string]string{"alertname": "test", "instance": "a"}, entries[i].InstanceLabels)
+		}
+
+		var streamLabels map[string]string
+		require.NoError(t, json.Unmarshal(frame.Fields[2].At(0).(json.RawMessage), &streamLabels))
+		assert.Equal(t, rule1.NamespaceUID, streamLabels[FolderUIDLabel])
+		assert.Equal(t, StateHistoryLabelValue, streamLabels[StateHistoryLabelKey])
+
+		require.Len(t, querier.queries, 1)
+		assert.Equal(t, `{__name__="test_metric_name"}`, querier.queries[0])
+		assert.Equal(t, prometheusMinQueryStep, querier.steps[0])
+	})
+
+	t.Run("should return only the latest transitions if limit is set", func(t *testing.T) {
+		backend := createBackend(&fakeSeriesQuerier{result: matrix}, true)
+
+		frame, err := backend.Query(context.Background(), ngmodels.HistoryQuery{OrgID: orgID, From: from, To: to, Limit: 1, SignedInUser: usr})
+		require.NoError(t, err)
+
+		times, entries := readEntries(t, frame)
+		require.Len(t, entries, 1)
+		assert.Equal(t, at(7).Time(), times[0])
+	})
+
+	t.Run("should filter by rule UID and labels", func(t *testing.T) {
+		querier := &fakeSeriesQuerier{result: matrix}
+		backend := createBackend(querier, true)
+
+		_, err := backend.Query(context.Background(), ngmodels.HistoryQuery{
+			OrgID:        orgID,
+			RuleUID:      rule1.UID,
+			Labels:       map[string]string{"instance": "a", "some.label": `"quoted"`},
+			From:         from,
+			To:           to,
+			SignedInUser: usr,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, querier.queries, 1)
+		assert.Equal(t, `{__name__="test_metric_name",grafana_rule_uid="`+rule1.UID+`",instance="a",some_label="\"quoted\""}`, querier.queries[0])
+	})
+
+	t.Run("should return error if rule does not exist", func(t *testing.T) {
+		querier := &fakeSeriesQuerier{result: matrix}
+		backend := createBackend(querier, true)
+
+		_, err := backend.Query(context.Background(), ngmodels.HistoryQuery{OrgID: orgID, RuleUID: "not-found", SignedInUser: usr})
+		require.ErrorIs(t, err, ngmodels.ErrAlertRuleNotFound)
+		require.Empty(t, querier.queries)
+	})
+
+	t.Run("should only return transitions of rules in folders the user can read", func(t *testing.T) {
+		backend := createBackend(&fakeSeriesQuerier{result: matrix}, false)
+
+		frame, err := backend.Query(context.Background(), ngmodels.HistoryQuery{OrgID: orgID, From: from, To: to, SignedInUser: usr})
+		require.NoError(t, err)
+
+		_, entries := readEntries(t, frame)
+		require.Len(t, entries, 3)
+		for _, e := range entries {
+			assert.Equal(t, rule1.UID, e.RuleUID)
+		}
+	})
+
+	t.Run("should return error if query fails", func(t *testing.T) {
+		backend := createBackend(&fakeSeriesQuerier{err: errors.New("boom")}, true)
+
+		_, err := backend.Query(context.Background(), ngmodels.HistoryQuery{OrgID: orgID, From: from, To: to, SignedInUser: usr})
+		require.ErrorContains(t, err, "boom")
+	})
+}
+
+func TestPrometheusQueryStep(t *testing.T) {
+	now := time.Now()
+	assert.Equal(t, prometheusMinQueryStep, prometheusQueryStep(now.Add(-time.Hour), now))
+	step := prometheusQueryStep(now.Add(-30*24*time.Hour), now)
+	assert.Equal(t, 236*time.Second, step)
+	assert.LessOrEqual(t, int(30*24*time.Hour/step), prometheusMaxQueryPoints)
+}
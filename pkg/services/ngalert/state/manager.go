@@ -179,41 +179,7 @@ func (st *Manager) Warm(ctx context.Context, orgReader OrgReader, rulesReader Ru
 				// TODO Should we delete the orphaned state from the db?
 				continue
 			}
-
-			// nil safety.
-			annotations := ruleForEntry.Annotations
-			if annotations == nil {
-				annotations = make(map[string]string)
-			}
-
-			lbs := map[string]string(entry.Labels)
-			cacheID := entry.Labels.Fingerprint()
-			var resultFp data.Fingerprint
-			if entry.ResultFingerprint != "" {
-				fp, err := strconv.ParseUint(entry.ResultFingerprint, 16, 64)
-				if err != nil {
-					logger.Error("Failed to parse result fingerprint of alert instance", "error", err, "rule_uid", entry.RuleUID)
-				}
-				resultFp = data.Fingerprint(fp)
-			}
-			state := &State{
-				AlertRuleUID:         entry.RuleUID,
-				OrgID:                entry.RuleOrgID,
-				CacheID:              cacheID,
-				Labels:               lbs,
-				State:                translateInstanceState(entry.CurrentState),
-				StateReason:          entry.CurrentReason,
-				LastEvaluationString: "",
-				StartsAt:             entry.CurrentStateSince,
-				EndsAt:               entry.CurrentStateEnd,
-				FiredAt:              entry.FiredAt,
-				LastEvaluationTime:   entry.LastEvalTime,
-				Annotations:          annotations,
-				ResultFingerprint:    resultFp,
-				ResolvedAt:           entry.ResolvedAt,
-				LastSentAt:           entry.LastSentAt,
-			}
-			st.cache.set(state)
+			st.cache.set(stateFromAlertInstance(logger, entry, ruleForEntry))
 			statesCount++
 		}
 	}
@@ -221,6 +187,95 @@ func (st *Manager) Warm(ctx context.Context, orgReader OrgReader, rulesReader Ru
 	logger.Info("State cache has been initialized", "states", statesCount, "duration", time.Since(startTime))
 }
 
+// LoadStateByRule replaces the cached state of the rule with the state saved in the instance store.
+// It is used when the evaluation of the rule is taken over from another replica.
+func (st *Manager) LoadStateByRule(ctx context.Context, rule *ngModels.AlertRule) error {
+	if st.instanceStore == nil {
+		return nil
+	}
+	alertInstances, err := st.instanceStore.ListAlertInstances(ctx, &ngModels.ListAlertInstancesQuery{
+		RuleOrgID: rule.OrgID,
+		RuleUID:   rule.UID,
+	})
+	if err != nil {
+		return err
+	}
+	logger := st.log.FromContext(ctx)
+	st.cache.removeByRuleUID(rule.OrgID, rule.UID)
+	for _, entry := range alertInstances {
+		st.cache.set(stateFromAlertInstance(logger, entry, rule))
+	}
+	logger.Debug("Rule state has been loaded", append(rule.GetKey().LogContext(), "states", len(alertInstances))...)
+	return nil
+}
+
+// LoadStateByRules replaces the cached state of the rules of the organization with the state saved in the instance store.
+// It is used to refresh the state of the rules that are evaluated by other replicas.
+func (st *Manager) LoadStateByRules(ctx context.Context, orgID int64, rules []*ngModels.AlertRule) error {
+	if st.instanceStore == nil || len(rules) == 0 {
+		return nil
+	}
+	alertInstances, err := st.instanceStore.ListAlertInstances(ctx, &ngModels.ListAlertInstancesQuery{
+		RuleOrgID: orgID,
+	})
+	if err != nil {
+		return err
+	}
+	ruleByUID := make(map[string]*ngModels.AlertRule, len(rules))
+	for _, rule := range rules {
+		ruleByUID[rule.UID] = rule
+		st.cache.removeByRuleUID(orgID, rule.UID)
+	}
+	logger := st.log.FromContext(ctx)
+	statesCount := 0
+	for _, entry := range alertInstances {
+		rule, ok := ruleByUID[entry.RuleUID]
+		if !ok {
+			continue
+		}
+		st.cache.set(stateFromAlertInstance(logger, entry, rule))
+		statesCount++
+	}
+	logger.Debug("Rules state has been loaded", "org_id", orgID, "rules", len(rules), "states", statesCount)
+	return nil
+}
+
+func stateFromAlertInstance(logger log.Logger, entry *ngModels.AlertInstance, rule *ngModels.AlertRule) *State {
+	// nil safety.
+	annotations := rule.Annotations
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+
+	lbs := map[string]string(entry.Labels)
+	cacheID := entry.Labels.Fingerprint()
+	var resultFp data.Fingerprint
+	if entry.ResultFingerprint != "" {
+		fp, err := strconv.ParseUint(entry.ResultFingerprint, 16, 64)
+		if err != nil {
+			logger.Error("Failed to parse result fingerprint of alert instance", "error", err, "rule_uid", entry.RuleUID)
+		}
+		resultFp = data.Fingerprint(fp)
+	}
+	return &State{
+		AlertRuleUID:         entry.RuleUID,
+		OrgID:                entry.RuleOrgID,
+		CacheID:              cacheID,
+		Labels:               lbs,
+		State:                translateInstanceState(entry.CurrentState),
+		StateReason:          entry.CurrentReason,
+		LastEvaluationString: "",
+		StartsAt:             entry.CurrentStateSince,
+		EndsAt:               entry.CurrentStateEnd,
+		FiredAt:              entry.FiredAt,
+		LastEvaluationTime:   entry.LastEvalTime,
+		Annotations:          annotations,
+		ResultFingerprint:    resultFp,
+		ResolvedAt:           entry.ResolvedAt,
+		LastSentAt:           entry.LastSentAt,
+	}
+}
+
 func (st *Manager) Get(orgID int64, alertRuleUID string, stateId data.Fingerprint) *State {
 	return st.cache.get(orgID, alertRuleUID, stateId)
 }
@@ -395,6 +450,7 @@ func (st *Manager) updateLastSentAt(states StateTransitions, evaluatedAt time.Ti
 }
 
 func (st *Manager) setNextStateForRule(ctx context.Context, alertRule *ngModels.AlertRule, results eval.Results, extraLabels data.Labels, logger log.Logger, takeImageFn takeImageFn, now time.Time) []StateTransition {
+	suppressor := newDependencySuppressor(st.cache, alertRule, logger)
 	if results.IsNoData() && (alertRule.NoDataState == ngModels.Alerting || alertRule.NoDataState == ngModels.OK || alertRule.NoDataState == ngModels.KeepLast) { // If it is no data, check the mapping and switch all results to the new state
 		// aggregate UID of datasources that returned NoData into one and provide as auxiliary info via annotationa. See: https://github.com/grafana/grafana/issues/88184
 		var refIds strings.Builder
@@ -430,14 +486,14 @@ func (st *Manager) setNextStateForRule(ctx context.Context, alertRule *ngModels.
 		if len(results) > 0 {
 			result = results[0]
 		}
-		transitions := st.setNextStateForAll(alertRule, result, logger, annotations, takeImageFn)
+		transitions := st.setNextStateForAll(alertRule, result, logger, annotations, takeImageFn, suppressor)
 		if len(transitions) > 0 {
 			return transitions // if there are no current states for the rule. Create ones for each result
 		}
 	}
 	if results.IsError() && (alertRule.ExecErrState == ngModels.AlertingErrState || alertRule.ExecErrState == ngModels.OkErrState || alertRule.ExecErrState == ngModels.KeepLastErrState) {
 		// TODO squash all errors into one, and provide as annotation
-		transitions := st.setNextStateForAll(alertRule, results[0], logger, nil, takeImageFn)
+		transitions := st.setNextStateForAll(alertRule, results[0], logger, nil, takeImageFn, suppressor)
 		if len(transitions) > 0 {
 			return transitions // if there are no current states for the rule. Create ones for each result
 		}
@@ -449,7 +505,7 @@ func (st *Manager) setNextStateForRule(ctx context.Context, alertRule *ngModels.
 			patch(newState, curState, result)
 		}
 		start := st.clock.Now()
-		s := newState.transition(alertRule, result, nil, logger, takeImageFn)
+		s := newState.transition(alertRule, result, nil, logger, takeImageFn, suppressor.suppresses(newState.Labels))
 		if st.metrics != nil {
 			st.metrics.StateUpdateDuration.Observe(st.clock.Now().Sub(start).Seconds())
 		}
@@ -459,7 +515,7 @@ func (st *Manager) setNextStateForRule(ctx context.Context, alertRule *ngModels.
 	return transitions
 }
 
-func (st *Manager) setNextStateForAll(alertRule *ngModels.AlertRule, result eval.Result, logger log.Logger, extraAnnotations data.Labels, takeImageFn takeImageFn) []StateTransition {
+func (st *Manager) setNextStateForAll(alertRule *ngModels.AlertRule, result eval.Result, logger log.Logger, extraAnnotations data.Labels, takeImageFn takeImageFn, suppressor *dependencySuppressor) []StateTransition {
 	currentStates := st.cache.getStatesForRuleUID(alertRule.OrgID, alertRule.UID)
 	transitions := make([]StateTransition, 0, len(currentStates))
 	updated := ruleStates{
@@ -468,7 +524,7 @@ func (st *Manager) setNextStateForAll(alertRule *ngModels.AlertRule, result eval
 	for _, currentState := range currentStates {
 		start := st.clock.Now()
 		newState := currentState.Copy()
-		t := newState.transition(alertRule, result, extraAnnotations, logger, takeImageFn)
+		t := newState.transition(alertRule, result, extraAnnotations, logger, takeImageFn, suppressor.suppresses(newState.Labels))
 		if st.metrics != nil {
 			st.metrics.StateUpdateDuration.Observe(st.clock.Now().Sub(start).Seconds())
 		}
@@ -512,6 +568,8 @@ func translateInstanceState(state ngModels.InstanceStateType) eval.State {
 		return eval.Pending
 	case ngModels.InstanceStateRecovering:
 		return eval.Recovering
+	case ngModels.InstanceStateSuppressed:
+		return eval.Suppressed
 	default:
 		return eval.Error
 	}
@@ -617,6 +675,7 @@ func StatesToRuleStatus(states []*State) ngModels.RuleStatus {
 		case eval.Pending:
 		case eval.Alerting:
 		case eval.Recovering:
+		case eval.Suppressed:
 		case eval.Error:
 			status.Health = "error"
 		case eval.NoData:
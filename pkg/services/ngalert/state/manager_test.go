@@ -2290,3 +2290,108 @@ func TestStateManager_HistorianIntegration(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessEvalResults_Dependencies(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewMock()
+	cfg := state.ManagerCfg{
+		Metrics:       metrics.NewNGAlert(prometheus.NewPedanticRegistry()).GetStateMetrics(),
+		InstanceStore: &state.FakeInstanceStore{},
+		Images:        &state.NoopImageService{},
+		Clock:         clk,
+		Historian:     &state.FakeHistorian{},
+		Tracer:        tracing.InitializeTracerForTest(),
+		Log:           log.New("ngalert.state.manager"),
+	}
+	st := state.NewManager(cfg, state.NewNoopPersister())
+
+	gen := models.RuleGen.With(
+		models.RuleMuts.WithOrgID(1),
+		models.RuleMuts.WithFor(0),
+		models.RuleMuts.WithKeepFiringFor(0),
+		models.RuleMuts.WithIntervalSeconds(10),
+		models.RuleMuts.WithLabels(nil),
+	)
+	parent := gen.With(models.RuleMuts.WithUID("parent")).GenerateRef()
+	child := gen.With(
+		models.RuleMuts.WithUID("child"),
+		models.RuleMuts.WithDependencies(models.AlertRuleDependency{
+			RuleUID:  parent.UID,
+			Matchers: []string{`severity="critical"`},
+			Equal:    []string{"cluster"},
+		}),
+	).GenerateRef()
+
+	result := func(s eval.State, lbls data.Labels) eval.Result {
+		return eval.ResultGen(eval.WithState(s), eval.WithEvaluatedAt(clk.Now()), eval.WithLabels(lbls))()
+	}
+	evaluate := func(rule *models.AlertRule, results ...eval.Result) map[string]state.StateTransition {
+		transitions := st.ProcessEvalResults(ctx, clk.Now(), rule, results, nil, func(context.Context, state.StateTransitions) {})
+		byCluster := make(map[string]state.StateTransition, len(transitions))
+		for _, tr := range transitions {
+			byCluster[tr.Labels["cluster"]] = tr
+		}
+		return byCluster
+	}
+
+	t.Run("instances are not suppressed while dependency is not firing", func(t *testing.T) {
+		evaluate(parent, result(eval.Normal, data.Labels{"cluster": "a", "severity": "critical"}))
+		transitions := evaluate(child,
+			result(eval.Alerting, data.Labels{"cluster": "a"}),
+			result(eval.Alerting, data.Labels{"cluster": "b"}),
+		)
+		require.Equal(t, eval.Alerting, transitions["a"].State.State)
+		require.Equal(t, eval.Alerting, transitions["b"].State.State)
+	})
+
+	clk.Add(10 * time.Second)
+
+	t.Run("instances with equal labels are suppressed and resolved while dependency is firing", func(t *testing.T) {
+		evaluate(parent,
+			result(eval.Alerting, data.Labels{"cluster": "a", "severity": "critical"}),
+			result(eval.Alerting, data.Labels{"cluster": "b", "severity": "warning"}),
+		)
+		transitions := evaluate(child,
+			result(eval.Alerting, data.Labels{"cluster": "a"}),
+			result(eval.Alerting, data.Labels{"cluster": "b"}),
+		)
+		suppressed := transitions["a"]
+		require.Equal(t, eval.Suppressed, suppressed.State.State)
+		require.Equal(t, eval.Alerting, suppressed.PreviousState)
+		require.NotNil(t, suppressed.ResolvedAt)
+		require.Equal(t, clk.Now(), suppressed.EndsAt)
+		// The resolved alert is sent to the Alertmanager.
+		require.NotNil(t, suppressed.LastSentAt)
+		require.Equal(t, clk.Now(), *suppressed.LastSentAt)
+
+		// The alert instance of the dependency with cluster=b does not match the matchers.
+		require.Equal(t, eval.Alerting, transitions["b"].State.State)
+	})
+
+	clk.Add(10 * time.Second)
+
+	t.Run("suppressed instance stays suppressed", func(t *testing.T) {
+		evaluate(parent, result(eval.Alerting, data.Labels{"cluster": "a", "severity": "critical"}))
+		transitions := evaluate(child, result(eval.Alerting, data.Labels{"cluster": "a"}))
+		require.Equal(t, eval.Suppressed, transitions["a"].State.State)
+		require.Equal(t, eval.Suppressed, transitions["a"].PreviousState)
+		require.False(t, transitions["a"].NeedsSending(clk.Now().Add(time.Hour), time.Minute, time.Minute))
+	})
+
+	clk.Add(10 * time.Second)
+
+	t.Run("instance fires again when dependency stops firing", func(t *testing.T) {
+		evaluate(parent, result(eval.Normal, data.Labels{"cluster": "a", "severity": "critical"}))
+		transitions := evaluate(child, result(eval.Alerting, data.Labels{"cluster": "a"}))
+		require.Equal(t, eval.Alerting, transitions["a"].State.State)
+		require.Equal(t, eval.Suppressed, transitions["a"].PreviousState)
+		require.Nil(t, transitions["a"].ResolvedAt)
+	})
+
+	t.Run("normal results are not suppressed", func(t *testing.T) {
+		clk.Add(10 * time.Second)
+		evaluate(parent, result(eval.Alerting, data.Labels{"cluster": "a", "severity": "critical"}))
+		transitions := evaluate(child, result(eval.Normal, data.Labels{"cluster": "a"}))
+		require.Equal(t, eval.Normal, transitions["a"].State.State)
+	})
+}
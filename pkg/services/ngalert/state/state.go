@@ -187,6 +187,15 @@ func (a *State) SetRecovering(reason string, startsAt, endsAt time.Time) {
 	a.Error = nil
 }
 
+// SetSuppressed sets the state to Suppressed. It changes both the start and end time.
+func (a *State) SetSuppressed(reason string, startsAt, endsAt time.Time) {
+	a.State = eval.Suppressed
+	a.StateReason = reason
+	a.StartsAt = startsAt
+	a.EndsAt = endsAt
+	a.Error = nil
+}
+
 // SetNoData sets the state to NoData. It changes both the start and end time.
 func (a *State) SetNoData(reason string, startsAt, endsAt time.Time) {
 	a.State = eval.NoData
@@ -459,6 +468,28 @@ func resultAlerting(state *State, rule *models.AlertRule, result eval.Result, lo
 	}
 }
 
+// resultSuppressed is used instead of the other handlers when the result did not evaluate to Normal
+// while a rule the alert rule depends on is firing.
+func resultSuppressed(state *State, result eval.Result, logger log.Logger) {
+	if state.State == eval.Suppressed {
+		logger.Debug("Keeping state", "state", state.State)
+	} else {
+		logger.Debug("Changing state",
+			"previous_state",
+			state.State,
+			"next_state",
+			eval.Suppressed,
+			"previous_ends_at",
+			state.EndsAt,
+			"next_ends_at",
+			result.EvaluatedAt)
+		// Suppressed states have the same start and end timestamps, the same as Normal states.
+		state.SetSuppressed("", result.EvaluatedAt, result.EvaluatedAt)
+	}
+	// Keep the error so it is still reported in the health of the rule.
+	state.Error = result.Error
+}
+
 func resultError(state *State, rule *models.AlertRule, result eval.Result, logger log.Logger) {
 	handlerStr := "resultError"
 
@@ -600,9 +631,9 @@ func (a *State) NeedsSending(now time.Time, resendDelay time.Duration, resolvedR
 		return true
 	}
 
-	// For normal states, we should only be sending if this is a resolved notification or a re-send of the resolved
+	// For normal and suppressed states, we should only be sending if this is a resolved notification or a re-send of the resolved
 	// notification within the resolvedRetention period.
-	if a.State == eval.Normal && (a.ResolvedAt == nil || now.Sub(*a.ResolvedAt) > resolvedRetention) {
+	if (a.State == eval.Normal || a.State == eval.Suppressed) && (a.ResolvedAt == nil || now.Sub(*a.ResolvedAt) > resolvedRetention) {
 		return false
 	}
 
@@ -806,7 +837,9 @@ func patch(newState, existingState *State, result eval.Result) {
 	}
 }
 
-func (a *State) transition(alertRule *models.AlertRule, result eval.Result, extraAnnotations data.Labels, logger log.Logger, takeImageFn takeImageFn) StateTransition {
+// transition sets the next state of the alert instance from the result of the evaluation.
+// If suppressed is true, a result that did not evaluate to Normal sets the state to Suppressed.
+func (a *State) transition(alertRule *models.AlertRule, result eval.Result, extraAnnotations data.Labels, logger log.Logger, takeImageFn takeImageFn, suppressed bool) StateTransition {
 	a.LastEvaluationTime = result.EvaluatedAt
 	a.EvaluationDuration = result.EvaluationDuration
 	a.SetNextValues(result)
@@ -823,21 +856,23 @@ func (a *State) transition(alertRule *models.AlertRule, result eval.Result, extr
 	// Add the instance to the log context to help correlate log lines for a state
 	logger = logger.New("instance", result.Instance)
 
-	switch result.State {
-	case eval.Normal:
+	switch {
+	case suppressed && result.State != eval.Normal:
+		logger.Debug("Setting next state", "handler", "resultSuppressed")
+		resultSuppressed(a, result, logger)
+	case result.State == eval.Normal:
 		logger.Debug("Setting next state", "handler", "resultNormal")
 		resultNormal(a, alertRule, result, logger, "")
-	case eval.Alerting:
+	case result.State == eval.Alerting:
 		logger.Debug("Setting next state", "handler", "resultAlerting")
 		resultAlerting(a, alertRule, result, logger, "")
-	case eval.Error:
+	case result.State == eval.Error:
 		logger.Debug("Setting next state", "handler", "resultError")
 		resultError(a, alertRule, result, logger)
-	case eval.NoData:
+	case result.State == eval.NoData:
 		logger.Debug("Setting next state", "handler", "resultNoData")
 		resultNoData(a, alertRule, result, logger)
-	case eval.Pending,
-		eval.Recovering: // we do not emit results with these states
+	default: // we do not emit results with other states
 		logger.Debug("Ignoring set next state", "state", result.State)
 	}
 
@@ -845,6 +880,7 @@ func (a *State) transition(alertRule *models.AlertRule, result eval.Result, extr
 	a.StateReason = ""
 
 	if a.State != result.State &&
+		a.State != eval.Suppressed &&
 		result.State != eval.Normal &&
 		result.State != eval.Alerting {
 		a.StateReason = resultStateReason(result, alertRule)
@@ -853,10 +889,10 @@ func (a *State) transition(alertRule *models.AlertRule, result eval.Result, extr
 	// Set Resolved property so the scheduler knows to send a postable alert
 	// to Alertmanager.
 	newlyResolved := false
-	if oldState == eval.Alerting && a.State == eval.Normal || oldState == eval.Recovering && a.State == eval.Normal {
+	if (oldState == eval.Alerting || oldState == eval.Recovering) && (a.State == eval.Normal || a.State == eval.Suppressed) {
 		a.ResolvedAt = &result.EvaluatedAt
 		newlyResolved = true
-	} else if a.State != eval.Normal && a.State != eval.Pending { // Retain the last resolved time for Normal->Normal and Normal->Pending.
+	} else if a.State != eval.Normal && a.State != eval.Pending && a.State != eval.Suppressed { // Retain the last resolved time for Normal->Normal, Normal->Pending and Suppressed.
 		a.ResolvedAt = nil
 	}
 
@@ -96,6 +96,13 @@ func alertRuleToModelsAlertRule(ar alertRule, l log.Logger) (models.AlertRule, e
 		}
 	}
 
+	if ar.Dependencies != "" {
+		err = json.Unmarshal([]byte(ar.Dependencies), &result.Dependencies)
+		if err != nil {
+			return models.AlertRule{}, fmt.Errorf("failed to parse dependencies: %w", err)
+		}
+	}
+
 	return result, nil
 }
 
@@ -174,6 +181,14 @@ func alertRuleFromModelsAlertRule(ar models.AlertRule) (alertRule, error) {
 		result.NotificationSettings = string(notificationSettingsData)
 	}
 
+	if len(ar.Dependencies) > 0 {
+		dependenciesData, err := json.Marshal(ar.Dependencies)
+		if err != nil {
+			return alertRule{}, fmt.Errorf("failed to marshal dependencies: %w", err)
+		}
+		result.Dependencies = string(dependenciesData)
+	}
+
 	metadata, err := json.Marshal(ar.Metadata)
 	if err != nil {
 		return alertRule{}, fmt.Errorf("failed to metadata: %w", err)
@@ -211,6 +226,7 @@ func alertRuleToAlertRuleVersion(rule alertRule) alertRuleVersion {
 		NotificationSettings:        rule.NotificationSettings,
 		Metadata:                    rule.Metadata,
 		MissingSeriesEvalsToResolve: rule.MissingSeriesEvalsToResolve,
+		Dependencies:                rule.Dependencies,
 	}
 }
 
@@ -245,5 +261,6 @@ func alertRuleVersionToAlertRule(version alertRuleVersion) alertRule {
 		NotificationSettings:        version.NotificationSettings,
 		Metadata:                    version.Metadata,
 		MissingSeriesEvalsToResolve: version.MissingSeriesEvalsToResolve,
+		Dependencies:                version.Dependencies,
 	}
 }
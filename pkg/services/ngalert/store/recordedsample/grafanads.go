@@ -0,0 +1,39 @@
+package recordedsample
+
+import (
+	"context"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/tsdb/grafanads"
+)
+
+var _ grafanads.RecordedSampleReader = (*GrafanaDSReader)(nil)
+
+// GrafanaDSReader reads the recorded samples for the queries of the Grafana data source.
+type GrafanaDSReader struct {
+	store *Store
+}
+
+func ProvideGrafanaDSReader(sqlStore db.DB) *GrafanaDSReader {
+	return &GrafanaDSReader{store: NewStore(sqlStore)}
+}
+
+func (r *GrafanaDSReader) GetRecordedSamples(ctx context.Context, query grafanads.RecordedSamplesQuery) ([]grafanads.RecordedSample, error) {
+	samples, err := r.store.GetRecordedSamples(ctx, models.GetRecordedSamplesQuery{
+		OrgID:  query.OrgID,
+		Metric: query.Metric,
+		Labels: query.Labels,
+		From:   query.From,
+		To:     query.To,
+		Limit:  query.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]grafanads.RecordedSample, 0, len(samples))
+	for _, s := range samples {
+		result = append(result, grafanads.RecordedSample{Labels: s.Labels, Time: s.Time, Value: s.Value})
+	}
+	return result, nil
+}
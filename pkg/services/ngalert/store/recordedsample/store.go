@@ -0,0 +1,171 @@
+// Package recordedsample stores the samples of recording rules that write to the Grafana database.
+package recordedsample
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// recordedSample represents a record in alert_rule_recorded_sample table
+type recordedSample struct {
+	ID         int64   `xorm:"pk autoincr 'id'"`
+	OrgID      int64   `xorm:"org_id"`
+	Metric     string  `xorm:"metric"`
+	Labels     string  `xorm:"labels"`
+	LabelsHash string  `xorm:"labels_hash"`
+	SampleTime int64   `xorm:"sample_time"`
+	Value      float64 `xorm:"value"`
+}
+
+func (s recordedSample) TableName() string {
+	return "alert_rule_recorded_sample"
+}
+
+// Store reads and writes the samples in the alert_rule_recorded_sample table.
+type Store struct {
+	SQLStore db.DB
+}
+
+func NewStore(sqlStore db.DB) *Store {
+	return &Store{SQLStore: sqlStore}
+}
+
+// SaveRecordedSamples saves the samples of recording rules that write to the Grafana database.
+func (st *Store) SaveRecordedSamples(ctx context.Context, samples []models.RecordedSample) error {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	rows := make([]recordedSample, 0, len(samples))
+	for _, s := range samples {
+		labels := models.InstanceLabels(s.Labels)
+		labelsString, hash, err := labels.StringAndHash()
+		if err != nil {
+			return err
+		}
+		rows = append(rows, recordedSample{
+			OrgID:      s.OrgID,
+			Metric:     s.Metric,
+			Labels:     labelsString,
+			LabelsHash: hash,
+			SampleTime: s.Time.UnixMilli(),
+			Value:      s.Value,
+		})
+	}
+
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		if _, err := sess.Insert(&rows); err != nil {
+			return fmt.Errorf("failed to save recorded samples: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetRecordedSamples returns the samples of a metric written to the Grafana database, ordered by time.
+func (st *Store) GetRecordedSamples(ctx context.Context, query models.GetRecordedSamplesQuery) ([]models.RecordedSample, error) {
+	result := make([]models.RecordedSample, 0)
+	for offset := 0; ; {
+		rows, err := st.findRecordedSamples(ctx, query, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get recorded samples: %w", err)
+		}
+		for _, row := range rows {
+			var labels models.InstanceLabels
+			if err := labels.FromDB([]byte(row.Labels)); err != nil {
+				return nil, fmt.Errorf("failed to read labels of recorded sample: %w", err)
+			}
+			// LIKE is case-insensitive in some databases, so the labels are compared again.
+			if !hasLabels(labels, query.Labels) {
+				continue
+			}
+			if query.Limit > 0 && len(result) >= query.Limit {
+				break
+			}
+			result = append(result, models.RecordedSample{
+				OrgID:  row.OrgID,
+				Metric: row.Metric,
+				Labels: data.Labels(labels),
+				Time:   time.UnixMilli(row.SampleTime),
+				Value:  row.Value,
+			})
+		}
+		// The next rows are only needed if rows of series that differ in case were left out of a full page.
+		offset += len(rows)
+		if query.Limit <= 0 || len(rows) < query.Limit || len(result) >= query.Limit {
+			break
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Time.Before(result[j].Time)
+	})
+	return result, nil
+}
+
+// findRecordedSamples returns a page of the latest rows of the query, starting at the offset. Labels are matched in
+// the labels column, which has the JSON encoded label pairs of a sample.
+func (st *Store) findRecordedSamples(ctx context.Context, query models.GetRecordedSamplesQuery, offset int) ([]recordedSample, error) {
+	var rows []recordedSample
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		q := sess.Table(recordedSample{}).
+			Where("org_id = ? AND metric = ?", query.OrgID, query.Metric)
+		if !query.From.IsZero() {
+			q = q.And("sample_time >= ?", query.From.UnixMilli())
+		}
+		if !query.To.IsZero() {
+			q = q.And("sample_time <= ?", query.To.UnixMilli())
+		}
+		for _, name := range slices.Sorted(maps.Keys(query.Labels)) {
+			pair, err := json.Marshal([2]string{name, query.Labels[name]})
+			if err != nil {
+				return err
+			}
+			filter, param := st.SQLStore.GetDialect().LikeOperator("labels", true, likeEscaper.Replace(string(pair)), true)
+			q = q.And(filter+" ESCAPE '!'", param)
+		}
+		q = q.Desc("sample_time", "id")
+		if query.Limit > 0 {
+			q = q.Limit(query.Limit, offset)
+		}
+		return q.Find(&rows)
+	})
+	return rows, err
+}
+
+// DeleteRecordedSamples deletes the samples of the metric written to the Grafana database before the given time.
+// It returns the number of deleted samples.
+func (st *Store) DeleteRecordedSamples(ctx context.Context, orgID int64, metric string, before time.Time) (int64, error) {
+	var deleted int64
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		n, err := sess.Where("org_id = ? AND metric = ? AND sample_time < ?", orgID, metric, before.UnixMilli()).Delete(&recordedSample{})
+		if err != nil {
+			return fmt.Errorf("failed to delete recorded samples: %w", err)
+		}
+		deleted = n
+		return nil
+	})
+	return deleted, err
+}
+
+// likeEscaper escapes the wildcards of LIKE patterns with '!', which, unlike a backslash, needs no escaping in the string
+// literals of any of the supported databases.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+func hasLabels(labels models.InstanceLabels, subset map[string]string) bool {
+	for k, v := range subset {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,112 @@
+package recordedsample_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/store/recordedsample"
+	"github.com/grafana/grafana/pkg/tests/testsuite"
+)
+
+func TestMain(m *testing.M) {
+	testsuite.Run(m)
+}
+
+func TestIntegrationStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	dbstore := recordedsample.NewStore(db.InitTestDB(t))
+
+	start := time.UnixMilli(1700000000000)
+	var samples []models.RecordedSample
+	for i := 0; i < 3; i++ {
+		ts := start.Add(time.Duration(i) * time.Minute)
+		samples = append(samples,
+			models.RecordedSample{OrgID: 1, Metric: "requests", Labels: data.Labels{"job": "a"}, Time: ts, Value: float64(i)},
+			models.RecordedSample{OrgID: 1, Metric: "requests", Labels: data.Labels{"job": "b"}, Time: ts, Value: float64(10 + i)},
+			models.RecordedSample{OrgID: 1, Metric: "errors", Labels: data.Labels{"job": "a"}, Time: ts, Value: 1},
+			models.RecordedSample{OrgID: 2, Metric: "requests", Labels: data.Labels{"job": "a"}, Time: ts, Value: 1},
+		)
+	}
+	require.NoError(t, dbstore.SaveRecordedSamples(ctx, samples))
+
+	t.Run("returns the samples of the metric in the organization ordered by time", func(t *testing.T) {
+		result, err := dbstore.GetRecordedSamples(ctx, models.GetRecordedSamplesQuery{OrgID: 1, Metric: "requests"})
+		require.NoError(t, err)
+		require.Len(t, result, 6)
+		for i := 1; i < len(result); i++ {
+			require.False(t, result[i].Time.Before(result[i-1].Time))
+		}
+		require.Equal(t, start, result[0].Time)
+	})
+
+	t.Run("filters by labels and time range", func(t *testing.T) {
+		result, err := dbstore.GetRecordedSamples(ctx, models.GetRecordedSamplesQuery{
+			OrgID:  1,
+			Metric: "requests",
+			Labels: data.Labels{"job": "b"},
+			From:   start.Add(time.Minute),
+			To:     start.Add(2 * time.Minute),
+		})
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		require.Equal(t, 11.0, result[0].Value)
+		require.Equal(t, 12.0, result[1].Value)
+		require.Equal(t, data.Labels{"job": "b"}, result[0].Labels)
+	})
+
+	t.Run("returns the latest samples if the limit is exceeded", func(t *testing.T) {
+		result, err := dbstore.GetRecordedSamples(ctx, models.GetRecordedSamplesQuery{OrgID: 1, Metric: "requests", Labels: data.Labels{"job": "a"}, Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		require.Equal(t, 1.0, result[0].Value)
+		require.Equal(t, 2.0, result[1].Value)
+	})
+
+	t.Run("matches labels exactly", func(t *testing.T) {
+		var other []models.RecordedSample
+		for i := 0; i < 3; i++ {
+			ts := start.Add(time.Duration(i) * time.Minute)
+			other = append(other,
+				models.RecordedSample{OrgID: 1, Metric: "latency", Labels: data.Labels{"job": "a_1", "env": "prod"}, Time: ts, Value: float64(i)},
+				models.RecordedSample{OrgID: 1, Metric: "latency", Labels: data.Labels{"job": "A_1", "env": "prod"}, Time: ts.Add(time.Second), Value: float64(10 + i)},
+				models.RecordedSample{OrgID: 1, Metric: "latency", Labels: data.Labels{"job": "ab1", "env": "prod"}, Time: ts.Add(time.Second), Value: float64(20 + i)},
+			)
+		}
+		require.NoError(t, dbstore.SaveRecordedSamples(ctx, other))
+
+		result, err := dbstore.GetRecordedSamples(ctx, models.GetRecordedSamplesQuery{OrgID: 1, Metric: "latency", Labels: data.Labels{"job": "a_1"}, Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		require.Equal(t, 1.0, result[0].Value)
+		require.Equal(t, 2.0, result[1].Value)
+		require.Equal(t, data.Labels{"job": "a_1", "env": "prod"}, result[0].Labels)
+
+		result, err = dbstore.GetRecordedSamples(ctx, models.GetRecordedSamplesQuery{OrgID: 1, Metric: "latency", Labels: data.Labels{"job": "a%"}})
+		require.NoError(t, err)
+		require.Empty(t, result)
+	})
+
+	t.Run("deletes the samples of the metric before the given time", func(t *testing.T) {
+		deleted, err := dbstore.DeleteRecordedSamples(ctx, 1, "requests", start.Add(2*time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, int64(4), deleted)
+
+		result, err := dbstore.GetRecordedSamples(ctx, models.GetRecordedSamplesQuery{OrgID: 1, Metric: "requests"})
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+
+		result, err = dbstore.GetRecordedSamples(ctx, models.GetRecordedSamplesQuery{OrgID: 1, Metric: "errors"})
+		require.NoError(t, err)
+		require.Len(t, result, 3)
+	})
+}
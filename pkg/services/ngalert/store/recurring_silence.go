@@ -0,0 +1,288 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// recurringSilence represents a record in alert_recurring_silence table.
+// Deleted recurring silences are kept until the silence created for them is expired.
+type recurringSilence struct {
+	ID             int64  `xorm:"pk autoincr 'id'"`
+	OrgID          int64  `xorm:"org_id"`
+	UID            string `xorm:"uid"`
+	Matchers       string `xorm:"matchers"`
+	Comment        string `xorm:"comment"`
+	CreatedBy      string `xorm:"created_by"`
+	Schedule       string `xorm:"schedule"`
+	Version        int64  `xorm:"'version'"`
+	Updated        time.Time
+	Deleted        bool   `xorm:"'deleted'"`
+	SilenceID      string `xorm:"silence_id"`
+	WindowStartsAt int64  `xorm:"window_starts_at"`
+	WindowEndsAt   int64  `xorm:"window_ends_at"`
+	WindowVersion  int64  `xorm:"window_version"`
+}
+
+func (s recurringSilence) TableName() string {
+	return "alert_recurring_silence"
+}
+
+// ListRecurringSilences returns the recurring silences of the organization ordered by UID. If orgID is 0 then the
+// recurring silences of all organizations are returned.
+func (st DBstore) ListRecurringSilences(ctx context.Context, orgID int64) ([]*models.RecurringSilence, error) {
+	var result []*models.RecurringSilence
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		q := sess.Where("deleted = ?", false)
+		if orgID > 0 {
+			q = q.And("org_id = ?", orgID)
+		}
+		var rows []recurringSilence
+		if err := q.Asc("org_id", "uid").Find(&rows); err != nil {
+			return err
+		}
+		result = make([]*models.RecurringSilence, 0, len(rows))
+		for _, row := range rows {
+			s, err := recurringSilenceToModel(row)
+			if err != nil {
+				return err
+			}
+			result = append(result, s)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// ListDeletedRecurringSilences returns the recurring silences of all organizations that were deleted but not purged.
+func (st DBstore) ListDeletedRecurringSilences(ctx context.Context) ([]*models.RecurringSilence, error) {
+	var result []*models.RecurringSilence
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		var rows []recurringSilence
+		if err := sess.Where("deleted = ?", true).Find(&rows); err != nil {
+			return err
+		}
+		result = make([]*models.RecurringSilence, 0, len(rows))
+		for _, row := range rows {
+			s, err := recurringSilenceToModel(row)
+			if err != nil {
+				return err
+			}
+			result = append(result, s)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// GetRecurringSilence returns the recurring silence with the UID or ErrRecurringSilenceNotFound.
+func (st DBstore) GetRecurringSilence(ctx context.Context, orgID int64, uid string) (*models.RecurringSilence, error) {
+	var result *models.RecurringSilence
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		row := recurringSilence{}
+		has, err := sess.Where("org_id = ? AND uid = ? AND deleted = ?", orgID, uid, false).Get(&row)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return models.ErrRecurringSilenceNotFound.Errorf("")
+		}
+		result, err = recurringSilenceToModel(row)
+		return err
+	})
+	return result, err
+}
+
+// InsertRecurringSilence creates the recurring silence and sets its version. If a deleted recurring silence with
+// the same UID was not purged yet, it is replaced and the silence created for it is expired later.
+func (st DBstore) InsertRecurringSilence(ctx context.Context, s *models.RecurringSilence) error {
+	row, err := recurringSilenceFromModel(s)
+	if err != nil {
+		return err
+	}
+	return st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		existing := recurringSilence{}
+		has, err := sess.Where("org_id = ? AND uid = ?", s.OrgID, s.UID).Get(&existing)
+		if err != nil {
+			return err
+		}
+		if has && !existing.Deleted {
+			return models.ErrRecurringSilenceExists.Errorf("")
+		}
+		row.Updated = TimeNow().UTC()
+		if !has {
+			row.Version = 1
+			if _, err := sess.Insert(&row); err != nil {
+				return fmt.Errorf("failed to insert recurring silence: %w", err)
+			}
+		} else {
+			row.Version = existing.Version + 1
+			if err := updateRecurringSilenceRow(sess, row, existing.Version, true); err != nil {
+				return err
+			}
+		}
+		s.Version = row.Version
+		s.Updated = row.Updated
+		return nil
+	})
+}
+
+// UpdateRecurringSilence updates the recurring silence if its version matches the stored version, and increments
+// the version.
+func (st DBstore) UpdateRecurringSilence(ctx context.Context, s *models.RecurringSilence) error {
+	row, err := recurringSilenceFromModel(s)
+	if err != nil {
+		return err
+	}
+	return st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		existing := recurringSilence{}
+		has, err := sess.Where("org_id = ? AND uid = ? AND deleted = ?", s.OrgID, s.UID, false).Get(&existing)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return models.ErrRecurringSilenceNotFound.Errorf("")
+		}
+		if existing.Version != s.Version {
+			return models.MakeErrRecurringSilenceConflict(s.UID, s.Version, existing.Version)
+		}
+		row.Version = existing.Version + 1
+		row.Updated = TimeNow().UTC()
+		if err := updateRecurringSilenceRow(sess, row, existing.Version, false); err != nil {
+			return err
+		}
+		s.Version = row.Version
+		s.Updated = row.Updated
+		return nil
+	})
+}
+
+func updateRecurringSilenceRow(sess *db.Session, row recurringSilence, version int64, deleted bool) error {
+	res, err := sess.Exec(
+		"UPDATE alert_recurring_silence SET matchers = ?, comment = ?, created_by = ?, schedule = ?, version = ?, updated = ?, deleted = ? WHERE org_id = ? AND uid = ? AND version = ? AND deleted = ?",
+		row.Matchers, row.Comment, row.CreatedBy, row.Schedule, row.Version, row.Updated, false, row.OrgID, row.UID, version, deleted,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update recurring silence: %w", err)
+	}
+	if affected, err := res.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return fmt.Errorf("recurring silence %s was changed concurrently", row.UID)
+	}
+	return nil
+}
+
+// DeleteRecurringSilence marks the recurring silence as deleted. It is purged after the silence created for it
+// is expired.
+func (st DBstore) DeleteRecurringSilence(ctx context.Context, orgID int64, uid string) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		res, err := sess.Exec(
+			"UPDATE alert_recurring_silence SET deleted = ?, version = version + 1, updated = ? WHERE org_id = ? AND uid = ? AND deleted = ?",
+			true, TimeNow().UTC(), orgID, uid, false,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to delete recurring silence: %w", err)
+		}
+		if affected, err := res.RowsAffected(); err != nil {
+			return err
+		} else if affected == 0 {
+			return models.ErrRecurringSilenceNotFound.Errorf("")
+		}
+		return nil
+	})
+}
+
+// PurgeRecurringSilence removes the deleted recurring silence if it was not changed since the given version.
+func (st DBstore) PurgeRecurringSilence(ctx context.Context, orgID int64, uid string, version int64) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Exec(
+			"DELETE FROM alert_recurring_silence WHERE org_id = ? AND uid = ? AND version = ? AND deleted = ?",
+			orgID, uid, version, true,
+		)
+		return err
+	})
+}
+
+// UpdateRecurringSilenceWindow saves the window for which a silence was created. The window is only saved if the
+// recurring silence still has the version of the window and the silence of the previous window is still the one
+// that was given, so that only one silence is created for a window when several Grafana instances run concurrently.
+// It returns false if the window was not saved.
+func (st DBstore) UpdateRecurringSilenceWindow(ctx context.Context, orgID int64, uid string, previousSilenceID string, window models.RecurringSilenceWindow) (bool, error) {
+	var updated bool
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		res, err := sess.Exec(
+			"UPDATE alert_recurring_silence SET silence_id = ?, window_starts_at = ?, window_ends_at = ?, window_version = ? WHERE org_id = ? AND uid = ? AND version = ? AND silence_id = ?",
+			window.SilenceID, window.StartsAt.UnixMilli(), window.EndsAt.UnixMilli(), window.Version, orgID, uid, window.Version, previousSilenceID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update window of recurring silence: %w", err)
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		updated = affected > 0
+		return nil
+	})
+	return updated, err
+}
+
+func recurringSilenceFromModel(s *models.RecurringSilence) (recurringSilence, error) {
+	matchers, err := json.Marshal(s.Matchers)
+	if err != nil {
+		return recurringSilence{}, fmt.Errorf("failed to marshal matchers: %w", err)
+	}
+	schedule, err := json.Marshal(s.Schedule)
+	if err != nil {
+		return recurringSilence{}, fmt.Errorf("failed to marshal schedule: %w", err)
+	}
+	return recurringSilence{
+		OrgID:     s.OrgID,
+		UID:       s.UID,
+		Matchers:  string(matchers),
+		Comment:   s.Comment,
+		CreatedBy: s.CreatedBy,
+		Schedule:  string(schedule),
+		Version:   s.Version,
+	}, nil
+}
+
+func recurringSilenceToModel(row recurringSilence) (*models.RecurringSilence, error) {
+	var matchers amv2.Matchers
+	if err := json.Unmarshal([]byte(row.Matchers), &matchers); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal matchers of recurring silence %s: %w", row.UID, err)
+	}
+	var schedule models.RecurringSilenceSchedule
+	if err := json.Unmarshal([]byte(row.Schedule), &schedule); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal schedule of recurring silence %s: %w", row.UID, err)
+	}
+	s := &models.RecurringSilence{
+		UID:       row.UID,
+		OrgID:     row.OrgID,
+		Matchers:  matchers,
+		Comment:   row.Comment,
+		CreatedBy: row.CreatedBy,
+		Schedule:  schedule,
+		Version:   row.Version,
+		Updated:   row.Updated,
+		Window: models.RecurringSilenceWindow{
+			SilenceID: row.SilenceID,
+			Version:   row.WindowVersion,
+		},
+	}
+	if row.WindowStartsAt > 0 {
+		s.Window.StartsAt = time.UnixMilli(row.WindowStartsAt).UTC()
+	}
+	if row.WindowEndsAt > 0 {
+		s.Window.EndsAt = time.UnixMilli(row.WindowEndsAt).UTC()
+	}
+	return s, nil
+}
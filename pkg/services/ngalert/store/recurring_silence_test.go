@@ -0,0 +1,145 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/tests"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+func TestIntegrationRecurringSilences(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	_, dbStore := tests.SetupTestEnv(t, testAlertingIntervalSeconds)
+
+	silence := func(uid string) *models.RecurringSilence {
+		return &models.RecurringSilence{
+			UID:       uid,
+			OrgID:     1,
+			Matchers:  amv2.Matchers{{Name: util.Pointer("env"), Value: util.Pointer("staging"), IsEqual: util.Pointer(true), IsRegex: util.Pointer(false)}},
+			Comment:   "nightly maintenance",
+			CreatedBy: "admin",
+			Schedule:  models.RecurringSilenceSchedule{Cron: "CRON_TZ=Europe/Berlin 0 22 * * *", Duration: 8 * time.Hour},
+		}
+	}
+
+	t.Run("insert and get", func(t *testing.T) {
+		s := silence("insert")
+		require.NoError(t, dbStore.InsertRecurringSilence(ctx, s))
+		assert.EqualValues(t, 1, s.Version)
+
+		stored, err := dbStore.GetRecurringSilence(ctx, 1, "insert")
+		require.NoError(t, err)
+		assert.Equal(t, s.Matchers, stored.Matchers)
+		assert.Equal(t, s.Schedule, stored.Schedule)
+		assert.Equal(t, s.Comment, stored.Comment)
+		assert.EqualValues(t, 1, stored.Version)
+
+		_, err = dbStore.GetRecurringSilence(ctx, 2, "insert")
+		require.ErrorIs(t, err, models.ErrRecurringSilenceNotFound)
+
+		err = dbStore.InsertRecurringSilence(ctx, silence("insert"))
+		require.ErrorIs(t, err, models.ErrRecurringSilenceExists)
+	})
+
+	t.Run("update checks version", func(t *testing.T) {
+		s := silence("update")
+		require.NoError(t, dbStore.InsertRecurringSilence(ctx, s))
+
+		s.Comment = "changed"
+		require.NoError(t, dbStore.UpdateRecurringSilence(ctx, s))
+		assert.EqualValues(t, 2, s.Version)
+
+		s.Version = 1
+		err := dbStore.UpdateRecurringSilence(ctx, s)
+		require.ErrorIs(t, err, models.ErrRecurringSilenceConflict)
+
+		stored, err := dbStore.GetRecurringSilence(ctx, 1, "update")
+		require.NoError(t, err)
+		assert.Equal(t, "changed", stored.Comment)
+		assert.EqualValues(t, 2, stored.Version)
+	})
+
+	t.Run("window is saved only for the current version and previous silence", func(t *testing.T) {
+		s := silence("window")
+		require.NoError(t, dbStore.InsertRecurringSilence(ctx, s))
+		window := models.RecurringSilenceWindow{
+			SilenceID: "silence-1",
+			StartsAt:  time.Date(2024, 3, 4, 21, 0, 0, 0, time.UTC),
+			EndsAt:    time.Date(2024, 3, 5, 5, 0, 0, 0, time.UTC),
+			Version:   s.Version,
+		}
+
+		saved, err := dbStore.UpdateRecurringSilenceWindow(ctx, 1, "window", "", window)
+		require.NoError(t, err)
+		require.True(t, saved)
+
+		// Another instance created a silence for the same window.
+		duplicate := window
+		duplicate.SilenceID = "silence-2"
+		saved, err = dbStore.UpdateRecurringSilenceWindow(ctx, 1, "window", "", duplicate)
+		require.NoError(t, err)
+		require.False(t, saved)
+
+		stale := window
+		stale.Version = s.Version + 1
+		saved, err = dbStore.UpdateRecurringSilenceWindow(ctx, 1, "window", "silence-1", stale)
+		require.NoError(t, err)
+		require.False(t, saved)
+
+		stored, err := dbStore.GetRecurringSilence(ctx, 1, "window")
+		require.NoError(t, err)
+		assert.Equal(t, window, stored.Window)
+	})
+
+	t.Run("deleted recurring silence is kept until purged", func(t *testing.T) {
+		s := silence("delete")
+		require.NoError(t, dbStore.InsertRecurringSilence(ctx, s))
+		require.NoError(t, dbStore.DeleteRecurringSilence(ctx, 1, "delete"))
+
+		_, err := dbStore.GetRecurringSilence(ctx, 1, "delete")
+		require.ErrorIs(t, err, models.ErrRecurringSilenceNotFound)
+		require.ErrorIs(t, dbStore.DeleteRecurringSilence(ctx, 1, "delete"), models.ErrRecurringSilenceNotFound)
+
+		all, err := dbStore.ListRecurringSilences(ctx, 0)
+		require.NoError(t, err)
+		for _, s := range all {
+			assert.NotEqual(t, "delete", s.UID)
+		}
+
+		deleted, err := dbStore.ListDeletedRecurringSilences(ctx)
+		require.NoError(t, err)
+		require.Len(t, deleted, 1)
+		assert.EqualValues(t, 2, deleted[0].Version)
+
+		t.Run("inserting with the same UID replaces it", func(t *testing.T) {
+			s := silence("delete")
+			require.NoError(t, dbStore.InsertRecurringSilence(ctx, s))
+			assert.EqualValues(t, 3, s.Version)
+
+			deleted, err := dbStore.ListDeletedRecurringSilences(ctx)
+			require.NoError(t, err)
+			require.Empty(t, deleted)
+		})
+
+		t.Run("purge removes it", func(t *testing.T) {
+			require.NoError(t, dbStore.DeleteRecurringSilence(ctx, 1, "delete"))
+			require.NoError(t, dbStore.PurgeRecurringSilence(ctx, 1, "delete", 4))
+
+			deleted, err := dbStore.ListDeletedRecurringSilences(ctx)
+			require.NoError(t, err)
+			require.Empty(t, deleted)
+			require.NoError(t, dbStore.InsertRecurringSilence(ctx, silence("delete")))
+		})
+	})
+}
@@ -0,0 +1,188 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// ruleTemplate represents a record in alert_rule_template table.
+type ruleTemplate struct {
+	ID         int64  `xorm:"pk autoincr 'id'"`
+	OrgID      int64  `xorm:"org_id"`
+	UID        string `xorm:"uid"`
+	Title      string `xorm:"title"`
+	Parameters string `xorm:"parameters"`
+	Rule       string `xorm:"rule_definition"`
+	Instances  string `xorm:"instances"`
+	Version    int64  `xorm:"'version'"`
+	Updated    time.Time
+}
+
+func (t ruleTemplate) TableName() string {
+	return "alert_rule_template"
+}
+
+// ListRuleTemplates returns the rule templates of the organization ordered by UID.
+func (st DBstore) ListRuleTemplates(ctx context.Context, orgID int64) ([]*models.RuleTemplate, error) {
+	var result []*models.RuleTemplate
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		var rows []ruleTemplate
+		if err := sess.Where("org_id = ?", orgID).Asc("uid").Find(&rows); err != nil {
+			return err
+		}
+		result = make([]*models.RuleTemplate, 0, len(rows))
+		for _, row := range rows {
+			t, err := ruleTemplateToModel(row)
+			if err != nil {
+				return err
+			}
+			result = append(result, t)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// GetRuleTemplate returns the rule template with the UID or ErrRuleTemplateNotFound.
+func (st DBstore) GetRuleTemplate(ctx context.Context, orgID int64, uid string) (*models.RuleTemplate, error) {
+	var result *models.RuleTemplate
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		row := ruleTemplate{}
+		has, err := sess.Where("org_id = ? AND uid = ?", orgID, uid).Get(&row)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return models.ErrRuleTemplateNotFound.Errorf("")
+		}
+		result, err = ruleTemplateToModel(row)
+		return err
+	})
+	return result, err
+}
+
+// InsertRuleTemplate creates the rule template and sets its version.
+func (st DBstore) InsertRuleTemplate(ctx context.Context, t *models.RuleTemplate) error {
+	row, err := ruleTemplateFromModel(t)
+	if err != nil {
+		return err
+	}
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		exists, err := sess.Where("org_id = ? AND uid = ?", t.OrgID, t.UID).Exist(&ruleTemplate{})
+		if err != nil {
+			return err
+		}
+		if exists {
+			return models.ErrRuleTemplateExists.Errorf("")
+		}
+		row.Version = 1
+		row.Updated = TimeNow().UTC()
+		if _, err := sess.Insert(&row); err != nil {
+			return fmt.Errorf("failed to insert rule template: %w", err)
+		}
+		t.Version = row.Version
+		t.Updated = row.Updated
+		return nil
+	})
+}
+
+// UpdateRuleTemplate updates the rule template if its version matches the stored version, and increments
+// the version.
+func (st DBstore) UpdateRuleTemplate(ctx context.Context, t *models.RuleTemplate) error {
+	row, err := ruleTemplateFromModel(t)
+	if err != nil {
+		return err
+	}
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		row.Version = t.Version + 1
+		row.Updated = TimeNow().UTC()
+		res, err := sess.Exec(
+			"UPDATE alert_rule_template SET title = ?, parameters = ?, rule_definition = ?, instances = ?, version = ?, updated = ? WHERE org_id = ? AND uid = ? AND version = ?",
+			row.Title, row.Parameters, row.Rule, row.Instances, row.Version, row.Updated, row.OrgID, row.UID, t.Version,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update rule template: %w", err)
+		}
+		if affected, err := res.RowsAffected(); err != nil {
+			return err
+		} else if affected == 0 {
+			existing := ruleTemplate{}
+			has, err := sess.Where("org_id = ? AND uid = ?", t.OrgID, t.UID).Get(&existing)
+			if err != nil {
+				return err
+			}
+			if !has {
+				return models.ErrRuleTemplateNotFound.Errorf("")
+			}
+			return models.MakeErrRuleTemplateConflict(t.UID, t.Version, existing.Version)
+		}
+		t.Version = row.Version
+		t.Updated = row.Updated
+		return nil
+	})
+}
+
+// DeleteRuleTemplate removes the rule template. It does not delete the rules created from it.
+func (st DBstore) DeleteRuleTemplate(ctx context.Context, orgID int64, uid string) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		res, err := sess.Exec("DELETE FROM alert_rule_template WHERE org_id = ? AND uid = ?", orgID, uid)
+		if err != nil {
+			return fmt.Errorf("failed to delete rule template: %w", err)
+		}
+		if affected, err := res.RowsAffected(); err != nil {
+			return err
+		} else if affected == 0 {
+			return models.ErrRuleTemplateNotFound.Errorf("")
+		}
+		return nil
+	})
+}
+
+func ruleTemplateFromModel(t *models.RuleTemplate) (ruleTemplate, error) {
+	parameters, err := json.Marshal(t.Parameters)
+	if err != nil {
+		return ruleTemplate{}, fmt.Errorf("failed to marshal parameters: %w", err)
+	}
+	rule, err := json.Marshal(t.Rule)
+	if err != nil {
+		return ruleTemplate{}, fmt.Errorf("failed to marshal rule: %w", err)
+	}
+	instances, err := json.Marshal(t.Instances)
+	if err != nil {
+		return ruleTemplate{}, fmt.Errorf("failed to marshal instances: %w", err)
+	}
+	return ruleTemplate{
+		OrgID:      t.OrgID,
+		UID:        t.UID,
+		Title:      t.Title,
+		Parameters: string(parameters),
+		Rule:       string(rule),
+		Instances:  string(instances),
+		Version:    t.Version,
+	}, nil
+}
+
+func ruleTemplateToModel(row ruleTemplate) (*models.RuleTemplate, error) {
+	t := &models.RuleTemplate{
+		UID:     row.UID,
+		OrgID:   row.OrgID,
+		Title:   row.Title,
+		Version: row.Version,
+		Updated: row.Updated,
+	}
+	if err := json.Unmarshal([]byte(row.Parameters), &t.Parameters); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal parameters of rule template %s: %w", row.UID, err)
+	}
+	if err := json.Unmarshal([]byte(row.Rule), &t.Rule); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rule of rule template %s: %w", row.UID, err)
+	}
+	if err := json.Unmarshal([]byte(row.Instances), &t.Instances); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal instances of rule template %s: %w", row.UID, err)
+	}
+	return t, nil
+}
@@ -0,0 +1,106 @@
+package store_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/tests"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+func TestIntegrationRuleTemplates(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	_, dbStore := tests.SetupTestEnv(t, testAlertingIntervalSeconds)
+
+	template := func(uid string) *models.RuleTemplate {
+		return &models.RuleTemplate{
+			UID:        uid,
+			OrgID:      1,
+			Title:      "CPU usage",
+			Parameters: []models.RuleTemplateParameter{{Name: "cluster", Description: "Cluster name"}, {Name: "threshold", Default: util.Pointer("80")}},
+			Rule: models.RuleTemplateRule{
+				Title:     "High CPU usage on ${cluster}",
+				Condition: "A",
+				Data: []models.AlertQuery{{
+					RefID:         "A",
+					DatasourceUID: "__expr__",
+					Model:         json.RawMessage(`{"expression":"$A + ${threshold}","type":"math"}`),
+				}},
+				NoDataState:  models.NoData,
+				ExecErrState: models.ErrorErrState,
+				For:          time.Minute,
+				Labels:       map[string]string{"cluster": "${cluster}"},
+			},
+			Instances: []models.RuleTemplateInstance{
+				{RuleUID: "cpu-eu", FolderUID: "folder", RuleGroup: "group", Parameters: map[string]string{"cluster": "eu"}},
+			},
+		}
+	}
+
+	t.Run("insert and get", func(t *testing.T) {
+		tmpl := template("insert")
+		require.NoError(t, dbStore.InsertRuleTemplate(ctx, tmpl))
+		assert.EqualValues(t, 1, tmpl.Version)
+
+		stored, err := dbStore.GetRuleTemplate(ctx, 1, "insert")
+		require.NoError(t, err)
+		assert.Equal(t, tmpl.Title, stored.Title)
+		assert.Equal(t, tmpl.Parameters, stored.Parameters)
+		assert.Equal(t, tmpl.Rule, stored.Rule)
+		assert.Equal(t, tmpl.Instances, stored.Instances)
+		assert.EqualValues(t, 1, stored.Version)
+
+		_, err = dbStore.GetRuleTemplate(ctx, 2, "insert")
+		require.ErrorIs(t, err, models.ErrRuleTemplateNotFound)
+
+		err = dbStore.InsertRuleTemplate(ctx, template("insert"))
+		require.ErrorIs(t, err, models.ErrRuleTemplateExists)
+	})
+
+	t.Run("update checks version", func(t *testing.T) {
+		tmpl := template("update")
+		require.NoError(t, dbStore.InsertRuleTemplate(ctx, tmpl))
+
+		tmpl.Title = "changed"
+		require.NoError(t, dbStore.UpdateRuleTemplate(ctx, tmpl))
+		assert.EqualValues(t, 2, tmpl.Version)
+
+		tmpl.Version = 1
+		err := dbStore.UpdateRuleTemplate(ctx, tmpl)
+		require.ErrorIs(t, err, models.ErrRuleTemplateConflict)
+
+		stored, err := dbStore.GetRuleTemplate(ctx, 1, "update")
+		require.NoError(t, err)
+		assert.Equal(t, "changed", stored.Title)
+		assert.EqualValues(t, 2, stored.Version)
+
+		err = dbStore.UpdateRuleTemplate(ctx, template("missing"))
+		require.ErrorIs(t, err, models.ErrRuleTemplateNotFound)
+	})
+
+	t.Run("list and delete", func(t *testing.T) {
+		templates, err := dbStore.ListRuleTemplates(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, templates, 2)
+		assert.Equal(t, "insert", templates[0].UID)
+		assert.Equal(t, "update", templates[1].UID)
+
+		require.NoError(t, dbStore.DeleteRuleTemplate(ctx, 1, "insert"))
+		err = dbStore.DeleteRuleTemplate(ctx, 1, "insert")
+		require.ErrorIs(t, err, models.ErrRuleTemplateNotFound)
+
+		templates, err = dbStore.ListRuleTemplates(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, templates, 1)
+	})
+}
@@ -0,0 +1,92 @@
+package writer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/datasources"
+)
+
+// DatasourceReader runs PromQL queries against Prometheus data sources, usually the same
+// data sources the DatasourceWriter writes to.
+type DatasourceReader struct {
+	datasources           datasources.DataSourceService
+	httpClientProvider    HttpClientProvider
+	pluginContextProvider PluginContextProvider
+	l                     log.Logger
+}
+
+func NewDatasourceReader(
+	datasources datasources.DataSourceService,
+	httpClientProvider HttpClientProvider,
+	pluginContextProvider PluginContextProvider,
+	l log.Logger,
+) *DatasourceReader {
+	return &DatasourceReader{
+		datasources:           datasources,
+		httpClientProvider:    httpClientProvider,
+		pluginContextProvider: pluginContextProvider,
+		l:                     l,
+	}
+}
+
+// QueryRange evaluates the PromQL expression over the range [start, end] with the given resolution
+// against the Prometheus API of the data source.
+func (r *DatasourceReader) QueryRange(ctx context.Context, dsUID string, orgID int64, query string, start, end time.Time, step time.Duration) (model.Matrix, error) {
+	ds, err := r.datasources.GetDataSource(ctx, &datasources.GetDataSourceQuery{
+		UID:   dsUID,
+		OrgID: orgID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if ds.Type != datasources.DS_PROMETHEUS {
+		return nil, errors.New("can only query data sources of type prometheus")
+	}
+
+	ho, err := datasourceHTTPClientOptions(ctx, ds, r.datasources, r.pluginContextProvider, r.l)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient, err := r.httpClientProvider.New(ho)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := api.NewClient(api.Config{
+		Address: ds.URL,
+		Client:  httpClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.l.Debug("Querying Prometheus data source", "datasource_uid", dsUID, "org_id", orgID, "query", query, "start", start, "end", end, "step", step)
+
+	res, warnings, err := promv1.NewAPI(client).QueryRange(ctx, query, promv1.Range{
+		Start: start,
+		End:   end,
+		Step:  step,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query data source: %w", err)
+	}
+	if len(warnings) > 0 {
+		r.l.Warn("Query to Prometheus data source returned warnings", "datasource_uid", dsUID, "org_id", orgID, "warnings", warnings)
+	}
+
+	matrix, ok := res.(model.Matrix)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type %s, expected matrix", res.Type())
+	}
+	return matrix, nil
+}
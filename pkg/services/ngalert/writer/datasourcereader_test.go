@@ -0,0 +1,62 @@
+package writer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/httpclient"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	dsfakes "github.com/grafana/grafana/pkg/services/datasources/fakes"
+)
+
+func TestDatasourceReader(t *testing.T) {
+	var lastQuery, lastStep string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/prom/api/v1/query_range", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		lastQuery = r.Form.Get("query")
+		lastStep = r.Form.Get("step")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[{"metric":{"__name__":"ALERTS","alertstate":"firing"},"values":[[1000,"1"],[1015,"1"]]}]}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	testDS := &dsfakes.FakeDataSourceService{}
+	p, _ := testDS.AddDataSource(context.Background(), &datasources.AddDataSourceCommand{
+		Name: "prom",
+		UID:  "prom",
+		Type: datasources.DS_PROMETHEUS,
+	})
+	p.URL = srv.URL + "/api/prom"
+	_, _ = testDS.AddDataSource(context.Background(), &datasources.AddDataSourceCommand{
+		Name: "loki",
+		UID:  "loki",
+		Type: datasources.DS_LOKI,
+	})
+
+	reader := NewDatasourceReader(testDS, httpclient.NewProvider(), &mockPluginContextProvider{}, log.New("test"))
+
+	t.Run("when querying a prometheus datasource then the matrix is returned", func(t *testing.T) {
+		res, err := reader.QueryRange(context.Background(), "prom", 1, `ALERTS{alertstate="firing"}`, time.Unix(1000, 0), time.Unix(1015, 0), 15*time.Second)
+		require.NoError(t, err)
+
+		require.Len(t, res, 1)
+		assert.Equal(t, model.LabelValue("firing"), res[0].Metric["alertstate"])
+		assert.Len(t, res[0].Values, 2)
+		assert.Equal(t, `ALERTS{alertstate="firing"}`, lastQuery)
+		assert.Equal(t, "15", lastStep)
+	})
+
+	t.Run("when querying a non-prometheus datasource then an error is returned", func(t *testing.T) {
+		_, err := reader.QueryRange(context.Background(), "loki", 1, "up", time.Unix(1000, 0), time.Unix(1015, 0), 15*time.Second)
+		require.EqualError(t, err, "can only query data sources of type prometheus")
+	})
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/datasources"
 	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
 	"github.com/grafana/grafana/pkg/services/pluginsintegration/adapters"
+	"github.com/grafana/grafana/pkg/tsdb/grafanads"
 )
 
 const (
@@ -51,6 +52,15 @@ type DatasourceWriterConfig struct {
 	// CustomHeaders is a map of optional custom HTTP headers
 	// to include in recording rule write requests.
 	CustomHeaders map[string]string
+
+	// SQLWriter writes the result of recording rules that target the Grafana data source
+	// to the Grafana database. If it is nil, the Grafana data source cannot be written to.
+	SQLWriter *SQLWriter
+}
+
+// datasourceTypeWriter writes the result of recording rules to a single data source.
+type datasourceTypeWriter interface {
+	Write(ctx context.Context, name string, t time.Time, frames data.Frames, orgID int64, extraLabels map[string]string) error
 }
 
 type PluginContextProvider interface {
@@ -90,12 +100,33 @@ func NewDatasourceWriter(
 	}
 }
 
-func (w *DatasourceWriter) decrypt(ds *datasources.DataSource) (map[string]string, error) {
-	decryptedJsonData, err := w.datasources.DecryptedValues(context.Background(), ds)
+// datasourceHTTPClientOptions returns the options of the HTTP client for requests to the data source.
+func datasourceHTTPClientOptions(ctx context.Context, ds *datasources.DataSource, dsService datasources.DataSourceService, pluginContextProvider PluginContextProvider, l log.Logger) (httpclient.Options, error) {
+	decrypt := func(ds *datasources.DataSource) (map[string]string, error) {
+		decryptedJsonData, err := dsService.DecryptedValues(context.Background(), ds)
+		if err != nil {
+			l.Error("Failed to decrypt secure json data", "error", err)
+		}
+		return decryptedJsonData, err
+	}
+	is, err := adapters.ModelToInstanceSettings(ds, decrypt)
 	if err != nil {
-		w.l.Error("Failed to decrypt secure json data", "error", err)
+		return httpclient.Options{}, err
+	}
+
+	httpClientCtx := ctx
+	if pluginContextProvider != nil {
+		pluginCtx, err := pluginContextProvider.GetWithDataSource(ctx, ds.Type, nil, ds)
+		if err != nil {
+			return httpclient.Options{}, fmt.Errorf("failed to get plugin context: %w", err)
+		}
+		httpClientCtx = backend.WithGrafanaConfig(ctx, pluginCtx.GrafanaConfig)
+	} else {
+		// This should not happen, but if the plugin context provider is not set, log a warning.
+		l.Warn("Plugin context provider is not set, PDC-enabled data sources may not work correctly", "datasource_uid", ds.UID, "datasource_type", ds.Type)
 	}
-	return decryptedJsonData, err
+
+	return is.HTTPClientOptions(httpClientCtx)
 }
 
 func getPrometheusType(ds *datasources.DataSource) string {
@@ -113,6 +144,14 @@ func getPrometheusType(ds *datasources.DataSource) string {
 	return str
 }
 
+// jsonDataString returns the string value of the key in the JSON data of the data source, or an empty string.
+func jsonDataString(ds *datasources.DataSource, key string) string {
+	if ds.JsonData == nil {
+		return ""
+	}
+	return ds.JsonData.Get(key).MustString()
+}
+
 func getRemoteWriteURL(ds *datasources.DataSource) (*url.URL, error) {
 	u, err := url.Parse(ds.URL)
 	if err != nil {
@@ -165,7 +204,7 @@ func getRemoteWriteURL(ds *datasources.DataSource) (*url.URL, error) {
 	return u, nil
 }
 
-func (w *DatasourceWriter) makeWriter(ctx context.Context, orgID int64, dsUID string) (*PrometheusWriter, error) {
+func (w *DatasourceWriter) makeWriter(ctx context.Context, orgID int64, dsUID string) (datasourceTypeWriter, error) {
 	ds, err := w.datasources.GetDataSource(ctx, &datasources.GetDataSourceQuery{
 		UID:   dsUID,
 		OrgID: orgID,
@@ -174,67 +213,62 @@ func (w *DatasourceWriter) makeWriter(ctx context.Context, orgID int64, dsUID st
 		return nil, err
 	}
 
-	if ds.Type != datasources.DS_PROMETHEUS {
-		return nil, errors.New("can only write to data sources of type prometheus")
+	switch ds.Type {
+	case datasources.DS_PROMETHEUS, datasources.DS_INFLUXDB, datasources.DS_LOKI:
+	default:
+		return nil, errors.New("can only write to data sources of type prometheus, influxdb or loki")
 	}
 
-	is, err := adapters.ModelToInstanceSettings(ds, w.decrypt)
+	ho, err := datasourceHTTPClientOptions(ctx, ds, w.datasources, w.pluginContextProvider, w.l)
 	if err != nil {
 		return nil, err
 	}
 
-	httpClientCtx := ctx
-	if w.pluginContextProvider != nil {
-		pluginCtx, err := w.pluginContextProvider.GetWithDataSource(ctx, ds.Type, nil, ds)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get plugin context: %w", err)
-		}
-		httpClientCtx = backend.WithGrafanaConfig(ctx, pluginCtx.GrafanaConfig)
-	} else {
-		// This should not happen, but if the plugin context provider is not set, log a warning.
-		w.l.Warn("Plugin context provider is not set for the data source writer, PDC-enabled data sources may not work correctly", "datasource_uid", dsUID, "datasource_type", ds.Type)
+	headers := make(http.Header)
+	for k, v := range w.cfg.CustomHeaders {
+		headers.Add(k, v)
 	}
 
-	ho, err := is.HTTPClientOptions(httpClientCtx)
-	if err != nil {
-		return nil, err
+	httpOptions := httpclient.Options{
+		Timeouts:     ho.Timeouts,
+		TLS:          ho.TLS,
+		BasicAuth:    ho.BasicAuth,
+		Header:       headers,
+		ProxyOptions: ho.ProxyOptions,
+	}
+
+	switch ds.Type {
+	case datasources.DS_INFLUXDB:
+		return w.makeInfluxDBWriter(ctx, ds, httpOptions)
+	case datasources.DS_LOKI:
+		return w.makeLokiWriter(ds, httpOptions)
+	default:
+		return w.makePrometheusWriter(ds, httpOptions)
 	}
+}
 
+func (w *DatasourceWriter) makePrometheusWriter(ds *datasources.DataSource, httpOptions httpclient.Options) (*PrometheusWriter, error) {
 	u, err := getRemoteWriteURL(ds)
 	if err != nil {
 		return nil, err
 	}
 
-	headers := make(http.Header)
-	for k, v := range w.cfg.CustomHeaders {
-		headers.Add(k, v)
-	}
-
 	var backend backendType
-	if dsUID == string(grafanaCloudPromType) {
+	if ds.UID == string(grafanaCloudPromType) {
 		backend = grafanaCloudPromType
 	} else {
 		backend = prometheusType
 	}
 
 	cfg := PrometheusWriterConfig{
-		URL: u.String(),
-		HTTPOptions: httpclient.Options{
-			Timeouts:     ho.Timeouts,
-			TLS:          ho.TLS,
-			BasicAuth:    ho.BasicAuth,
-			Header:       headers,
-			ProxyOptions: ho.ProxyOptions,
-		},
+		URL:         u.String(),
+		HTTPOptions: httpOptions,
 		Timeout:     w.cfg.Timeout,
 		BackendType: backend,
 	}
-	if err != nil {
-		return nil, err
-	}
 
 	w.l.Debug("Created Prometheus remote writer",
-		"datasource_uid", dsUID,
+		"datasource_uid", ds.UID,
 		"type", ds.Type,
 		"prometheusType", getPrometheusType(ds),
 		"url", cfg.URL,
@@ -250,6 +284,88 @@ func (w *DatasourceWriter) makeWriter(ctx context.Context, orgID int64, dsUID st
 		w.metrics)
 }
 
+func (w *DatasourceWriter) makeInfluxDBWriter(ctx context.Context, ds *datasources.DataSource, httpOptions httpclient.Options) (*InfluxDBWriter, error) {
+	u, err := getInfluxDBWriteURL(ds)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := InfluxDBWriterConfig{
+		URL:         u.String(),
+		HTTPOptions: httpOptions,
+		Timeout:     w.cfg.Timeout,
+	}
+	redactedURL := cfg.URL
+	switch getInfluxDBVersion(ds) {
+	case influxVersionFlux, influxVersionSQL:
+		decrypted, err := w.datasources.DecryptedValues(ctx, ds)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Token = decrypted["token"]
+	default:
+		// The InfluxDB user is sent with basic authentication, unless basic authentication is enabled
+		// for the data source, for example for a proxy in front of InfluxDB. Then it is sent in the query.
+		if ds.BasicAuth && ds.User != "" {
+			password, err := w.datasources.DecryptedPassword(ctx, ds)
+			if err != nil {
+				return nil, err
+			}
+			q := u.Query()
+			q.Set("u", ds.User)
+			q.Set("p", password)
+			u.RawQuery = q.Encode()
+			cfg.URL = u.String()
+		}
+	}
+
+	w.l.Debug("Created InfluxDB writer",
+		"datasource_uid", ds.UID,
+		"type", ds.Type,
+		"version", getInfluxDBVersion(ds),
+		"url", redactedURL,
+		"user", ds.User,
+		"tls", cfg.HTTPOptions.TLS != nil,
+		"basic_auth", cfg.HTTPOptions.BasicAuth != nil,
+		"token", cfg.Token != "",
+		"timeout", cfg.Timeout)
+
+	return NewInfluxDBWriter(
+		cfg,
+		w.httpClientProvider,
+		w.clock,
+		w.l,
+		w.metrics)
+}
+
+func (w *DatasourceWriter) makeLokiWriter(ds *datasources.DataSource, httpOptions httpclient.Options) (*LokiWriter, error) {
+	u, err := getLokiPushURL(ds)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := LokiWriterConfig{
+		URL:         u.String(),
+		HTTPOptions: httpOptions,
+		Timeout:     w.cfg.Timeout,
+	}
+
+	w.l.Debug("Created Loki writer",
+		"datasource_uid", ds.UID,
+		"type", ds.Type,
+		"url", cfg.URL,
+		"tls", cfg.HTTPOptions.TLS != nil,
+		"basic_auth", cfg.HTTPOptions.BasicAuth != nil,
+		"timeout", cfg.Timeout)
+
+	return NewLokiWriter(
+		cfg,
+		w.httpClientProvider,
+		w.clock,
+		w.l,
+		w.metrics)
+}
+
 func uidKey(orgID int64, uid string) string {
 	return fmt.Sprintf("%d-%s", orgID, uid)
 }
@@ -264,14 +380,21 @@ func (w *DatasourceWriter) WriteDatasource(ctx context.Context, dsUID string, na
 			"org_id", orgID, "datasource_uid", dsUID)
 	}
 
+	if dsUID == grafanads.DatasourceUID {
+		if w.cfg.SQLWriter == nil {
+			return errors.New("writing to the Grafana data source is not enabled")
+		}
+		return w.cfg.SQLWriter.Write(ctx, name, t, frames, orgID, extraLabels)
+	}
+
 	key := uidKey(orgID, dsUID)
 
-	var writer *PrometheusWriter
+	var writer datasourceTypeWriter
 
 	val, ok := w.writers.Get(key)
 	if ok {
 		var ok bool
-		writer, ok = val.(*PrometheusWriter)
+		writer, ok = val.(datasourceTypeWriter)
 		if !ok {
 			return errors.New("type in cache not a Writer")
 		}
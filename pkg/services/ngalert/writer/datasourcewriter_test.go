@@ -165,12 +165,88 @@ func TestDatasourceWriter(t *testing.T) {
 		require.EqualError(t, err, "data source not found")
 	})
 
-	t.Run("when writing a non-prometheus datasource then an error is returned", func(t *testing.T) {
+	t.Run("when writing an unsupported datasource then an error is returned", func(t *testing.T) {
 		testDS.Reset()
 
-		err := writer.WriteDatasource(context.Background(), "loki-1", "metric", time.Now(), frames, 1, map[string]string{})
+		_, _ = testDS.AddDataSource(context.Background(), &datasources.AddDataSourceCommand{
+			Name: "es-1",
+			UID:  "es-1",
+			Type: datasources.DS_ES,
+		})
+
+		err := writer.WriteDatasource(context.Background(), "es-1", "metric", time.Now(), frames, 1, map[string]string{})
 		require.Error(t, err)
-		require.EqualError(t, err, "can only write to data sources of type prometheus")
+		require.EqualError(t, err, "can only write to data sources of type prometheus, influxdb or loki")
+	})
+
+	t.Run("when writing an influxdb or loki datasource then the request is made to the expected endpoint", func(t *testing.T) {
+		influx := NewTestRemoteWriteTarget(t)
+		defer influx.Close()
+		influx.ExpectedPath = "/write"
+		influxDS, _ := testDS.AddDataSource(context.Background(), &datasources.AddDataSourceCommand{
+			Name:     "influx-1",
+			UID:      "influx-1",
+			Type:     datasources.DS_INFLUXDB,
+			JsonData: simplejson.MustJson([]byte(`{"dbName":"metrics"}`)),
+		})
+		influxDS.URL = influx.srv.URL
+
+		loki := NewTestRemoteWriteTarget(t)
+		defer loki.Close()
+		loki.ExpectedPath = "/loki/api/v1/push"
+		lokiDS, _ := testDS.AddDataSource(context.Background(), &datasources.AddDataSourceCommand{
+			Name: "loki-2",
+			UID:  "loki-2",
+			Type: datasources.DS_LOKI,
+		})
+		lokiDS.URL = loki.srv.URL
+
+		err := writer.WriteDatasource(context.Background(), "influx-1", "metric", time.Now(), frames, 1, map[string]string{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, influx.RequestsCount)
+		assert.Contains(t, influx.LastRequestBody, "metric,foo=1 value=")
+
+		err = writer.WriteDatasource(context.Background(), "loki-2", "metric", time.Now(), frames, 1, map[string]string{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, loki.RequestsCount)
+		assert.Contains(t, loki.LastRequestBody, `"streams"`)
+	})
+
+	t.Run("when an influxdb datasource uses basic authentication then the influxdb user is sent in the query", func(t *testing.T) {
+		influx := NewTestRemoteWriteTarget(t)
+		defer influx.Close()
+		influx.ExpectedPath = "/write"
+		influxDS, _ := testDS.AddDataSource(context.Background(), &datasources.AddDataSourceCommand{
+			Name:     "influx-2",
+			UID:      "influx-2",
+			Type:     datasources.DS_INFLUXDB,
+			JsonData: simplejson.MustJson([]byte(`{"dbName":"metrics"}`)),
+		})
+		influxDS.URL = influx.srv.URL
+		influxDS.BasicAuth = true
+		influxDS.BasicAuthUser = "proxy"
+		influxDS.User = "writer"
+
+		err := writer.WriteDatasource(context.Background(), "influx-2", "metric", time.Now(), frames, 1, map[string]string{})
+		require.NoError(t, err)
+		assert.Equal(t, "writer", influx.LastQuery.Get("u"))
+		user, _, ok := (&http.Request{Header: influx.LastHeaders}).BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "proxy", user)
+	})
+
+	t.Run("when writing the grafana datasource then the samples are written to the database", func(t *testing.T) {
+		err := writer.WriteDatasource(context.Background(), "grafana", "metric", time.Now(), frames, 1, map[string]string{})
+		require.EqualError(t, err, "writing to the Grafana data source is not enabled")
+
+		store := &fakeRecordedSampleStore{}
+		cfg := cfg
+		cfg.SQLWriter = NewSQLWriter(store, 0, clock.New(), log.New("test"), met)
+		writer := NewDatasourceWriter(cfg, testDS, httpclient.NewProvider(), pluginContextProvider, clock.New(), log.New("test"), met)
+
+		err = writer.WriteDatasource(context.Background(), "grafana", "metric", time.Now(), frames, 1, map[string]string{})
+		require.NoError(t, err)
+		assert.Len(t, store.saved, len(series))
 	})
 
 	t.Run("when writing with an empty datasource uid then the default is written", func(t *testing.T) {
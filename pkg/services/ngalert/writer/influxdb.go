@@ -0,0 +1,182 @@
+package writer
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/httpclient"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+)
+
+const (
+	influxDBType backendType = "influxdb"
+
+	// influxDBValueField is the field that holds the value of the sample. The name of the metric is the measurement.
+	influxDBValueField = "value"
+
+	influxVersionInfluxQL = "InfluxQL"
+	influxVersionFlux     = "Flux"
+	influxVersionSQL      = "SQL"
+)
+
+var (
+	influxMeasurementEscaper = strings.NewReplacer(`,`, `\,`, ` `, `\ `, "\n", `\n`)
+	influxTagEscaper         = strings.NewReplacer(`,`, `\,`, `=`, `\=`, ` `, `\ `, "\n", `\n`)
+)
+
+// InfluxDBWriter writes the result of recording rules to InfluxDB using the line protocol.
+type InfluxDBWriter struct {
+	client pushClient
+	logger log.Logger
+}
+
+type InfluxDBWriterConfig struct {
+	// URL is the write endpoint, including the database or the organization and bucket.
+	URL string
+	// Token is used to authenticate in InfluxDB 2.x and 3.x. Basic authentication is configured in HTTPOptions.
+	Token       string
+	HTTPOptions httpclient.Options
+	Timeout     time.Duration
+}
+
+func NewInfluxDBWriter(
+	cfg InfluxDBWriterConfig,
+	httpClientProvider HttpClientProvider,
+	clock clock.Clock,
+	l log.Logger,
+	metrics *metrics.RemoteWriter,
+) (*InfluxDBWriter, error) {
+	header := make(http.Header)
+	if cfg.Token != "" {
+		header.Set("Authorization", "Token "+cfg.Token)
+	}
+
+	client, err := newPushClient(cfg.URL, header, cfg.HTTPOptions, cfg.Timeout, influxDBType, httpClientProvider, clock, l, metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InfluxDBWriter{
+		client: client,
+		logger: l,
+	}, nil
+}
+
+// Write writes the given frames to the InfluxDB write endpoint.
+func (w InfluxDBWriter) Write(ctx context.Context, name string, t time.Time, frames data.Frames, orgID int64, extraLabels map[string]string) error {
+	l := w.logger.FromContext(ctx)
+
+	points, err := PointsFromFrames(name, t, frames, extraLabels)
+	if err != nil {
+		return errors.Join(ErrBadFrame, err)
+	}
+
+	body := influxLineProtocol(points)
+	if len(body) == 0 {
+		l.Debug("No points to write", "name", name)
+		return nil
+	}
+
+	l.Debug("Writing metric", "name", name)
+	return w.client.push(ctx, orgID, "text/plain; charset=utf-8", body)
+}
+
+// influxLineProtocol encodes the points in the InfluxDB line protocol with nanosecond precision.
+// The labels of the points are written as tags. Points with values that InfluxDB cannot store, such as NaN, are skipped.
+func influxLineProtocol(points []Point) []byte {
+	var sb strings.Builder
+	for _, p := range points {
+		if math.IsNaN(p.Metric.V) || math.IsInf(p.Metric.V, 0) {
+			continue
+		}
+
+		sb.WriteString(influxMeasurementEscaper.Replace(p.Name))
+
+		keys := make([]string, 0, len(p.Labels))
+		for k, v := range p.Labels {
+			// InfluxDB does not accept tags with empty values.
+			if v != "" {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			sb.WriteByte(',')
+			sb.WriteString(influxTagEscaper.Replace(k))
+			sb.WriteByte('=')
+			sb.WriteString(influxTagEscaper.Replace(p.Labels[k]))
+		}
+
+		sb.WriteByte(' ')
+		sb.WriteString(influxDBValueField)
+		sb.WriteByte('=')
+		sb.WriteString(strconv.FormatFloat(p.Metric.V, 'g', -1, 64))
+		sb.WriteByte(' ')
+		sb.WriteString(strconv.FormatInt(p.Metric.T.UnixNano(), 10))
+		sb.WriteByte('\n')
+	}
+	return []byte(sb.String())
+}
+
+func getInfluxDBVersion(ds *datasources.DataSource) string {
+	version := jsonDataString(ds, "version")
+	if version == "" {
+		return influxVersionInfluxQL
+	}
+	return version
+}
+
+// getInfluxDBWriteURL returns the write endpoint of the data source. InfluxQL data sources use the 1.x API,
+// Flux and SQL data sources use the 2.x API that is also supported by InfluxDB 3.x.
+func getInfluxDBWriteURL(ds *datasources.DataSource) (*url.URL, error) {
+	u, err := url.Parse(ds.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	q.Set("precision", "ns")
+
+	switch getInfluxDBVersion(ds) {
+	case influxVersionFlux:
+		org, bucket := jsonDataString(ds, "organization"), jsonDataString(ds, "defaultBucket")
+		if org == "" || bucket == "" {
+			return nil, errors.New("the organization and the default bucket of the data source are required to write to InfluxDB")
+		}
+		u = u.JoinPath("/api/v2/write")
+		q.Set("org", org)
+		q.Set("bucket", bucket)
+	case influxVersionSQL:
+		bucket := jsonDataString(ds, "dbName")
+		if bucket == "" {
+			return nil, errors.New("the database of the data source is required to write to InfluxDB")
+		}
+		u = u.JoinPath("/api/v2/write")
+		q.Set("bucket", bucket)
+	default:
+		database := jsonDataString(ds, "dbName")
+		if database == "" {
+			database = ds.Database
+		}
+		if database == "" {
+			return nil, errors.New("the database of the data source is required to write to InfluxDB")
+		}
+		u = u.JoinPath("/write")
+		q.Set("db", database)
+	}
+
+	u.RawQuery = q.Encode()
+	return u, nil
+}
@@ -0,0 +1,153 @@
+package writer
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/httpclient"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+)
+
+func TestInfluxLineProtocol(t *testing.T) {
+	ts := time.Unix(1700000000, 123)
+	points := []Point{
+		{
+			Name:   "cpu usage",
+			Labels: map[string]string{"host": "a,b", "zone": "eu=1", "empty": ""},
+			Metric: Metric{T: ts, V: 1.5},
+		},
+		{
+			Name:   "cpu",
+			Labels: map[string]string{"host": "c"},
+			Metric: Metric{T: ts, V: math.NaN()},
+		},
+		{
+			Name:   "cpu",
+			Metric: Metric{T: ts, V: 2},
+		},
+	}
+
+	expected := `cpu\ usage,host=a\,b,zone=eu\=1 value=1.5 1700000000000000123
+cpu value=2 1700000000000000123
+`
+	require.Equal(t, expected, string(influxLineProtocol(points)))
+}
+
+func TestGetInfluxDBWriteURL(t *testing.T) {
+	tc := []struct {
+		name string
+		ds   datasources.DataSource
+		url  string
+	}{
+		{
+			"influxql",
+			datasources.DataSource{
+				JsonData: simplejson.MustJson([]byte(`{"dbName":"metrics"}`)),
+				URL:      "http://example.com/influx",
+			},
+			"http://example.com/influx/write?db=metrics&precision=ns",
+		},
+		{
+			"influxql with legacy database field",
+			datasources.DataSource{
+				Database: "legacy",
+				URL:      "http://example.com",
+			},
+			"http://example.com/write?db=legacy&precision=ns",
+		},
+		{
+			"flux",
+			datasources.DataSource{
+				JsonData: simplejson.MustJson([]byte(`{"version":"Flux","organization":"my-org","defaultBucket":"my-bucket"}`)),
+				URL:      "http://example.com",
+			},
+			"http://example.com/api/v2/write?bucket=my-bucket&org=my-org&precision=ns",
+		},
+		{
+			"sql",
+			datasources.DataSource{
+				JsonData: simplejson.MustJson([]byte(`{"version":"SQL","dbName":"metrics"}`)),
+				URL:      "http://example.com",
+			},
+			"http://example.com/api/v2/write?bucket=metrics&precision=ns",
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := getInfluxDBWriteURL(&tt.ds)
+			require.NoError(t, err)
+			require.Equal(t, tt.url, res.String())
+		})
+	}
+
+	t.Run("missing database or bucket", func(t *testing.T) {
+		for _, jsonData := range []string{`{}`, `{"version":"Flux","organization":"my-org"}`, `{"version":"Flux","defaultBucket":"my-bucket"}`, `{"version":"SQL"}`} {
+			_, err := getInfluxDBWriteURL(&datasources.DataSource{
+				JsonData: simplejson.MustJson([]byte(jsonData)),
+				URL:      "http://example.com",
+			})
+			require.Error(t, err, jsonData)
+		}
+	})
+}
+
+func TestInfluxDBWriter_Write(t *testing.T) {
+	target := NewTestRemoteWriteTarget(t)
+	defer target.Close()
+	target.ExpectedPath = "/api/v2/write"
+
+	writer, err := NewInfluxDBWriter(InfluxDBWriterConfig{
+		URL:     target.DatasourceURL() + "/api/v2/write?bucket=metrics",
+		Token:   "secret",
+		Timeout: time.Second,
+	}, httpclient.NewProvider(), clock.New(), log.New("test"), metrics.NewRemoteWriterMetrics(prometheus.NewRegistry()))
+	require.NoError(t, err)
+
+	now := time.Unix(1700000000, 0)
+	frames := frameGenFromLabels(t, data.FrameTypeNumericWide, []map[string]string{{"foo": "1"}, {"foo": "2"}})
+	points, err := PointsFromFrames("test", now, frames, map[string]string{"extra": "label"})
+	require.NoError(t, err)
+
+	t.Run("writes the points in line protocol", func(t *testing.T) {
+		err := writer.Write(context.Background(), "test", now, frames, 1, map[string]string{"extra": "label"})
+		require.NoError(t, err)
+
+		require.Equal(t, 1, target.RequestsCount)
+		require.Equal(t, string(influxLineProtocol(points)), target.LastRequestBody)
+		require.Equal(t, "Token secret", target.LastHeaders.Get("Authorization"))
+		require.Equal(t, "text/plain; charset=utf-8", target.LastHeaders.Get("Content-Type"))
+	})
+
+	t.Run("error when frames are empty", func(t *testing.T) {
+		err := writer.Write(context.Background(), "test", now, data.Frames{data.NewFrame("test")}, 1, nil)
+		require.ErrorIs(t, err, ErrBadFrame)
+	})
+
+	t.Run("maps the status code of the response to an error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"partial write: field type conflict"}`))
+		}))
+		defer srv.Close()
+
+		writer, err := NewInfluxDBWriter(InfluxDBWriterConfig{URL: srv.URL}, httpclient.NewProvider(), clock.New(), log.New("test"), metrics.NewRemoteWriterMetrics(prometheus.NewRegistry()))
+		require.NoError(t, err)
+
+		err = writer.Write(context.Background(), "test", now, frames, 1, nil)
+		require.ErrorIs(t, err, ErrRejectedWrite)
+		require.ErrorContains(t, err, "field type conflict")
+	})
+}
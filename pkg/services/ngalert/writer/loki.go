@@ -0,0 +1,121 @@
+package writer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/httpclient"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+)
+
+const (
+	lokiType backendType = "loki"
+
+	// lokiMetricLabel is the stream label that holds the name of the metric,
+	// unless the series already has a label with the same name.
+	lokiMetricLabel = "metric"
+)
+
+// LokiWriter writes the result of recording rules to Loki. Each series is written as a stream with the labels of the series,
+// and each sample as a logfmt line with the name of the metric and the value, so that the samples can be queried
+// with LogQL metric queries, for example with unwrap.
+type LokiWriter struct {
+	client pushClient
+	logger log.Logger
+}
+
+type LokiWriterConfig struct {
+	URL         string
+	HTTPOptions httpclient.Options
+	Timeout     time.Duration
+}
+
+func NewLokiWriter(
+	cfg LokiWriterConfig,
+	httpClientProvider HttpClientProvider,
+	clock clock.Clock,
+	l log.Logger,
+	metrics *metrics.RemoteWriter,
+) (*LokiWriter, error) {
+	client, err := newPushClient(cfg.URL, nil, cfg.HTTPOptions, cfg.Timeout, lokiType, httpClientProvider, clock, l, metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LokiWriter{
+		client: client,
+		logger: l,
+	}, nil
+}
+
+type lokiPushRequest struct {
+	Streams []lokiStream `json:"streams"`
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// Write writes the given frames to the Loki push endpoint.
+func (w LokiWriter) Write(ctx context.Context, name string, t time.Time, frames data.Frames, orgID int64, extraLabels map[string]string) error {
+	l := w.logger.FromContext(ctx)
+
+	points, err := PointsFromFrames(name, t, frames, extraLabels)
+	if err != nil {
+		return errors.Join(ErrBadFrame, err)
+	}
+	if len(points) == 0 {
+		l.Debug("No points to write", "name", name)
+		return nil
+	}
+
+	body, err := json.Marshal(lokiPushRequestFromPoints(points))
+	if err != nil {
+		return err
+	}
+
+	l.Debug("Writing metric", "name", name)
+	return w.client.push(ctx, orgID, "application/json", body)
+}
+
+func lokiPushRequestFromPoints(points []Point) lokiPushRequest {
+	req := lokiPushRequest{Streams: make([]lokiStream, 0, len(points))}
+	for _, p := range points {
+		stream := make(map[string]string, len(p.Labels)+1)
+		stream[lokiMetricLabel] = p.Name
+		for k, v := range p.Labels {
+			stream[k] = v
+		}
+		line := fmt.Sprintf("%s=%s value=%s", lokiMetricLabel, strconv.Quote(p.Name), strconv.FormatFloat(p.Metric.V, 'g', -1, 64))
+		req.Streams = append(req.Streams, lokiStream{
+			Stream: stream,
+			Values: [][2]string{{strconv.FormatInt(p.Metric.T.UnixNano(), 10), line}},
+		})
+	}
+	// Sort the streams to make the requests deterministic.
+	sort.Slice(req.Streams, func(i, j int) bool {
+		return data.Labels(req.Streams[i].Stream).String() < data.Labels(req.Streams[j].Stream).String()
+	})
+	return req
+}
+
+// getLokiPushURL returns the push endpoint of the data source.
+func getLokiPushURL(ds *datasources.DataSource) (*url.URL, error) {
+	u, err := url.Parse(ds.URL)
+	if err != nil {
+		return nil, err
+	}
+	return u.JoinPath("/loki/api/v1/push"), nil
+}
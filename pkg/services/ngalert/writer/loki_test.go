@@ -0,0 +1,75 @@
+package writer
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/httpclient"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+)
+
+func TestLokiPushRequestFromPoints(t *testing.T) {
+	ts := time.Unix(1700000000, 0)
+	points := []Point{
+		{
+			Name:   "requests_total",
+			Labels: map[string]string{"job": "b"},
+			Metric: Metric{T: ts, V: 2},
+		},
+		{
+			Name:   "requests_total",
+			Labels: map[string]string{"job": "a", "metric": "series-label"},
+			Metric: Metric{T: ts, V: 0.5},
+		},
+	}
+
+	req := lokiPushRequestFromPoints(points)
+	require.Equal(t, lokiPushRequest{Streams: []lokiStream{
+		{
+			Stream: map[string]string{"job": "a", "metric": "series-label"},
+			Values: [][2]string{{"1700000000000000000", `metric="requests_total" value=0.5`}},
+		},
+		{
+			Stream: map[string]string{"job": "b", "metric": "requests_total"},
+			Values: [][2]string{{"1700000000000000000", `metric="requests_total" value=2`}},
+		},
+	}}, req)
+}
+
+func TestLokiWriter_Write(t *testing.T) {
+	target := NewTestRemoteWriteTarget(t)
+	defer target.Close()
+	target.ExpectedPath = "/loki/api/v1/push"
+
+	writer, err := NewLokiWriter(LokiWriterConfig{
+		URL:     target.DatasourceURL() + "/loki/api/v1/push",
+		Timeout: time.Second,
+	}, httpclient.NewProvider(), clock.New(), log.New("test"), metrics.NewRemoteWriterMetrics(prometheus.NewRegistry()))
+	require.NoError(t, err)
+
+	now := time.Unix(1700000000, 0)
+	frames := frameGenFromLabels(t, data.FrameTypeNumericWide, []map[string]string{{"foo": "1"}, {"foo": "2"}})
+
+	err = writer.Write(context.Background(), "test", now, frames, 1, map[string]string{"extra": "label"})
+	require.NoError(t, err)
+
+	require.Equal(t, 1, target.RequestsCount)
+	require.Equal(t, "application/json", target.LastHeaders.Get("Content-Type"))
+
+	var req lokiPushRequest
+	require.NoError(t, json.Unmarshal([]byte(target.LastRequestBody), &req))
+	require.Len(t, req.Streams, 2)
+	for i, foo := range []string{"1", "2"} {
+		require.Equal(t, map[string]string{"foo": foo, "extra": "label", "metric": "test"}, req.Streams[i].Stream)
+		require.Len(t, req.Streams[i].Values, 1)
+		require.Equal(t, "1700000000000000000", req.Streams[i].Values[0][0])
+	}
+}
@@ -0,0 +1,114 @@
+package writer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/httpclient"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+)
+
+// maxErrorBodySize is the maximum number of bytes of the response body that are included in write errors.
+const maxErrorBodySize = 1024
+
+// pushClient sends samples to data sources that accept writes over a plain HTTP push API,
+// such as InfluxDB and Loki.
+type pushClient struct {
+	client      *http.Client
+	url         string
+	header      http.Header
+	clock       clock.Clock
+	logger      log.Logger
+	metrics     *metrics.RemoteWriter
+	backendType backendType
+}
+
+func newPushClient(
+	url string,
+	header http.Header,
+	httpOptions httpclient.Options,
+	timeout time.Duration,
+	backend backendType,
+	httpClientProvider HttpClientProvider,
+	clock clock.Clock,
+	l log.Logger,
+	metrics *metrics.RemoteWriter,
+) (pushClient, error) {
+	cl, err := httpClientProvider.New(httpOptions)
+	if err != nil {
+		return pushClient{}, err
+	}
+	if timeout > 0 {
+		cl.Timeout = timeout
+	}
+
+	if header == nil {
+		header = make(http.Header)
+	}
+	header.Set("User-Agent", "grafana-recording-rule")
+
+	return pushClient{
+		client:      cl,
+		url:         url,
+		header:      header,
+		clock:       clock,
+		logger:      l,
+		metrics:     metrics,
+		backendType: backend,
+	}, nil
+}
+
+// push sends the body to the write endpoint and maps the response to the errors of the package.
+func (c pushClient) push(ctx context.Context, orgID int64, contentType string, body []byte) error {
+	lvs := []string{fmt.Sprint(orgID), string(c.backendType)}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header = c.header.Clone()
+	req.Header.Set("Content-Type", contentType)
+
+	writeStart := c.clock.Now()
+	res, err := c.client.Do(req)
+	c.metrics.WriteDuration.WithLabelValues(lvs...).Observe(c.clock.Now().Sub(writeStart).Seconds())
+	if err != nil {
+		// The URL can contain credentials, such as the password of an InfluxDB user.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("%w: %v", ErrConnectionFailure, err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	lvs = append(lvs, fmt.Sprint(res.StatusCode))
+	c.metrics.WritesTotal.WithLabelValues(lvs...).Inc()
+
+	if res.StatusCode/100 == 2 {
+		return nil
+	}
+
+	msg, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorBodySize))
+	switch {
+	case res.StatusCode == http.StatusBadRequest:
+		return fmt.Errorf("%w: %s", ErrRejectedWrite, msg)
+	case res.StatusCode == http.StatusUnauthorized:
+		return fmt.Errorf("%w: %s", ErrDatasourceUnauthorized, msg)
+	case res.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("%w: %s", ErrDatasourceForbidden, msg)
+	default:
+		return fmt.Errorf("%w: unexpected status code %d: %s", ErrUnexpectedWriteFailure, res.StatusCode, msg)
+	}
+}
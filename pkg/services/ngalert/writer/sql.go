@@ -0,0 +1,98 @@
+package writer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+const sqlType backendType = "grafana"
+
+// RecordedSampleStore stores the samples of recording rules in the Grafana database.
+type RecordedSampleStore interface {
+	SaveRecordedSamples(ctx context.Context, samples []models.RecordedSample) error
+	DeleteRecordedSamples(ctx context.Context, orgID int64, metric string, before time.Time) (int64, error)
+}
+
+// SQLWriter writes the result of recording rules to the Grafana database,
+// where the samples can be queried with the Grafana data source.
+type SQLWriter struct {
+	store     RecordedSampleStore
+	retention time.Duration
+	clock     clock.Clock
+	logger    log.Logger
+	metrics   *metrics.RemoteWriter
+}
+
+// NewSQLWriter creates a writer that stores the samples in the Grafana database.
+// Samples older than the retention are deleted when new samples of the same metric are written.
+func NewSQLWriter(store RecordedSampleStore, retention time.Duration, clock clock.Clock, l log.Logger, metrics *metrics.RemoteWriter) *SQLWriter {
+	return &SQLWriter{
+		store:     store,
+		retention: retention,
+		clock:     clock,
+		logger:    l,
+		metrics:   metrics,
+	}
+}
+
+// Write writes the given frames to the Grafana database.
+func (w SQLWriter) Write(ctx context.Context, name string, t time.Time, frames data.Frames, orgID int64, extraLabels map[string]string) error {
+	l := w.logger.FromContext(ctx)
+	lvs := []string{fmt.Sprint(orgID), string(sqlType)}
+
+	points, err := PointsFromFrames(name, t, frames, extraLabels)
+	if err != nil {
+		return errors.Join(ErrBadFrame, err)
+	}
+
+	samples := make([]models.RecordedSample, 0, len(points))
+	for _, p := range points {
+		// Not all supported databases can store NaN and infinite values.
+		if math.IsNaN(p.Metric.V) || math.IsInf(p.Metric.V, 0) {
+			continue
+		}
+		samples = append(samples, models.RecordedSample{
+			OrgID:  orgID,
+			Metric: p.Name,
+			Labels: p.Labels,
+			Time:   p.Metric.T,
+			Value:  p.Metric.V,
+		})
+	}
+
+	l.Debug("Writing metric", "name", name)
+	writeStart := w.clock.Now()
+	err = w.store.SaveRecordedSamples(ctx, samples)
+	w.metrics.WriteDuration.WithLabelValues(lvs...).Observe(w.clock.Now().Sub(writeStart).Seconds())
+
+	status := "200"
+	if err != nil {
+		status = "500"
+	}
+	w.metrics.WritesTotal.WithLabelValues(append(lvs, status)...).Inc()
+	if err != nil {
+		return errors.Join(ErrUnexpectedWriteFailure, err)
+	}
+
+	if w.retention > 0 {
+		deleted, err := w.store.DeleteRecordedSamples(ctx, orgID, name, t.Add(-w.retention))
+		if err != nil {
+			// The samples were written, so the rule does not fail if expired samples could not be deleted.
+			l.Warn("Failed to delete expired samples", "name", name, "error", err)
+		} else if deleted > 0 {
+			l.Debug("Deleted expired samples", "name", name, "count", deleted)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package writer
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+type fakeRecordedSampleStore struct {
+	saved     []models.RecordedSample
+	deleted   []time.Time
+	saveErr   error
+	deleteErr error
+}
+
+func (f *fakeRecordedSampleStore) SaveRecordedSamples(_ context.Context, samples []models.RecordedSample) error {
+	if f.saveErr != nil {
+		return f.saveErr
+	}
+	f.saved = append(f.saved, samples...)
+	return nil
+}
+
+func (f *fakeRecordedSampleStore) DeleteRecordedSamples(_ context.Context, _ int64, _ string, before time.Time) (int64, error) {
+	f.deleted = append(f.deleted, before)
+	return 0, f.deleteErr
+}
+
+func TestSQLWriter_Write(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	t.Run("saves a sample for each series and deletes expired samples", func(t *testing.T) {
+		store := &fakeRecordedSampleStore{}
+		writer := NewSQLWriter(store, time.Hour, clock.New(), log.New("test"), metrics.NewRemoteWriterMetrics(prometheus.NewRegistry()))
+
+		frames := frameGenFromLabels(t, data.FrameTypeNumericWide, []map[string]string{{"foo": "1"}, {"foo": "2"}})
+		err := writer.Write(context.Background(), "test", now, frames, 1, map[string]string{"extra": "label"})
+		require.NoError(t, err)
+
+		require.Len(t, store.saved, 2)
+		for _, s := range store.saved {
+			require.Equal(t, int64(1), s.OrgID)
+			require.Equal(t, "test", s.Metric)
+			require.Equal(t, now, s.Time)
+			require.Equal(t, "label", s.Labels["extra"])
+		}
+		require.Equal(t, []time.Time{now.Add(-time.Hour)}, store.deleted)
+	})
+
+	t.Run("skips values that cannot be stored", func(t *testing.T) {
+		store := &fakeRecordedSampleStore{}
+		writer := NewSQLWriter(store, 0, clock.New(), log.New("test"), metrics.NewRemoteWriterMetrics(prometheus.NewRegistry()))
+
+		frame := data.NewFrame("test",
+			data.NewField("T", nil, []time.Time{now}),
+			data.NewField("value", data.Labels{"foo": "1"}, []float64{math.NaN()}),
+			data.NewField("value", data.Labels{"foo": "2"}, []float64{1}),
+		)
+		frame.SetMeta(&data.FrameMeta{Type: data.FrameTypeNumericWide, TypeVersion: data.FrameTypeVersion{0, 1}})
+
+		err := writer.Write(context.Background(), "test", now, data.Frames{frame}, 1, nil)
+		require.NoError(t, err)
+		require.Len(t, store.saved, 1)
+		require.Equal(t, "2", store.saved[0].Labels["foo"])
+		require.Empty(t, store.deleted)
+	})
+
+	t.Run("returns an error if the samples cannot be saved", func(t *testing.T) {
+		store := &fakeRecordedSampleStore{saveErr: errors.New("database is locked")}
+		writer := NewSQLWriter(store, time.Hour, clock.New(), log.New("test"), metrics.NewRemoteWriterMetrics(prometheus.NewRegistry()))
+
+		frames := frameGenFromLabels(t, data.FrameTypeNumericWide, []map[string]string{{"foo": "1"}})
+		err := writer.Write(context.Background(), "test", now, frames, 1, nil)
+		require.ErrorIs(t, err, ErrUnexpectedWriteFailure)
+		require.ErrorIs(t, err, store.saveErr)
+	})
+
+	t.Run("does not fail if expired samples cannot be deleted", func(t *testing.T) {
+		store := &fakeRecordedSampleStore{deleteErr: errors.New("database is locked")}
+		writer := NewSQLWriter(store, time.Hour, clock.New(), log.New("test"), metrics.NewRemoteWriterMetrics(prometheus.NewRegistry()))
+
+		frames := frameGenFromLabels(t, data.FrameTypeNumericWide, []map[string]string{{"foo": "1"}})
+		err := writer.Write(context.Background(), "test", now, frames, 1, nil)
+		require.NoError(t, err)
+		require.Len(t, store.saved, 1)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"sync"
 	"testing"
 	"time"
@@ -21,6 +22,7 @@ type TestRemoteWriteTarget struct {
 	RequestsCount   int
 	LastRequestBody string
 	LastHeaders     http.Header
+	LastQuery       url.Values
 
 	ExpectedPath string
 }
@@ -44,6 +46,7 @@ func NewTestRemoteWriteTarget(t *testing.T) *TestRemoteWriteTarget {
 		defer target.mtx.Unlock()
 		target.RequestsCount += 1
 		target.LastHeaders = r.Header.Clone()
+		target.LastQuery = r.URL.Query()
 		bd, err := io.ReadAll(r.Body)
 		defer func() {
 			_ = r.Body.Close()
@@ -82,4 +85,5 @@ func (s *TestRemoteWriteTarget) Reset() {
 	s.RequestsCount = 0
 	s.LastRequestBody = ""
 	s.LastHeaders = http.Header{}
+	s.LastQuery = nil
 }
@@ -0,0 +1,98 @@
+package alerting
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/pkg/labels"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/provisioning/values"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+type RecurringSilenceV1 struct {
+	OrgID     values.Int64Value                    `json:"orgId" yaml:"orgId"`
+	UID       values.StringValue                   `json:"uid" yaml:"uid"`
+	Matchers  []values.StringValue                 `json:"matchers" yaml:"matchers"`
+	Comment   values.StringValue                   `json:"comment" yaml:"comment"`
+	CreatedBy values.StringValue                   `json:"createdBy" yaml:"createdBy"`
+	Schedule  definitions.RecurringSilenceSchedule `json:"schedule" yaml:"schedule"`
+}
+
+func (v1 *RecurringSilenceV1) mapToModel() (RecurringSilence, error) {
+	uid := strings.TrimSpace(v1.UID.Value())
+	if uid == "" {
+		return RecurringSilence{}, errors.New("recurring silence missing uid")
+	}
+	orgID := v1.OrgID.Value()
+	if orgID < 1 {
+		orgID = 1
+	}
+	matchers := make(amv2.Matchers, 0, len(v1.Matchers))
+	for _, value := range v1.Matchers {
+		m, err := labels.ParseMatcher(value.Value())
+		if err != nil {
+			return RecurringSilence{}, fmt.Errorf("recurring silence %s: invalid matcher: %w", uid, err)
+		}
+		matchers = append(matchers, &amv2.Matcher{
+			Name:    util.Pointer(m.Name),
+			Value:   util.Pointer(m.Value),
+			IsRegex: util.Pointer(m.Type == labels.MatchRegexp || m.Type == labels.MatchNotRegexp),
+			IsEqual: util.Pointer(m.Type == labels.MatchEqual || m.Type == labels.MatchRegexp),
+		})
+	}
+	createdBy := v1.CreatedBy.Value()
+	if createdBy == "" {
+		createdBy = "provisioning"
+	}
+	return RecurringSilence{
+		OrgID: orgID,
+		RecurringSilence: models.RecurringSilence{
+			UID:       uid,
+			OrgID:     orgID,
+			Matchers:  matchers,
+			Comment:   v1.Comment.Value(),
+			CreatedBy: createdBy,
+			Schedule: models.RecurringSilenceSchedule{
+				Cron:          v1.Schedule.Cron,
+				Duration:      time.Duration(v1.Schedule.Duration),
+				TimeIntervals: v1.Schedule.TimeIntervals,
+			},
+		},
+	}, nil
+}
+
+type RecurringSilence struct {
+	OrgID            int64
+	RecurringSilence models.RecurringSilence
+}
+
+type DeleteRecurringSilenceV1 struct {
+	OrgID values.Int64Value  `json:"orgId" yaml:"orgId"`
+	UID   values.StringValue `json:"uid" yaml:"uid"`
+}
+
+func (v1 *DeleteRecurringSilenceV1) mapToModel() (DeleteRecurringSilence, error) {
+	uid := strings.TrimSpace(v1.UID.Value())
+	if uid == "" {
+		return DeleteRecurringSilence{}, errors.New("delete recurring silence missing uid")
+	}
+	orgID := v1.OrgID.Value()
+	if orgID < 1 {
+		orgID = 1
+	}
+	return DeleteRecurringSilence{
+		OrgID: orgID,
+		UID:   uid,
+	}, nil
+}
+
+type DeleteRecurringSilence struct {
+	OrgID int64
+	UID   string
+}
@@ -0,0 +1,73 @@
+package alerting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRecurringSilence(t *testing.T) {
+	t.Run("cron schedule", func(t *testing.T) {
+		data := `uid: nightly
+matchers:
+  - env="staging"
+  - team=~"infra|platform"
+comment: nightly maintenance
+schedule:
+  cron: CRON_TZ=Europe/Berlin 0 22 * * 1-5
+  duration: 8h
+`
+		var model RecurringSilenceV1
+
+		err := yaml.Unmarshal([]byte(data), &model)
+		require.NoError(t, err)
+		rs, err := model.mapToModel()
+		require.NoError(t, err)
+		require.Equal(t, int64(1), rs.OrgID)
+		require.Equal(t, int64(1), rs.RecurringSilence.OrgID)
+		require.Equal(t, "nightly", rs.RecurringSilence.UID)
+		require.Equal(t, "provisioning", rs.RecurringSilence.CreatedBy)
+		require.Equal(t, 8*time.Hour, rs.RecurringSilence.Schedule.Duration)
+		require.Len(t, rs.RecurringSilence.Matchers, 2)
+		require.Equal(t, "team", *rs.RecurringSilence.Matchers[1].Name)
+		require.True(t, *rs.RecurringSilence.Matchers[1].IsRegex)
+		require.True(t, *rs.RecurringSilence.Matchers[1].IsEqual)
+		require.NoError(t, rs.RecurringSilence.Validate())
+	})
+
+	t.Run("time intervals schedule", func(t *testing.T) {
+		data := `orgId: 2
+uid: weekend
+matchers: ['env!="production"', 'team="infra"']
+schedule:
+  time_intervals:
+    - weekdays: [saturday, sunday]
+`
+		var model RecurringSilenceV1
+
+		err := yaml.Unmarshal([]byte(data), &model)
+		require.NoError(t, err)
+		rs, err := model.mapToModel()
+		require.NoError(t, err)
+		require.Equal(t, int64(2), rs.OrgID)
+		require.Len(t, rs.RecurringSilence.Schedule.TimeIntervals, 1)
+		require.False(t, *rs.RecurringSilence.Matchers[0].IsEqual)
+		require.NoError(t, rs.RecurringSilence.Validate())
+	})
+
+	t.Run("missing uid", func(t *testing.T) {
+		var model RecurringSilenceV1
+		require.NoError(t, yaml.Unmarshal([]byte(`matchers: ['env="staging"']`), &model))
+		_, err := model.mapToModel()
+		require.ErrorContains(t, err, "missing uid")
+	})
+
+	t.Run("invalid matcher", func(t *testing.T) {
+		var model RecurringSilenceV1
+		require.NoError(t, yaml.Unmarshal([]byte("uid: test\nmatchers: ['env=~\"(\"']"), &model))
+		_, err := model.mapToModel()
+		require.ErrorContains(t, err, "invalid matcher")
+	})
+}
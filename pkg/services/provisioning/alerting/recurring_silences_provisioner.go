@@ -0,0 +1,68 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/provisioning"
+)
+
+type RecurringSilencesProvisioner interface {
+	Provision(ctx context.Context, files []*AlertingFile) error
+	Unprovision(ctx context.Context, files []*AlertingFile) error
+}
+
+type defaultRecurringSilencesProvisioner struct {
+	logger                  log.Logger
+	recurringSilenceService *provisioning.RecurringSilenceService
+}
+
+func NewRecurringSilencesProvisioner(logger log.Logger,
+	recurringSilenceService *provisioning.RecurringSilenceService) RecurringSilencesProvisioner {
+	return &defaultRecurringSilencesProvisioner{
+		logger:                  logger,
+		recurringSilenceService: recurringSilenceService,
+	}
+}
+
+func (c *defaultRecurringSilencesProvisioner) Provision(ctx context.Context,
+	files []*AlertingFile) error {
+	if c.recurringSilenceService == nil {
+		return nil
+	}
+	for _, file := range files {
+		for _, silence := range file.RecurringSilences {
+			_, err := c.recurringSilenceService.GetRecurringSilence(ctx, silence.OrgID, silence.RecurringSilence.UID)
+			if err != nil && !errors.Is(err, models.ErrRecurringSilenceNotFound) {
+				return err
+			}
+			if err == nil {
+				_, err = c.recurringSilenceService.UpdateRecurringSilence(ctx, silence.RecurringSilence, models.ProvenanceFile)
+			} else {
+				_, err = c.recurringSilenceService.CreateRecurringSilence(ctx, silence.RecurringSilence, models.ProvenanceFile)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *defaultRecurringSilencesProvisioner) Unprovision(ctx context.Context,
+	files []*AlertingFile) error {
+	if c.recurringSilenceService == nil {
+		return nil
+	}
+	for _, file := range files {
+		for _, deleteSilence := range file.DeleteRecurringSilences {
+			err := c.recurringSilenceService.DeleteRecurringSilence(ctx, deleteSilence.OrgID, deleteSilence.UID, models.ProvenanceFile)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -79,6 +79,7 @@ type AlertRuleV1 struct {
 	IsPaused                    values.BoolValue        `json:"isPaused" yaml:"isPaused"`
 	NotificationSettings        *NotificationSettingsV1 `json:"notification_settings" yaml:"notification_settings"`
 	Record                      *RecordV1               `json:"record" yaml:"record"`
+	Dependencies                []DependencyV1          `json:"dependencies" yaml:"dependencies"`
 }
 
 func withFallback(value, fallback string) *string {
@@ -182,6 +183,9 @@ func (rule *AlertRuleV1) mapToModel(orgID int64) (models.AlertRule, error) {
 		}
 		alertRule.Record = &record
 	}
+	for _, dependencyV1 := range rule.Dependencies {
+		alertRule.Dependencies = append(alertRule.Dependencies, dependencyV1.mapToModel())
+	}
 	return alertRule, nil
 }
 
@@ -222,6 +226,25 @@ func (queryV1 *QueryV1) mapToModel() (models.AlertQuery, error) {
 	}, nil
 }
 
+type DependencyV1 struct {
+	RuleUID  values.StringValue   `json:"rule_uid" yaml:"rule_uid"`
+	Matchers []values.StringValue `json:"matchers,omitempty" yaml:"matchers"`
+	Equal    []values.StringValue `json:"equal,omitempty" yaml:"equal"`
+}
+
+func (dV1 *DependencyV1) mapToModel() models.AlertRuleDependency {
+	dependency := models.AlertRuleDependency{
+		RuleUID: dV1.RuleUID.Value(),
+	}
+	for _, m := range dV1.Matchers {
+		dependency.Matchers = append(dependency.Matchers, m.Value())
+	}
+	for _, l := range dV1.Equal {
+		dependency.Equal = append(dependency.Equal, l.Value())
+	}
+	return dependency
+}
+
 type NotificationSettingsV1 struct {
 	Receiver            values.StringValue   `json:"receiver" yaml:"receiver"`
 	GroupBy             []values.StringValue `json:"group_by,omitempty" yaml:"group_by"`
@@ -200,6 +200,21 @@ func TestRules(t *testing.T) {
 		require.Len(t, ruleMapped.NotificationSettings, 1)
 		require.Equal(t, models.NotificationSettings{Receiver: "test-receiver"}, ruleMapped.NotificationSettings[0])
 	})
+	t.Run("a rule with dependencies should map them correctly", func(t *testing.T) {
+		rule := validRuleV1(t)
+		rule.Dependencies = []DependencyV1{{
+			RuleUID:  stringToStringValue("cluster-down"),
+			Matchers: []values.StringValue{stringToStringValue(`cluster=~"prod-.*"`)},
+			Equal:    []values.StringValue{stringToStringValue("cluster")},
+		}}
+		ruleMapped, err := rule.mapToModel(1)
+		require.NoError(t, err)
+		require.Equal(t, []models.AlertRuleDependency{{
+			RuleUID:  "cluster-down",
+			Matchers: []string{`cluster=~"prod-.*"`},
+			Equal:    []string{"cluster"},
+		}}, ruleMapped.Dependencies)
+	})
 }
 
 func TestNotificationsSettingsV1MapToModel(t *testing.T) {
@@ -199,6 +199,18 @@ func addAnnotationMig(mg *Migrator) {
 	}))
 
 	mg.AddMigration("Add missing dashboard_uid to annotation table", &SetDashboardUIDMigration{})
+
+	//
+	// Add column external_id, the client-supplied ID of imported annotations. It is unique per organization, and NULL
+	// for annotations that were not imported.
+	//
+	mg.AddMigration("Add external_id column to annotation table", NewAddColumnMigration(table, &Column{
+		Name: "external_id", Type: DB_NVarchar, Length: 255, Nullable: true,
+	}))
+
+	mg.AddMigration("Add unique index for org_id_external_id on annotation table", NewAddIndexMigration(table, &Index{
+		Cols: []string{"org_id", "external_id"}, Type: UniqueIndex,
+	}))
 }
 
 type AddMakeRegionSingleRowMigration struct {
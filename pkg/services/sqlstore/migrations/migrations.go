@@ -155,4 +155,14 @@ func (oss *OSSMigrations) AddMigration(mg *Migrator) {
 	ualert.DropTitleUniqueIndexMigration(mg)
 
 	ualert.AddStateFiredAtColumn(mg)
+
+	ualert.AddAlertRuleDependencies(mg)
+
+	ualert.AddRecordedSampleTable(mg)
+
+	ualert.AddRecurringSilenceTable(mg)
+
+	ualert.AddRuleTemplateTable(mg)
+
+	addDashboardReportMigrations(mg)
 }
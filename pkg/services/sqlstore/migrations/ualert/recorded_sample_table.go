@@ -0,0 +1,31 @@
+package ualert
+
+import "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+// AddRecordedSampleTable adds the table that stores the samples of recording rules that write to the Grafana database.
+func AddRecordedSampleTable(mg *migrator.Migrator) {
+	recordedSampleTable := migrator.Table{
+		Name: "alert_rule_recorded_sample",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "metric", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "labels", Type: migrator.DB_Text, Nullable: false},
+			{Name: "labels_hash", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "sample_time", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "value", Type: migrator.DB_Double, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "metric", "sample_time"}, Type: migrator.IndexType},
+		},
+	}
+
+	mg.AddMigration(
+		"add alert_rule_recorded_sample table",
+		migrator.NewAddTableMigration(recordedSampleTable),
+	)
+	mg.AddMigration(
+		"add index to alert_rule_recorded_sample on org_id, metric and sample_time columns",
+		migrator.NewAddIndexMigration(recordedSampleTable, recordedSampleTable.Indices[0]),
+	)
+}
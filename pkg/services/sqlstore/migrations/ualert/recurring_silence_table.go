@@ -0,0 +1,38 @@
+package ualert
+
+import "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+// AddRecurringSilenceTable adds the table that stores recurring silences and the silence created for their current window.
+func AddRecurringSilenceTable(mg *migrator.Migrator) {
+	recurringSilenceTable := migrator.Table{
+		Name: "alert_recurring_silence",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "uid", Type: migrator.DB_NVarchar, Length: UIDMaxLength, Nullable: false},
+			{Name: "matchers", Type: migrator.DB_Text, Nullable: false},
+			{Name: "comment", Type: migrator.DB_Text, Nullable: false},
+			{Name: "created_by", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "schedule", Type: migrator.DB_Text, Nullable: false},
+			{Name: "version", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "updated", Type: migrator.DB_DateTime, Nullable: false},
+			{Name: "deleted", Type: migrator.DB_Bool, Nullable: false},
+			{Name: "silence_id", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "window_starts_at", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "window_ends_at", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "window_version", Type: migrator.DB_BigInt, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "uid"}, Type: migrator.UniqueIndex},
+		},
+	}
+
+	mg.AddMigration(
+		"add alert_recurring_silence table",
+		migrator.NewAddTableMigration(recurringSilenceTable),
+	)
+	mg.AddMigration(
+		"add unique index to alert_recurring_silence on org_id and uid columns",
+		migrator.NewAddIndexMigration(recurringSilenceTable, recurringSilenceTable.Indices[0]),
+	)
+}
@@ -0,0 +1,33 @@
+package ualert
+
+import "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+// AddRuleTemplateTable adds the table that stores rule templates and their instances.
+func AddRuleTemplateTable(mg *migrator.Migrator) {
+	ruleTemplateTable := migrator.Table{
+		Name: "alert_rule_template",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "uid", Type: migrator.DB_NVarchar, Length: UIDMaxLength, Nullable: false},
+			{Name: "title", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "parameters", Type: migrator.DB_Text, Nullable: false},
+			{Name: "rule_definition", Type: migrator.DB_MediumText, Nullable: false},
+			{Name: "instances", Type: migrator.DB_MediumText, Nullable: false},
+			{Name: "version", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "updated", Type: migrator.DB_DateTime, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "uid"}, Type: migrator.UniqueIndex},
+		},
+	}
+
+	mg.AddMigration(
+		"add alert_rule_template table",
+		migrator.NewAddTableMigration(ruleTemplateTable),
+	)
+	mg.AddMigration(
+		"add unique index to alert_rule_template on org_id and uid columns",
+		migrator.NewAddIndexMigration(ruleTemplateTable, ruleTemplateTable.Indices[0]),
+	)
+}
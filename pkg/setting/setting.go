@@ -306,6 +306,12 @@ type Cfg struct {
 	// DistributedCache
 	RemoteCacheOptions *RemoteCacheSettings
 
+	// Query caching
+	QueryCaching QueryCachingSettings
+
+	// Dashboard reports
+	DashboardReports DashboardReportsSettings
+
 	// Deprecated: no longer used
 	ViewersCanEdit bool
 
@@ -467,6 +473,14 @@ type Cfg struct {
 	// LiveMessageSizeLimit is the maximum size in bytes of Websocket messages
 	// from clients. Defaults to 64KB.
 	LiveMessageSizeLimit int
+	// LiveHistoryMaxFrames is a maximum number of frames kept in the history
+	// of every managed stream channel. 0 means at most 1000 frames when
+	// LiveHistoryMaxAge is set.
+	LiveHistoryMaxFrames int
+	// LiveHistoryMaxAge is a maximum age of frames kept in the history of every
+	// managed stream channel. 0 means no limit by age. History is disabled
+	// when neither LiveHistoryMaxFrames nor LiveHistoryMaxAge is set.
+	LiveHistoryMaxAge time.Duration
 
 	// Grafana.com URL, used for OAuth redirect.
 	GrafanaComURL string
@@ -1402,6 +1416,8 @@ func (cfg *Cfg) parseINIFile(iniFile *ini.File) error {
 	cfg.GeomapEnableCustomBaseLayers = geomapSection.Key("enable_custom_baselayers").MustBool(true)
 
 	cfg.readRemoteCacheSettings()
+	cfg.readQueryCachingSettings()
+	cfg.readDashboardReportsSettings()
 	cfg.readDateFormats()
 	cfg.readGrafanaJavascriptAgentConfig()
 
@@ -2057,6 +2073,14 @@ func (cfg *Cfg) readLiveSettings(iniFile *ini.File) error {
 	cfg.LiveHAPrefix = section.Key("ha_prefix").MustString("")
 	cfg.LiveHAEngineAddress = section.Key("ha_engine_address").MustString("127.0.0.1:6379")
 	cfg.LiveHAEnginePassword = section.Key("ha_engine_password").MustString("")
+	cfg.LiveHistoryMaxFrames = section.Key("history_max_frames").MustInt(0)
+	if cfg.LiveHistoryMaxFrames < 0 {
+		return fmt.Errorf("unexpected value %d for [live] history_max_frames", cfg.LiveHistoryMaxFrames)
+	}
+	cfg.LiveHistoryMaxAge = section.Key("history_max_age").MustDuration(0)
+	if cfg.LiveHistoryMaxAge < 0 {
+		return fmt.Errorf("unexpected value %s for [live] history_max_age", cfg.LiveHistoryMaxAge)
+	}
 
 	allowedOrigins := section.Key("allowed_origins").MustString("")
 	origins := strings.Split(allowedOrigins, ",")
@@ -0,0 +1,30 @@
+package setting
+
+import "time"
+
+const defaultDashboardReportsCheckInterval = time.Minute
+
+type DashboardReportsSettings struct {
+	Enabled bool
+	// CheckInterval is how often the schedules of the reports are checked for reports that are due.
+	CheckInterval time.Duration
+	// RenderingTimeout is the timeout of rendering one attachment of a report.
+	RenderingTimeout time.Duration
+	// MaxRunHistory is the number of runs that are kept in the history of each report.
+	MaxRunHistory int
+}
+
+func (cfg *Cfg) readDashboardReportsSettings() {
+	section := cfg.Raw.Section("dashboard_reports")
+	cfg.DashboardReports = DashboardReportsSettings{
+		Enabled:          section.Key("enabled").MustBool(false),
+		CheckInterval:    section.Key("check_interval").MustDuration(defaultDashboardReportsCheckInterval),
+		RenderingTimeout: section.Key("rendering_timeout").MustDuration(time.Minute),
+		MaxRunHistory:    section.Key("max_run_history").MustInt(50),
+	}
+
+	if cfg.DashboardReports.CheckInterval <= 0 {
+		cfg.Logger.Warn("[dashboard_reports.check_interval] must be positive, using the default", "default", defaultDashboardReportsCheckInterval)
+		cfg.DashboardReports.CheckInterval = defaultDashboardReportsCheckInterval
+	}
+}
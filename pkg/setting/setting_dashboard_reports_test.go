@@ -0,0 +1,38 @@
+package setting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ini.v1"
+)
+
+func TestReadDashboardReportsSettings(t *testing.T) {
+	t.Run("dashboard reports are disabled by default", func(t *testing.T) {
+		cfg := NewCfg()
+		cfg.Raw = ini.Empty()
+
+		cfg.readDashboardReportsSettings()
+
+		assert.False(t, cfg.DashboardReports.Enabled)
+		assert.Equal(t, time.Minute, cfg.DashboardReports.CheckInterval)
+	})
+
+	for _, interval := range []string{"0s", "-1m"} {
+		t.Run("check interval "+interval+" falls back to the default", func(t *testing.T) {
+			f := ini.Empty()
+			s, err := f.NewSection("dashboard_reports")
+			require.NoError(t, err)
+			_, err = s.NewKey("check_interval", interval)
+			require.NoError(t, err)
+			cfg := NewCfg()
+			cfg.Raw = f
+
+			cfg.readDashboardReportsSettings()
+
+			assert.Equal(t, time.Minute, cfg.DashboardReports.CheckInterval)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package setting
+
+import "time"
+
+type QueryCachingSettings struct {
+	Enabled bool
+	// TTL is the default time to live of cached query results.
+	TTL time.Duration
+	// ResourcesTTL is the time to live of cached resource responses. Resource requests are not cached if it is zero.
+	ResourcesTTL time.Duration
+	// TimeRangeBucket is the interval to which time ranges relative to now are rounded.
+	TimeRangeBucket time.Duration
+	// MaxValueSize is the maximum size in bytes of a cached response.
+	MaxValueSize int
+}
+
+func (cfg *Cfg) readQueryCachingSettings() {
+	section := cfg.Raw.Section("query_caching")
+	cfg.QueryCaching = QueryCachingSettings{
+		Enabled:         section.Key("enabled").MustBool(false),
+		TTL:             section.Key("ttl").MustDuration(time.Minute),
+		ResourcesTTL:    section.Key("resources_ttl").MustDuration(5 * time.Minute),
+		TimeRangeBucket: section.Key("time_range_bucket").MustDuration(10 * time.Second),
+		MaxValueSize:    section.Key("max_value_size").MustInt(1024 * 1024),
+	}
+}
@@ -67,6 +67,7 @@ const (
 	notificationHistoryDefaultEnabled      = false
 	lokiDefaultMaxQueryLength              = 721 * time.Hour // 30d1h, matches the default value in Loki
 	defaultRecordingRequestTimeout         = 10 * time.Second
+	defaultRecordingDatabaseRetention      = 24 * time.Hour
 	lokiDefaultMaxQuerySize                = 65536 // 64kb
 	defaultHistorianPrometheusWriteTimeout = 10 * time.Second
 	defaultHistorianPrometheusMetricName   = "GRAFANA_ALERTS"
@@ -104,6 +105,7 @@ type UnifiedAlertingSettings struct {
 	HARedisMaxConns                 int
 	HARedisTLSEnabled               bool
 	HARedisTLSConfig                dstls.ClientConfig
+	HARuleShardingEnabled           bool
 	InitializationTimeout           time.Duration
 	MaxAttempts                     int64
 	MinInterval                     time.Duration
@@ -146,6 +148,10 @@ type UnifiedAlertingSettings struct {
 
 	// DeletedRuleRetention defines the maximum duration to retain deleted alerting rules before permanent removal.
 	DeletedRuleRetention time.Duration
+
+	// EvaluationTraceSize defines how many of the most recent evaluations of each alert rule are kept in memory
+	// for debugging. The frames of each evaluation are truncated by the scheduler. 0 value disables it.
+	EvaluationTraceSize int
 }
 
 type RecordingRuleSettings struct {
@@ -153,6 +159,8 @@ type RecordingRuleSettings struct {
 	CustomHeaders        map[string]string
 	Timeout              time.Duration
 	DefaultDatasourceUID string
+	// DatabaseRetention is how long the samples of recording rules that write to the Grafana database are kept.
+	DatabaseRetention time.Duration
 }
 
 // RemoteAlertmanagerSettings contains the configuration needed
@@ -334,6 +342,7 @@ func (cfg *Cfg) ReadUnifiedAlertingSettings(iniFile *ini.File) error {
 	uaCfg.HARedisTLSConfig.InsecureSkipVerify = ua.Key("ha_redis_tls_insecure_skip_verify").MustBool(false)
 	uaCfg.HARedisTLSConfig.CipherSuites = ua.Key("ha_redis_tls_cipher_suites").MustString("")
 	uaCfg.HARedisTLSConfig.MinVersion = ua.Key("ha_redis_tls_min_version").MustString("")
+	uaCfg.HARuleShardingEnabled = ua.Key("ha_rule_sharding_enabled").MustBool(false)
 
 	// TODO load from ini file
 	uaCfg.DefaultConfiguration = alertmanagerDefaultConfiguration
@@ -513,6 +522,7 @@ func (cfg *Cfg) ReadUnifiedAlertingSettings(iniFile *ini.File) error {
 		Enabled:              rr.Key("enabled").MustBool(true),
 		Timeout:              rr.Key("timeout").MustDuration(defaultRecordingRequestTimeout),
 		DefaultDatasourceUID: rr.Key("default_datasource_uid").MustString(""),
+		DatabaseRetention:    rr.Key("database_retention").MustDuration(defaultRecordingDatabaseRetention),
 	}
 
 	rrHeaders := iniFile.Section("recording_rules.custom_headers")
@@ -553,6 +563,11 @@ func (cfg *Cfg) ReadUnifiedAlertingSettings(iniFile *ini.File) error {
 		return fmt.Errorf("setting 'deleted_rule_retention' is invalid, only 0 or a positive duration are allowed")
 	}
 
+	uaCfg.EvaluationTraceSize = ua.Key("evaluation_trace_size").MustInt(0)
+	if uaCfg.EvaluationTraceSize < 0 {
+		return fmt.Errorf("setting 'evaluation_trace_size' is invalid, only 0 or a positive integer are allowed")
+	}
+
 	cfg.UnifiedAlerting = uaCfg
 	return nil
 }
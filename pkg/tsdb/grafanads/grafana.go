@@ -6,14 +6,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
 	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/annotations"
 	"github.com/grafana/grafana/pkg/services/datasources"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/searchV2"
@@ -52,27 +55,58 @@ var (
 	)
 )
 
-func ProvideService(search searchV2.SearchService, store store.StorageService, features featuremgmt.FeatureToggles) *Service {
-	return newService(search, store, features)
+func ProvideService(search searchV2.SearchService, store store.StorageService, features featuremgmt.FeatureToggles, samples RecordedSampleReader, annotationsRepo annotations.Repository) *Service {
+	return newService(search, store, features, samples, annotationsRepo)
 }
 
-func newService(search searchV2.SearchService, store store.StorageService, features featuremgmt.FeatureToggles) *Service {
+func newService(search searchV2.SearchService, store store.StorageService, features featuremgmt.FeatureToggles, samples RecordedSampleReader, annotationsRepo annotations.Repository) *Service {
 	s := &Service{
-		search:   search,
-		store:    store,
-		log:      log.New("grafanads"),
-		features: features,
+		search:      search,
+		store:       store,
+		samples:     samples,
+		annotations: annotationsRepo,
+		log:         log.New("grafanads"),
+		features:    features,
 	}
 
 	return s
 }
 
+// RecordedSampleReader reads the samples of recording rules that write to the Grafana database.
+type RecordedSampleReader interface {
+	GetRecordedSamples(ctx context.Context, query RecordedSamplesQuery) ([]RecordedSample, error)
+}
+
+// RecordedSamplesQuery is the query to get the samples of a metric that were written to the Grafana database.
+type RecordedSamplesQuery struct {
+	OrgID  int64
+	Metric string
+	// Labels filters the samples to the series that have all of the labels.
+	Labels data.Labels
+	From   time.Time
+	To     time.Time
+	// Limit is the maximum number of samples to return. The latest samples are returned if the limit is exceeded.
+	Limit int
+}
+
+// RecordedSample is a sample of a recording rule that was written to the Grafana database.
+type RecordedSample struct {
+	Labels data.Labels
+	Time   time.Time
+	Value  float64
+}
+
+// maxRecordedSamples is the maximum number of samples returned by a recorded metrics query.
+const maxRecordedSamples = 100000
+
 // Service exists regardless of user settings
 type Service struct {
-	search   searchV2.SearchService
-	store    store.StorageService
-	log      log.Logger
-	features featuremgmt.FeatureToggles
+	search      searchV2.SearchService
+	store       store.StorageService
+	samples     RecordedSampleReader
+	annotations annotations.Repository
+	log         log.Logger
+	features    featuremgmt.FeatureToggles
 }
 
 func DataSourceModel(orgId int64) *datasources.DataSource {
@@ -100,6 +134,10 @@ func (s *Service) QueryData(ctx context.Context, req *backend.QueryDataRequest)
 			response.Responses[q.RefID] = s.doReadQuery(ctx, q)
 		case queryTypeSearch, queryTypeSearchNext:
 			response.Responses[q.RefID] = s.doSearchQuery(ctx, req, q)
+		case queryTypeRecordedMetrics:
+			response.Responses[q.RefID] = s.doRecordedMetricsQuery(ctx, req, q)
+		case queryTypeAnnotationRegions:
+			response.Responses[q.RefID] = s.doAnnotationRegionsQuery(ctx, req, q)
 		default:
 			response.Responses[q.RefID] = backend.DataResponse{
 				Error: fmt.Errorf("unknown query type"),
@@ -206,6 +244,134 @@ func (s *Service) doSearchQuery(ctx context.Context, req *backend.QueryDataReque
 	return *s.search.DoDashboardQuery(ctx, req.PluginContext.User, req.PluginContext.OrgID, m.Search)
 }
 
+func (s *Service) doRecordedMetricsQuery(ctx context.Context, req *backend.QueryDataRequest, query backend.DataQuery) backend.DataResponse {
+	q := &recordedMetricsQueryModel{}
+	response := backend.DataResponse{}
+	err := json.Unmarshal(query.JSON, &q)
+	if err != nil {
+		response.Error = err
+		return response
+	}
+	if q.Metric == "" {
+		response.Error = fmt.Errorf("metric is required")
+		return response
+	}
+	if s.samples == nil {
+		response.Error = fmt.Errorf("recorded metrics are not available")
+		return response
+	}
+
+	samples, err := s.samples.GetRecordedSamples(ctx, RecordedSamplesQuery{
+		OrgID:  req.PluginContext.OrgID,
+		Metric: q.Metric,
+		Labels: q.Labels,
+		From:   query.TimeRange.From,
+		To:     query.TimeRange.To,
+		Limit:  maxRecordedSamples,
+	})
+	if err != nil {
+		response.Error = err
+		return response
+	}
+
+	response.Frames = recordedSamplesToFrames(q.Metric, samples)
+	return response
+}
+
+// recordedSamplesToFrames returns a frame for each series of the samples, in the multi-frame time series format.
+// The samples must be ordered by time.
+func recordedSamplesToFrames(metric string, samples []RecordedSample) data.Frames {
+	frames := data.Frames{}
+	series := map[data.Fingerprint]*data.Frame{}
+	for _, sample := range samples {
+		fp := sample.Labels.Fingerprint()
+		frame, ok := series[fp]
+		if !ok {
+			frame = data.NewFrame(metric,
+				data.NewField(data.TimeSeriesTimeFieldName, nil, []time.Time{}),
+				data.NewField(data.TimeSeriesValueFieldName, sample.Labels, []float64{}),
+			)
+			frame.SetMeta(&data.FrameMeta{
+				Type:        data.FrameTypeTimeSeriesMulti,
+				TypeVersion: data.FrameTypeVersion{0, 1},
+			})
+			series[fp] = frame
+			frames = append(frames, frame)
+		}
+		frame.AppendRow(sample.Time, sample.Value)
+	}
+	return frames
+}
+
+func (s *Service) doAnnotationRegionsQuery(ctx context.Context, req *backend.QueryDataRequest, query backend.DataQuery) backend.DataResponse {
+	q := &annotationRegionsQueryModel{}
+	response := backend.DataResponse{}
+	err := json.Unmarshal(query.JSON, &q)
+	if err != nil {
+		response.Error = err
+		return response
+	}
+	if s.annotations == nil {
+		response.Error = fmt.Errorf("annotations are not available")
+		return response
+	}
+	// Annotations are filtered by the permissions of the user who runs the query.
+	requester, err := identity.GetRequester(ctx)
+	if err != nil {
+		response.Error = fmt.Errorf("annotation regions can only be queried by a signed in user: %w", err)
+		return response
+	}
+
+	stats, err := annotations.FindRegionStats(ctx, s.annotations, &annotations.RegionStatsQuery{
+		ItemQuery: annotations.ItemQuery{
+			OrgID:        req.PluginContext.OrgID,
+			From:         query.TimeRange.From.UnixMilli(),
+			To:           query.TimeRange.To.UnixMilli(),
+			DashboardUID: q.DashboardUID,
+			PanelID:      q.PanelID,
+			Tags:         q.Tags,
+			MatchAny:     q.MatchAny,
+			TagMatch:     q.TagMatch,
+			Text:         q.Text,
+			Type:         q.Type,
+			SignedInUser: requester,
+		},
+		Bucket:   q.Bucket,
+		Timezone: q.Timezone,
+	})
+	if err != nil {
+		response.Error = err
+		return response
+	}
+
+	response.Frames = data.Frames{regionStatsToFrame(stats)}
+	return response
+}
+
+// regionStatsToFrame returns the buckets of region stats as a time series with the number of regions and the time
+// that they cover in each bucket.
+func regionStatsToFrame(stats *annotations.RegionStats) *data.Frame {
+	times := make([]time.Time, 0, len(stats.Buckets))
+	counts := make([]int64, 0, len(stats.Buckets))
+	durations := make([]int64, 0, len(stats.Buckets))
+	for _, b := range stats.Buckets {
+		times = append(times, time.UnixMilli(b.Time).UTC())
+		counts = append(counts, b.Count)
+		durations = append(durations, b.Duration)
+	}
+
+	frame := data.NewFrame("regions",
+		data.NewField(data.TimeSeriesTimeFieldName, nil, times),
+		data.NewField("count", nil, counts),
+		data.NewField("duration", nil, durations).SetConfig(&data.FieldConfig{Unit: "ms"}),
+	)
+	frame.SetMeta(&data.FrameMeta{
+		Type:        data.FrameTypeTimeSeriesWide,
+		TypeVersion: data.FrameTypeVersion{0, 1},
+	})
+	return frame
+}
+
 type requestModel struct {
 	QueryType string                  `json:"queryType"`
 	Search    searchV2.DashboardQuery `json:"search,omitempty"`
@@ -17,6 +17,12 @@ const (
 	// currently only .csv files are supported,
 	// other file types will eventually be supported (parquet, etc)
 	queryTypeRead = "read"
+
+	// queryTypeRecordedMetrics will read the samples of recording rules that write to the Grafana database
+	queryTypeRecordedMetrics = "recordedMetrics"
+
+	// queryTypeAnnotationRegions will return the time covered by region annotations per day or per week
+	queryTypeAnnotationRegions = "annotationRegions"
 )
 
 type listQueryModel struct {
@@ -25,3 +31,20 @@ type listQueryModel struct {
 type readQueryModel struct {
 	Path string `json:"path"`
 }
+
+type recordedMetricsQueryModel struct {
+	Metric string            `json:"metric"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type annotationRegionsQueryModel struct {
+	DashboardUID string   `json:"dashboardUID,omitempty"`
+	PanelID      int64    `json:"panelId,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	MatchAny     bool     `json:"matchAny,omitempty"`
+	TagMatch     string   `json:"tagMatch,omitempty"`
+	Text         string   `json:"text,omitempty"`
+	Type         string   `json:"type,omitempty"`
+	Bucket       string   `json:"bucket,omitempty"`
+	Timezone     string   `json:"timezone,omitempty"`
+}
@@ -83,6 +83,17 @@ export const reducerTypes: Array<SelectableValue<string>> = [
   { value: ReducerID.sum, label: 'Sum', description: 'Get the sum of all values' },
   { value: ReducerID.count, label: 'Count', description: 'Get the number of values' },
   { value: ReducerID.last, label: 'Last', description: 'Get the last value' },
+  { value: ReducerID.first, label: 'First', description: 'Get the first value' },
+  { value: ReducerID.stdDev, label: 'Standard deviation', description: 'Get the standard deviation of all values' },
+  { value: ReducerID.range, label: 'Range', description: 'Get the difference between the maximum and minimum values' },
+  { value: ReducerID.diff, label: 'Difference', description: 'Get the difference between the last and first values' },
+  {
+    value: ReducerID.diffperc,
+    label: 'Difference percent',
+    description: 'Get the percentage change from the first to the last value',
+  },
+  { value: ReducerID.p95, label: '95th percentile', description: 'Get the 95th percentile value' },
+  { value: ReducerID.p99, label: '99th percentile', description: 'Get the 99th percentile value' },
 ];
 
 export enum ReducerMode {
@@ -121,6 +132,8 @@ export const upsamplingTypes: Array<SelectableValue<string>> = [
   { value: 'pad', label: 'pad', description: 'fill with the last known value' },
   { value: 'backfilling', label: 'backfilling', description: 'fill with the next known value' },
   { value: 'fillna', label: 'fillna', description: 'Fill with NaNs' },
+  { value: 'linear', label: 'linear', description: 'interpolate between the last and the next known values' },
+  { value: 'nearest', label: 'nearest', description: 'fill with the closest known value in time' },
 ];
 
 export const thresholdFunctions: Array<SelectableValue<EvalFunction>> = [